@@ -4,7 +4,7 @@ Package Behaviour provides a mechanism for reactors to report behaviour of peers
 Instead of a reactor calling the switch directly it will call the behaviour module which will
 handle the stoping and marking peer as good on behalf of the reactor.
 
-There are four different behaviours a reactor can report.
+There are five different behaviours a reactor can report.
 
 1. bad message
 
@@ -12,7 +12,7 @@ type badMessage struct {
 	explanation string
 }
 
-This message will request the peer be stopped for an error
+This message will lower the score of the peer and request the peer be stopped for an error
 
 2. message out of order
 
@@ -20,7 +20,7 @@ type messageOutOfOrder struct {
 	explanation string
 }
 
-This message will request the peer be stopped for an error
+This message will lower the score of the peer and request the peer be stopped for an error
 
 3. consesnsus Vote
 
@@ -38,5 +38,18 @@ type blockPart struct {
 
 This message will request the peer be marked as good
 
+5. valid message
+
+type validMessage struct {
+	explanation string
+}
+
+This message will request the peer be marked as good. Reactors only report it
+for messages which were useful to us, and not more than once in a while, so a
+peer can't earn trust by flooding us.
+
+Peers which keep sending bad messages while their score is low get banned by
+the switch for a while.
+
 */
 package behaviour
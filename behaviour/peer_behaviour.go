@@ -47,3 +47,12 @@ type blockPart struct {
 func BlockPart(peerID p2p.ID, explanation string) PeerBehaviour {
 	return PeerBehaviour{peerID: peerID, reason: blockPart{explanation}}
 }
+
+type validMessage struct {
+	explanation string
+}
+
+// ValidMessage returns a validMessage PeerBehaviour.
+func ValidMessage(peerID p2p.ID, explanation string) PeerBehaviour {
+	return PeerBehaviour{peerID: peerID, reason: validMessage{explanation}}
+}
@@ -33,11 +33,13 @@ func (spbr *SwitchReporter) Report(behaviour PeerBehaviour) error {
 	}
 
 	switch reason := behaviour.reason.(type) {
-	case consensusVote, blockPart:
+	case consensusVote, blockPart, validMessage:
 		spbr.sw.MarkPeerAsGood(peer)
 	case badMessage:
+		spbr.sw.MarkPeerAsBad(peer, reason.explanation)
 		spbr.sw.StopPeerForError(peer, reason.explanation)
 	case messageOutOfOrder:
+		spbr.sw.MarkPeerAsBad(peer, reason.explanation)
 		spbr.sw.StopPeerForError(peer, reason.explanation)
 	default:
 		return errors.New("unknown reason reported")
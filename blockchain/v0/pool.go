@@ -78,6 +78,10 @@ type BlockPool struct {
 	requestsCh chan<- BlockRequest
 	errorsCh   chan<- peerError
 
+	// peerScore returns the trust score of a peer. Peers with a higher score
+	// are asked for blocks first.
+	peerScore func(p2p.ID) int
+
 	backend monaco.BackendProxy
 }
 
@@ -102,6 +106,15 @@ func (pool *BlockPool) SetBackendProxy(backend monaco.BackendProxy) {
 	pool.backend = backend
 }
 
+// SetPeerScoreFunc sets the function used to rank the peers when picking one
+// to request a block from.
+func (pool *BlockPool) SetPeerScoreFunc(peerScore func(p2p.ID) int) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	pool.peerScore = peerScore
+}
+
 // OnStart implements service.Service by spawning requesters routine and recording
 // pool's start time.
 func (pool *BlockPool) OnStart() error {
@@ -209,9 +222,10 @@ func (pool *BlockPool) PeekTwoBlocks() (first *types.Block, second *types.Block)
 	return
 }
 
-// PopRequest pops the first block at pool.height.
+// PopRequest pops the first block at pool.height and returns the ID of the
+// peer which sent it.
 // It must have been validated by 'second'.Commit from PeekTwoBlocks().
-func (pool *BlockPool) PopRequest() {
+func (pool *BlockPool) PopRequest() p2p.ID {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
@@ -226,9 +240,9 @@ func (pool *BlockPool) PopRequest() {
 		}
 		delete(pool.requesters, pool.height)
 		pool.height++
-	} else {
-		panic(fmt.Sprintf("Expected requester to pop, got nothing at height %v", pool.height))
+		return r.getPeerID()
 	}
+	panic(fmt.Sprintf("Expected requester to pop, got nothing at height %v", pool.height))
 }
 
 // RedoRequest invalidates the block at pool.height,
@@ -358,12 +372,17 @@ func (pool *BlockPool) updateMaxPeerHeight() {
 	pool.maxPeerHeight = max
 }
 
-// Pick an available peer with the given height available.
+// Pick an available peer with the given height available, preferring the
+// peers with the highest score.
 // If no peers are available, returns nil.
 func (pool *BlockPool) pickIncrAvailablePeer(height int64) *bpPeer {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
+	var (
+		best      *bpPeer
+		bestScore int
+	)
 	for _, peer := range pool.peers {
 		if peer.didTimeout {
 			pool.removePeer(peer.id)
@@ -375,10 +394,19 @@ func (pool *BlockPool) pickIncrAvailablePeer(height int64) *bpPeer {
 		if height < peer.base || height > peer.height {
 			continue
 		}
-		peer.incrPending()
-		return peer
+		if pool.peerScore == nil {
+			best = peer
+			break
+		}
+		if score := pool.peerScore(peer.id); best == nil || score > bestScore {
+			best, bestScore = peer, score
+		}
 	}
-	return nil
+	if best == nil {
+		return nil
+	}
+	best.incrPending()
+	return best
 }
 
 func (pool *BlockPool) makeNextRequester() {
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/arcology-network/consensus-engine/behaviour"
 	bc "github.com/arcology-network/consensus-engine/blockchain"
 	"github.com/arcology-network/consensus-engine/libs/log"
 	"github.com/arcology-network/consensus-engine/mempool"
@@ -62,6 +63,8 @@ type BlockchainReactor struct {
 	requestsCh <-chan BlockRequest
 	errorsCh   <-chan peerError
 
+	reporter behaviour.Reporter
+
 	backend monaco.BackendProxy
 }
 
@@ -93,6 +96,7 @@ func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store sm.
 		fastSync:     fastSync,
 		requestsCh:   requestsCh,
 		errorsCh:     errorsCh,
+		reporter:     behaviour.NewMockReporter(),
 	}
 	bcR.BaseReactor = *p2p.NewBaseReactor("BlockchainReactor", bcR)
 	return bcR
@@ -125,11 +129,20 @@ func NewBlockchainReactorEx(state sm.State, blockExec *sm.BlockExecutor, store s
 		fastSync:     fastSync,
 		requestsCh:   requestsCh,
 		errorsCh:     errorsCh,
+		reporter:     behaviour.NewMockReporter(),
 	}
 	bcR.BaseReactor = *p2p.NewBaseReactor("BlockchainReactor", bcR)
 	return bcR
 }
 
+// SetSwitch implements Reactor by also reporting peer behaviour to the switch
+// and asking the best scored peers for blocks first.
+func (bcR *BlockchainReactor) SetSwitch(sw *p2p.Switch) {
+	bcR.BaseReactor.SetSwitch(sw)
+	bcR.reporter = behaviour.NewSwitchReporter(sw)
+	bcR.pool.SetPeerScoreFunc(sw.PeerScore)
+}
+
 func (bcR *BlockchainReactor) SetBackendProxy(backend monaco.BackendProxy) {
 	bcR.backend = backend
 	bcR.pool.SetBackendProxy(backend)
@@ -185,6 +198,7 @@ func (bcR *BlockchainReactor) GetChannels() []*p2p.ChannelDescriptor {
 			SendQueueCapacity:   1000,
 			RecvBufferCapacity:  50 * 4096,
 			RecvMessageCapacity: bc.MaxMsgSize,
+			MessageType:         &bcproto.Message{},
 		},
 	}
 }
@@ -278,13 +292,13 @@ func (bcR *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 	msg, err := bc.DecodeMsg(msgBytes)
 	if err != nil {
 		bcR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
-		bcR.Switch.StopPeerForError(src, err)
+		_ = bcR.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 		return
 	}
 
 	if err = bc.ValidateMsg(msg); err != nil {
 		bcR.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
-		bcR.Switch.StopPeerForError(src, err)
+		_ = bcR.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 		return
 	}
 
@@ -441,23 +455,19 @@ FOR_LOOP:
 				chainID, firstID, first.Height, second.LastCommit)
 			if err != nil {
 				bcR.Logger.Error("Error in validation", "err", err)
+				explanation := fmt.Sprintf("blockchainReactor validation error: %v", err)
 				peerID := bcR.pool.RedoRequest(first.Height)
-				peer := bcR.Switch.Peers().Get(peerID)
-				if peer != nil {
-					// NOTE: we've already removed the peer's request, but we
-					// still need to clean up the rest.
-					bcR.Switch.StopPeerForError(peer, fmt.Errorf("blockchainReactor validation error: %v", err))
-				}
+				// NOTE: we've already removed the peer's request, but we
+				// still need to clean up the rest.
+				_ = bcR.reporter.Report(behaviour.BadMessage(peerID, explanation))
 				peerID2 := bcR.pool.RedoRequest(second.Height)
-				peer2 := bcR.Switch.Peers().Get(peerID2)
-				if peer2 != nil && peer2 != peer {
-					// NOTE: we've already removed the peer's request, but we
-					// still need to clean up the rest.
-					bcR.Switch.StopPeerForError(peer2, fmt.Errorf("blockchainReactor validation error: %v", err))
+				if peerID2 != peerID {
+					_ = bcR.reporter.Report(behaviour.BadMessage(peerID2, explanation))
 				}
 				continue FOR_LOOP
 			} else {
-				bcR.pool.PopRequest()
+				peerID := bcR.pool.PopRequest()
+				_ = bcR.reporter.Report(behaviour.ValidMessage(peerID, "block"))
 
 				// TODO: batch saves so we dont persist to disk every block
 				bcR.store.SaveBlock(first, firstParts, second.LastCommit)
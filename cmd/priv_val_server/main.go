@@ -11,14 +11,20 @@ import (
 	tmos "github.com/arcology-network/consensus-engine/libs/os"
 
 	"github.com/arcology-network/consensus-engine/privval"
+	tmgrpc "github.com/arcology-network/consensus-engine/privval/grpc"
+	"github.com/arcology-network/consensus-engine/types"
 )
 
 func main() {
 	var (
-		addr             = flag.String("addr", ":26659", "Address of client to connect to")
+		addr = flag.String("addr", ":26659",
+			"Address of client to connect to, or address to listen on for grpc:// addresses")
 		chainID          = flag.String("chain-id", "mychain", "chain id")
 		privValKeyPath   = flag.String("priv-key", "", "priv val key file path")
 		privValStatePath = flag.String("priv-state", "", "priv val state file path")
+		certFile         = flag.String("cert", "", "PEM encoded server certificate (grpc only)")
+		keyFile          = flag.String("key", "", "PEM encoded server private key (grpc only)")
+		rootCAFile       = flag.String("root-ca", "", "PEM encoded root CA used to verify clients (grpc only)")
 
 		logger = log.NewTMLogger(
 			log.NewSyncWriter(os.Stdout),
@@ -39,6 +45,10 @@ func main() {
 	var dialer privval.SocketDialer
 	protocol, address := tmnet.ProtocolAndAddress(*addr)
 	switch protocol {
+	case "grpc":
+		files := tmgrpc.TLSFiles{CertFile: *certFile, KeyFile: *keyFile, RootCAFile: *rootCAFile}
+		serveGRPC(address, *chainID, pv, files, logger)
+		return
 	case "unix":
 		dialer = privval.DialUnixFn(address)
 	case "tcp":
@@ -68,3 +78,26 @@ func main() {
 	// Run forever.
 	select {}
 }
+
+// serveGRPC serves the PrivValidatorAPI on address until the process is
+// terminated.
+func serveGRPC(address, chainID string, pv types.PrivValidator, files tmgrpc.TLSFiles, logger log.Logger) {
+	srv, err := tmgrpc.NewServer(chainID, pv, files, logger)
+	if err != nil {
+		logger.Error("Failed to create gRPC server", "err", err)
+		os.Exit(1)
+	}
+
+	ln, err := tmgrpc.Listen(address)
+	if err != nil {
+		logger.Error("Failed to listen", "addr", address, "err", err)
+		os.Exit(1)
+	}
+
+	// Stop upon receiving SIGTERM or CTRL-C.
+	tmos.TrapSignal(logger, srv.GracefulStop)
+
+	if err := srv.Serve(ln); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arcology-network/consensus-engine/privval"
+)
+
+// EncryptPrivValidatorKeyCmd encrypts an existing plain text
+// priv_validator_key.json in place.
+var EncryptPrivValidatorKeyCmd = &cobra.Command{
+	Use:     "encrypt-priv-validator-key",
+	Aliases: []string{"encrypt_priv_validator_key"},
+	Short:   "Encrypt this node's plain text private validator key with a passphrase",
+	Long: `Encrypt this node's plain text private validator key with a passphrase.
+
+The passphrase is read from priv_validator_key_passphrase_file if set, from
+$TM_PRIV_VALIDATOR_KEY_PASSPHRASE, or else prompted for.`,
+	RunE:   encryptPrivValidatorKey,
+	PreRun: deprecateSnakeCase,
+}
+
+func encryptPrivValidatorKey(cmd *cobra.Command, args []string) error {
+	keyFilePath := config.PrivValidatorKeyFile()
+	keyBytes, err := ioutil.ReadFile(keyFilePath)
+	if err != nil {
+		return fmt.Errorf("can't read private validator key: %w", err)
+	}
+	if privval.IsEncryptedKey(keyBytes) {
+		return fmt.Errorf("private validator key %s is already encrypted", keyFilePath)
+	}
+
+	passphrase, err := newPassphrase()
+	if err != nil {
+		return err
+	}
+
+	pv := privval.LoadFilePVEmptyState(keyFilePath, config.PrivValidatorStateFile())
+	pv.Key.SetPassphrase(passphrase)
+	pv.Key.Save()
+
+	logger.Info("Encrypted private validator key", "keyFile", keyFilePath)
+	return nil
+}
+
+// loadFilePV loads the node's FilePV, unlocking an encrypted key with the
+// passphrase from passphraseFile, the environment or the terminal.
+func loadFilePV(keyFilePath, stateFilePath, passphraseFile string) *privval.FilePV {
+	return privval.LoadFilePVWithPassphrase(keyFilePath, stateFilePath,
+		privval.DefaultPassphrase(passphraseFile))
+}
+
+// newPassphrase returns the passphrase used to encrypt a key. Unless it
+// comes from a file or the environment, it's prompted for twice.
+func newPassphrase() ([]byte, error) {
+	passphraseFile := config.PrivValidatorKeyPassphraseFile()
+	if passphraseFile != "" || os.Getenv(privval.PassphraseEnvVar) != "" {
+		return privval.DefaultPassphrase(passphraseFile)()
+	}
+
+	passphrase, err := privval.PassphraseFromPrompt("Enter a passphrase for the private validator key: ")()
+	if err != nil {
+		return nil, err
+	}
+	if len(passphrase) == 0 {
+		return nil, errors.New("empty passphrase")
+	}
+	repeated, err := privval.PassphraseFromPrompt("Repeat the passphrase: ")()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(passphrase, repeated) {
+		return nil, errors.New("passphrases don't match")
+	}
+	return passphrase, nil
+}
+
+// encryptKeyIfRequested sets the passphrase of pv's key if encrypt is true.
+func encryptKeyIfRequested(pv *privval.FilePV, encrypt bool) error {
+	if !encrypt {
+		return nil
+	}
+	passphrase, err := newPassphrase()
+	if err != nil {
+		return err
+	}
+	pv.Key.SetPassphrase(passphrase)
+	return nil
+}
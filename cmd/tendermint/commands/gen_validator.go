@@ -16,15 +16,37 @@ var GenValidatorCmd = &cobra.Command{
 	Aliases: []string{"gen_validator"},
 	Short:   "Generate new validator keypair",
 	PreRun:  deprecateSnakeCase,
-	Run:     genValidator,
+	RunE:    genValidator,
 }
 
-func genValidator(cmd *cobra.Command, args []string) {
+var encryptGenKey bool
+
+func init() {
+	GenValidatorCmd.Flags().BoolVar(&encryptGenKey, "encrypt", false,
+		"print the private validator key encrypted with a passphrase")
+}
+
+func genValidator(cmd *cobra.Command, args []string) error {
 	pv := privval.GenFilePV("", "")
+
+	if encryptGenKey {
+		passphrase, err := newPassphrase()
+		if err != nil {
+			return err
+		}
+		keyBytes, err := privval.EncryptFilePVKey(pv.Key, passphrase)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(keyBytes))
+		return nil
+	}
+
 	jsbz, err := tmjson.Marshal(pv)
 	if err != nil {
 		panic(err)
 	}
 	fmt.Printf(`%v
 `, string(jsbz))
+	return nil
 }
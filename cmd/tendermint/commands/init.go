@@ -21,6 +21,13 @@ var InitFilesCmd = &cobra.Command{
 	RunE:  initFiles,
 }
 
+var encryptKey bool
+
+func init() {
+	InitFilesCmd.Flags().BoolVar(&encryptKey, "encrypt-key", false,
+		"encrypt a newly generated private validator key with a passphrase")
+}
+
 func initFiles(cmd *cobra.Command, args []string) error {
 	return initFilesWithConfig(config)
 }
@@ -31,11 +38,14 @@ func initFilesWithConfig(config *cfg.Config) error {
 	privValStateFile := config.PrivValidatorStateFile()
 	var pv *privval.FilePV
 	if tmos.FileExists(privValKeyFile) {
-		pv = privval.LoadFilePV(privValKeyFile, privValStateFile)
+		pv = loadFilePV(privValKeyFile, privValStateFile, config.PrivValidatorKeyPassphraseFile())
 		logger.Info("Found private validator", "keyFile", privValKeyFile,
 			"stateFile", privValStateFile)
 	} else {
 		pv = privval.GenFilePV(privValKeyFile, privValStateFile)
+		if err := encryptKeyIfRequested(pv, encryptKey); err != nil {
+			return err
+		}
 		pv.Save()
 		logger.Info("Generated private validator", "keyFile", privValKeyFile,
 			"stateFile", privValStateFile)
@@ -1,9 +1,18 @@
 package commands
 
 import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
 	"github.com/spf13/cobra"
 
 	"github.com/arcology-network/consensus-engine/consensus"
+	tmos "github.com/arcology-network/consensus-engine/libs/os"
+	"github.com/arcology-network/consensus-engine/privval"
+	"github.com/arcology-network/consensus-engine/types"
 )
 
 // ReplayCmd allows replaying of messages from the WAL.
@@ -26,3 +35,61 @@ var ReplayConsoleCmd = &cobra.Command{
 	},
 	PreRun: deprecateSnakeCase,
 }
+
+var replayTraceWithPrivVal bool
+
+// ReplayTraceCmd replays the consensus inputs recorded in a trace file.
+var ReplayTraceCmd = &cobra.Command{
+	Use:   "replay-trace [trace-file]",
+	Short: "Replay the consensus inputs recorded in a trace file",
+	Long: `Replay the last run recorded in a consensus trace file (consensus.trace_file
+by default) and report the first event at which the state machine behaves
+differently from the recording.
+
+The data directory must hold the chain state the recorded run started from,
+e.g. a copy taken before starting the node. Blocks are never executed by the
+application: the backend calls are served from the recording.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: replayTrace,
+}
+
+func init() {
+	ReplayTraceCmd.Flags().BoolVar(&replayTraceWithPrivVal, "priv-validator", true,
+		"sign with the node's private validator key, as the recorded node did")
+}
+
+func replayTrace(cmd *cobra.Command, args []string) error {
+	var traceFile string
+	switch {
+	case len(args) > 0:
+		traceFile = args[0]
+	case config.Consensus.TracePath != "":
+		traceFile = config.Consensus.TraceFile()
+	default:
+		return errors.New("no trace file given and consensus.trace_file is not set")
+	}
+
+	var privVal types.PrivValidator
+	if replayTraceWithPrivVal && tmos.FileExists(config.PrivValidatorKeyFile()) {
+		// the replay must not touch the last sign state of the node
+		dir, err := ioutil.TempDir("", "replay-trace")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+
+		privVal = privval.LoadFilePVEmptyStateWithPassphrase(config.PrivValidatorKeyFile(),
+			filepath.Join(dir, "priv_validator_state.json"),
+			privval.DefaultPassphrase(config.PrivValidatorKeyPassphraseFile()))
+	}
+
+	divergence, err := consensus.RunReplayTrace(config.BaseConfig, config.Consensus, traceFile, privVal)
+	if err != nil {
+		return err
+	}
+	if divergence != nil {
+		return divergence
+	}
+	fmt.Println("The replay matches the recording")
+	return nil
+}
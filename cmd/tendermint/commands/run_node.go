@@ -29,6 +29,10 @@ func AddNodeFlags(cmd *cobra.Command) {
 		"priv_validator_laddr",
 		config.PrivValidatorListenAddr,
 		"socket address to listen on for connections from external priv_validator process")
+	cmd.Flags().String(
+		"priv_validator_grpc_laddr",
+		config.PrivValidatorGRPCListenAddr,
+		"address of an external priv_validator gRPC server (mutual TLS)")
 
 	// node flags
 	cmd.Flags().Bool("fast_sync", config.FastSyncMode, "fast blockchain syncing")
@@ -56,7 +60,7 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().String(
 		"rpc.grpc_laddr",
 		config.RPC.GRPCListenAddress,
-		"GRPC listen address (BroadcastTx only). Port required")
+		"GRPC listen address (BroadcastTx and QueryAPI). Port required")
 	cmd.Flags().Bool("rpc.unsafe", config.RPC.Unsafe, "enabled unsafe rpc methods")
 	cmd.Flags().String("rpc.pprof_laddr", config.RPC.PprofListenAddress, "pprof listen address (https://golang.org/pkg/net/http/pprof)")
 
@@ -70,8 +74,11 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().String("p2p.unconditional_peer_ids",
 		config.P2P.UnconditionalPeerIDs, "comma-delimited IDs of unconditional peers")
 	cmd.Flags().Bool("p2p.upnp", config.P2P.UPNP, "enable/disable UPNP port forwarding")
+	cmd.Flags().Bool("p2p.nat_pmp", config.P2P.NATPMP, "enable/disable NAT-PMP/PCP port forwarding")
 	cmd.Flags().Bool("p2p.pex", config.P2P.PexReactor, "enable/disable Peer-Exchange")
 	cmd.Flags().Bool("p2p.seed_mode", config.P2P.SeedMode, "enable/disable seed mode")
+	cmd.Flags().String("p2p.crawler_laddr", config.P2P.CrawlerListenAddress,
+		"address to serve the statistics of the crawled network on (seed mode only)")
 	cmd.Flags().String("p2p.private_peer_ids", config.P2P.PrivatePeerIDs, "comma-delimited private peer IDs")
 
 	// consensus flags
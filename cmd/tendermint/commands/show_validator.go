@@ -7,7 +7,6 @@ import (
 
 	tmjson "github.com/arcology-network/consensus-engine/libs/json"
 	tmos "github.com/arcology-network/consensus-engine/libs/os"
-	"github.com/arcology-network/consensus-engine/privval"
 )
 
 // ShowValidatorCmd adds capabilities for showing the validator info.
@@ -25,7 +24,7 @@ func showValidator(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("private validator file %s does not exist", keyFilePath)
 	}
 
-	pv := privval.LoadFilePV(keyFilePath, config.PrivValidatorStateFile())
+	pv := loadFilePV(keyFilePath, config.PrivValidatorStateFile(), config.PrivValidatorKeyPassphraseFile())
 
 	pubKey, err := pv.GetPubKey()
 	if err != nil {
@@ -14,7 +14,6 @@ import (
 	"github.com/arcology-network/consensus-engine/libs/bytes"
 	tmrand "github.com/arcology-network/consensus-engine/libs/rand"
 	"github.com/arcology-network/consensus-engine/p2p"
-	"github.com/arcology-network/consensus-engine/privval"
 	"github.com/arcology-network/consensus-engine/types"
 	tmtime "github.com/arcology-network/consensus-engine/types/time"
 )
@@ -142,7 +141,7 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 
 		pvKeyFile := filepath.Join(nodeDir, config.BaseConfig.PrivValidatorKey)
 		pvStateFile := filepath.Join(nodeDir, config.BaseConfig.PrivValidatorState)
-		pv := privval.LoadFilePV(pvKeyFile, pvStateFile)
+		pv := loadFilePV(pvKeyFile, pvStateFile, config.PrivValidatorKeyPassphraseFile())
 
 		pubKey, err := pv.GetPubKey()
 		if err != nil {
@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cs "github.com/arcology-network/consensus-engine/consensus"
+	tmjson "github.com/arcology-network/consensus-engine/libs/json"
+)
+
+var (
+	walFile          string
+	walHeight        int64
+	walFormat        string
+	walOutput        string
+	walSkipCorrupted bool
+)
+
+// WALCmd groups the commands to inspect and repair the consensus WAL.
+var WALCmd = &cobra.Command{
+	Use:   "wal",
+	Short: "Inspect, verify and repair the consensus write-ahead log",
+}
+
+// WALInspectCmd lists the messages of the consensus WAL.
+var WALInspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "List the heights, rounds and message types in the WAL",
+	RunE:  walInspect,
+}
+
+// WALVerifyCmd checks the CRCs of every WAL entry.
+var WALVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check the WAL for corrupted entries",
+	RunE:  walVerify,
+}
+
+// WALTruncateCmd drops the WAL entries written after a height.
+var WALTruncateCmd = &cobra.Command{
+	Use:   "truncate",
+	Short: "(unsafe) Drop every WAL entry written after the given height",
+	RunE:  walTruncate,
+}
+
+// WALExportCmd writes the WAL as JSON.
+var WALExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the WAL as JSON or newline delimited JSON",
+	RunE:  walExport,
+}
+
+func init() {
+	WALCmd.PersistentFlags().StringVar(&walFile, "wal-file", "",
+		"path to the WAL head file (defaults to consensus.wal_file of the config)")
+
+	WALTruncateCmd.Flags().Int64Var(&walHeight, "height", 0, "last height to keep")
+
+	WALExportCmd.Flags().StringVar(&walFormat, "format", "json", "output format: json or ndjson")
+	WALExportCmd.Flags().StringVarP(&walOutput, "output", "o", "", "output file (defaults to stdout)")
+	WALExportCmd.Flags().BoolVar(&walSkipCorrupted, "skip-corrupted", false, "skip corrupted entries instead of failing")
+
+	WALCmd.AddCommand(WALInspectCmd, WALVerifyCmd, WALTruncateCmd, WALExportCmd)
+}
+
+func walFilePath() string {
+	if walFile != "" {
+		return walFile
+	}
+	return config.Consensus.WalFile()
+}
+
+func walInspect(cmd *cobra.Command, args []string) error {
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "FILE\tOFFSET\tHEIGHT\tROUND\tTYPE\tTIME")
+	err := cs.ScanWAL(walFilePath(), func(entry cs.WALEntry) error {
+		if entry.Err != nil {
+			fmt.Fprintf(w, "%s\t%d\t-\t-\tCORRUPTED\t%v\n", entry.Path, entry.Offset, entry.Err)
+			return nil
+		}
+		height, round, kind, ok := cs.WALMessageInfo(entry.Msg.Msg)
+		if ok {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\t%s\n",
+				entry.Path, entry.Offset, height, round, kind, entry.Msg.Time.Format(time.RFC3339Nano))
+		} else {
+			fmt.Fprintf(w, "%s\t%d\t-\t-\t%s\t%s\n",
+				entry.Path, entry.Offset, kind, entry.Msg.Time.Format(time.RFC3339Nano))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func walVerify(cmd *cobra.Command, args []string) error {
+	var entries, corrupted int
+	err := cs.ScanWAL(walFilePath(), func(entry cs.WALEntry) error {
+		if entry.Err != nil {
+			corrupted++
+			fmt.Fprintf(cmd.OutOrStdout(), "%s: offset %d: %v\n", entry.Path, entry.Offset, entry.Err)
+			return nil
+		}
+		entries++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if corrupted > 0 {
+		return fmt.Errorf("found %d corrupted region(s) in the WAL (%d valid entries)", corrupted, entries)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "WAL is valid (%d entries)\n", entries)
+	return nil
+}
+
+func walTruncate(cmd *cobra.Command, args []string) error {
+	if walHeight <= 0 {
+		return fmt.Errorf("--height must be positive, got %d", walHeight)
+	}
+	if err := cs.TruncateWAL(walFilePath(), walHeight); err != nil {
+		return err
+	}
+	logger.Info("Truncated WAL", "file", walFilePath(), "height", walHeight)
+	return nil
+}
+
+func walExport(cmd *cobra.Command, args []string) error {
+	if walFormat != "json" && walFormat != "ndjson" {
+		return fmt.Errorf("unknown format %q, expected json or ndjson", walFormat)
+	}
+
+	var out io.Writer = cmd.OutOrStdout()
+	if walOutput != "" {
+		f, err := os.Create(walOutput)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	bw := bufio.NewWriter(out)
+
+	first := true
+	if walFormat == "json" {
+		bw.WriteString("[")
+	}
+	err := cs.ScanWAL(walFilePath(), func(entry cs.WALEntry) error {
+		if entry.Err != nil {
+			if walSkipCorrupted {
+				return nil
+			}
+			return fmt.Errorf("%s: offset %d: %w", entry.Path, entry.Offset, entry.Err)
+		}
+
+		jsonBytes, err := tmjson.Marshal(entry.Msg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal msg: %w", err)
+		}
+		switch {
+		case walFormat == "ndjson":
+		case first:
+			bw.WriteString("\n")
+		default:
+			bw.WriteString(",\n")
+		}
+		first = false
+		bw.Write(jsonBytes)
+		if walFormat == "ndjson" {
+			bw.WriteString("\n")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if walFormat == "json" {
+		bw.WriteString("\n]\n")
+	}
+	return bw.Flush()
+}
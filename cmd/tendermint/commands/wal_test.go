@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cs "github.com/arcology-network/consensus-engine/consensus"
+	"github.com/arcology-network/consensus-engine/types"
+	tmtime "github.com/arcology-network/consensus-engine/types/time"
+)
+
+// writeTestWAL writes a WAL with two rounds of state for each of the given
+// heights, each followed by an EndHeightMessage.
+func writeTestWAL(t *testing.T, heights int64) string {
+	dir, err := ioutil.TempDir("", "wal_cmd")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "wal")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	enc := cs.NewWALEncoder(f)
+	now := tmtime.Now()
+	for h := int64(1); h <= heights; h++ {
+		for r := int32(0); r < 2; r++ {
+			msg := types.EventDataRoundState{Height: h, Round: r, Step: "RoundStepPropose"}
+			require.NoError(t, enc.Encode(&cs.TimedWALMessage{Time: now, Msg: msg}))
+		}
+		require.NoError(t, enc.Encode(&cs.TimedWALMessage{Time: now, Msg: cs.EndHeightMessage{Height: h}}))
+	}
+	return path
+}
+
+func runWALCmd(t *testing.T, cmd *cobra.Command, run func(*cobra.Command, []string) error) (string, error) {
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	t.Cleanup(func() { cmd.SetOut(nil) })
+	err := run(cmd, nil)
+	return buf.String(), err
+}
+
+func TestWALInspect(t *testing.T) {
+	walFile = writeTestWAL(t, 2)
+	t.Cleanup(func() { walFile = "" })
+
+	out, err := runWALCmd(t, WALInspectCmd, walInspect)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 7)
+	assert.Regexp(t, `\s1\s+1\s+RoundState`, lines[2])
+	assert.Regexp(t, `\s2\s+0\s+EndHeight`, lines[6])
+}
+
+func TestWALVerifyCorrupted(t *testing.T) {
+	walFile = writeTestWAL(t, 3)
+	t.Cleanup(func() { walFile = "" })
+
+	out, err := runWALCmd(t, WALVerifyCmd, walVerify)
+	require.NoError(t, err)
+	assert.Contains(t, out, "9 entries")
+
+	// flip a byte in the payload of the fourth entry
+	var offsets []int64
+	require.NoError(t, cs.ScanWAL(walFile, func(entry cs.WALEntry) error {
+		offsets = append(offsets, entry.Offset)
+		return nil
+	}))
+	data, err := ioutil.ReadFile(walFile)
+	require.NoError(t, err)
+	data[offsets[3]+10] ^= 0xFF
+	require.NoError(t, ioutil.WriteFile(walFile, data, 0600))
+
+	out, err = runWALCmd(t, WALVerifyCmd, walVerify)
+	require.Error(t, err)
+	assert.Contains(t, out, "offset "+strconv.FormatInt(offsets[3], 10))
+	assert.Equal(t, 1, strings.Count(out, "offset"))
+}
+
+func TestWALTruncate(t *testing.T) {
+	walFile = writeTestWAL(t, 3)
+	t.Cleanup(func() { walFile, walHeight = "", 0 })
+
+	walHeight = 2
+	_, err := runWALCmd(t, WALTruncateCmd, walTruncate)
+	require.NoError(t, err)
+
+	var last cs.WALMessage
+	n := 0
+	require.NoError(t, cs.ScanWAL(walFile, func(entry cs.WALEntry) error {
+		require.NoError(t, entry.Err)
+		last = entry.Msg.Msg
+		n++
+		return nil
+	}))
+	assert.Equal(t, 6, n)
+	assert.Equal(t, cs.EndHeightMessage{Height: 2}, last)
+
+	walHeight = 5
+	_, err = runWALCmd(t, WALTruncateCmd, walTruncate)
+	assert.Error(t, err)
+}
+
+func TestWALExport(t *testing.T) {
+	walFile = writeTestWAL(t, 1)
+	t.Cleanup(func() { walFile, walFormat = "", "json" })
+
+	walFormat = "json"
+	out, err := runWALCmd(t, WALExportCmd, walExport)
+	require.NoError(t, err)
+	var msgs []json.RawMessage
+	require.NoError(t, json.Unmarshal([]byte(out), &msgs))
+	assert.Len(t, msgs, 3)
+
+	walFormat = "ndjson"
+	out, err = runWALCmd(t, WALExportCmd, walExport)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 3)
+	for _, line := range lines {
+		assert.True(t, json.Valid([]byte(line)), line)
+	}
+
+	walFormat = "xml"
+	_, err = runWALCmd(t, WALExportCmd, walExport)
+	assert.Error(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -23,6 +24,11 @@ const (
 
 	// DefaultLogLevel defines a default log level as INFO.
 	DefaultLogLevel = "info"
+
+	// SentryModeValidator runs a validator which only connects to its sentries
+	SentryModeValidator = "validator"
+	// SentryModeSentry runs a sentry node in front of validators
+	SentryModeSentry = "sentry"
 )
 
 // NOTE: Most of the structs & relevant comments + the
@@ -67,6 +73,7 @@ type Config struct {
 	FastSync        *FastSyncConfig        `mapstructure:"fastsync"`
 	Consensus       *ConsensusConfig       `mapstructure:"consensus"`
 	TxIndex         *TxIndexConfig         `mapstructure:"tx_index"`
+	ValidatorStats  *ValidatorStatsConfig  `mapstructure:"validator_stats"`
 	Instrumentation *InstrumentationConfig `mapstructure:"instrumentation"`
 }
 
@@ -81,6 +88,7 @@ func DefaultConfig() *Config {
 		FastSync:        DefaultFastSyncConfig(),
 		Consensus:       DefaultConsensusConfig(),
 		TxIndex:         DefaultTxIndexConfig(),
+		ValidatorStats:  DefaultValidatorStatsConfig(),
 		Instrumentation: DefaultInstrumentationConfig(),
 	}
 }
@@ -96,6 +104,7 @@ func TestConfig() *Config {
 		FastSync:        TestFastSyncConfig(),
 		Consensus:       TestConsensusConfig(),
 		TxIndex:         TestTxIndexConfig(),
+		ValidatorStats:  TestValidatorStatsConfig(),
 		Instrumentation: TestInstrumentationConfig(),
 	}
 }
@@ -134,6 +143,9 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Consensus.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [consensus] section: %w", err)
 	}
+	if err := cfg.ValidatorStats.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [validator_stats] section: %w", err)
+	}
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [instrumentation] section: %w", err)
 	}
@@ -200,6 +212,11 @@ type BaseConfig struct { //nolint: maligned
 	// Path to the JSON file containing the private key to use as a validator in the consensus protocol
 	PrivValidatorKey string `mapstructure:"priv_validator_key_file"`
 
+	// Path to a file holding the passphrase of an encrypted priv_validator_key_file.
+	// If empty, the passphrase is read from $TM_PRIV_VALIDATOR_KEY_PASSPHRASE or
+	// prompted for.
+	PrivValidatorKeyPassphrase string `mapstructure:"priv_validator_key_passphrase_file"`
+
 	// Path to the JSON file containing the last sign state of a validator
 	PrivValidatorState string `mapstructure:"priv_validator_state_file"`
 
@@ -207,6 +224,28 @@ type BaseConfig struct { //nolint: maligned
 	// connections from an external PrivValidator process
 	PrivValidatorListenAddr string `mapstructure:"priv_validator_laddr"`
 
+	// TCP address of an external PrivValidator process serving the
+	// PrivValidatorAPI gRPC service. Tendermint dials it using mutual TLS.
+	PrivValidatorGRPCListenAddr string `mapstructure:"priv_validator_grpc_laddr"`
+
+	// Path to the PEM encoded certificate presented to the gRPC PrivValidator
+	PrivValidatorGRPCCert string `mapstructure:"priv_validator_grpc_cert_file"`
+
+	// Path to the PEM encoded private key of priv_validator_grpc_cert_file
+	PrivValidatorGRPCKey string `mapstructure:"priv_validator_grpc_key_file"`
+
+	// Path to the PEM encoded root CA used to verify the gRPC PrivValidator
+	PrivValidatorGRPCRootCA string `mapstructure:"priv_validator_grpc_root_ca_file"`
+
+	// TCP addresses of several external PrivValidator processes serving the
+	// PrivValidatorAPI gRPC service and holding the same key. Tendermint dials
+	// each of them using mutual TLS and signs through a MultiSignerPV.
+	PrivValidatorMultiSignerAddrs []string `mapstructure:"priv_validator_multi_signer_addrs"`
+
+	// Number of signers which must accept a vote or proposal before it is
+	// signed. 0 means a majority of priv_validator_multi_signer_addrs.
+	PrivValidatorMultiSignerThreshold int `mapstructure:"priv_validator_multi_signer_threshold"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node_key_file"`
 
@@ -261,11 +300,38 @@ func (cfg BaseConfig) PrivValidatorKeyFile() string {
 	return rootify(cfg.PrivValidatorKey, cfg.RootDir)
 }
 
+// PrivValidatorKeyPassphraseFile returns the full path to the passphrase file
+// of an encrypted priv_validator_key.json, or "" if none is configured.
+func (cfg BaseConfig) PrivValidatorKeyPassphraseFile() string {
+	if cfg.PrivValidatorKeyPassphrase == "" {
+		return ""
+	}
+	return rootify(cfg.PrivValidatorKeyPassphrase, cfg.RootDir)
+}
+
 // PrivValidatorFile returns the full path to the priv_validator_state.json file
 func (cfg BaseConfig) PrivValidatorStateFile() string {
 	return rootify(cfg.PrivValidatorState, cfg.RootDir)
 }
 
+// PrivValidatorGRPCCertFile returns the full path to the gRPC PrivValidator
+// client certificate.
+func (cfg BaseConfig) PrivValidatorGRPCCertFile() string {
+	return rootify(cfg.PrivValidatorGRPCCert, cfg.RootDir)
+}
+
+// PrivValidatorGRPCKeyFile returns the full path to the gRPC PrivValidator
+// client key.
+func (cfg BaseConfig) PrivValidatorGRPCKeyFile() string {
+	return rootify(cfg.PrivValidatorGRPCKey, cfg.RootDir)
+}
+
+// PrivValidatorGRPCRootCAFile returns the full path to the gRPC PrivValidator
+// root CA.
+func (cfg BaseConfig) PrivValidatorGRPCRootCAFile() string {
+	return rootify(cfg.PrivValidatorGRPCRootCA, cfg.RootDir)
+}
+
 // NodeKeyFile returns the full path to the node_key.json file
 func (cfg BaseConfig) NodeKeyFile() string {
 	return rootify(cfg.NodeKey, cfg.RootDir)
@@ -284,9 +350,43 @@ func (cfg BaseConfig) ValidateBasic() error {
 	default:
 		return errors.New("unknown log_format (must be 'plain' or 'json')")
 	}
+	if cfg.PrivValidatorGRPCListenAddr != "" {
+		if cfg.PrivValidatorListenAddr != "" {
+			return errors.New("priv_validator_laddr and priv_validator_grpc_laddr can't both be set")
+		}
+		if cfg.PrivValidatorGRPCCert == "" || cfg.PrivValidatorGRPCKey == "" || cfg.PrivValidatorGRPCRootCA == "" {
+			return errors.New("priv_validator_grpc_laddr requires priv_validator_grpc_cert_file, " +
+				"priv_validator_grpc_key_file and priv_validator_grpc_root_ca_file")
+		}
+	}
+	if n := len(cfg.PrivValidatorMultiSignerAddrs); n > 0 {
+		if cfg.PrivValidatorListenAddr != "" || cfg.PrivValidatorGRPCListenAddr != "" {
+			return errors.New("priv_validator_multi_signer_addrs can't be used together with " +
+				"priv_validator_laddr or priv_validator_grpc_laddr")
+		}
+		if cfg.PrivValidatorGRPCCert == "" || cfg.PrivValidatorGRPCKey == "" || cfg.PrivValidatorGRPCRootCA == "" {
+			return errors.New("priv_validator_multi_signer_addrs requires priv_validator_grpc_cert_file, " +
+				"priv_validator_grpc_key_file and priv_validator_grpc_root_ca_file")
+		}
+		if t := cfg.PrivValidatorMultiSignerThreshold; t != 0 && (t <= n/2 || t > n) {
+			return fmt.Errorf("priv_validator_multi_signer_threshold must be a majority of "+
+				"priv_validator_multi_signer_addrs (%d..%d), got %d", n/2+1, n, t)
+		}
+	} else if cfg.PrivValidatorMultiSignerThreshold != 0 {
+		return errors.New("priv_validator_multi_signer_threshold requires priv_validator_multi_signer_addrs")
+	}
 	return nil
 }
 
+// PrivValidatorMultiSignerQuorum returns the number of signers a
+// MultiSignerPV built from PrivValidatorMultiSignerAddrs must hear from.
+func (cfg BaseConfig) PrivValidatorMultiSignerQuorum() int {
+	if cfg.PrivValidatorMultiSignerThreshold > 0 {
+		return cfg.PrivValidatorMultiSignerThreshold
+	}
+	return len(cfg.PrivValidatorMultiSignerAddrs)/2 + 1
+}
+
 //-----------------------------------------------------------------------------
 // RPCConfig
 
@@ -310,7 +410,10 @@ type RPCConfig struct {
 	CORSAllowedHeaders []string `mapstructure:"cors_allowed_headers"`
 
 	// TCP or UNIX socket address for the gRPC server to listen on
-	// NOTE: This server only supports /broadcast_tx_commit
+	// NOTE: This server supports /broadcast_tx_commit and the queries of the
+	// QueryAPI service (see proto/tendermint/rpc/grpc/query.proto).
+	// The gRPC server has no authentication nor rate limiting, so it must
+	// not be exposed publicly.
 	GRPCListenAddress string `mapstructure:"grpc_laddr"`
 
 	// Maximum number of simultaneous connections.
@@ -342,6 +445,31 @@ type RPCConfig struct {
 	// to the estimated maximum number of broadcast_tx_commit calls per block.
 	MaxSubscriptionsPerClient int `mapstructure:"max_subscriptions_per_client"`
 
+	// Number of events buffered for each subscription of a client, before
+	// subscription_overflow_policy applies.
+	SubscriptionBufferSize int `mapstructure:"subscription_buffer_size"`
+
+	// What happens when a client doesn't pull the events fast enough and the
+	// buffer of its subscription is full, unless the client chose another
+	// policy when subscribing:
+	//   1) "cancel" - the subscription is cancelled
+	//   2) "drop_oldest" - the oldest buffered event is dropped
+	//   3) "drop_newest" - the new event is dropped
+	//   4) "spill" - the events are written to disk until the client catches
+	//   up, the subscription is cancelled if they exceed
+	//   subscription_spill_max_bytes
+	SubscriptionOverflowPolicy string `mapstructure:"subscription_overflow_policy"`
+
+	// Maximum size of the events written to disk for a subscription with the
+	// "spill" policy, in bytes.
+	// 0 - clients can't use the "spill" policy.
+	SubscriptionSpillMaxBytes int64 `mapstructure:"subscription_spill_max_bytes"`
+
+	// Maximum number of committed blocks whose events a subscription can
+	// replay with from_height or cursor. Older heights are rejected.
+	// 0 - the events can't be replayed.
+	SubscriptionMaxReplayBlocks int64 `mapstructure:"subscription_max_replay_blocks"`
+
 	// How long to wait for a tx to be committed during /broadcast_tx_commit
 	// WARNING: Using a value larger than 10s will result in increasing the
 	// global HTTP write timeout, which applies to all connections and endpoints.
@@ -354,6 +482,33 @@ type RPCConfig struct {
 	// Maximum size of request header, in bytes
 	MaxHeaderBytes int `mapstructure:"max_header_bytes"`
 
+	// Number of responses of /block, /commit, /block_results and /validators
+	// for committed heights kept in memory.
+	// 0 - disable the cache.
+	ResponseCacheSize int `mapstructure:"response_cache_size"`
+
+	// Maximum number of requests in a JSON-RPC batch.
+	// 0 - unlimited.
+	MaxBatchSize int `mapstructure:"max_batch_size"`
+
+	// Number of requests of a JSON-RPC batch, and of a websocket connection,
+	// executed at the same time. Broadcasts and unsafe methods still wait for
+	// the requests before them.
+	// 1 - execute the requests one after another.
+	BatchConcurrency     int `mapstructure:"batch_concurrency"`
+	WebsocketConcurrency int `mapstructure:"websocket_concurrency"`
+
+	// How long a method may run before the server replies with an error.
+	// Does not apply to /subscribe. A method, which times out, keeps running
+	// in the background unless it stops once the request is canceled (like
+	// tx_search and block_search), hence no timeout by default.
+	// 0 - no timeout.
+	MethodTimeout time.Duration `mapstructure:"method_timeout"`
+
+	// Timeouts of individual methods, overriding method_timeout, as
+	// "method=duration" (e.g. "tx_search=30s").
+	MethodTimeouts []string `mapstructure:"method_timeouts"`
+
 	// The path to a file containing certificate that is used to create the HTTPS server.
 	// Might be either absolute path or path related to Tendermint's config directory.
 	//
@@ -372,6 +527,29 @@ type RPCConfig struct {
 	// Otherwise, HTTP server is run.
 	TLSKeyFile string `mapstructure:"tls_key_file"`
 
+	// The path to a JSON file with the API keys accepted by the RPC server.
+	// Might be either absolute path or path related to Tendermint's config directory.
+	// Keys are either "bearer" tokens or "hmac" secrets used to sign requests,
+	// optionally restricted to a list of methods and given their own rate limit.
+	AuthKeysFile string `mapstructure:"auth_keys_file"`
+
+	// Reject requests, which don't carry valid credentials.
+	// Otherwise, requests without credentials are served subject to the per-IP
+	// rate limit.
+	AuthRequired bool `mapstructure:"auth_required"`
+
+	// Number of requests per second a key may make, unless set in the key
+	// itself, and the number of requests it may make at once.
+	// 0 - unlimited.
+	RateLimitPerKey      float64 `mapstructure:"rate_limit_per_key"`
+	RateLimitPerKeyBurst int     `mapstructure:"rate_limit_per_key_burst"`
+
+	// Number of requests per second an unauthenticated client IP may make and
+	// the number of requests it may make at once.
+	// 0 - unlimited.
+	RateLimitPerIP      float64 `mapstructure:"rate_limit_per_ip"`
+	RateLimitPerIPBurst int     `mapstructure:"rate_limit_per_ip_burst"`
+
 	// pprof listen address (https://golang.org/pkg/net/http/pprof)
 	PprofListenAddress string `mapstructure:"pprof_laddr"`
 }
@@ -393,11 +571,32 @@ func DefaultRPCConfig() *RPCConfig {
 		MaxSubscriptionsPerClient: 5,
 		TimeoutBroadcastTxCommit:  10 * time.Second,
 
+		SubscriptionBufferSize:     100,
+		SubscriptionOverflowPolicy: "cancel",
+		SubscriptionSpillMaxBytes:  0,
+
+		SubscriptionMaxReplayBlocks: 1000,
+
 		MaxBodyBytes:   int64(1000000), // 1MB
 		MaxHeaderBytes: 1 << 20,        // same as the net/http default
 
+		ResponseCacheSize: 1000,
+
+		MaxBatchSize:         100,
+		BatchConcurrency:     4,
+		WebsocketConcurrency: 4,
+		MethodTimeout:        0,
+		MethodTimeouts:       []string{},
+
 		TLSCertFile: "",
 		TLSKeyFile:  "",
+
+		AuthKeysFile:         "",
+		AuthRequired:         false,
+		RateLimitPerKey:      0,
+		RateLimitPerKeyBurst: 0,
+		RateLimitPerIP:       0,
+		RateLimitPerIPBurst:  0,
 	}
 }
 
@@ -425,6 +624,24 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxSubscriptionsPerClient < 0 {
 		return errors.New("max_subscriptions_per_client can't be negative")
 	}
+	if cfg.SubscriptionBufferSize <= 0 {
+		return errors.New("subscription_buffer_size must be positive")
+	}
+	switch cfg.SubscriptionOverflowPolicy {
+	case "cancel", "drop_oldest", "drop_newest":
+	case "spill":
+		if cfg.SubscriptionSpillMaxBytes == 0 {
+			return errors.New("subscription_overflow_policy is \"spill\", but subscription_spill_max_bytes is 0")
+		}
+	default:
+		return fmt.Errorf("unknown subscription_overflow_policy %q", cfg.SubscriptionOverflowPolicy)
+	}
+	if cfg.SubscriptionSpillMaxBytes < 0 {
+		return errors.New("subscription_spill_max_bytes can't be negative")
+	}
+	if cfg.SubscriptionMaxReplayBlocks < 0 {
+		return errors.New("subscription_max_replay_blocks can't be negative")
+	}
 	if cfg.TimeoutBroadcastTxCommit < 0 {
 		return errors.New("timeout_broadcast_tx_commit can't be negative")
 	}
@@ -434,9 +651,62 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxHeaderBytes < 0 {
 		return errors.New("max_header_bytes can't be negative")
 	}
+	if cfg.ResponseCacheSize < 0 {
+		return errors.New("response_cache_size can't be negative")
+	}
+	if cfg.MaxBatchSize < 0 {
+		return errors.New("max_batch_size can't be negative")
+	}
+	if cfg.BatchConcurrency <= 0 {
+		return errors.New("batch_concurrency must be positive")
+	}
+	if cfg.WebsocketConcurrency <= 0 {
+		return errors.New("websocket_concurrency must be positive")
+	}
+	if cfg.MethodTimeout < 0 {
+		return errors.New("method_timeout can't be negative")
+	}
+	if _, err := cfg.MethodTimeoutsByName(); err != nil {
+		return fmt.Errorf("invalid method_timeouts: %w", err)
+	}
+	if cfg.AuthRequired && cfg.AuthKeysFile == "" {
+		return errors.New("auth_required is set, but auth_keys_file is empty")
+	}
+	if cfg.RateLimitPerKey < 0 {
+		return errors.New("rate_limit_per_key can't be negative")
+	}
+	if cfg.RateLimitPerKeyBurst < 0 {
+		return errors.New("rate_limit_per_key_burst can't be negative")
+	}
+	if cfg.RateLimitPerIP < 0 {
+		return errors.New("rate_limit_per_ip can't be negative")
+	}
+	if cfg.RateLimitPerIPBurst < 0 {
+		return errors.New("rate_limit_per_ip_burst can't be negative")
+	}
 	return nil
 }
 
+// MethodTimeoutsByName parses MethodTimeouts.
+func (cfg *RPCConfig) MethodTimeoutsByName() (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration, len(cfg.MethodTimeouts))
+	for _, entry := range cfg.MethodTimeouts {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("%q is not method=duration", entry)
+		}
+		timeout, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", entry, err)
+		}
+		if timeout < 0 {
+			return nil, fmt.Errorf("%q: negative duration", entry)
+		}
+		timeouts[parts[0]] = timeout
+	}
+	return timeouts, nil
+}
+
 // IsCorsEnabled returns true if cross-origin resource sharing is enabled.
 func (cfg *RPCConfig) IsCorsEnabled() bool {
 	return len(cfg.CORSAllowedOrigins) != 0
@@ -462,6 +732,21 @@ func (cfg RPCConfig) IsTLSEnabled() bool {
 	return cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
 }
 
+// AuthKeysPath returns the full path to the API keys file.
+func (cfg RPCConfig) AuthKeysPath() string {
+	path := cfg.AuthKeysFile
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(defaultConfigDir, path), cfg.RootDir)
+}
+
+// IsAccessControlEnabled returns true if RPC requests need to be
+// authenticated or rate limited.
+func (cfg RPCConfig) IsAccessControlEnabled() bool {
+	return cfg.AuthKeysFile != "" || cfg.AuthRequired || cfg.RateLimitPerIP > 0
+}
+
 //-----------------------------------------------------------------------------
 // P2PConfig
 
@@ -472,7 +757,11 @@ type P2PConfig struct { //nolint: maligned
 	// Address to listen for incoming connections
 	ListenAddress string `mapstructure:"laddr"`
 
+	// Transport used to connect to peers: "tcp" or "quic"
+	Transport string `mapstructure:"transport"`
+
 	// Address to advertise to peers for them to dial
+	// If empty, the address is learned from the port forwarding or from peers
 	ExternalAddress string `mapstructure:"external_address"`
 
 	// Comma separated list of seed nodes to connect to
@@ -485,7 +774,20 @@ type P2PConfig struct { //nolint: maligned
 	// UPNP port forwarding
 	UPNP bool `mapstructure:"upnp"`
 
-	// Path to address book
+	// NAT-PMP/PCP port forwarding
+	NATPMP bool `mapstructure:"nat_pmp"`
+
+	// Address of the NAT-PMP/PCP gateway. If empty, the default gateway is used,
+	// which is only found on Linux.
+	NATGateway string `mapstructure:"nat_gateway"`
+
+	// Learn the external IP from the addresses peers observe our connections
+	// from. Only used if external_address is empty and there is no port
+	// forwarding.
+	DiscoverExternalAddress bool `mapstructure:"discover_external_address"`
+
+	// Path to a JSON address book to import. The address book is kept in the
+	// addrbook DB; the file is only read when that DB is empty.
 	AddrBook string `mapstructure:"addr_book_file"`
 
 	// Set true for strict address routability rules
@@ -516,6 +818,21 @@ type P2PConfig struct { //nolint: maligned
 	// Rate at which packets can be received, in bytes/second
 	RecvRate int64 `mapstructure:"recv_rate"`
 
+	// Comma separated list of node IDs of validators, whose connections use the
+	// validator rate limits of peer_rate_limits
+	ValidatorPeerIDs string `mapstructure:"validator_peer_ids"`
+
+	// Connection rate limits per peer class, overriding send_rate and recv_rate.
+	// Comma separated list of class=send_rate:recv_rate, in bytes/second, where
+	// class is validator, unconditional or persistent. 0 is unlimited.
+	PeerRateLimits string `mapstructure:"peer_rate_limits"`
+
+	// Rate limits per channel, within the connection rate limits. Comma
+	// separated list of channel=send_rate:recv_rate, in bytes/second, where
+	// channel is a reactor name (e.g. mempool) or a channel ID (e.g. 0x30).
+	// 0 is unlimited.
+	ChannelRateLimits string `mapstructure:"channel_rate_limits"`
+
 	// Set true to enable the peer-exchange reactor
 	PexReactor bool `mapstructure:"pex"`
 
@@ -525,10 +842,27 @@ type P2PConfig struct { //nolint: maligned
 	// Does not work if the peer-exchange reactor is disabled.
 	SeedMode bool `mapstructure:"seed_mode"`
 
+	// Address to serve the statistics of the crawled network on, as HTTP/JSON.
+	// Only used in seed mode. If empty, the statistics are not served.
+	CrawlerListenAddress string `mapstructure:"crawler_laddr"`
+
 	// Comma separated list of peer IDs to keep private (will not be gossiped to
 	// other peers)
 	PrivatePeerIDs string `mapstructure:"private_peer_ids"`
 
+	// Sentry topology mode: "" (off), "validator" or "sentry".
+	//
+	// A validator only connects to the sentries in SentryPeers and runs
+	// without the peer-exchange reactor. A sentry keeps connected to the
+	// validators in SentryValidators and never gossips their addresses.
+	SentryMode string `mapstructure:"sentry_mode"`
+
+	// Comma separated list of ID@host:port sentries of a validator
+	SentryPeers string `mapstructure:"sentry_peers"`
+
+	// Comma separated list of ID@host:port validators behind a sentry
+	SentryValidators string `mapstructure:"sentry_validators"`
+
 	// Toggle to disable guard against peers connecting from the same ip.
 	AllowDuplicateIP bool `mapstructure:"allow_duplicate_ip"`
 
@@ -536,6 +870,12 @@ type P2PConfig struct { //nolint: maligned
 	HandshakeTimeout time.Duration `mapstructure:"handshake_timeout"`
 	DialTimeout      time.Duration `mapstructure:"dial_timeout"`
 
+	// Trust score (0-100) below which a peer that keeps misbehaving is banned.
+	// Set to 0 to disable banning.
+	PeerBanThreshold int `mapstructure:"peer_ban_threshold"`
+	// How long a banned peer is refused
+	PeerBanDuration time.Duration `mapstructure:"peer_ban_duration"`
+
 	// Testing params.
 	// Force dial to fail
 	TestDialFail bool `mapstructure:"test_dial_fail"`
@@ -548,8 +888,12 @@ type P2PConfig struct { //nolint: maligned
 func DefaultP2PConfig() *P2PConfig {
 	return &P2PConfig{
 		ListenAddress:                "tcp://0.0.0.0:26656",
+		Transport:                    "tcp",
 		ExternalAddress:              "",
 		UPNP:                         false,
+		NATPMP:                       false,
+		NATGateway:                   "",
+		DiscoverExternalAddress:      true,
 		AddrBook:                     defaultAddrBookPath,
 		AddrBookStrict:               true,
 		MaxNumInboundPeers:           40,
@@ -561,9 +905,12 @@ func DefaultP2PConfig() *P2PConfig {
 		RecvRate:                     5120000, // 5 mB/s
 		PexReactor:                   true,
 		SeedMode:                     false,
+		CrawlerListenAddress:         "",
 		AllowDuplicateIP:             false,
 		HandshakeTimeout:             20 * time.Second,
 		DialTimeout:                  3 * time.Second,
+		PeerBanThreshold:             20,
+		PeerBanDuration:              24 * time.Hour,
 		TestDialFail:                 false,
 		TestFuzz:                     false,
 		TestFuzzConfig:               DefaultFuzzConnConfig(),
@@ -587,6 +934,11 @@ func (cfg *P2PConfig) AddrBookFile() string {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *P2PConfig) ValidateBasic() error {
+	switch cfg.Transport {
+	case "tcp", "quic":
+	default:
+		return fmt.Errorf("unknown transport %q, expected tcp or quic", cfg.Transport)
+	}
 	if cfg.MaxNumInboundPeers < 0 {
 		return errors.New("max_num_inbound_peers can't be negative")
 	}
@@ -608,9 +960,75 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return errors.New("recv_rate can't be negative")
 	}
+	if cfg.PeerBanThreshold < 0 || cfg.PeerBanThreshold > 100 {
+		return errors.New("peer_ban_threshold must be between 0 and 100")
+	}
+	if cfg.PeerBanDuration < 0 {
+		return errors.New("peer_ban_duration can't be negative")
+	}
+	if cfg.CrawlerListenAddress != "" && !cfg.SeedMode {
+		return errors.New("crawler_laddr requires seed_mode")
+	}
+	if cfg.UPNP && cfg.NATPMP {
+		return errors.New("only one of upnp and nat_pmp can be set")
+	}
+	return cfg.validateSentryMode()
+}
+
+// validateSentryMode checks that the sentry mode settings are complete and
+// don't contradict the other peer settings.
+func (cfg *P2PConfig) validateSentryMode() error {
+	switch cfg.SentryMode {
+	case "":
+		if cfg.SentryPeers != "" || cfg.SentryValidators != "" {
+			return errors.New("sentry_peers and sentry_validators require a sentry_mode")
+		}
+	case SentryModeValidator:
+		if cfg.SentryPeers == "" {
+			return errors.New("sentry_mode = \"validator\" requires sentry_peers")
+		}
+		if cfg.SentryValidators != "" {
+			return errors.New("sentry_validators requires sentry_mode = \"sentry\"")
+		}
+		if cfg.Seeds != "" || cfg.SeedMode {
+			return errors.New("a validator in sentry mode can't use seeds or seed_mode")
+		}
+		sentries := peerIDs(cfg.SentryPeers)
+		for id := range peerIDs(cfg.PersistentPeers) {
+			if !sentries[id] {
+				return fmt.Errorf("persistent peer %v is not in sentry_peers", id)
+			}
+		}
+	case SentryModeSentry:
+		if cfg.SentryValidators == "" {
+			return errors.New("sentry_mode = \"sentry\" requires sentry_validators")
+		}
+		if cfg.SentryPeers != "" {
+			return errors.New("sentry_peers requires sentry_mode = \"validator\"")
+		}
+		if cfg.SeedMode {
+			return errors.New("a sentry can't run in seed_mode")
+		}
+	default:
+		return fmt.Errorf("unknown sentry_mode %q, expected validator or sentry", cfg.SentryMode)
+	}
 	return nil
 }
 
+// peerIDs returns the IDs of a comma separated list of ID@host:port
+// addresses.
+func peerIDs(addrs string) map[string]bool {
+	ids := make(map[string]bool)
+	for _, addr := range strings.Split(addrs, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		ids[strings.SplitN(addr, "@", 2)[0]] = true
+	}
+	return ids
+}
+
 // FuzzConnConfig is a FuzzedConnection configuration.
 type FuzzConnConfig struct {
 	Mode         int
@@ -822,6 +1240,10 @@ type ConsensusConfig struct {
 	WalPath string `mapstructure:"wal_file"`
 	walFile string // overrides WalPath if set
 
+	// Path to the file the inputs of the consensus state machine are traced
+	// to. Tracing is disabled if empty.
+	TracePath string `mapstructure:"trace_file"`
+
 	// How long we wait for a proposal block before prevoting nil
 	TimeoutPropose time.Duration `mapstructure:"timeout_propose"`
 	// How much timeout_propose increases with each round
@@ -937,6 +1359,11 @@ func (cfg *ConsensusConfig) SetWalFile(walFile string) {
 	cfg.walFile = walFile
 }
 
+// TraceFile returns the full path to the trace file.
+func (cfg *ConsensusConfig) TraceFile() string {
+	return rootify(cfg.TracePath, cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *ConsensusConfig) ValidateBasic() error {
@@ -1011,6 +1438,48 @@ func TestTxIndexConfig() *TxIndexConfig {
 	return DefaultTxIndexConfig()
 }
 
+//-----------------------------------------------------------------------------
+// ValidatorStatsConfig
+
+// ValidatorStatsConfig defines the configuration for the validator signing
+// statistics served by the validator_stats RPC route.
+type ValidatorStatsConfig struct {
+	// Number of recent blocks for which the votes of the validators are kept.
+	// This is also the largest window validator_stats can be queried for.
+	// 0 - the statistics are not tracked.
+	RetainBlocks int64 `mapstructure:"retain_blocks"`
+}
+
+// DefaultValidatorStatsConfig returns a default configuration for the
+// validator statistics.
+func DefaultValidatorStatsConfig() *ValidatorStatsConfig {
+	return &ValidatorStatsConfig{
+		RetainBlocks: 10000,
+	}
+}
+
+// TestValidatorStatsConfig returns a configuration for testing the validator
+// statistics.
+func TestValidatorStatsConfig() *ValidatorStatsConfig {
+	return &ValidatorStatsConfig{
+		RetainBlocks: 100,
+	}
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *ValidatorStatsConfig) ValidateBasic() error {
+	if cfg.RetainBlocks < 0 {
+		return errors.New("retain_blocks can't be negative")
+	}
+	return nil
+}
+
+// IsEnabled returns true if the validator statistics are tracked.
+func (cfg *ValidatorStatsConfig) IsEnabled() bool {
+	return cfg.RetainBlocks > 0
+}
+
 //-----------------------------------------------------------------------------
 // InstrumentationConfig
 
@@ -53,6 +53,11 @@ func TestTLSConfiguration(t *testing.T) {
 	assert.Equal("/abs/path/to/file.crt", cfg.RPC.CertFile())
 	cfg.RPC.TLSKeyFile = "/abs/path/to/file.key"
 	assert.Equal("/abs/path/to/file.key", cfg.RPC.KeyFile())
+
+	cfg.RPC.AuthKeysFile = "keys.json"
+	assert.Equal("/home/user/config/keys.json", cfg.RPC.AuthKeysPath())
+	cfg.RPC.AuthKeysFile = "/abs/path/to/keys.json"
+	assert.Equal("/abs/path/to/keys.json", cfg.RPC.AuthKeysPath())
 }
 
 func TestBaseConfigValidateBasic(t *testing.T) {
@@ -62,6 +67,40 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	// gRPC priv validator requires TLS files
+	cfg = TestBaseConfig()
+	cfg.PrivValidatorGRPCListenAddr = "tcp://127.0.0.1:26659"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorGRPCCert = "config/client.crt"
+	cfg.PrivValidatorGRPCKey = "config/client.key"
+	cfg.PrivValidatorGRPCRootCA = "config/ca.crt"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// and excludes the socket priv validator
+	cfg.PrivValidatorListenAddr = "tcp://127.0.0.1:26658"
+	assert.Error(t, cfg.ValidateBasic())
+
+	// multi signer requires TLS files and a majority threshold
+	cfg = TestBaseConfig()
+	cfg.PrivValidatorMultiSignerAddrs = []string{"tcp://10.0.0.1:26659", "tcp://10.0.0.2:26659", "tcp://10.0.0.3:26659"}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorGRPCCert = "config/client.crt"
+	cfg.PrivValidatorGRPCKey = "config/client.key"
+	cfg.PrivValidatorGRPCRootCA = "config/ca.crt"
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.Equal(t, 2, cfg.PrivValidatorMultiSignerQuorum())
+	cfg.PrivValidatorMultiSignerThreshold = 1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorMultiSignerThreshold = 4
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorMultiSignerThreshold = 3
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.Equal(t, 3, cfg.PrivValidatorMultiSignerQuorum())
+
+	// and excludes the other remote priv validators
+	cfg.PrivValidatorGRPCListenAddr = "tcp://127.0.0.1:26659"
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -73,9 +112,16 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		"MaxOpenConnections",
 		"MaxSubscriptionClients",
 		"MaxSubscriptionsPerClient",
+		"SubscriptionSpillMaxBytes",
+		"SubscriptionMaxReplayBlocks",
 		"TimeoutBroadcastTxCommit",
 		"MaxBodyBytes",
 		"MaxHeaderBytes",
+		"ResponseCacheSize",
+		"MaxBatchSize",
+		"MethodTimeout",
+		"RateLimitPerKeyBurst",
+		"RateLimitPerIPBurst",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -83,6 +129,44 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	for _, fieldName := range []string{"RateLimitPerKey", "RateLimitPerIP"} {
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetFloat(-1)
+		assert.Error(t, cfg.ValidateBasic())
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetFloat(0)
+	}
+
+	cfg.AuthRequired = true
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.AuthKeysFile = "keys.json"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.SubscriptionBufferSize = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.SubscriptionBufferSize = 100
+
+	cfg.SubscriptionOverflowPolicy = "drop_all"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.SubscriptionOverflowPolicy = "spill"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.SubscriptionSpillMaxBytes = 1 << 20
+	assert.NoError(t, cfg.ValidateBasic())
+
+	for _, fieldName := range []string{"BatchConcurrency", "WebsocketConcurrency"} {
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
+		assert.Error(t, cfg.ValidateBasic())
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(1)
+	}
+
+	cfg.MethodTimeouts = []string{"tx_search"}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.MethodTimeouts = []string{"tx_search=-1s"}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.MethodTimeouts = []string{"tx_search=30s", "status=0s"}
+	assert.NoError(t, cfg.ValidateBasic())
+	timeouts, err := cfg.MethodTimeoutsByName()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"tx_search": 30 * time.Second, "status": 0}, timeouts)
 }
 
 func TestP2PConfigValidateBasic(t *testing.T) {
@@ -96,6 +180,8 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		"MaxPacketMsgPayloadSize",
 		"SendRate",
 		"RecvRate",
+		"PeerBanThreshold",
+		"PeerBanDuration",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -103,6 +189,85 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.PeerBanThreshold = 101
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PeerBanThreshold = 0
+
+	cfg.Transport = "quic"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Transport = "udp"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Transport = "tcp"
+
+	cfg.CrawlerListenAddress = "127.0.0.1:26670"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.SeedMode = true
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.CrawlerListenAddress, cfg.SeedMode = "", false
+
+	cfg.UPNP, cfg.NATPMP = true, true
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.UPNP = false
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.NATPMP = false
+}
+
+func TestP2PConfigValidateSentryMode(t *testing.T) {
+	const (
+		sentry1   = "1111111111111111111111111111111111111111@127.0.0.1:26656"
+		sentry2   = "2222222222222222222222222222222222222222@127.0.0.1:26666"
+		validator = "3333333333333333333333333333333333333333@127.0.0.1:26676"
+	)
+
+	testcases := map[string]struct {
+		modify    func(*P2PConfig)
+		expectErr bool
+	}{
+		"off":                       {func(c *P2PConfig) {}, false},
+		"sentry peers without mode": {func(c *P2PConfig) { c.SentryPeers = sentry1 }, true},
+		"unknown mode":              {func(c *P2PConfig) { c.SentryMode = "guard" }, true},
+		"validator": {func(c *P2PConfig) {
+			c.SentryMode = SentryModeValidator
+			c.SentryPeers = sentry1 + "," + sentry2
+			c.PersistentPeers = sentry2
+		}, false},
+		"validator without sentries": {func(c *P2PConfig) { c.SentryMode = SentryModeValidator }, true},
+		"validator with seeds": {func(c *P2PConfig) {
+			c.SentryMode = SentryModeValidator
+			c.SentryPeers = sentry1
+			c.Seeds = sentry2
+		}, true},
+		"validator with other persistent peers": {func(c *P2PConfig) {
+			c.SentryMode = SentryModeValidator
+			c.SentryPeers = sentry1
+			c.PersistentPeers = sentry2
+		}, true},
+		"sentry": {func(c *P2PConfig) {
+			c.SentryMode = SentryModeSentry
+			c.SentryValidators = validator
+		}, false},
+		"sentry without validators": {func(c *P2PConfig) { c.SentryMode = SentryModeSentry }, true},
+		"sentry in seed mode": {func(c *P2PConfig) {
+			c.SentryMode = SentryModeSentry
+			c.SentryValidators = validator
+			c.SeedMode = true
+		}, true},
+	}
+	for desc, tc := range testcases {
+		tc := tc // appease linter
+		t.Run(desc, func(t *testing.T) {
+			cfg := TestP2PConfig()
+			tc.modify(cfg)
+
+			err := cfg.ValidateBasic()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -182,6 +347,19 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 	}
 }
 
+func TestValidatorStatsConfigValidateBasic(t *testing.T) {
+	cfg := TestValidatorStatsConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.True(t, cfg.IsEnabled())
+
+	cfg.RetainBlocks = 0
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.False(t, cfg.IsEnabled())
+
+	cfg.RetainBlocks = -1
+	assert.Error(t, cfg.ValidateBasic())
+}
+
 func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	cfg := TestInstrumentationConfig()
 	assert.NoError(t, cfg.ValidateBasic())
@@ -130,6 +130,11 @@ genesis_file = "{{ js .BaseConfig.Genesis }}"
 # Path to the JSON file containing the private key to use as a validator in the consensus protocol
 priv_validator_key_file = "{{ js .BaseConfig.PrivValidatorKey }}"
 
+# Path to a file holding the passphrase of an encrypted priv_validator_key_file.
+# If empty, the passphrase is read from $TM_PRIV_VALIDATOR_KEY_PASSPHRASE or
+# prompted for.
+priv_validator_key_passphrase_file = "{{ js .BaseConfig.PrivValidatorKeyPassphrase }}"
+
 # Path to the JSON file containing the last sign state of a validator
 priv_validator_state_file = "{{ js .BaseConfig.PrivValidatorState }}"
 
@@ -137,6 +142,27 @@ priv_validator_state_file = "{{ js .BaseConfig.PrivValidatorState }}"
 # connections from an external PrivValidator process
 priv_validator_laddr = "{{ .BaseConfig.PrivValidatorListenAddr }}"
 
+# TCP address of an external PrivValidator process serving the
+# PrivValidatorAPI gRPC service. Tendermint dials it using mutual TLS.
+# Can't be used together with priv_validator_laddr.
+priv_validator_grpc_laddr = "{{ .BaseConfig.PrivValidatorGRPCListenAddr }}"
+
+# PEM encoded certificate and key Tendermint presents to the gRPC PrivValidator,
+# and the root CA used to verify it. Paths are relative to the home directory.
+priv_validator_grpc_cert_file = "{{ js .BaseConfig.PrivValidatorGRPCCert }}"
+priv_validator_grpc_key_file = "{{ js .BaseConfig.PrivValidatorGRPCKey }}"
+priv_validator_grpc_root_ca_file = "{{ js .BaseConfig.PrivValidatorGRPCRootCA }}"
+
+# TCP addresses of several external PrivValidator processes serving the
+# PrivValidatorAPI gRPC service and holding the same key. Tendermint dials
+# each of them using the priv_validator_grpc_*_file credentials and only
+# signs once priv_validator_multi_signer_threshold of them agree.
+# Can't be used together with priv_validator_laddr or priv_validator_grpc_laddr.
+priv_validator_multi_signer_addrs = [{{ range .BaseConfig.PrivValidatorMultiSignerAddrs }}{{ printf "%q, " . }}{{end}}]
+
+# Number of signers which must agree; 0 means a majority.
+priv_validator_multi_signer_threshold = {{ .BaseConfig.PrivValidatorMultiSignerThreshold }}
+
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node_key_file = "{{ js .BaseConfig.NodeKey }}"
 
@@ -172,7 +198,10 @@ cors_allowed_methods = [{{ range .RPC.CORSAllowedMethods }}{{ printf "%q, " . }}
 cors_allowed_headers = [{{ range .RPC.CORSAllowedHeaders }}{{ printf "%q, " . }}{{end}}]
 
 # TCP or UNIX socket address for the gRPC server to listen on
-# NOTE: This server only supports /broadcast_tx_commit
+# NOTE: This server supports /broadcast_tx_commit and the queries of the
+# QueryAPI service (see proto/tendermint/rpc/grpc/query.proto).
+# The gRPC server has no authentication nor rate limiting, so it must
+# not be exposed publicly.
 grpc_laddr = "{{ .RPC.GRPCListenAddress }}"
 
 # Maximum number of simultaneous connections.
@@ -206,6 +235,31 @@ max_subscription_clients = {{ .RPC.MaxSubscriptionClients }}
 # the estimated # maximum number of broadcast_tx_commit calls per block.
 max_subscriptions_per_client = {{ .RPC.MaxSubscriptionsPerClient }}
 
+# Number of events buffered for each subscription of a client, before
+# subscription_overflow_policy applies.
+subscription_buffer_size = {{ .RPC.SubscriptionBufferSize }}
+
+# What happens when a client doesn't pull the events fast enough and the
+# buffer of its subscription is full, unless the client chose another
+# policy when subscribing:
+#   1) "cancel" - the subscription is cancelled
+#   2) "drop_oldest" - the oldest buffered event is dropped
+#   3) "drop_newest" - the new event is dropped
+#   4) "spill" - the events are written to disk until the client catches
+#   up, the subscription is cancelled if they exceed
+#   subscription_spill_max_bytes
+subscription_overflow_policy = "{{ .RPC.SubscriptionOverflowPolicy }}"
+
+# Maximum size of the events written to disk for a subscription with the
+# "spill" policy, in bytes.
+# 0 - clients can't use the "spill" policy.
+subscription_spill_max_bytes = {{ .RPC.SubscriptionSpillMaxBytes }}
+
+# Maximum number of committed blocks whose events a subscription can replay
+# with from_height or cursor. Older heights are rejected.
+# 0 - the events can't be replayed.
+subscription_max_replay_blocks = {{ .RPC.SubscriptionMaxReplayBlocks }}
+
 # How long to wait for a tx to be committed during /broadcast_tx_commit.
 # WARNING: Using a value larger than 10s will result in increasing the
 # global HTTP write timeout, which applies to all connections and endpoints.
@@ -218,6 +272,34 @@ max_body_bytes = {{ .RPC.MaxBodyBytes }}
 # Maximum size of request header, in bytes
 max_header_bytes = {{ .RPC.MaxHeaderBytes }}
 
+# Number of responses of /block, /commit, /block_results and /validators
+# for committed heights kept in memory.
+# 0 - disable the cache.
+response_cache_size = {{ .RPC.ResponseCacheSize }}
+
+# Maximum number of requests in a JSON-RPC batch.
+# 0 - unlimited.
+max_batch_size = {{ .RPC.MaxBatchSize }}
+
+# Number of requests of a JSON-RPC batch, and of a websocket connection,
+# executed at the same time. Broadcasts and unsafe methods still wait for
+# the requests before them.
+# 1 - execute the requests one after another.
+batch_concurrency = {{ .RPC.BatchConcurrency }}
+websocket_concurrency = {{ .RPC.WebsocketConcurrency }}
+
+# How long a method may run before the server replies with an error.
+# Does not apply to /subscribe. A method, which times out, keeps running
+# in the background unless it stops once the request is canceled (like
+# tx_search and block_search), hence no timeout by default.
+# 0 - no timeout.
+method_timeout = "{{ .RPC.MethodTimeout }}"
+
+# Timeouts of individual methods, overriding method_timeout, as
+# "method=duration".
+# Example: ["tx_search=30s", "block_search=30s"]
+method_timeouts = [{{ range .RPC.MethodTimeouts }}{{ printf "%q, " . }}{{end}}]
+
 # The path to a file containing certificate that is used to create the HTTPS server.
 # Might be either absolute path or path related to Tendermint's config directory.
 # If the certificate is signed by a certificate authority,
@@ -233,6 +315,30 @@ tls_cert_file = "{{ .RPC.TLSCertFile }}"
 # Otherwise, HTTP server is run.
 tls_key_file = "{{ .RPC.TLSKeyFile }}"
 
+# The path to a JSON file with the API keys accepted by the RPC server.
+# Might be either absolute path or path related to Tendermint's config directory.
+# Example: {"keys": [{"id": "partner", "type": "bearer", "secret": "...", "methods": ["status", "block"], "rate": 10, "burst": 20}]}
+# "bearer" keys are sent as "Authorization: Bearer <secret>". "hmac" keys sign
+# each request: "Authorization: HMAC <id>:<unix timestamp>:<hex signature>".
+# "methods", "rate" and "burst" are optional.
+auth_keys_file = "{{ .RPC.AuthKeysFile }}"
+
+# Reject requests, which don't carry valid credentials (401).
+# Otherwise, requests without credentials are served subject to the per-IP rate limit.
+auth_required = {{ .RPC.AuthRequired }}
+
+# Number of requests per second a key may make (unless set in the key itself),
+# and the number of requests it may make at once. Exceeding it results in 429.
+# 0 - unlimited.
+rate_limit_per_key = {{ .RPC.RateLimitPerKey }}
+rate_limit_per_key_burst = {{ .RPC.RateLimitPerKeyBurst }}
+
+# Number of requests per second an unauthenticated client IP may make, and the
+# number of requests it may make at once. Exceeding it results in 429.
+# 0 - unlimited.
+rate_limit_per_ip = {{ .RPC.RateLimitPerIP }}
+rate_limit_per_ip_burst = {{ .RPC.RateLimitPerIPBurst }}
+
 # pprof listen address (https://golang.org/pkg/net/http/pprof)
 pprof_laddr = "{{ .RPC.PprofListenAddress }}"
 
@@ -244,9 +350,15 @@ pprof_laddr = "{{ .RPC.PprofListenAddress }}"
 # Address to listen for incoming connections
 laddr = "{{ .P2P.ListenAddress }}"
 
+# Transport used to connect to peers, "tcp" or "quic". QUIC sends every
+# channel over a stream of its own, so a busy channel can't hold back the
+# others. It listens on the UDP port of laddr, and a QUIC node can only
+# connect to other QUIC nodes.
+transport = "{{ .P2P.Transport }}"
+
 # Address to advertise to peers for them to dial
 # If empty, will use the same port as the laddr,
-# and will introspect on the listener or use UPnP
+# and will use UPnP or NAT-PMP/PCP, or ask the peers,
 # to figure out the address.
 external_address = "{{ .P2P.ExternalAddress }}"
 
@@ -259,7 +371,20 @@ persistent_peers = "{{ .P2P.PersistentPeers }}"
 # UPNP port forwarding
 upnp = {{ .P2P.UPNP }}
 
-# Path to address book
+# NAT-PMP/PCP port forwarding
+nat_pmp = {{ .P2P.NATPMP }}
+
+# Address of the NAT-PMP/PCP gateway. If empty, the default gateway is used,
+# which is only found on Linux.
+nat_gateway = "{{ .P2P.NATGateway }}"
+
+# Learn the external IP from the addresses peers observe our connections
+# from. Only used if external_address is empty and there is no port
+# forwarding.
+discover_external_address = {{ .P2P.DiscoverExternalAddress }}
+
+# Path to a JSON address book to import. The address book is kept in the
+# addrbook DB; the file is only read when that DB is empty.
 addr_book_file = "{{ js .P2P.AddrBook }}"
 
 # Set true for strict address routability rules
@@ -290,6 +415,25 @@ send_rate = {{ .P2P.SendRate }}
 # Rate at which packets can be received, in bytes/second
 recv_rate = {{ .P2P.RecvRate }}
 
+# Comma separated list of node IDs of validators, whose connections use the
+# validator rate limits of peer_rate_limits
+validator_peer_ids = "{{ .P2P.ValidatorPeerIDs }}"
+
+# Connection rate limits per peer class, overriding send_rate and recv_rate.
+# Comma separated list of class=send_rate:recv_rate, in bytes/second, where
+# class is validator, unconditional or persistent. 0 is unlimited.
+# Example: "validator=0:0,persistent=10240000:10240000"
+peer_rate_limits = "{{ .P2P.PeerRateLimits }}"
+
+# Rate limits per channel, within the connection rate limits. Comma
+# separated list of channel=send_rate:recv_rate, in bytes/second, where
+# channel is a reactor name (e.g. mempool) or a channel ID (e.g. 0x30).
+# 0 is unlimited. A receive limit pauses reading from the whole connection,
+# so prefer send limits: every node limiting what it sends on the mempool
+# channel keeps a tx flood from starving consensus gossip.
+# Example: "mempool=512000:0"
+channel_rate_limits = "{{ .P2P.ChannelRateLimits }}"
+
 # Set true to enable the peer-exchange reactor
 pex = {{ .P2P.PexReactor }}
 
@@ -299,9 +443,27 @@ pex = {{ .P2P.PexReactor }}
 # Does not work if the peer-exchange reactor is disabled.
 seed_mode = {{ .P2P.SeedMode }}
 
+# Address to serve the statistics of the crawled network on, as HTTP/JSON.
+# Only used in seed mode. If empty, the statistics are not served.
+crawler_laddr = "{{ .P2P.CrawlerListenAddress }}"
+
 # Comma separated list of peer IDs to keep private (will not be gossiped to other peers)
 private_peer_ids = "{{ .P2P.PrivatePeerIDs }}"
 
+# Sentry topology mode: "" (off), "validator" or "sentry".
+#
+# A validator only connects to the sentries in sentry_peers, which it keeps
+# connected like persistent peers, and runs without the peer-exchange
+# reactor. A sentry keeps connected to the validators in sentry_validators,
+# redials them when they stop responding and never gossips their addresses.
+sentry_mode = "{{ .P2P.SentryMode }}"
+
+# Comma separated list of ID@host:port sentries of a validator
+sentry_peers = "{{ .P2P.SentryPeers }}"
+
+# Comma separated list of ID@host:port validators behind a sentry
+sentry_validators = "{{ .P2P.SentryValidators }}"
+
 # Toggle to disable guard against peers connecting from the same ip.
 allow_duplicate_ip = {{ .P2P.AllowDuplicateIP }}
 
@@ -309,6 +471,13 @@ allow_duplicate_ip = {{ .P2P.AllowDuplicateIP }}
 handshake_timeout = "{{ .P2P.HandshakeTimeout }}"
 dial_timeout = "{{ .P2P.DialTimeout }}"
 
+# Peers are scored (0-100) based on the good and bad behaviour reactors
+# report. A peer whose score stays below peer_ban_threshold while it keeps
+# misbehaving is disconnected and refused for peer_ban_duration.
+# Set peer_ban_threshold to 0 to disable banning.
+peer_ban_threshold = {{ .P2P.PeerBanThreshold }}
+peer_ban_duration = "{{ .P2P.PeerBanDuration }}"
+
 #######################################################
 ###          Mempool Configuration Option          ###
 #######################################################
@@ -390,6 +559,12 @@ version = "{{ .FastSync.Version }}"
 
 wal_file = "{{ js .Consensus.WalPath }}"
 
+# Path to a file the inputs of the consensus state machine (messages, timeouts,
+# backend calls) are recorded to as newline delimited JSON, for debugging.
+# A recorded run is replayed with "tendermint replay-trace".
+# Leave empty to disable tracing.
+trace_file = "{{ js .Consensus.TracePath }}"
+
 # How long we wait for a proposal block before prevoting nil
 timeout_propose = "{{ .Consensus.TimeoutPropose }}"
 # How much timeout_propose increases with each round
@@ -440,6 +615,17 @@ peer_query_maj23_sleep_duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 # 		- When "kv" is chosen "tx.height" and "tx.hash" will always be indexed.
 indexer = "{{ .TxIndex.Indexer }}"
 
+#######################################################
+###    Validator Statistics Configuration Options   ###
+#######################################################
+[validator_stats]
+
+# Number of recent blocks for which the votes of the validators are kept to
+# serve the validator_stats RPC route. This is also the largest window the
+# route can be queried for.
+# 0 - the statistics are not tracked.
+retain_blocks = {{ .ValidatorStats.RetainBlocks }}
+
 #######################################################
 ###       Instrumentation Configuration Options     ###
 #######################################################
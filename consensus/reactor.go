@@ -9,6 +9,7 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 
+	"github.com/arcology-network/consensus-engine/behaviour"
 	cstypes "github.com/arcology-network/consensus-engine/consensus/types"
 	"github.com/arcology-network/consensus-engine/libs/bits"
 	tmevents "github.com/arcology-network/consensus-engine/libs/events"
@@ -47,6 +48,7 @@ type Reactor struct {
 	mtx      tmsync.RWMutex
 	waitSync bool
 	eventBus *types.EventBus
+	reporter behaviour.Reporter
 
 	Metrics *Metrics
 }
@@ -60,6 +62,7 @@ func NewReactor(consensusState *State, waitSync bool, options ...ReactorOption)
 		conS:     consensusState,
 		waitSync: waitSync,
 		Metrics:  NopMetrics(),
+		reporter: behaviour.NewMockReporter(),
 	}
 	conR.BaseReactor = *p2p.NewBaseReactor("Consensus", conR)
 
@@ -70,6 +73,12 @@ func NewReactor(consensusState *State, waitSync bool, options ...ReactorOption)
 	return conR
 }
 
+// SetSwitch implements Reactor by also reporting peer behaviour to the switch.
+func (conR *Reactor) SetSwitch(sw *p2p.Switch) {
+	conR.BaseReactor.SetSwitch(sw)
+	conR.reporter = behaviour.NewSwitchReporter(sw)
+}
+
 func (conR *Reactor) SetBackendProxy(backend monaco.BackendProxy) {
 	conR.conS.SetBackendProxy(backend)
 }
@@ -147,31 +156,40 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 	return []*p2p.ChannelDescriptor{
 		{
 			ID:                  StateChannel,
+			Class:               p2p.PriorityClassHigh,
 			Priority:            6,
 			SendQueueCapacity:   100,
 			RecvMessageCapacity: maxMsgSize,
+			MessageType:         &tmcons.Message{},
 		},
 		{
 			ID: DataChannel, // maybe split between gossiping current block and catchup stuff
 			// once we gossip the whole block there's nothing left to send until next height or round
+			Class:               p2p.PriorityClassHigh,
 			Priority:            10,
 			SendQueueCapacity:   100,
 			RecvBufferCapacity:  50 * 4096,
 			RecvMessageCapacity: maxMsgSize,
+			MessageType:         &tmcons.Message{},
 		},
 		{
-			ID:                  VoteChannel,
+			ID: VoteChannel,
+			// votes go before anything else, most of all the block parts
+			Class:               p2p.PriorityClassCritical,
 			Priority:            7,
 			SendQueueCapacity:   100,
 			RecvBufferCapacity:  100 * 100,
 			RecvMessageCapacity: maxMsgSize,
+			MessageType:         &tmcons.Message{},
 		},
 		{
 			ID:                  VoteSetBitsChannel,
+			Class:               p2p.PriorityClassHigh,
 			Priority:            1,
 			SendQueueCapacity:   2,
 			RecvBufferCapacity:  1024,
 			RecvMessageCapacity: maxMsgSize,
+			MessageType:         &tmcons.Message{},
 		},
 	}
 }
@@ -234,13 +252,13 @@ func (conR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	msg, err := decodeMsg(msgBytes)
 	if err != nil {
 		conR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
-		conR.Switch.StopPeerForError(src, err)
+		_ = conR.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 		return
 	}
 
 	if err = msg.ValidateBasic(); err != nil {
 		conR.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
-		conR.Switch.StopPeerForError(src, err)
+		_ = conR.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 		return
 	}
 
@@ -261,7 +279,7 @@ func (conR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 			conR.conS.mtx.Unlock()
 			if err = msg.ValidateHeight(initialHeight); err != nil {
 				conR.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
-				conR.Switch.StopPeerForError(src, err)
+				_ = conR.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 				return
 			}
 			ps.ApplyNewRoundStepMessage(msg)
@@ -280,7 +298,7 @@ func (conR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 			// Peer claims to have a maj23 for some BlockID at H,R,S,
 			err := votes.SetPeerMaj23(msg.Round, msg.Type, ps.peer.ID(), msg.BlockID)
 			if err != nil {
-				conR.Switch.StopPeerForError(src, err)
+				_ = conR.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 				return
 			}
 			// Respond with a VoteSetBitsMessage showing which votes we have.
@@ -882,11 +900,11 @@ func (conR *Reactor) peerStatsRoutine() {
 			switch msg.Msg.(type) {
 			case *VoteMessage:
 				if numVotes := ps.RecordVote(); numVotes%votesToContributeToBecomeGoodPeer == 0 {
-					conR.Switch.MarkPeerAsGood(peer)
+					_ = conR.reporter.Report(behaviour.ConsensusVote(peer.ID(), "votes"))
 				}
 			case *BlockPartMessage:
 				if numParts := ps.RecordBlockPart(); numParts%blocksToContributeToBecomeGoodPeer == 0 {
-					conR.Switch.MarkPeerAsGood(peer)
+					_ = conR.reporter.Report(behaviour.BlockPart(peer.ID(), "block parts"))
 				}
 			}
 		case <-conR.conS.Quit():
@@ -1189,15 +1189,28 @@ func (bs *mockBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
 		Header:  block.Header,
 	}
 }
+func (bs *mockBlockStore) LoadBlockMetaByHash(hash []byte) *types.BlockMeta {
+	for _, block := range bs.chain {
+		if block != nil && bytes.Equal(block.Hash(), hash) {
+			return bs.LoadBlockMeta(block.Height)
+		}
+	}
+	return nil
+}
 func (bs *mockBlockStore) LoadBlockPart(height int64, index int) *types.Part { return nil }
 func (bs *mockBlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
 }
+func (bs *mockBlockStore) SaveBlockAsync(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
+}
 func (bs *mockBlockStore) LoadBlockCommit(height int64) *types.Commit {
 	return bs.commits[height-1]
 }
 func (bs *mockBlockStore) LoadSeenCommit(height int64) *types.Commit {
 	return bs.commits[height-1]
 }
+func (bs *mockBlockStore) SaveSeenCommit(height int64, seenCommit *types.Commit) error {
+	return nil
+}
 
 func (bs *mockBlockStore) PruneBlocks(height int64) (uint64, error) {
 	pruned := uint64(0)
@@ -151,6 +151,9 @@ type State struct {
 	ntxsOfBeforePrevious int
 
 	backend monaco.BackendProxy
+
+	// records the inputs of the receiveRoutine, if set
+	tracer *Tracer
 }
 
 // StateOption sets an optional parameter on the State.
@@ -401,6 +404,12 @@ func (cs *State) OnStart() error {
 		return err
 	}
 
+	// backend calls made by fast sync and the WAL catchup are not part of
+	// the trace
+	if cs.tracer != nil {
+		cs.tracer.discardCalls()
+	}
+
 	// now start the receiveRoutine
 	go cs.receiveRoutine(0)
 
@@ -778,6 +787,7 @@ func (cs *State) receiveRoutine(maxSteps int) {
 		select {
 		case <-cs.txNotifier.TxsAvailable():
 			cs.handleTxsAvailable()
+			cs.trace(nil, true)
 
 		case mi = <-cs.peerMsgQueue:
 			if err := cs.wal.Write(mi); err != nil {
@@ -787,6 +797,7 @@ func (cs *State) receiveRoutine(maxSteps int) {
 			// handles proposals, block parts, votes
 			// may generate internal events (votes, complete proposals, 2/3 majorities)
 			cs.handleMsg(mi)
+			cs.trace(mi, false)
 
 		case mi = <-cs.internalMsgQueue:
 			/*
@@ -809,6 +820,7 @@ func (cs *State) receiveRoutine(maxSteps int) {
 
 			// handles proposals, block parts, votes
 			cs.handleMsg(mi)
+			cs.trace(mi, false)
 
 		case ti := <-cs.timeoutTicker.Chan(): // tockChan:
 			if err := cs.wal.Write(ti); err != nil {
@@ -818,6 +830,7 @@ func (cs *State) receiveRoutine(maxSteps int) {
 			// if the timeout is relevant to the rs
 			// go to the next step
 			cs.handleTimeout(ti, rs)
+			cs.trace(ti, false)
 
 		case <-cs.Quit():
 			onExit(cs)
@@ -826,6 +839,16 @@ func (cs *State) receiveRoutine(maxSteps int) {
 	}
 }
 
+// trace records an input processed by the receiveRoutine, if tracing is enabled.
+func (cs *State) trace(input WALMessage, txsAvailable bool) {
+	if cs.tracer == nil {
+		return
+	}
+	if err := cs.tracer.record(input, txsAvailable, &cs.RoundState); err != nil {
+		cs.Logger.Error("failed writing to trace", "err", err)
+	}
+}
+
 // state transitions on complete-proposal, 2/3-any, 2/3-one
 func (cs *State) handleMsg(mi msgInfo) {
 	cs.mtx.Lock()
@@ -0,0 +1,421 @@
+package consensus
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	cfg "github.com/arcology-network/consensus-engine/config"
+	cstypes "github.com/arcology-network/consensus-engine/consensus/types"
+	tmjson "github.com/arcology-network/consensus-engine/libs/json"
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
+	"github.com/arcology-network/consensus-engine/monaco"
+	"github.com/arcology-network/consensus-engine/types"
+	tmtime "github.com/arcology-network/consensus-engine/types/time"
+)
+
+// Backend methods recorded in a trace.
+const (
+	TraceCallReap         = "Reap"
+	TraceCallApplyTxsSync = "ApplyTxsSync"
+)
+
+// TraceEvent is one input processed by the receiveRoutine, together with the
+// backend calls it caused and the height/round/step it left the state in.
+// Input is a msgInfo or timeoutInfo, or nil if TxsAvailable is set.
+type TraceEvent struct {
+	Seq          int64              `json:"seq"`
+	Time         time.Time          `json:"time"`
+	Input        WALMessage         `json:"input,omitempty"`
+	TxsAvailable bool               `json:"txs_available,omitempty"`
+	Calls        []TraceBackendCall `json:"calls,omitempty"`
+
+	Height int64  `json:"height"`
+	Round  int32  `json:"round"`
+	Step   string `json:"step"`
+}
+
+// TraceBackendCall is a call to monaco.BackendProxy made by the state
+// machine. Only the fields relevant to Method are set.
+type TraceBackendCall struct {
+	Method string `json:"method"`
+	Height int64  `json:"height"`
+
+	// Reap
+	MaxBytes int64    `json:"max_bytes,omitempty"`
+	MaxGas   int64    `json:"max_gas,omitempty"`
+	Txs      [][]byte `json:"txs,omitempty"`
+
+	// ApplyTxsSync
+	Coinbase  []byte    `json:"coinbase,omitempty"`
+	Timestamp time.Time `json:"timestamp,omitempty"`
+	AppHash   []byte    `json:"app_hash,omitempty"`
+
+	// argument of ApplyTxsSync, result of Reap
+	Hashes [][]byte `json:"hashes,omitempty"`
+}
+
+// Tracer writes the inputs processed by the consensus state machine as newline
+// delimited JSON. Unlike the WAL it also records timeouts that are no longer
+// relevant, TxsAvailable notifications and the calls made to the backend, so
+// two runs can be diffed and replayed with ReplayTrace.
+type Tracer struct {
+	mtx     tmsync.Mutex
+	w       io.Writer
+	seq     int64
+	pending []TraceBackendCall
+}
+
+// NewTracer returns a Tracer writing to w.
+func NewTracer(w io.Writer) *Tracer {
+	return &Tracer{w: w}
+}
+
+// StateTracer sets the Tracer recording the inputs of the State.
+func StateTracer(t *Tracer) StateOption {
+	return func(cs *State) { cs.tracer = t }
+}
+
+func (t *Tracer) recordCall(call TraceBackendCall) {
+	t.mtx.Lock()
+	t.pending = append(t.pending, call)
+	t.mtx.Unlock()
+}
+
+// discardCalls drops the backend calls recorded so far.
+func (t *Tracer) discardCalls() {
+	t.mtx.Lock()
+	t.pending = nil
+	t.mtx.Unlock()
+}
+
+// record writes an event for input, attaching the backend calls made since the
+// previous event.
+func (t *Tracer) record(input WALMessage, txsAvailable bool, rs *cstypes.RoundState) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.seq++
+	event := TraceEvent{
+		Seq:          t.seq,
+		Time:         tmtime.Now(),
+		Input:        input,
+		TxsAvailable: txsAvailable,
+		Calls:        t.pending,
+		Height:       rs.Height,
+		Round:        rs.Round,
+		Step:         rs.Step.String(),
+	}
+	t.pending = nil
+
+	bz, err := tmjson.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trace event: %w", err)
+	}
+	_, err = t.w.Write(append(bz, '\n'))
+	return err
+}
+
+// ReadTrace reads the events written by a Tracer.
+func ReadTrace(r io.Reader) ([]TraceEvent, error) {
+	var (
+		events []TraceEvent
+		br     = bufio.NewReader(r)
+	)
+	for line := 1; ; line++ {
+		bz, err := br.ReadBytes('\n')
+		if len(bytes.TrimSpace(bz)) > 0 {
+			var event TraceEvent
+			if err := tmjson.Unmarshal(bz, &event); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			events = append(events, event)
+		}
+		if err == io.EOF {
+			return events, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// LastTraceRun returns the events of the last run recorded in events. A node
+// appends to its trace file and numbers the events of every run from 1.
+func LastTraceRun(events []TraceEvent) []TraceEvent {
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Seq == 1 {
+			return events[i:]
+		}
+	}
+	return events
+}
+
+// tracingBackend records the Reap and ApplyTxsSync calls made through it.
+type tracingBackend struct {
+	monaco.BackendProxy
+	tracer *Tracer
+}
+
+// NewTracingBackend wraps backend so Reap and ApplyTxsSync calls are recorded
+// by tracer. The wrapper must be passed to the BlockExecutor of the traced
+// State.
+func NewTracingBackend(backend monaco.BackendProxy, tracer *Tracer) monaco.BackendProxy {
+	return &tracingBackend{BackendProxy: backend, tracer: tracer}
+}
+
+func (b *tracingBackend) Reap(maxBytes int64, maxGas int64, height int64) ([][]byte, [][]byte) {
+	txs, hashes := b.BackendProxy.Reap(maxBytes, maxGas, height)
+	b.tracer.recordCall(TraceBackendCall{
+		Method:   TraceCallReap,
+		Height:   height,
+		MaxBytes: maxBytes,
+		MaxGas:   maxGas,
+		Txs:      txs,
+		Hashes:   hashes,
+	})
+	return txs, hashes
+}
+
+func (b *tracingBackend) ApplyTxsSync(height int64, coinbase []byte, timestamp time.Time, hashes [][]byte) []byte {
+	appHash := b.BackendProxy.ApplyTxsSync(height, coinbase, timestamp, hashes)
+	b.tracer.recordCall(TraceBackendCall{
+		Method:    TraceCallApplyTxsSync,
+		Height:    height,
+		Coinbase:  coinbase,
+		Timestamp: timestamp,
+		Hashes:    hashes,
+		AppHash:   appHash,
+	})
+	return appHash
+}
+
+//-----------------------------------------------------------------------------
+// Replay
+
+// TraceDivergence describes the first event at which a replay behaved
+// differently from the recording.
+type TraceDivergence struct {
+	Seq      int64
+	Reason   string
+	Expected string
+	Got      string
+}
+
+func (d *TraceDivergence) Error() string {
+	return fmt.Sprintf("replay diverged at event %d: %s (expected %s, got %s)",
+		d.Seq, d.Reason, d.Expected, d.Got)
+}
+
+// replayBackend serves the recorded results of the backend calls of the
+// current event and reports calls that don't match the recording.
+type replayBackend struct {
+	monaco.BackendProxy
+	calls      []TraceBackendCall
+	divergence *TraceDivergence
+}
+
+func (b *replayBackend) next(method string) (TraceBackendCall, bool) {
+	if b.divergence != nil {
+		return TraceBackendCall{}, false
+	}
+	if len(b.calls) == 0 {
+		b.divergence = &TraceDivergence{Reason: "unexpected backend call", Expected: "no call", Got: method}
+		return TraceBackendCall{}, false
+	}
+	call := b.calls[0]
+	b.calls = b.calls[1:]
+	if call.Method != method {
+		b.divergence = &TraceDivergence{Reason: "unexpected backend call", Expected: call.Method, Got: method}
+		return TraceBackendCall{}, false
+	}
+	return call, true
+}
+
+func (b *replayBackend) diverge(reason string, expected, got interface{}) {
+	if b.divergence == nil {
+		b.divergence = &TraceDivergence{
+			Reason:   reason,
+			Expected: fmt.Sprintf("%v", expected),
+			Got:      fmt.Sprintf("%v", got),
+		}
+	}
+}
+
+func (b *replayBackend) Reap(maxBytes int64, maxGas int64, height int64) ([][]byte, [][]byte) {
+	call, ok := b.next(TraceCallReap)
+	if !ok {
+		return nil, nil
+	}
+	if call.Height != height || call.MaxBytes != maxBytes || call.MaxGas != maxGas {
+		b.diverge("Reap arguments differ",
+			fmt.Sprintf("%d/%d/%d", call.Height, call.MaxBytes, call.MaxGas),
+			fmt.Sprintf("%d/%d/%d", height, maxBytes, maxGas))
+	}
+	return call.Txs, call.Hashes
+}
+
+func (b *replayBackend) ApplyTxsSync(height int64, coinbase []byte, timestamp time.Time, hashes [][]byte) []byte {
+	call, ok := b.next(TraceCallApplyTxsSync)
+	if !ok {
+		return nil
+	}
+	switch {
+	case call.Height != height:
+		b.diverge("ApplyTxsSync height differs", call.Height, height)
+	case !bytes.Equal(call.Coinbase, coinbase):
+		b.diverge("ApplyTxsSync coinbase differs", fmt.Sprintf("%X", call.Coinbase), fmt.Sprintf("%X", coinbase))
+	case !call.Timestamp.Equal(timestamp):
+		b.diverge("ApplyTxsSync timestamp differs", call.Timestamp, timestamp)
+	case !equalHashes(call.Hashes, hashes):
+		b.diverge("ApplyTxsSync hashes differ", fmt.Sprintf("%X", call.Hashes), fmt.Sprintf("%X", hashes))
+	}
+	return call.AppHash
+}
+
+func equalHashes(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// replayTicker drops scheduled timeouts; the timeouts to process are taken
+// from the recording.
+type replayTicker struct {
+	TimeoutTicker
+}
+
+func (replayTicker) ScheduleTimeout(ti timeoutInfo) {}
+
+// ReplayTrace feeds the recorded events to cs, serving backend calls from the
+// recording, and returns the first point at which cs behaves differently: an
+// unexpected or missing backend call, different call arguments, a different
+// height/round/step after an event, or a panic while processing an event. cs must be a fresh State
+// created from the chain state the recording started at, and must not be
+// running; since the trace starts when the receiveRoutine does, that is the
+// state after the WAL catchup. Messages cs sends to itself are dropped, since the recording
+// contains them as separate events; to reproduce them cs needs the same
+// private validator as the recorded node.
+func (cs *State) ReplayTrace(events []TraceEvent) (*TraceDivergence, error) {
+	if cs.IsRunning() {
+		return nil, errors.New("cs is already running, cannot replay")
+	}
+
+	if cs.eventBus == nil {
+		eventBus := types.NewEventBus()
+		if err := eventBus.Start(); err != nil {
+			return nil, err
+		}
+		defer eventBus.Stop() //nolint:errcheck
+		cs.SetEventBus(eventBus)
+	}
+
+	backend := &replayBackend{BackendProxy: cs.backend}
+	cs.blockExec.SetBackendProxy(backend)
+	cs.SetBackendProxy(backend)
+	cs.timeoutTicker = replayTicker{cs.timeoutTicker}
+	cs.replayMode = true
+	defer func() { cs.replayMode = false }()
+
+	for _, event := range events {
+		backend.calls, backend.divergence = event.Calls, nil
+
+		if err := cs.replayTraceEvent(event); err != nil {
+			return &TraceDivergence{Seq: event.Seq, Reason: "event failed", Expected: "no error", Got: err.Error()}, nil
+		}
+		cs.drainInternalMsgQueue()
+
+		if d := backend.divergence; d != nil {
+			d.Seq = event.Seq
+			return d, nil
+		}
+		if len(backend.calls) > 0 {
+			return &TraceDivergence{
+				Seq:      event.Seq,
+				Reason:   "missing backend call",
+				Expected: backend.calls[0].Method,
+				Got:      "no call",
+			}, nil
+		}
+
+		expected := fmt.Sprintf("%d/%d/%s", event.Height, event.Round, event.Step)
+		got := fmt.Sprintf("%d/%d/%s", cs.Height, cs.Round, cs.Step)
+		if expected != got {
+			return &TraceDivergence{Seq: event.Seq, Reason: "round state differs", Expected: expected, Got: got}, nil
+		}
+	}
+	return nil, nil
+}
+
+// RunReplayTrace replays the last run recorded in traceFile against the latest
+// state stored in the node's data directory, which must be the state the run
+// started from. privVal must hold the key of the recorded node, or be nil if it
+// wasn't a validator.
+func RunReplayTrace(
+	config cfg.BaseConfig,
+	csConfig *cfg.ConsensusConfig,
+	traceFile string,
+	privVal types.PrivValidator,
+) (*TraceDivergence, error) {
+	f, err := os.Open(traceFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	events, err := ReadTrace(f)
+	if err != nil {
+		return nil, fmt.Errorf("can't read trace: %w", err)
+	}
+	events = LastTraceRun(events)
+	if len(events) == 0 {
+		return nil, fmt.Errorf("trace %s is empty", traceFile)
+	}
+
+	cs := newConsensusStateForReplay(config, csConfig, true)
+	if privVal != nil {
+		cs.SetPrivValidator(privVal)
+	}
+	return cs.ReplayTrace(events)
+}
+
+func (cs *State) replayTraceEvent(event TraceEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	switch input := event.Input.(type) {
+	case msgInfo:
+		cs.handleMsg(input)
+	case timeoutInfo:
+		cs.handleTimeout(input, cs.RoundState)
+	case nil:
+		if event.TxsAvailable {
+			cs.handleTxsAvailable()
+		}
+	default:
+		return fmt.Errorf("unknown trace input %T", event.Input)
+	}
+	return nil
+}
+
+func (cs *State) drainInternalMsgQueue() {
+	for {
+		select {
+		case <-cs.internalMsgQueue:
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package consensus
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/arcology-network/consensus-engine/consensus/types"
+	"github.com/arcology-network/consensus-engine/libs/log"
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
+	"github.com/arcology-network/consensus-engine/monaco"
+	tmproto "github.com/arcology-network/consensus-engine/proto/tendermint/types"
+	"github.com/arcology-network/consensus-engine/types"
+	tmtime "github.com/arcology-network/consensus-engine/types/time"
+)
+
+type traceTestBackend struct {
+	monaco.BackendProxy
+}
+
+func (traceTestBackend) Reap(maxBytes int64, maxGas int64, height int64) ([][]byte, [][]byte) {
+	return [][]byte{{1, 2, 3}}, [][]byte{{4, 5, 6}}
+}
+
+func (traceTestBackend) AddToMempool(txs [][]byte, src string) {}
+
+func (traceTestBackend) SwitchToConsensus() {}
+
+func (traceTestBackend) ApplyTxsSync(height int64, coinbase []byte, timestamp time.Time, hashes [][]byte) []byte {
+	return []byte{7, 8, 9}
+}
+
+func TestTracerRoundTrip(t *testing.T) {
+	buf := new(bytes.Buffer)
+	tracer := NewTracer(buf)
+	backend := NewTracingBackend(traceTestBackend{}, tracer)
+
+	vote := &types.Vote{
+		Type:      tmproto.PrevoteType,
+		Height:    1,
+		Round:     0,
+		Timestamp: tmtime.Now(),
+	}
+	rs := &cstypes.RoundState{Height: 1, Round: 0, Step: cstypes.RoundStepPropose}
+
+	txs, hashes := backend.Reap(100, -1, 1)
+	assert.Equal(t, [][]byte{{1, 2, 3}}, txs)
+	require.NoError(t, tracer.record(msgInfo{Msg: &VoteMessage{vote}, PeerID: "peer"}, false, rs))
+
+	now := tmtime.Now()
+	appHash := backend.ApplyTxsSync(1, []byte{0xAA}, now, hashes)
+	assert.Equal(t, []byte{7, 8, 9}, appHash)
+	rs.Step = cstypes.RoundStepCommit
+	require.NoError(t, tracer.record(timeoutInfo{time.Second, 1, 0, cstypes.RoundStepPrevoteWait}, false, rs))
+	require.NoError(t, tracer.record(nil, true, rs))
+
+	events, err := ReadTrace(buf)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.EqualValues(t, 1, events[0].Seq)
+	require.Len(t, events[0].Calls, 1)
+	assert.Equal(t, TraceCallReap, events[0].Calls[0].Method)
+	assert.Equal(t, int64(100), events[0].Calls[0].MaxBytes)
+	assert.Equal(t, [][]byte{{4, 5, 6}}, events[0].Calls[0].Hashes)
+	mi, ok := events[0].Input.(msgInfo)
+	require.True(t, ok)
+	assert.Equal(t, "peer", string(mi.PeerID))
+	assert.Equal(t, vote.Height, mi.Msg.(*VoteMessage).Vote.Height)
+	assert.Equal(t, "RoundStepPropose", events[0].Step)
+
+	require.Len(t, events[1].Calls, 1)
+	call := events[1].Calls[0]
+	assert.Equal(t, TraceCallApplyTxsSync, call.Method)
+	assert.True(t, now.Equal(call.Timestamp))
+	assert.Equal(t, []byte{7, 8, 9}, call.AppHash)
+	assert.Equal(t, timeoutInfo{time.Second, 1, 0, cstypes.RoundStepPrevoteWait}, events[1].Input)
+	assert.Equal(t, "RoundStepCommit", events[1].Step)
+
+	assert.Nil(t, events[2].Input)
+	assert.True(t, events[2].TxsAvailable)
+	assert.Empty(t, events[2].Calls)
+}
+
+func TestTracerDiscardCalls(t *testing.T) {
+	buf := new(bytes.Buffer)
+	tracer := NewTracer(buf)
+	backend := NewTracingBackend(traceTestBackend{}, tracer)
+
+	backend.Reap(100, -1, 1)
+	tracer.discardCalls()
+	require.NoError(t, tracer.record(nil, true, &cstypes.RoundState{}))
+
+	events, err := ReadTrace(buf)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Empty(t, events[0].Calls)
+}
+
+func TestLastTraceRun(t *testing.T) {
+	events := []TraceEvent{{Seq: 1}, {Seq: 2}, {Seq: 3}, {Seq: 1, Height: 5}, {Seq: 2, Height: 5}}
+	last := LastTraceRun(events)
+	require.Len(t, last, 2)
+	assert.EqualValues(t, 5, last[0].Height)
+
+	assert.Empty(t, LastTraceRun(nil))
+}
+
+func TestReplayBackendDivergence(t *testing.T) {
+	now := tmtime.Now()
+	calls := []TraceBackendCall{
+		{Method: TraceCallReap, Height: 1, MaxBytes: 100, MaxGas: -1, Txs: [][]byte{{1}}, Hashes: [][]byte{{2}}},
+		{Method: TraceCallApplyTxsSync, Height: 1, Coinbase: []byte{0xAA}, Timestamp: now,
+			Hashes: [][]byte{{2}}, AppHash: []byte{3}},
+	}
+
+	// matching calls are served from the recording
+	b := &replayBackend{calls: calls}
+	txs, hashes := b.Reap(100, -1, 1)
+	assert.Equal(t, [][]byte{{1}}, txs)
+	assert.Equal(t, []byte{3}, b.ApplyTxsSync(1, []byte{0xAA}, now, hashes))
+	assert.Nil(t, b.divergence)
+	assert.Empty(t, b.calls)
+
+	// different arguments
+	b = &replayBackend{calls: calls}
+	b.Reap(100, -1, 1)
+	b.ApplyTxsSync(1, []byte{0xAA}, now, [][]byte{{9}})
+	require.NotNil(t, b.divergence)
+	assert.Equal(t, "ApplyTxsSync hashes differ", b.divergence.Reason)
+
+	// calls out of order
+	b = &replayBackend{calls: calls}
+	b.ApplyTxsSync(1, []byte{0xAA}, now, [][]byte{{2}})
+	require.NotNil(t, b.divergence)
+	assert.Equal(t, TraceCallReap, b.divergence.Expected)
+	assert.Equal(t, TraceCallApplyTxsSync, b.divergence.Got)
+
+	// unexpected call
+	b = &replayBackend{}
+	b.Reap(100, -1, 1)
+	require.NotNil(t, b.divergence)
+	assert.Equal(t, "unexpected backend call", b.divergence.Reason)
+}
+
+func TestReplayTraceRoundStateDivergence(t *testing.T) {
+	state, privVals := randGenesisState(1, false, 10)
+	cs := newState(state, privVals[0], newCounter())
+	cs.SetBackendProxy(traceTestBackend{})
+	cs.SetLogger(log.TestingLogger())
+
+	// the timeout of a different height is ignored, so the state doesn't move
+	events := []TraceEvent{{
+		Seq:    1,
+		Input:  timeoutInfo{time.Second, cs.Height + 1, 0, cstypes.RoundStepNewHeight},
+		Height: cs.Height,
+		Round:  cs.Round,
+		Step:   cs.Step.String(),
+	}, {
+		Seq:    2,
+		Input:  timeoutInfo{time.Second, cs.Height + 1, 0, cstypes.RoundStepNewHeight},
+		Height: cs.Height + 1,
+		Round:  0,
+		Step:   cstypes.RoundStepNewRound.String(),
+	}}
+
+	divergence, err := cs.ReplayTrace(events)
+	require.NoError(t, err)
+	require.NotNil(t, divergence)
+	assert.EqualValues(t, 2, divergence.Seq)
+	assert.Equal(t, "round state differs", divergence.Reason)
+}
+
+// lockedBuffer is a bytes.Buffer safe for concurrent use.
+type lockedBuffer struct {
+	mtx tmsync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) Bytes() []byte {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return append([]byte(nil), b.buf.Bytes()...)
+}
+
+func TestReplayTrace(t *testing.T) {
+	state, privVals := randGenesisState(1, false, 10)
+
+	// record the first height
+	buf := new(lockedBuffer)
+	tracer := NewTracer(buf)
+	cs1 := newState(state.Copy(), privVals[0], newCounter())
+	cs1.tracer = tracer
+	backend := NewTracingBackend(traceTestBackend{}, tracer)
+	cs1.blockExec.SetBackendProxy(backend)
+	cs1.SetBackendProxy(backend)
+	newRoundCh := subscribe(cs1.eventBus, types.EventQueryNewRound)
+
+	require.NoError(t, cs1.Start())
+	ensureNewRound(newRoundCh, 1, 0)
+	ensureNewRound(newRoundCh, 2, 0)
+
+	// the event is written once the receiveRoutine is done with it
+	var events []TraceEvent
+	require.Eventually(t, func() bool {
+		all, err := ReadTrace(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		for i, event := range all {
+			if event.Height == 2 {
+				events = all[:i+1]
+				return true
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, cs1.Stop())
+	cs1.Wait()
+
+	// the recording replays without divergence
+	cs2 := newState(state.Copy(), privVals[0], newCounter())
+	cs2.SetBackendProxy(traceTestBackend{})
+	divergence, err := cs2.ReplayTrace(events)
+	require.NoError(t, err)
+	require.Nil(t, divergence)
+	assert.EqualValues(t, 2, cs2.Height)
+
+	// a different app hash is not noticed until the next height, but a
+	// missing backend call is
+	var applyEvent int
+	for i, event := range events {
+		if len(event.Calls) > 0 && event.Calls[0].Method == TraceCallApplyTxsSync {
+			applyEvent = i
+		}
+	}
+	require.NotZero(t, applyEvent)
+	tampered := append([]TraceEvent(nil), events...)
+	tampered[applyEvent].Calls = nil
+
+	cs3 := newState(state.Copy(), privVals[0], newCounter())
+	cs3.SetBackendProxy(traceTestBackend{})
+	divergence, err = cs3.ReplayTrace(tampered)
+	require.NoError(t, err)
+	require.NotNil(t, divergence)
+	assert.Equal(t, events[applyEvent].Seq, divergence.Seq)
+	assert.Equal(t, "unexpected backend call", divergence.Reason)
+}
@@ -0,0 +1,191 @@
+package consensus
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+
+	auto "github.com/arcology-network/consensus-engine/libs/autofile"
+	"github.com/arcology-network/consensus-engine/types"
+)
+
+// WALEntry is a message read from a WAL file together with its location and
+// encoded size. If the data at Offset is corrupted, Msg is nil and Err holds
+// the DataCorruptionError.
+type WALEntry struct {
+	Path   string
+	Offset int64
+	Size   int64
+	Msg    *TimedWALMessage
+	Err    error
+}
+
+// WALFilePaths returns the paths of the files in the WAL group with the head
+// walFile, oldest first.
+func WALFilePaths(walFile string) ([]string, error) {
+	if _, err := os.Stat(walFile); err != nil {
+		return nil, err
+	}
+
+	group, err := auto.OpenGroup(walFile)
+	if err != nil {
+		return nil, err
+	}
+	defer group.Close()
+
+	info := group.ReadGroupInfo()
+	paths := make([]string, 0, info.MaxIndex-info.MinIndex+1)
+	for index := info.MinIndex; index < info.MaxIndex; index++ {
+		paths = append(paths, fmt.Sprintf("%v.%03d", walFile, index))
+	}
+	return append(paths, walFile), nil
+}
+
+// ScanWAL decodes every message of the WAL group with the head walFile, in
+// order, and passes it to visit. After a corrupted entry the rest of the file
+// is searched for the next valid entry, so every corrupted region is reported
+// once. Scanning stops at the first error returned by visit.
+func ScanWAL(walFile string, visit func(WALEntry) error) error {
+	paths, err := WALFilePaths(walFile)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		if err := scanWALFile(path, visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanWALFile(path string, visit func(WALEntry) error) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var offset int64
+	for offset < int64(len(data)) {
+		rd := bytes.NewReader(data[offset:])
+		msg, err := NewWALDecoder(rd).Decode()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			if err := visit(WALEntry{Path: path, Offset: offset, Err: err}); err != nil {
+				return err
+			}
+			next, found := resyncWAL(data, offset+1)
+			if !found {
+				return nil
+			}
+			offset = next
+			continue
+		}
+
+		next := int64(len(data)) - int64(rd.Len())
+		if err := visit(WALEntry{Path: path, Offset: offset, Size: next - offset, Msg: msg}); err != nil {
+			return err
+		}
+		offset = next
+	}
+	return nil
+}
+
+// resyncWAL returns the offset of the first entry at or after from which
+// decodes without error.
+func resyncWAL(data []byte, from int64) (int64, bool) {
+	for offset := from; offset+8 < int64(len(data)); offset++ {
+		if _, err := NewWALDecoder(bytes.NewReader(data[offset:])).Decode(); err == nil {
+			return offset, true
+		}
+	}
+	return 0, false
+}
+
+// WALMessageInfo returns the height and round a WAL message refers to and a
+// short name of its type. ok is false if the message carries no height.
+func WALMessageInfo(msg WALMessage) (height int64, round int32, kind string, ok bool) {
+	switch m := msg.(type) {
+	case EndHeightMessage:
+		return m.Height, 0, "EndHeight", true
+	case types.EventDataRoundState:
+		return m.Height, m.Round, "RoundState", true
+	case timeoutInfo:
+		return m.Height, m.Round, "Timeout", true
+	case msgInfo:
+		kind := reflect.Indirect(reflect.ValueOf(m.Msg)).Type().Name()
+		switch cm := m.Msg.(type) {
+		case *ProposalMessage:
+			return cm.Proposal.Height, cm.Proposal.Round, kind, true
+		case *BlockPartMessage:
+			return cm.Height, cm.Round, kind, true
+		case *VoteMessage:
+			return cm.Vote.Height, cm.Vote.Round, kind, true
+		default:
+			return 0, 0, kind, false
+		}
+	default:
+		return 0, 0, fmt.Sprintf("%T", msg), false
+	}
+}
+
+// TruncateWAL drops every entry written after the EndHeightMessage for height
+// from the WAL group with the head walFile. Later files of the group are
+// removed and the file containing the marker becomes the new head. The node
+// must not be running.
+func TruncateWAL(walFile string, height int64) error {
+	paths, err := WALFilePaths(walFile)
+	if err != nil {
+		return err
+	}
+
+	var (
+		cutPath   string
+		cutOffset int64
+	)
+	errFound := errors.New("found")
+	err = ScanWAL(walFile, func(entry WALEntry) error {
+		if entry.Msg == nil {
+			return nil
+		}
+		if m, ok := entry.Msg.Msg.(EndHeightMessage); ok && m.Height == height {
+			cutPath, cutOffset = entry.Path, entry.Offset+entry.Size
+			return errFound
+		}
+		return nil
+	})
+	if err != nil && err != errFound {
+		return err
+	}
+	if cutPath == "" {
+		return fmt.Errorf("no EndHeightMessage for height %d in WAL", height)
+	}
+
+	if err := os.Truncate(cutPath, cutOffset); err != nil {
+		return err
+	}
+
+	cut := false
+	for _, path := range paths {
+		if path == cutPath {
+			cut = true
+			continue
+		}
+		if cut {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+		}
+	}
+	if cutPath != walFile {
+		return os.Rename(cutPath, walFile)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/arcology-network/consensus-engine/behaviour"
 	clist "github.com/arcology-network/consensus-engine/libs/clist"
 	"github.com/arcology-network/consensus-engine/libs/log"
 	"github.com/arcology-network/consensus-engine/p2p"
@@ -30,17 +31,25 @@ type Reactor struct {
 	p2p.BaseReactor
 	evpool   *Pool
 	eventBus *types.EventBus
+	reporter behaviour.Reporter
 }
 
 // NewReactor returns a new Reactor with the given config and evpool.
 func NewReactor(evpool *Pool) *Reactor {
 	evR := &Reactor{
-		evpool: evpool,
+		evpool:   evpool,
+		reporter: behaviour.NewMockReporter(),
 	}
 	evR.BaseReactor = *p2p.NewBaseReactor("Evidence", evR)
 	return evR
 }
 
+// SetSwitch implements Reactor by also reporting peer behaviour to the switch.
+func (evR *Reactor) SetSwitch(sw *p2p.Switch) {
+	evR.BaseReactor.SetSwitch(sw)
+	evR.reporter = behaviour.NewSwitchReporter(sw)
+}
+
 // SetLogger sets the Logger on the reactor and the underlying Evidence.
 func (evR *Reactor) SetLogger(l log.Logger) {
 	evR.Logger = l
@@ -55,6 +64,7 @@ func (evR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			ID:                  EvidenceChannel,
 			Priority:            6,
 			RecvMessageCapacity: maxMsgSize,
+			MessageType:         &tmproto.EvidenceList{},
 		},
 	}
 }
@@ -70,7 +80,7 @@ func (evR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	evis, err := decodeMsg(msgBytes)
 	if err != nil {
 		evR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
-		evR.Switch.StopPeerForError(src, err)
+		_ = evR.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 		return
 	}
 
@@ -80,9 +90,10 @@ func (evR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 		case *types.ErrInvalidEvidence:
 			evR.Logger.Error(err.Error())
 			// punish peer
-			evR.Switch.StopPeerForError(src, err)
+			_ = evR.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 			return
 		case nil:
+			_ = evR.reporter.Report(behaviour.ValidMessage(src.ID(), "evidence"))
 		default:
 			// continue to the next piece of evidence
 			evR.Logger.Error("Evidence has not been added", "evidence", evis, "err", err)
@@ -0,0 +1,89 @@
+package pubsub
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "pubsub"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of messages waiting to be pulled by a client, in memory and on
+	// disk, across its subscriptions.
+	BufferedMessages metrics.Gauge
+	// Number of messages dropped because a client wasn't pulling them fast
+	// enough, by overflow policy.
+	DroppedMessages metrics.Counter
+	// Number of messages written to disk because a client wasn't pulling them
+	// fast enough.
+	SpilledMessages metrics.Counter
+
+	// removes the BufferedMessages series of a client, which has no
+	// subscriptions left (nil if there are no series).
+	deleteBufferedMessages func(clientID string)
+}
+
+// clientGone removes the series of a client, which has no subscriptions left,
+// so that the number of series is bounded by the number of clients.
+func (m *Metrics) clientGone(clientID string) {
+	if m.deleteBufferedMessages != nil {
+		m.deleteBufferedMessages(clientID)
+	}
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	constLabels := stdprometheus.Labels{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+		constLabels[labelsAndValues[i]] = labelsAndValues[i+1]
+	}
+	buffered := stdprometheus.NewGaugeVec(stdprometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: MetricsSubsystem,
+		Name:      "buffered_messages",
+		Help:      "Number of messages waiting to be pulled by a client.",
+	}, append(labels, "client_id"))
+	stdprometheus.MustRegister(buffered)
+	return &Metrics{
+		BufferedMessages: prometheus.NewGauge(buffered).With(labelsAndValues...),
+		DroppedMessages: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "dropped_messages_total",
+			Help:      "Number of messages dropped because a client wasn't pulling them fast enough.",
+		}, append(labels, "policy")).With(labelsAndValues...),
+		SpilledMessages: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "spilled_messages_total",
+			Help:      "Number of messages written to disk because a client wasn't pulling them fast enough.",
+		}, labels).With(labelsAndValues...),
+		deleteBufferedMessages: func(clientID string) {
+			series := stdprometheus.Labels{"client_id": clientID}
+			for label, value := range constLabels {
+				series[label] = value
+			}
+			buffered.Delete(series)
+		},
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		BufferedMessages: discard.NewGauge(),
+		DroppedMessages:  discard.NewCounter(),
+		SpilledMessages:  discard.NewCounter(),
+	}
+}
@@ -37,6 +37,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/arcology-network/consensus-engine/libs/service"
 	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
@@ -51,6 +53,10 @@ const (
 	shutdown
 )
 
+// bufferMetricsInterval is how often the number of buffered messages is
+// measured.
+const bufferMetricsInterval = time.Second
+
 var (
 	// ErrSubscriptionNotFound is returned when a client tries to unsubscribe
 	// from not existing subscription.
@@ -93,6 +99,10 @@ type Server struct {
 	cmds    chan cmd
 	cmdsCap int
 
+	metrics       *Metrics
+	spillDir      string
+	spillMaxBytes int64
+
 	// check if we have subscription before
 	// subscribing or unsubscribing
 	mtx           tmsync.RWMutex
@@ -108,6 +118,7 @@ type Option func(*Server)
 func NewServer(options ...Option) *Server {
 	s := &Server{
 		subscriptions: make(map[string]map[string]struct{}),
+		metrics:       NopMetrics(),
 	}
 	s.BaseService = *service.NewBaseService(nil, "PubSub", s)
 
@@ -133,6 +144,22 @@ func BufferCapacity(cap int) Option {
 	}
 }
 
+// WithMetrics sets the metrics.
+func WithMetrics(metrics *Metrics) Option {
+	return func(s *Server) { s.metrics = metrics }
+}
+
+// SpillDirectory sets the directory where the subscriptions with the
+// OverflowSpill policy write the messages their clients are not pulling fast
+// enough, up to maxBytes per subscription. The files left behind in dir are
+// removed when the server starts.
+func SpillDirectory(dir string, maxBytes int64) Option {
+	return func(s *Server) {
+		s.spillDir = dir
+		s.spillMaxBytes = maxBytes
+	}
+}
+
 // BufferCapacity returns capacity of the internal server's queue.
 func (s *Server) BufferCapacity() int {
 	return s.cmdsCap
@@ -159,17 +186,63 @@ func (s *Server) Subscribe(
 		outCap = outCapacity[0]
 	}
 
-	return s.subscribe(ctx, clientID, query, outCap)
+	return s.subscribe(ctx, clientID, query, NewSubscription(outCap))
+}
+
+// SubscribeWithPolicy does the same as Subscribe, except the given policy
+// tells what happens when the client is not pulling messages fast enough,
+// instead of cancelling the subscription. Panics if outCapacity is less than
+// or equal to zero. An error is returned if the policy is unknown or if it's
+// OverflowSpill and the server has no SpillDirectory.
+func (s *Server) SubscribeWithPolicy(
+	ctx context.Context,
+	clientID string,
+	query Query,
+	outCapacity int,
+	policy OverflowPolicy) (*Subscription, error) {
+	if outCapacity <= 0 {
+		panic("Negative or zero capacity. Use SubscribeUnbuffered if you want an unbuffered channel")
+	}
+	if err := policy.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	subscription := NewSubscription(outCapacity)
+	subscription.policy = policy
+	if policy == OverflowSpill {
+		if s.spillDir == "" {
+			return nil, errors.New("spilling messages to disk is not enabled")
+		}
+		spill, err := newSpillQueue(s.spillDir, s.spillMaxBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create spill file: %w", err)
+		}
+		subscription.spill = spill
+	}
+
+	sub, err := s.subscribe(ctx, clientID, query, subscription)
+	if subscription.spill != nil {
+		if sub == nil {
+			subscription.spill.close()
+		} else {
+			go subscription.drainSpill()
+		}
+	}
+	return sub, err
 }
 
 // SubscribeUnbuffered does the same as Subscribe, except it returns a
 // subscription with unbuffered channel. Use with caution as it can freeze the
 // server.
 func (s *Server) SubscribeUnbuffered(ctx context.Context, clientID string, query Query) (*Subscription, error) {
-	return s.subscribe(ctx, clientID, query, 0)
+	return s.subscribe(ctx, clientID, query, NewSubscription(0))
 }
 
-func (s *Server) subscribe(ctx context.Context, clientID string, query Query, outCapacity int) (*Subscription, error) {
+func (s *Server) subscribe(
+	ctx context.Context,
+	clientID string,
+	query Query,
+	subscription *Subscription) (*Subscription, error) {
 	s.mtx.RLock()
 	clientSubscriptions, ok := s.subscriptions[clientID]
 	if ok {
@@ -180,7 +253,6 @@ func (s *Server) subscribe(ctx context.Context, clientID string, query Query, ou
 		return nil, ErrAlreadySubscribed
 	}
 
-	subscription := NewSubscription(outCapacity)
 	select {
 	case s.cmds <- cmd{op: sub, clientID: clientID, query: query, subscription: subscription}:
 		s.mtx.Lock()
@@ -295,6 +367,8 @@ type state struct {
 	subscriptions map[string]map[string]*Subscription
 	// query string -> queryPlusRefCount
 	queries map[string]*queryPlusRefCount
+
+	metrics *Metrics
 }
 
 // queryPlusRefCount holds a pointer to a query and reference counter. When
@@ -306,9 +380,16 @@ type queryPlusRefCount struct {
 
 // OnStart implements Service.OnStart by starting the server.
 func (s *Server) OnStart() error {
+	if s.spillDir != "" {
+		if err := removeSpillFiles(s.spillDir); err != nil {
+			return fmt.Errorf("failed to remove old spill files: %w", err)
+		}
+	}
+
 	go s.loop(state{
 		subscriptions: make(map[string]map[string]*Subscription),
 		queries:       make(map[string]*queryPlusRefCount),
+		metrics:       s.metrics,
 	})
 	return nil
 }
@@ -319,24 +400,32 @@ func (s *Server) OnReset() error {
 }
 
 func (s *Server) loop(state state) {
+	ticker := time.NewTicker(bufferMetricsInterval)
+	defer ticker.Stop()
+
 loop:
-	for cmd := range s.cmds {
-		switch cmd.op {
-		case unsub:
-			if cmd.query != nil {
-				state.remove(cmd.clientID, cmd.query.String(), ErrUnsubscribed)
-			} else {
-				state.removeClient(cmd.clientID, ErrUnsubscribed)
-			}
-		case shutdown:
-			state.removeAll(nil)
-			break loop
-		case sub:
-			state.add(cmd.clientID, cmd.query, cmd.subscription)
-		case pub:
-			if err := state.send(cmd.msg, cmd.events); err != nil {
-				s.Logger.Error("Error querying for events", "err", err)
+	for {
+		select {
+		case cmd := <-s.cmds:
+			switch cmd.op {
+			case unsub:
+				if cmd.query != nil {
+					state.remove(cmd.clientID, cmd.query.String(), ErrUnsubscribed)
+				} else {
+					state.removeClient(cmd.clientID, ErrUnsubscribed)
+				}
+			case shutdown:
+				state.removeAll(nil)
+				break loop
+			case sub:
+				state.add(cmd.clientID, cmd.query, cmd.subscription)
+			case pub:
+				if err := state.send(cmd.msg, cmd.events); err != nil {
+					s.Logger.Error("Error querying for events", "err", err)
+				}
 			}
+		case <-ticker.C:
+			state.updateBufferMetrics()
 		}
 	}
 }
@@ -385,6 +474,20 @@ func (state *state) remove(clientID string, qStr string, reason error) {
 	if state.queries[qStr].refCount == 0 {
 		delete(state.queries, qStr)
 	}
+
+	if !state.hasClient(clientID) {
+		state.metrics.clientGone(clientID)
+	}
+}
+
+// hasClient returns true if the client has at least one subscription.
+func (state *state) hasClient(clientID string) bool {
+	for _, clientSubscriptions := range state.subscriptions {
+		if _, ok := clientSubscriptions[clientID]; ok {
+			return true
+		}
+	}
+	return false
 }
 
 func (state *state) removeClient(clientID string, reason error) {
@@ -414,16 +517,8 @@ func (state *state) send(msg interface{}, events map[string][]string) error {
 
 		if match {
 			for clientID, subscription := range clientSubscriptions {
-				if cap(subscription.out) == 0 {
-					// block on unbuffered channel
-					subscription.out <- NewMessage(msg, events)
-				} else {
-					// don't block on buffered channels
-					select {
-					case subscription.out <- NewMessage(msg, events):
-					default:
-						state.remove(clientID, qStr, ErrOutOfCapacity)
-					}
+				if err := state.deliver(subscription, NewMessage(msg, events)); err != nil {
+					state.remove(clientID, qStr, err)
 				}
 			}
 		}
@@ -431,3 +526,79 @@ func (state *state) send(msg interface{}, events map[string][]string) error {
 
 	return nil
 }
+
+// deliver sends msg to the subscription, applying its policy if the client
+// is not pulling messages fast enough. An error is returned if the
+// subscription must be cancelled.
+func (state *state) deliver(subscription *Subscription, msg Message) error {
+	if cap(subscription.out) == 0 {
+		// block on unbuffered channel
+		subscription.out <- msg
+		return nil
+	}
+
+	// don't block on buffered channels
+	switch subscription.policy {
+	case OverflowDropOldest:
+		for {
+			select {
+			case subscription.out <- msg:
+				return nil
+			default:
+			}
+			// the client may have pulled the oldest message in the meantime
+			select {
+			case <-subscription.out:
+				atomic.AddUint64(&subscription.dropped, 1)
+				state.metrics.DroppedMessages.With("policy", string(subscription.policy)).Add(1)
+			default:
+			}
+		}
+
+	case OverflowDropNewest:
+		select {
+		case subscription.out <- msg:
+		default:
+			atomic.AddUint64(&subscription.dropped, 1)
+			state.metrics.DroppedMessages.With("policy", string(subscription.policy)).Add(1)
+		}
+		return nil
+
+	case OverflowSpill:
+		// once a message is spilled, the next ones follow it to keep the order
+		if subscription.spill.size() == 0 {
+			select {
+			case subscription.out <- msg:
+				return nil
+			default:
+			}
+		}
+		if err := subscription.spill.push(msg); err != nil {
+			return err
+		}
+		state.metrics.SpilledMessages.Add(1)
+		return nil
+
+	default:
+		select {
+		case subscription.out <- msg:
+			return nil
+		default:
+			return ErrOutOfCapacity
+		}
+	}
+}
+
+// updateBufferMetrics sets the number of messages buffered for each client
+// across its subscriptions.
+func (state *state) updateBufferMetrics() {
+	buffered := make(map[string]int)
+	for _, clientSubscriptions := range state.subscriptions {
+		for clientID, subscription := range clientSubscriptions {
+			buffered[clientID] += subscription.Buffered()
+		}
+	}
+	for clientID, n := range buffered {
+		state.metrics.BufferedMessages.With("client_id", clientID).Set(float64(n))
+	}
+}
@@ -3,13 +3,17 @@ package pubsub_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"runtime/debug"
 	"testing"
 	"time"
 
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	tmjson "github.com/arcology-network/consensus-engine/libs/json"
 	"github.com/arcology-network/consensus-engine/libs/log"
 
 	"github.com/arcology-network/consensus-engine/libs/pubsub"
@@ -149,6 +153,151 @@ func TestSlowClientIsRemovedWithErrOutOfCapacity(t *testing.T) {
 	assertCancelled(t, subscription, pubsub.ErrOutOfCapacity)
 }
 
+func TestSubscribeWithPolicyDropNewest(t *testing.T) {
+	s := pubsub.NewServer()
+	s.SetLogger(log.TestingLogger())
+	err := s.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	subscription, err := s.SubscribeWithPolicy(ctx, clientID, query.Empty{}, 1, pubsub.OverflowDropNewest)
+	require.NoError(t, err)
+	for _, msg := range []string{"Fat Cobra", "Viper", "Black Mamba"} {
+		require.NoError(t, s.Publish(ctx, msg))
+	}
+
+	assert.Eventually(t, func() bool { return subscription.Dropped() == 2 }, time.Second, 10*time.Millisecond)
+	assertReceive(t, "Fat Cobra", subscription.Out())
+	require.NoError(t, s.Publish(ctx, "Cobra"))
+	assertReceive(t, "Cobra", subscription.Out())
+	assert.Nil(t, subscription.Err())
+}
+
+func TestSubscribeWithPolicyDropOldest(t *testing.T) {
+	s := pubsub.NewServer()
+	s.SetLogger(log.TestingLogger())
+	err := s.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	subscription, err := s.SubscribeWithPolicy(ctx, clientID, query.Empty{}, 2, pubsub.OverflowDropOldest)
+	require.NoError(t, err)
+	for _, msg := range []string{"Fat Cobra", "Viper", "Black Mamba", "Cobra"} {
+		require.NoError(t, s.Publish(ctx, msg))
+	}
+
+	assert.Eventually(t, func() bool { return subscription.Dropped() == 2 }, time.Second, 10*time.Millisecond)
+	assertReceive(t, "Black Mamba", subscription.Out())
+	assertReceive(t, "Cobra", subscription.Out())
+	assert.Nil(t, subscription.Err())
+}
+
+type spilledMsg struct {
+	Name string
+}
+
+func init() {
+	tmjson.RegisterType(spilledMsg{}, "pubsub_test/spilledMsg")
+}
+
+func TestSubscribeWithPolicySpill(t *testing.T) {
+	dir := t.TempDir()
+	// left behind by a previous run
+	stale := filepath.Join(dir, "subscription-1.spill")
+	require.NoError(t, os.WriteFile(stale, []byte("stale"), 0600))
+
+	s := pubsub.NewServer(pubsub.SpillDirectory(dir, 1024))
+	s.SetLogger(log.TestingLogger())
+	err := s.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	assert.NoFileExists(t, stale)
+
+	ctx := context.Background()
+	subscription, err := s.SubscribeWithPolicy(ctx, clientID, query.Empty{}, 1, pubsub.OverflowSpill)
+	require.NoError(t, err)
+	names := []string{"Fat Cobra", "Viper", "Black Mamba", "Cobra"}
+	for _, name := range names {
+		require.NoError(t, s.Publish(ctx, spilledMsg{name}))
+	}
+	assert.Eventually(t, func() bool { return subscription.Buffered() == len(names) }, time.Second, 10*time.Millisecond)
+
+	// the messages are delivered in order
+	for _, name := range names {
+		assertReceive(t, spilledMsg{name}, subscription.Out())
+	}
+	require.NoError(t, s.Publish(ctx, spilledMsg{"Python"}))
+	assertReceive(t, spilledMsg{"Python"}, subscription.Out())
+	assert.Zero(t, subscription.Dropped())
+	assert.Nil(t, subscription.Err())
+
+	// the spill file is removed once unsubscribed
+	files, err := filepath.Glob(filepath.Join(dir, "*.spill"))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+	require.NoError(t, s.Unsubscribe(ctx, clientID, query.Empty{}))
+	assert.Eventually(t, func() bool {
+		files, err := filepath.Glob(filepath.Join(dir, "*.spill"))
+		return err == nil && len(files) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSubscribeWithPolicySpillIsRemovedWithErrOutOfCapacity(t *testing.T) {
+	s := pubsub.NewServer(pubsub.SpillDirectory(t.TempDir(), 64))
+	s.SetLogger(log.TestingLogger())
+	err := s.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	subscription, err := s.SubscribeWithPolicy(ctx, clientID, query.Empty{}, 1, pubsub.OverflowSpill)
+	require.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		require.NoError(t, s.Publish(ctx, spilledMsg{"Fat Cobra"}))
+	}
+
+	assertCancelled(t, subscription, pubsub.ErrOutOfCapacity)
+}
+
+func TestSubscribeWithPolicyErrors(t *testing.T) {
+	s := pubsub.NewServer()
+	s.SetLogger(log.TestingLogger())
+	err := s.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	_, err = s.SubscribeWithPolicy(ctx, clientID, query.Empty{}, 1, "drop_all")
+	assert.Error(t, err)
+	_, err = s.SubscribeWithPolicy(ctx, clientID, query.Empty{}, 1, pubsub.OverflowSpill)
+	assert.Error(t, err, "no spill directory")
+	assert.Panics(t, func() {
+		s.SubscribeWithPolicy(ctx, clientID, query.Empty{}, 0, pubsub.OverflowDropNewest) // nolint:errcheck
+	})
+}
+
 func TestDifferentClients(t *testing.T) {
 	s := pubsub.NewServer()
 	s.SetLogger(log.TestingLogger())
@@ -301,6 +450,65 @@ func TestUnsubscribe(t *testing.T) {
 	assertCancelled(t, subscription, pubsub.ErrUnsubscribed)
 }
 
+func TestBufferedMessagesMetricIsRemovedOnUnsubscribe(t *testing.T) {
+	// the metrics are registered globally, so the namespace is unique per run
+	namespace := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	s := pubsub.NewServer(pubsub.WithMetrics(pubsub.PrometheusMetrics(namespace)))
+	s.SetLogger(log.TestingLogger())
+	err := s.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	for _, q := range []string{"tm.events.type='NewBlock'", "tm.events.type='Tx'"} {
+		_, err := s.Subscribe(ctx, clientID, query.MustParse(q), 10)
+		require.NoError(t, err)
+	}
+	err = s.PublishWithEvents(ctx, "Nick Fury", map[string][]string{"tm.events.type": {"NewBlock"}})
+	require.NoError(t, err)
+
+	// the series is kept until the last subscription of the client is gone
+	require.Eventually(t, func() bool {
+		buffered, ok := bufferedMessages(t, namespace, clientID)
+		return ok && buffered == 1
+	}, 3*time.Second, 10*time.Millisecond)
+	err = s.Unsubscribe(ctx, clientID, query.MustParse("tm.events.type='NewBlock'"))
+	require.NoError(t, err)
+	_, ok := bufferedMessages(t, namespace, clientID)
+	assert.True(t, ok)
+
+	err = s.Unsubscribe(ctx, clientID, query.MustParse("tm.events.type='Tx'"))
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		_, ok := bufferedMessages(t, namespace, clientID)
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
+
+// bufferedMessages returns the value of the pubsub_buffered_messages series of
+// the client, if any.
+func bufferedMessages(t *testing.T, namespace, clientID string) (float64, bool) {
+	families, err := stdprometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != namespace+"_pubsub_buffered_messages" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "client_id" && label.GetValue() == clientID {
+					return metric.GetGauge().GetValue(), true
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
 func TestClientUnsubscribesTwice(t *testing.T) {
 	s := pubsub.NewServer()
 	s.SetLogger(log.TestingLogger())
@@ -84,6 +84,27 @@ func TestParser(t *testing.T) {
 
 		{"hash='136E18F7E4C348B780CF873A0BF43922E5BAFA63'", true},
 		{"hash=136E18F7E4C348B780CF873A0BF43922E5BAFA63", false},
+
+		{"tm.events.type='NewBlock' OR tm.events.type='Tx'", true},
+		{"tm.events.type='NewBlock' OR", false},
+		{"OR tm.events.type='NewBlock'", false},
+		{"tm.events.type='NewBlock' AND tx.height=3 OR tm.events.type='Tx'", true},
+		{"(tm.events.type='NewBlock')", true},
+		{"( tm.events.type='NewBlock' OR tm.events.type='Tx' ) AND tx.height=3", true},
+		{"tx.height=3 AND (tm.events.type='NewBlock' OR (tx.gas > 7 AND tx.gas < 9))", true},
+		{"(tm.events.type='NewBlock'", false},
+		{"tm.events.type='NewBlock')", false},
+		{"()", false},
+		{"NOT tm.events.type='NewBlock'", true},
+		{"NOT NOT tm.events.type='NewBlock'", true},
+		{"NOT(tm.events.type='NewBlock' OR tm.events.type='Tx')", true},
+		{"tx.height=3 AND NOT slashing EXISTS", true},
+		{"NOT", false},
+		{"tm.events.type='NewBlock' NOT tx.height=3", false},
+		// the keywords are still valid tags
+		{"NOT EXISTS", true},
+		{"NOT = 'x' AND OR = 1", true},
+		{"NOTE = 'x'", true},
 	}
 
 	for _, c := range cases {
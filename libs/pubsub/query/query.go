@@ -1,6 +1,7 @@
 // Package query provides a parser for a custom query format:
 //
 //		abci.invoice.number=22 AND abci.invoice.owner=Ivan
+//		tm.event='Tx' AND (transfer.sender='Ivan' OR NOT transfer.recipient EXISTS)
 //
 // See query.peg for the grammar, which is a https://en.wikipedia.org/wiki/Parsing_expression_grammar.
 // More: https://github.com/PhilippeSigaud/Pegged/wiki/PEG-Basics
@@ -9,6 +10,7 @@
 package query
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -21,10 +23,11 @@ var (
 	numRegex = regexp.MustCompile(`([0-9\.]+)`)
 )
 
-// Query holds the query string and the query parser.
+// Query holds the query string and the parsed query.
 type Query struct {
-	str    string
-	parser *QueryParser
+	str  string
+	expr Expression
+	err  error // converting the operands of the conditions
 }
 
 // Condition represents a single condition within a query and consists of composite key
@@ -35,6 +38,28 @@ type Condition struct {
 	Operand      interface{}
 }
 
+// Expression is a node of a query: a condition, or the AND, OR or NOT of other
+// expressions. AND binds tighter than OR, and NOT tighter than both.
+type Expression struct {
+	Op        ExpressionOp
+	Condition Condition    // if Op is ExprCondition
+	Operands  []Expression // one for NOT, two or more for AND and OR
+}
+
+// ExpressionOp is the kind of an expression.
+type ExpressionOp uint8
+
+const (
+	// a single condition
+	ExprCondition ExpressionOp = iota
+	// "AND"; all operands match
+	ExprAnd
+	// "OR"; any operand matches
+	ExprOr
+	// "NOT"; the operand doesn't match
+	ExprNot
+)
+
 // New parses the given string and returns a query or error if the string is
 // invalid.
 func New(s string) (*Query, error) {
@@ -43,7 +68,9 @@ func New(s string) (*Query, error) {
 	if err := p.Parse(); err != nil {
 		return nil, err
 	}
-	return &Query{str: s, parser: p}, nil
+	q := &Query{str: s}
+	q.expr, q.err = newExpression(p.AST(), []rune(p.Buffer))
+	return q, nil
 }
 
 // MustParse turns the given string into a query or panics; for tests or others
@@ -89,106 +116,42 @@ const (
 	TimeLayout = time.RFC3339
 )
 
-// Conditions returns a list of conditions. It returns an error if there is any
+// Expression returns the parsed query. It returns an error if there is any
 // error with the provided grammar in the Query.
-func (q *Query) Conditions() ([]Condition, error) {
-	var (
-		eventAttr string
-		op        Operator
-	)
-
-	conditions := make([]Condition, 0)
-	buffer, begin, end := q.parser.Buffer, 0, 0
-
-	// tokens must be in the following order: tag ("tx.gas") -> operator ("=") -> operand ("7")
-	for token := range q.parser.Tokens() {
-		switch token.pegRule {
-		case rulePegText:
-			begin, end = int(token.begin), int(token.end)
-
-		case ruletag:
-			eventAttr = buffer[begin:end]
-
-		case rulele:
-			op = OpLessEqual
-
-		case rulege:
-			op = OpGreaterEqual
-
-		case rulel:
-			op = OpLess
-
-		case ruleg:
-			op = OpGreater
-
-		case ruleequal:
-			op = OpEqual
-
-		case rulecontains:
-			op = OpContains
-
-		case ruleexists:
-			op = OpExists
-			conditions = append(conditions, Condition{eventAttr, op, nil})
-
-		case rulevalue:
-			// strip single quotes from value (i.e. "'NewBlock'" -> "NewBlock")
-			valueWithoutSingleQuotes := buffer[begin+1 : end-1]
-			conditions = append(conditions, Condition{eventAttr, op, valueWithoutSingleQuotes})
-
-		case rulenumber:
-			number := buffer[begin:end]
-			if strings.ContainsAny(number, ".") { // if it looks like a floating-point number
-				value, err := strconv.ParseFloat(number, 64)
-				if err != nil {
-					err = fmt.Errorf(
-						"got %v while trying to parse %s as float64 (should never happen if the grammar is correct)",
-						err, number,
-					)
-					return nil, err
-				}
-
-				conditions = append(conditions, Condition{eventAttr, op, value})
-			} else {
-				value, err := strconv.ParseInt(number, 10, 64)
-				if err != nil {
-					err = fmt.Errorf(
-						"got %v while trying to parse %s as int64 (should never happen if the grammar is correct)",
-						err, number,
-					)
-					return nil, err
-				}
-
-				conditions = append(conditions, Condition{eventAttr, op, value})
-			}
-
-		case ruletime:
-			value, err := time.Parse(TimeLayout, buffer[begin:end])
-			if err != nil {
-				err = fmt.Errorf(
-					"got %v while trying to parse %s as time.Time / RFC3339 (should never happen if the grammar is correct)",
-					err, buffer[begin:end],
-				)
-				return nil, err
-			}
+func (q *Query) Expression() (Expression, error) {
+	if q.err != nil {
+		return Expression{}, q.err
+	}
+	return q.expr, nil
+}
 
-			conditions = append(conditions, Condition{eventAttr, op, value})
+// Conditions returns a list of conditions, which all have to match. It returns
+// an error if there is any error with the provided grammar in the Query, or if
+// the query has OR or NOT (see Expression).
+func (q *Query) Conditions() ([]Condition, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	return q.expr.conditions()
+}
 
-		case ruledate:
-			value, err := time.Parse("2006-01-02", buffer[begin:end])
+func (e Expression) conditions() ([]Condition, error) {
+	switch e.Op {
+	case ExprCondition:
+		return []Condition{e.Condition}, nil
+	case ExprAnd:
+		conditions := make([]Condition, 0, len(e.Operands))
+		for _, operand := range e.Operands {
+			c, err := operand.conditions()
 			if err != nil {
-				err = fmt.Errorf(
-					"got %v while trying to parse %s as time.Time / '2006-01-02' (should never happen if the grammar is correct)",
-					err, buffer[begin:end],
-				)
 				return nil, err
 			}
-
-			conditions = append(conditions, Condition{eventAttr, op, value})
+			conditions = append(conditions, c...)
 		}
+		return conditions, nil
+	default:
+		return nil, errors.New("query with OR or NOT is not a list of conditions")
 	}
-
-	return conditions, nil
 }
 
 // Matches returns true if the query matches against any event in the given set
@@ -199,164 +162,215 @@ func (q *Query) Conditions() ([]Condition, error) {
 // For example, query "name=John" matches events = {"name": ["John", "Eric"]}.
 // More examples could be found in parser_test.go and query_test.go.
 func (q *Query) Matches(events map[string][]string) (bool, error) {
+	if q.err != nil {
+		return false, q.err
+	}
 	if len(events) == 0 {
 		return false, nil
 	}
+	return q.expr.Matches(events)
+}
 
-	var (
-		eventAttr string
-		op        Operator
-	)
+// Matches returns true if the expression matches against the given set of
+// events. See Query.Matches.
+func (e Expression) Matches(events map[string][]string) (bool, error) {
+	switch e.Op {
+	case ExprAnd:
+		for _, operand := range e.Operands {
+			match, err := operand.Matches(events)
+			if err != nil || !match {
+				return false, err
+			}
+		}
+		return true, nil
 
-	buffer, begin, end := q.parser.Buffer, 0, 0
+	case ExprOr:
+		for _, operand := range e.Operands {
+			match, err := operand.Matches(events)
+			if err != nil || match {
+				return match, err
+			}
+		}
+		return false, nil
 
-	// tokens must be in the following order:
+	case ExprNot:
+		match, err := e.Operands[0].Matches(events)
+		if err != nil {
+			return false, err
+		}
+		return !match, nil
+
+	default:
+		return e.Condition.matches(events)
+	}
+}
 
-	// tag ("tx.gas") -> operator ("=") -> operand ("7")
-	for token := range q.parser.Tokens() {
-		switch token.pegRule {
-		case rulePegText:
-			begin, end = int(token.begin), int(token.end)
+func (c Condition) matches(events map[string][]string) (bool, error) {
+	if c.Op != OpExists {
+		// see if the triplet (event attribute, operator, operand) matches any event
+		// "tx.gas", "=", "7", { "tx.gas": 7, "tx.ID": "4AE393495334" }
+		return match(c.CompositeKey, c.Op, reflect.ValueOf(c.Operand), events)
+	}
 
+	if strings.Contains(c.CompositeKey, ".") {
+		// Searching for a full "type.attribute" event.
+		_, ok := events[c.CompositeKey]
+		return ok, nil
+	}
+	for compositeKey := range events {
+		if strings.Index(compositeKey, c.CompositeKey) == 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// newExpression turns a node of the syntax tree into an expression.
+func newExpression(node *node32, buffer []rune) (Expression, error) {
+	switch node.pegRule {
+	case rulee, rulegroup:
+		for n := node.up; n != nil; n = n.next {
+			if n.pegRule == ruleexpression {
+				return newExpression(n, buffer)
+			}
+		}
+
+	case ruleexpression, ruleterm:
+		var (
+			op      = ExprOr
+			operand = ruleterm
+		)
+		if node.pegRule == ruleterm {
+			op, operand = ExprAnd, rulefactor
+		}
+		var operands []Expression
+		for n := node.up; n != nil; n = n.next {
+			if n.pegRule != operand {
+				continue
+			}
+			e, err := newExpression(n, buffer)
+			if err != nil {
+				return Expression{}, err
+			}
+			operands = append(operands, e)
+		}
+		if len(operands) == 1 {
+			return operands[0], nil
+		}
+		return Expression{Op: op, Operands: operands}, nil
+
+	case rulefactor:
+		var not bool
+		for n := node.up; n != nil; n = n.next {
+			switch n.pegRule {
+			case rulenot:
+				not = true
+			case rulefactor, rulegroup, rulecondition:
+				e, err := newExpression(n, buffer)
+				if err != nil || !not {
+					return e, err
+				}
+				return Expression{Op: ExprNot, Operands: []Expression{e}}, nil
+			}
+		}
+
+	case rulecondition:
+		c, err := newCondition(node, buffer)
+		if err != nil {
+			return Expression{}, err
+		}
+		return Expression{Op: ExprCondition, Condition: c}, nil
+	}
+
+	return Expression{}, fmt.Errorf("unexpected %v in the syntax tree (should never happen if the grammar is correct)",
+		rul3s[node.pegRule])
+}
+
+// newCondition turns a condition of the syntax tree into a condition.
+//
+// tokens must be in the following order: tag ("tx.gas") -> operator ("=") -> operand ("7")
+func newCondition(node *node32, buffer []rune) (Condition, error) {
+	var c Condition
+	for n := node.up; n != nil; n = n.next {
+		text := string(buffer[n.begin:n.end])
+
+		switch n.pegRule {
 		case ruletag:
-			eventAttr = buffer[begin:end]
+			c.CompositeKey = text
 
 		case rulele:
-			op = OpLessEqual
+			c.Op = OpLessEqual
 
 		case rulege:
-			op = OpGreaterEqual
+			c.Op = OpGreaterEqual
 
 		case rulel:
-			op = OpLess
+			c.Op = OpLess
 
 		case ruleg:
-			op = OpGreater
+			c.Op = OpGreater
 
 		case ruleequal:
-			op = OpEqual
+			c.Op = OpEqual
 
 		case rulecontains:
-			op = OpContains
+			c.Op = OpContains
+
 		case ruleexists:
-			op = OpExists
-			if strings.Contains(eventAttr, ".") {
-				// Searching for a full "type.attribute" event.
-				_, ok := events[eventAttr]
-				if !ok {
-					return false, nil
-				}
-			} else {
-				foundEvent := false
-
-			loop:
-				for compositeKey := range events {
-					if strings.Index(compositeKey, eventAttr) == 0 {
-						foundEvent = true
-						break loop
-					}
-				}
-				if !foundEvent {
-					return false, nil
-				}
-			}
+			c.Op = OpExists
 
 		case rulevalue:
 			// strip single quotes from value (i.e. "'NewBlock'" -> "NewBlock")
-			valueWithoutSingleQuotes := buffer[begin+1 : end-1]
-
-			// see if the triplet (event attribute, operator, operand) matches any event
-			// "tx.gas", "=", "7", { "tx.gas": 7, "tx.ID": "4AE393495334" }
-			match, err := match(eventAttr, op, reflect.ValueOf(valueWithoutSingleQuotes), events)
-			if err != nil {
-				return false, err
-			}
-
-			if !match {
-				return false, nil
-			}
+			c.Operand = text[1 : len(text)-1]
 
 		case rulenumber:
-			number := buffer[begin:end]
-			if strings.ContainsAny(number, ".") { // if it looks like a floating-point number
-				value, err := strconv.ParseFloat(number, 64)
+			if strings.ContainsAny(text, ".") { // if it looks like a floating-point number
+				value, err := strconv.ParseFloat(text, 64)
 				if err != nil {
 					err = fmt.Errorf(
 						"got %v while trying to parse %s as float64 (should never happen if the grammar is correct)",
-						err, number,
+						err, text,
 					)
-					return false, err
-				}
-
-				match, err := match(eventAttr, op, reflect.ValueOf(value), events)
-				if err != nil {
-					return false, err
-				}
-
-				if !match {
-					return false, nil
+					return Condition{}, err
 				}
+				c.Operand = value
 			} else {
-				value, err := strconv.ParseInt(number, 10, 64)
+				value, err := strconv.ParseInt(text, 10, 64)
 				if err != nil {
 					err = fmt.Errorf(
 						"got %v while trying to parse %s as int64 (should never happen if the grammar is correct)",
-						err, number,
+						err, text,
 					)
-					return false, err
-				}
-
-				match, err := match(eventAttr, op, reflect.ValueOf(value), events)
-				if err != nil {
-					return false, err
-				}
-
-				if !match {
-					return false, nil
+					return Condition{}, err
 				}
+				c.Operand = value
 			}
 
 		case ruletime:
-			value, err := time.Parse(TimeLayout, buffer[begin:end])
+			// the text is "TIME ..." and the time itself is in PegText
+			value, err := time.Parse(TimeLayout, string(buffer[n.up.begin:n.up.end]))
 			if err != nil {
 				err = fmt.Errorf(
 					"got %v while trying to parse %s as time.Time / RFC3339 (should never happen if the grammar is correct)",
-					err, buffer[begin:end],
+					err, text,
 				)
-				return false, err
-			}
-
-			match, err := match(eventAttr, op, reflect.ValueOf(value), events)
-			if err != nil {
-				return false, err
-			}
-
-			if !match {
-				return false, nil
+				return Condition{}, err
 			}
+			c.Operand = value
 
 		case ruledate:
-			value, err := time.Parse("2006-01-02", buffer[begin:end])
+			value, err := time.Parse(DateLayout, string(buffer[n.up.begin:n.up.end]))
 			if err != nil {
 				err = fmt.Errorf(
 					"got %v while trying to parse %s as time.Time / '2006-01-02' (should never happen if the grammar is correct)",
-					err, buffer[begin:end],
+					err, text,
 				)
-				return false, err
-			}
-
-			match, err := match(eventAttr, op, reflect.ValueOf(value), events)
-			if err != nil {
-				return false, err
-			}
-
-			if !match {
-				return false, nil
+				return Condition{}, err
 			}
+			c.Operand = value
 		}
 	}
-
-	return true, nil
+	return c, nil
 }
 
 // match returns true if the given triplet (attribute, operator, operand) matches
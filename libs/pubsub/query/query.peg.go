@@ -16,6 +16,10 @@ type pegRule uint8
 const (
 	ruleUnknown pegRule = iota
 	rulee
+	ruleexpression
+	ruleterm
+	rulefactor
+	rulegroup
 	rulecondition
 	ruletag
 	rulevalue
@@ -27,6 +31,8 @@ const (
 	rulemonth
 	ruleday
 	ruleand
+	ruleor
+	rulenot
 	ruleequal
 	rulecontains
 	ruleexists
@@ -44,6 +50,10 @@ const (
 var rul3s = [...]string{
 	"Unknown",
 	"e",
+	"expression",
+	"term",
+	"factor",
+	"group",
 	"condition",
 	"tag",
 	"value",
@@ -55,6 +65,8 @@ var rul3s = [...]string{
 	"month",
 	"day",
 	"and",
+	"or",
+	"not",
 	"equal",
 	"contains",
 	"exists",
@@ -356,7 +368,7 @@ func (t *tokens32) Expand(index int) {
 type QueryParser struct {
 	Buffer string
 	buffer []rune
-	rules  [21]func() bool
+	rules  [27]func() bool
 	Parse  func(rule ...int) error
 	Reset  func()
 	Pretty bool
@@ -494,7 +506,7 @@ func (p *QueryParser) Init() {
 
 	_rules = [...]func() bool{
 		nil,
-		/* 0 e <- <('"' condition (' '+ and ' '+ condition)* '"' !.)> */
+		/* 0 e <- <('"' expression '"' !.)> */
 		func() bool {
 			position0, tokenIndex0, depth0 := position, tokenIndex, depth
 			{
@@ -504,1334 +516,1553 @@ func (p *QueryParser) Init() {
 					goto l0
 				}
 				position++
-				if !_rules[rulecondition]() {
+				if !_rules[ruleexpression]() {
 					goto l0
 				}
-			l2:
+				if buffer[position] != rune('"') {
+					goto l0
+				}
+				position++
+				{
+					position2, tokenIndex2, depth2 := position, tokenIndex, depth
+					if !matchDot() {
+						goto l2
+					}
+					goto l0
+				l2:
+					position, tokenIndex, depth = position2, tokenIndex2, depth2
+				}
+				depth--
+				add(rulee, position1)
+			}
+			return true
+		l0:
+			position, tokenIndex, depth = position0, tokenIndex0, depth0
+			return false
+		},
+		/* 1 expression <- <(term (' '+ or ' '+ term)*)> */
+		func() bool {
+			position3, tokenIndex3, depth3 := position, tokenIndex, depth
+			{
+				position4 := position
+				depth++
+				if !_rules[ruleterm]() {
+					goto l3
+				}
+			l5:
 				{
-					position3, tokenIndex3, depth3 := position, tokenIndex, depth
+					position6, tokenIndex6, depth6 := position, tokenIndex, depth
 					if buffer[position] != rune(' ') {
-						goto l3
+						goto l6
 					}
 					position++
-				l4:
+				l7:
 					{
-						position5, tokenIndex5, depth5 := position, tokenIndex, depth
+						position8, tokenIndex8, depth8 := position, tokenIndex, depth
 						if buffer[position] != rune(' ') {
-							goto l5
+							goto l8
 						}
 						position++
-						goto l4
-					l5:
-						position, tokenIndex, depth = position5, tokenIndex5, depth5
+						goto l7
+					l8:
+						position, tokenIndex, depth = position8, tokenIndex8, depth8
 					}
 					{
-						position6 := position
+						position9 := position
 						depth++
 						{
-							position7, tokenIndex7, depth7 := position, tokenIndex, depth
-							if buffer[position] != rune('a') {
-								goto l8
-							}
-							position++
-							goto l7
-						l8:
-							position, tokenIndex, depth = position7, tokenIndex7, depth7
-							if buffer[position] != rune('A') {
-								goto l3
-							}
-							position++
-						}
-					l7:
-						{
-							position9, tokenIndex9, depth9 := position, tokenIndex, depth
-							if buffer[position] != rune('n') {
-								goto l10
+							position10, tokenIndex10, depth10 := position, tokenIndex, depth
+							if buffer[position] != rune('o') {
+								goto l11
 							}
 							position++
-							goto l9
-						l10:
-							position, tokenIndex, depth = position9, tokenIndex9, depth9
-							if buffer[position] != rune('N') {
-								goto l3
+							goto l10
+						l11:
+							position, tokenIndex, depth = position10, tokenIndex10, depth10
+							if buffer[position] != rune('O') {
+								goto l6
 							}
 							position++
 						}
-					l9:
+					l10:
 						{
-							position11, tokenIndex11, depth11 := position, tokenIndex, depth
-							if buffer[position] != rune('d') {
-								goto l12
+							position12, tokenIndex12, depth12 := position, tokenIndex, depth
+							if buffer[position] != rune('r') {
+								goto l13
 							}
 							position++
-							goto l11
-						l12:
-							position, tokenIndex, depth = position11, tokenIndex11, depth11
-							if buffer[position] != rune('D') {
-								goto l3
+							goto l12
+						l13:
+							position, tokenIndex, depth = position12, tokenIndex12, depth12
+							if buffer[position] != rune('R') {
+								goto l6
 							}
 							position++
 						}
-					l11:
+					l12:
 						depth--
-						add(ruleand, position6)
+						add(ruleor, position9)
 					}
 					if buffer[position] != rune(' ') {
-						goto l3
+						goto l6
 					}
 					position++
-				l13:
+				l14:
 					{
-						position14, tokenIndex14, depth14 := position, tokenIndex, depth
+						position15, tokenIndex15, depth15 := position, tokenIndex, depth
 						if buffer[position] != rune(' ') {
-							goto l14
+							goto l15
 						}
 						position++
-						goto l13
-					l14:
-						position, tokenIndex, depth = position14, tokenIndex14, depth14
+						goto l14
+					l15:
+						position, tokenIndex, depth = position15, tokenIndex15, depth15
 					}
-					if !_rules[rulecondition]() {
-						goto l3
-					}
-					goto l2
-				l3:
-					position, tokenIndex, depth = position3, tokenIndex3, depth3
-				}
-				if buffer[position] != rune('"') {
-					goto l0
-				}
-				position++
-				{
-					position15, tokenIndex15, depth15 := position, tokenIndex, depth
-					if !matchDot() {
-						goto l15
+					if !_rules[ruleterm]() {
+						goto l6
 					}
-					goto l0
-				l15:
-					position, tokenIndex, depth = position15, tokenIndex15, depth15
+					goto l5
+				l6:
+					position, tokenIndex, depth = position6, tokenIndex6, depth6
 				}
 				depth--
-				add(rulee, position1)
+				add(ruleexpression, position4)
 			}
 			return true
-		l0:
-			position, tokenIndex, depth = position0, tokenIndex0, depth0
+		l3:
+			position, tokenIndex, depth = position3, tokenIndex3, depth3
 			return false
 		},
-		/* 1 condition <- <(tag ' '* ((le ' '* ((&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number))) / (ge ' '* ((&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number))) / ((&('E' | 'e') exists) | (&('=') (equal ' '* ((&('\'') value) | (&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number)))) | (&('>') (g ' '* ((&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number)))) | (&('<') (l ' '* ((&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number)))) | (&('C' | 'c') (contains ' '* value)))))> */
+		/* 2 term <- <(factor (' '+ and ' '+ factor)*)> */
 		func() bool {
 			position16, tokenIndex16, depth16 := position, tokenIndex, depth
 			{
 				position17 := position
 				depth++
+				if !_rules[rulefactor]() {
+					goto l16
+				}
+			l18:
 				{
-					position18 := position
-					depth++
+					position19, tokenIndex19, depth19 := position, tokenIndex, depth
+					if buffer[position] != rune(' ') {
+						goto l19
+					}
+					position++
+				l20:
+					{
+						position21, tokenIndex21, depth21 := position, tokenIndex, depth
+						if buffer[position] != rune(' ') {
+							goto l21
+						}
+						position++
+						goto l20
+					l21:
+						position, tokenIndex, depth = position21, tokenIndex21, depth21
+					}
 					{
-						position19 := position
+						position22 := position
 						depth++
 						{
-							position22, tokenIndex22, depth22 := position, tokenIndex, depth
-							{
-								switch buffer[position] {
-								case '<':
-									if buffer[position] != rune('<') {
-										goto l22
-									}
-									position++
-									break
-								case '>':
-									if buffer[position] != rune('>') {
-										goto l22
-									}
-									position++
-									break
-								case '=':
-									if buffer[position] != rune('=') {
-										goto l22
-									}
-									position++
-									break
-								case '\'':
-									if buffer[position] != rune('\'') {
-										goto l22
-									}
-									position++
-									break
-								case '"':
-									if buffer[position] != rune('"') {
-										goto l22
-									}
-									position++
-									break
-								case ')':
-									if buffer[position] != rune(')') {
-										goto l22
-									}
-									position++
-									break
-								case '(':
-									if buffer[position] != rune('(') {
-										goto l22
-									}
-									position++
-									break
-								case '\\':
-									if buffer[position] != rune('\\') {
-										goto l22
-									}
-									position++
-									break
-								case '\r':
-									if buffer[position] != rune('\r') {
-										goto l22
-									}
-									position++
-									break
-								case '\n':
-									if buffer[position] != rune('\n') {
-										goto l22
-									}
-									position++
-									break
-								case '\t':
-									if buffer[position] != rune('\t') {
-										goto l22
-									}
-									position++
-									break
-								default:
-									if buffer[position] != rune(' ') {
-										goto l22
-									}
-									position++
-									break
-								}
+							position23, tokenIndex23, depth23 := position, tokenIndex, depth
+							if buffer[position] != rune('a') {
+								goto l24
 							}
-
-							goto l16
-						l22:
-							position, tokenIndex, depth = position22, tokenIndex22, depth22
+							position++
+							goto l23
+						l24:
+							position, tokenIndex, depth = position23, tokenIndex23, depth23
+							if buffer[position] != rune('A') {
+								goto l19
+							}
+							position++
 						}
-						if !matchDot() {
-							goto l16
+					l23:
+						{
+							position25, tokenIndex25, depth25 := position, tokenIndex, depth
+							if buffer[position] != rune('n') {
+								goto l26
+							}
+							position++
+							goto l25
+						l26:
+							position, tokenIndex, depth = position25, tokenIndex25, depth25
+							if buffer[position] != rune('N') {
+								goto l19
+							}
+							position++
 						}
-					l20:
+					l25:
 						{
-							position21, tokenIndex21, depth21 := position, tokenIndex, depth
-							{
-								position24, tokenIndex24, depth24 := position, tokenIndex, depth
-								{
-									switch buffer[position] {
-									case '<':
-										if buffer[position] != rune('<') {
-											goto l24
-										}
-										position++
-										break
-									case '>':
-										if buffer[position] != rune('>') {
-											goto l24
-										}
-										position++
-										break
-									case '=':
-										if buffer[position] != rune('=') {
-											goto l24
-										}
-										position++
-										break
-									case '\'':
-										if buffer[position] != rune('\'') {
-											goto l24
-										}
-										position++
-										break
-									case '"':
-										if buffer[position] != rune('"') {
-											goto l24
-										}
-										position++
-										break
-									case ')':
-										if buffer[position] != rune(')') {
-											goto l24
-										}
-										position++
-										break
-									case '(':
-										if buffer[position] != rune('(') {
-											goto l24
-										}
-										position++
-										break
-									case '\\':
-										if buffer[position] != rune('\\') {
-											goto l24
-										}
-										position++
-										break
-									case '\r':
-										if buffer[position] != rune('\r') {
-											goto l24
-										}
-										position++
-										break
-									case '\n':
-										if buffer[position] != rune('\n') {
-											goto l24
-										}
-										position++
-										break
-									case '\t':
-										if buffer[position] != rune('\t') {
-											goto l24
-										}
-										position++
-										break
-									default:
-										if buffer[position] != rune(' ') {
-											goto l24
-										}
-										position++
-										break
-									}
-								}
-
-								goto l21
-							l24:
-								position, tokenIndex, depth = position24, tokenIndex24, depth24
+							position27, tokenIndex27, depth27 := position, tokenIndex, depth
+							if buffer[position] != rune('d') {
+								goto l28
 							}
-							if !matchDot() {
-								goto l21
+							position++
+							goto l27
+						l28:
+							position, tokenIndex, depth = position27, tokenIndex27, depth27
+							if buffer[position] != rune('D') {
+								goto l19
 							}
-							goto l20
-						l21:
-							position, tokenIndex, depth = position21, tokenIndex21, depth21
+							position++
 						}
+					l27:
 						depth--
-						add(rulePegText, position19)
+						add(ruleand, position22)
 					}
-					depth--
-					add(ruletag, position18)
-				}
-			l26:
-				{
-					position27, tokenIndex27, depth27 := position, tokenIndex, depth
 					if buffer[position] != rune(' ') {
-						goto l27
+						goto l19
 					}
 					position++
-					goto l26
-				l27:
-					position, tokenIndex, depth = position27, tokenIndex27, depth27
-				}
-				{
-					position28, tokenIndex28, depth28 := position, tokenIndex, depth
+				l29:
 					{
-						position30 := position
-						depth++
-						if buffer[position] != rune('<') {
-							goto l29
-						}
-						position++
-						if buffer[position] != rune('=') {
-							goto l29
+						position30, tokenIndex30, depth30 := position, tokenIndex, depth
+						if buffer[position] != rune(' ') {
+							goto l30
 						}
 						position++
-						depth--
-						add(rulele, position30)
+						goto l29
+					l30:
+						position, tokenIndex, depth = position30, tokenIndex30, depth30
+					}
+					if !_rules[rulefactor]() {
+						goto l19
 					}
-				l31:
+					goto l18
+				l19:
+					position, tokenIndex, depth = position19, tokenIndex19, depth19
+				}
+				depth--
+				add(ruleterm, position17)
+			}
+			return true
+		l16:
+			position, tokenIndex, depth = position16, tokenIndex16, depth16
+			return false
+		},
+		/* 3 factor <- <((not ' '+ factor) / (not ' '* group) / group / condition)> */
+		func() bool {
+			position31, tokenIndex31, depth31 := position, tokenIndex, depth
+			{
+				position32 := position
+				depth++
+				{
+					position33, tokenIndex33, depth33 := position, tokenIndex, depth
+					if !_rules[rulenot]() {
+						goto l34
+					}
+					if buffer[position] != rune(' ') {
+						goto l34
+					}
+					position++
+				l35:
 					{
-						position32, tokenIndex32, depth32 := position, tokenIndex, depth
+						position36, tokenIndex36, depth36 := position, tokenIndex, depth
 						if buffer[position] != rune(' ') {
-							goto l32
+							goto l36
 						}
 						position++
-						goto l31
-					l32:
-						position, tokenIndex, depth = position32, tokenIndex32, depth32
+						goto l35
+					l36:
+						position, tokenIndex, depth = position36, tokenIndex36, depth36
 					}
-					{
-						switch buffer[position] {
-						case 'D', 'd':
-							if !_rules[ruledate]() {
-								goto l29
-							}
-							break
-						case 'T', 't':
-							if !_rules[ruletime]() {
-								goto l29
-							}
-							break
-						default:
-							if !_rules[rulenumber]() {
-								goto l29
-							}
-							break
-						}
+					if !_rules[rulefactor]() {
+						goto l34
 					}
-
-					goto l28
-				l29:
-					position, tokenIndex, depth = position28, tokenIndex28, depth28
-					{
-						position35 := position
-						depth++
-						if buffer[position] != rune('>') {
-							goto l34
-						}
-						position++
-						if buffer[position] != rune('=') {
-							goto l34
-						}
-						position++
-						depth--
-						add(rulege, position35)
+					goto l33
+				l34:
+					position, tokenIndex, depth = position33, tokenIndex33, depth33
+					if !_rules[rulenot]() {
+						goto l37
 					}
-				l36:
+				l38:
 					{
-						position37, tokenIndex37, depth37 := position, tokenIndex, depth
+						position39, tokenIndex39, depth39 := position, tokenIndex, depth
 						if buffer[position] != rune(' ') {
-							goto l37
+							goto l39
 						}
 						position++
-						goto l36
-					l37:
-						position, tokenIndex, depth = position37, tokenIndex37, depth37
-					}
+						goto l38
+					l39:
+						position, tokenIndex, depth = position39, tokenIndex39, depth39
+					}
+					if !_rules[rulegroup]() {
+						goto l37
+					}
+					goto l33
+				l37:
+					position, tokenIndex, depth = position33, tokenIndex33, depth33
+					if !_rules[rulegroup]() {
+						goto l40
+					}
+					goto l33
+				l40:
+					position, tokenIndex, depth = position33, tokenIndex33, depth33
 					{
-						switch buffer[position] {
-						case 'D', 'd':
-							if !_rules[ruledate]() {
-								goto l34
-							}
-							break
-						case 'T', 't':
-							if !_rules[ruletime]() {
-								goto l34
-							}
-							break
-						default:
-							if !_rules[rulenumber]() {
-								goto l34
-							}
-							break
-						}
-					}
-
-					goto l28
-				l34:
-					position, tokenIndex, depth = position28, tokenIndex28, depth28
-					{
-						switch buffer[position] {
-						case 'E', 'e':
+						position41 := position
+						depth++
+						{
+							position42 := position
+							depth++
 							{
-								position40 := position
+								position43 := position
 								depth++
 								{
-									position41, tokenIndex41, depth41 := position, tokenIndex, depth
-									if buffer[position] != rune('e') {
-										goto l42
-									}
-									position++
-									goto l41
-								l42:
-									position, tokenIndex, depth = position41, tokenIndex41, depth41
-									if buffer[position] != rune('E') {
-										goto l16
-									}
-									position++
-								}
-							l41:
-								{
-									position43, tokenIndex43, depth43 := position, tokenIndex, depth
-									if buffer[position] != rune('x') {
-										goto l44
-									}
-									position++
-									goto l43
-								l44:
-									position, tokenIndex, depth = position43, tokenIndex43, depth43
-									if buffer[position] != rune('X') {
-										goto l16
-									}
-									position++
-								}
-							l43:
-								{
-									position45, tokenIndex45, depth45 := position, tokenIndex, depth
-									if buffer[position] != rune('i') {
-										goto l46
+									position46, tokenIndex46, depth46 := position, tokenIndex, depth
+									{
+										switch buffer[position] {
+										case '<':
+											if buffer[position] != rune('<') {
+												goto l46
+											}
+											position++
+											break
+										case '>':
+											if buffer[position] != rune('>') {
+												goto l46
+											}
+											position++
+											break
+										case '=':
+											if buffer[position] != rune('=') {
+												goto l46
+											}
+											position++
+											break
+										case '\'':
+											if buffer[position] != rune('\'') {
+												goto l46
+											}
+											position++
+											break
+										case '"':
+											if buffer[position] != rune('"') {
+												goto l46
+											}
+											position++
+											break
+										case ')':
+											if buffer[position] != rune(')') {
+												goto l46
+											}
+											position++
+											break
+										case '(':
+											if buffer[position] != rune('(') {
+												goto l46
+											}
+											position++
+											break
+										case '\\':
+											if buffer[position] != rune('\\') {
+												goto l46
+											}
+											position++
+											break
+										case '\r':
+											if buffer[position] != rune('\r') {
+												goto l46
+											}
+											position++
+											break
+										case '\n':
+											if buffer[position] != rune('\n') {
+												goto l46
+											}
+											position++
+											break
+										case '\t':
+											if buffer[position] != rune('\t') {
+												goto l46
+											}
+											position++
+											break
+										default:
+											if buffer[position] != rune(' ') {
+												goto l46
+											}
+											position++
+											break
+										}
 									}
-									position++
-									goto l45
+
+									goto l31
 								l46:
-									position, tokenIndex, depth = position45, tokenIndex45, depth45
-									if buffer[position] != rune('I') {
-										goto l16
-									}
-									position++
+									position, tokenIndex, depth = position46, tokenIndex46, depth46
 								}
-							l45:
-								{
-									position47, tokenIndex47, depth47 := position, tokenIndex, depth
-									if buffer[position] != rune('s') {
-										goto l48
-									}
-									position++
-									goto l47
-								l48:
-									position, tokenIndex, depth = position47, tokenIndex47, depth47
-									if buffer[position] != rune('S') {
-										goto l16
-									}
-									position++
+								if !matchDot() {
+									goto l31
 								}
-							l47:
+							l44:
 								{
-									position49, tokenIndex49, depth49 := position, tokenIndex, depth
-									if buffer[position] != rune('t') {
-										goto l50
-									}
-									position++
-									goto l49
-								l50:
-									position, tokenIndex, depth = position49, tokenIndex49, depth49
-									if buffer[position] != rune('T') {
-										goto l16
-									}
-									position++
-								}
-							l49:
-								{
-									position51, tokenIndex51, depth51 := position, tokenIndex, depth
-									if buffer[position] != rune('s') {
-										goto l52
+									position45, tokenIndex45, depth45 := position, tokenIndex, depth
+									{
+										position48, tokenIndex48, depth48 := position, tokenIndex, depth
+										{
+											switch buffer[position] {
+											case '<':
+												if buffer[position] != rune('<') {
+													goto l48
+												}
+												position++
+												break
+											case '>':
+												if buffer[position] != rune('>') {
+													goto l48
+												}
+												position++
+												break
+											case '=':
+												if buffer[position] != rune('=') {
+													goto l48
+												}
+												position++
+												break
+											case '\'':
+												if buffer[position] != rune('\'') {
+													goto l48
+												}
+												position++
+												break
+											case '"':
+												if buffer[position] != rune('"') {
+													goto l48
+												}
+												position++
+												break
+											case ')':
+												if buffer[position] != rune(')') {
+													goto l48
+												}
+												position++
+												break
+											case '(':
+												if buffer[position] != rune('(') {
+													goto l48
+												}
+												position++
+												break
+											case '\\':
+												if buffer[position] != rune('\\') {
+													goto l48
+												}
+												position++
+												break
+											case '\r':
+												if buffer[position] != rune('\r') {
+													goto l48
+												}
+												position++
+												break
+											case '\n':
+												if buffer[position] != rune('\n') {
+													goto l48
+												}
+												position++
+												break
+											case '\t':
+												if buffer[position] != rune('\t') {
+													goto l48
+												}
+												position++
+												break
+											default:
+												if buffer[position] != rune(' ') {
+													goto l48
+												}
+												position++
+												break
+											}
+										}
+
+										goto l45
+									l48:
+										position, tokenIndex, depth = position48, tokenIndex48, depth48
 									}
-									position++
-									goto l51
-								l52:
-									position, tokenIndex, depth = position51, tokenIndex51, depth51
-									if buffer[position] != rune('S') {
-										goto l16
+									if !matchDot() {
+										goto l45
 									}
-									position++
+									goto l44
+								l45:
+									position, tokenIndex, depth = position45, tokenIndex45, depth45
 								}
-							l51:
 								depth--
-								add(ruleexists, position40)
+								add(rulePegText, position43)
+							}
+							depth--
+							add(ruletag, position42)
+						}
+					l50:
+						{
+							position51, tokenIndex51, depth51 := position, tokenIndex, depth
+							if buffer[position] != rune(' ') {
+								goto l51
 							}
-							break
-						case '=':
+							position++
+							goto l50
+						l51:
+							position, tokenIndex, depth = position51, tokenIndex51, depth51
+						}
+						{
+							position52, tokenIndex52, depth52 := position, tokenIndex, depth
 							{
-								position53 := position
+								position54 := position
 								depth++
+								if buffer[position] != rune('<') {
+									goto l53
+								}
+								position++
 								if buffer[position] != rune('=') {
-									goto l16
+									goto l53
 								}
 								position++
 								depth--
-								add(ruleequal, position53)
+								add(rulele, position54)
 							}
-						l54:
+						l55:
 							{
-								position55, tokenIndex55, depth55 := position, tokenIndex, depth
+								position56, tokenIndex56, depth56 := position, tokenIndex, depth
 								if buffer[position] != rune(' ') {
-									goto l55
+									goto l56
 								}
 								position++
-								goto l54
-							l55:
-								position, tokenIndex, depth = position55, tokenIndex55, depth55
+								goto l55
+							l56:
+								position, tokenIndex, depth = position56, tokenIndex56, depth56
 							}
 							{
 								switch buffer[position] {
-								case '\'':
-									if !_rules[rulevalue]() {
-										goto l16
-									}
-									break
 								case 'D', 'd':
 									if !_rules[ruledate]() {
-										goto l16
+										goto l53
 									}
 									break
 								case 'T', 't':
 									if !_rules[ruletime]() {
-										goto l16
+										goto l53
 									}
 									break
 								default:
 									if !_rules[rulenumber]() {
-										goto l16
+										goto l53
 									}
 									break
 								}
 							}
 
-							break
-						case '>':
+							goto l52
+						l53:
+							position, tokenIndex, depth = position52, tokenIndex52, depth52
 							{
-								position57 := position
+								position59 := position
 								depth++
 								if buffer[position] != rune('>') {
-									goto l16
-								}
-								position++
-								depth--
-								add(ruleg, position57)
-							}
-						l58:
-							{
-								position59, tokenIndex59, depth59 := position, tokenIndex, depth
-								if buffer[position] != rune(' ') {
-									goto l59
+									goto l58
 								}
 								position++
-								goto l58
-							l59:
-								position, tokenIndex, depth = position59, tokenIndex59, depth59
-							}
-							{
-								switch buffer[position] {
-								case 'D', 'd':
-									if !_rules[ruledate]() {
-										goto l16
-									}
-									break
-								case 'T', 't':
-									if !_rules[ruletime]() {
-										goto l16
-									}
-									break
-								default:
-									if !_rules[rulenumber]() {
-										goto l16
-									}
-									break
-								}
-							}
-
-							break
-						case '<':
-							{
-								position61 := position
-								depth++
-								if buffer[position] != rune('<') {
-									goto l16
+								if buffer[position] != rune('=') {
+									goto l58
 								}
 								position++
 								depth--
-								add(rulel, position61)
+								add(rulege, position59)
 							}
-						l62:
+						l60:
 							{
-								position63, tokenIndex63, depth63 := position, tokenIndex, depth
+								position61, tokenIndex61, depth61 := position, tokenIndex, depth
 								if buffer[position] != rune(' ') {
-									goto l63
+									goto l61
 								}
 								position++
-								goto l62
-							l63:
-								position, tokenIndex, depth = position63, tokenIndex63, depth63
+								goto l60
+							l61:
+								position, tokenIndex, depth = position61, tokenIndex61, depth61
 							}
 							{
 								switch buffer[position] {
 								case 'D', 'd':
 									if !_rules[ruledate]() {
-										goto l16
+										goto l58
 									}
 									break
 								case 'T', 't':
 									if !_rules[ruletime]() {
-										goto l16
+										goto l58
 									}
 									break
 								default:
 									if !_rules[rulenumber]() {
-										goto l16
+										goto l58
 									}
 									break
 								}
 							}
 
-							break
-						default:
+							goto l52
+						l58:
+							position, tokenIndex, depth = position52, tokenIndex52, depth52
 							{
-								position65 := position
-								depth++
-								{
-									position66, tokenIndex66, depth66 := position, tokenIndex, depth
-									if buffer[position] != rune('c') {
-										goto l67
-									}
-									position++
-									goto l66
-								l67:
-									position, tokenIndex, depth = position66, tokenIndex66, depth66
-									if buffer[position] != rune('C') {
-										goto l16
-									}
-									position++
-								}
-							l66:
-								{
-									position68, tokenIndex68, depth68 := position, tokenIndex, depth
-									if buffer[position] != rune('o') {
-										goto l69
-									}
-									position++
-									goto l68
-								l69:
-									position, tokenIndex, depth = position68, tokenIndex68, depth68
-									if buffer[position] != rune('O') {
-										goto l16
+								switch buffer[position] {
+								case 'E', 'e':
+									{
+										position64 := position
+										depth++
+										{
+											position65, tokenIndex65, depth65 := position, tokenIndex, depth
+											if buffer[position] != rune('e') {
+												goto l66
+											}
+											position++
+											goto l65
+										l66:
+											position, tokenIndex, depth = position65, tokenIndex65, depth65
+											if buffer[position] != rune('E') {
+												goto l31
+											}
+											position++
+										}
+									l65:
+										{
+											position67, tokenIndex67, depth67 := position, tokenIndex, depth
+											if buffer[position] != rune('x') {
+												goto l68
+											}
+											position++
+											goto l67
+										l68:
+											position, tokenIndex, depth = position67, tokenIndex67, depth67
+											if buffer[position] != rune('X') {
+												goto l31
+											}
+											position++
+										}
+									l67:
+										{
+											position69, tokenIndex69, depth69 := position, tokenIndex, depth
+											if buffer[position] != rune('i') {
+												goto l70
+											}
+											position++
+											goto l69
+										l70:
+											position, tokenIndex, depth = position69, tokenIndex69, depth69
+											if buffer[position] != rune('I') {
+												goto l31
+											}
+											position++
+										}
+									l69:
+										{
+											position71, tokenIndex71, depth71 := position, tokenIndex, depth
+											if buffer[position] != rune('s') {
+												goto l72
+											}
+											position++
+											goto l71
+										l72:
+											position, tokenIndex, depth = position71, tokenIndex71, depth71
+											if buffer[position] != rune('S') {
+												goto l31
+											}
+											position++
+										}
+									l71:
+										{
+											position73, tokenIndex73, depth73 := position, tokenIndex, depth
+											if buffer[position] != rune('t') {
+												goto l74
+											}
+											position++
+											goto l73
+										l74:
+											position, tokenIndex, depth = position73, tokenIndex73, depth73
+											if buffer[position] != rune('T') {
+												goto l31
+											}
+											position++
+										}
+									l73:
+										{
+											position75, tokenIndex75, depth75 := position, tokenIndex, depth
+											if buffer[position] != rune('s') {
+												goto l76
+											}
+											position++
+											goto l75
+										l76:
+											position, tokenIndex, depth = position75, tokenIndex75, depth75
+											if buffer[position] != rune('S') {
+												goto l31
+											}
+											position++
+										}
+									l75:
+										depth--
+										add(ruleexists, position64)
 									}
-									position++
-								}
-							l68:
-								{
-									position70, tokenIndex70, depth70 := position, tokenIndex, depth
-									if buffer[position] != rune('n') {
-										goto l71
+									break
+								case '=':
+									{
+										position77 := position
+										depth++
+										if buffer[position] != rune('=') {
+											goto l31
+										}
+										position++
+										depth--
+										add(ruleequal, position77)
 									}
-									position++
-									goto l70
-								l71:
-									position, tokenIndex, depth = position70, tokenIndex70, depth70
-									if buffer[position] != rune('N') {
-										goto l16
+								l78:
+									{
+										position79, tokenIndex79, depth79 := position, tokenIndex, depth
+										if buffer[position] != rune(' ') {
+											goto l79
+										}
+										position++
+										goto l78
+									l79:
+										position, tokenIndex, depth = position79, tokenIndex79, depth79
 									}
-									position++
-								}
-							l70:
-								{
-									position72, tokenIndex72, depth72 := position, tokenIndex, depth
-									if buffer[position] != rune('t') {
-										goto l73
+									{
+										switch buffer[position] {
+										case '\'':
+											if !_rules[rulevalue]() {
+												goto l31
+											}
+											break
+										case 'D', 'd':
+											if !_rules[ruledate]() {
+												goto l31
+											}
+											break
+										case 'T', 't':
+											if !_rules[ruletime]() {
+												goto l31
+											}
+											break
+										default:
+											if !_rules[rulenumber]() {
+												goto l31
+											}
+											break
+										}
 									}
-									position++
-									goto l72
-								l73:
-									position, tokenIndex, depth = position72, tokenIndex72, depth72
-									if buffer[position] != rune('T') {
-										goto l16
+
+									break
+								case '>':
+									{
+										position81 := position
+										depth++
+										if buffer[position] != rune('>') {
+											goto l31
+										}
+										position++
+										depth--
+										add(ruleg, position81)
 									}
-									position++
-								}
-							l72:
-								{
-									position74, tokenIndex74, depth74 := position, tokenIndex, depth
-									if buffer[position] != rune('a') {
-										goto l75
+								l82:
+									{
+										position83, tokenIndex83, depth83 := position, tokenIndex, depth
+										if buffer[position] != rune(' ') {
+											goto l83
+										}
+										position++
+										goto l82
+									l83:
+										position, tokenIndex, depth = position83, tokenIndex83, depth83
 									}
-									position++
-									goto l74
-								l75:
-									position, tokenIndex, depth = position74, tokenIndex74, depth74
-									if buffer[position] != rune('A') {
-										goto l16
+									{
+										switch buffer[position] {
+										case 'D', 'd':
+											if !_rules[ruledate]() {
+												goto l31
+											}
+											break
+										case 'T', 't':
+											if !_rules[ruletime]() {
+												goto l31
+											}
+											break
+										default:
+											if !_rules[rulenumber]() {
+												goto l31
+											}
+											break
+										}
 									}
-									position++
-								}
-							l74:
-								{
-									position76, tokenIndex76, depth76 := position, tokenIndex, depth
-									if buffer[position] != rune('i') {
-										goto l77
+
+									break
+								case '<':
+									{
+										position85 := position
+										depth++
+										if buffer[position] != rune('<') {
+											goto l31
+										}
+										position++
+										depth--
+										add(rulel, position85)
 									}
-									position++
-									goto l76
-								l77:
-									position, tokenIndex, depth = position76, tokenIndex76, depth76
-									if buffer[position] != rune('I') {
-										goto l16
+								l86:
+									{
+										position87, tokenIndex87, depth87 := position, tokenIndex, depth
+										if buffer[position] != rune(' ') {
+											goto l87
+										}
+										position++
+										goto l86
+									l87:
+										position, tokenIndex, depth = position87, tokenIndex87, depth87
 									}
-									position++
-								}
-							l76:
-								{
-									position78, tokenIndex78, depth78 := position, tokenIndex, depth
-									if buffer[position] != rune('n') {
-										goto l79
+									{
+										switch buffer[position] {
+										case 'D', 'd':
+											if !_rules[ruledate]() {
+												goto l31
+											}
+											break
+										case 'T', 't':
+											if !_rules[ruletime]() {
+												goto l31
+											}
+											break
+										default:
+											if !_rules[rulenumber]() {
+												goto l31
+											}
+											break
+										}
 									}
-									position++
-									goto l78
-								l79:
-									position, tokenIndex, depth = position78, tokenIndex78, depth78
-									if buffer[position] != rune('N') {
-										goto l16
+
+									break
+								default:
+									{
+										position89 := position
+										depth++
+										{
+											position90, tokenIndex90, depth90 := position, tokenIndex, depth
+											if buffer[position] != rune('c') {
+												goto l91
+											}
+											position++
+											goto l90
+										l91:
+											position, tokenIndex, depth = position90, tokenIndex90, depth90
+											if buffer[position] != rune('C') {
+												goto l31
+											}
+											position++
+										}
+									l90:
+										{
+											position92, tokenIndex92, depth92 := position, tokenIndex, depth
+											if buffer[position] != rune('o') {
+												goto l93
+											}
+											position++
+											goto l92
+										l93:
+											position, tokenIndex, depth = position92, tokenIndex92, depth92
+											if buffer[position] != rune('O') {
+												goto l31
+											}
+											position++
+										}
+									l92:
+										{
+											position94, tokenIndex94, depth94 := position, tokenIndex, depth
+											if buffer[position] != rune('n') {
+												goto l95
+											}
+											position++
+											goto l94
+										l95:
+											position, tokenIndex, depth = position94, tokenIndex94, depth94
+											if buffer[position] != rune('N') {
+												goto l31
+											}
+											position++
+										}
+									l94:
+										{
+											position96, tokenIndex96, depth96 := position, tokenIndex, depth
+											if buffer[position] != rune('t') {
+												goto l97
+											}
+											position++
+											goto l96
+										l97:
+											position, tokenIndex, depth = position96, tokenIndex96, depth96
+											if buffer[position] != rune('T') {
+												goto l31
+											}
+											position++
+										}
+									l96:
+										{
+											position98, tokenIndex98, depth98 := position, tokenIndex, depth
+											if buffer[position] != rune('a') {
+												goto l99
+											}
+											position++
+											goto l98
+										l99:
+											position, tokenIndex, depth = position98, tokenIndex98, depth98
+											if buffer[position] != rune('A') {
+												goto l31
+											}
+											position++
+										}
+									l98:
+										{
+											position100, tokenIndex100, depth100 := position, tokenIndex, depth
+											if buffer[position] != rune('i') {
+												goto l101
+											}
+											position++
+											goto l100
+										l101:
+											position, tokenIndex, depth = position100, tokenIndex100, depth100
+											if buffer[position] != rune('I') {
+												goto l31
+											}
+											position++
+										}
+									l100:
+										{
+											position102, tokenIndex102, depth102 := position, tokenIndex, depth
+											if buffer[position] != rune('n') {
+												goto l103
+											}
+											position++
+											goto l102
+										l103:
+											position, tokenIndex, depth = position102, tokenIndex102, depth102
+											if buffer[position] != rune('N') {
+												goto l31
+											}
+											position++
+										}
+									l102:
+										{
+											position104, tokenIndex104, depth104 := position, tokenIndex, depth
+											if buffer[position] != rune('s') {
+												goto l105
+											}
+											position++
+											goto l104
+										l105:
+											position, tokenIndex, depth = position104, tokenIndex104, depth104
+											if buffer[position] != rune('S') {
+												goto l31
+											}
+											position++
+										}
+									l104:
+										depth--
+										add(rulecontains, position89)
 									}
-									position++
-								}
-							l78:
-								{
-									position80, tokenIndex80, depth80 := position, tokenIndex, depth
-									if buffer[position] != rune('s') {
-										goto l81
+								l106:
+									{
+										position107, tokenIndex107, depth107 := position, tokenIndex, depth
+										if buffer[position] != rune(' ') {
+											goto l107
+										}
+										position++
+										goto l106
+									l107:
+										position, tokenIndex, depth = position107, tokenIndex107, depth107
 									}
-									position++
-									goto l80
-								l81:
-									position, tokenIndex, depth = position80, tokenIndex80, depth80
-									if buffer[position] != rune('S') {
-										goto l16
+									if !_rules[rulevalue]() {
+										goto l31
 									}
-									position++
-								}
-							l80:
-								depth--
-								add(rulecontains, position65)
-							}
-						l82:
-							{
-								position83, tokenIndex83, depth83 := position, tokenIndex, depth
-								if buffer[position] != rune(' ') {
-									goto l83
+									break
 								}
-								position++
-								goto l82
-							l83:
-								position, tokenIndex, depth = position83, tokenIndex83, depth83
-							}
-							if !_rules[rulevalue]() {
-								goto l16
 							}
-							break
+
 						}
+					l52:
+						depth--
+						add(rulecondition, position41)
 					}
-
 				}
-			l28:
+			l33:
 				depth--
-				add(rulecondition, position17)
+				add(rulefactor, position32)
 			}
 			return true
-		l16:
-			position, tokenIndex, depth = position16, tokenIndex16, depth16
+		l31:
+			position, tokenIndex, depth = position31, tokenIndex31, depth31
 			return false
 		},
-		/* 2 tag <- <<(!((&('<') '<') | (&('>') '>') | (&('=') '=') | (&('\'') '\'') | (&('"') '"') | (&(')') ')') | (&('(') '(') | (&('\\') '\\') | (&('\r') '\r') | (&('\n') '\n') | (&('\t') '\t') | (&(' ') ' ')) .)+>> */
+		/* 4 group <- <('(' ' '* expression ' '* ')')> */
+		func() bool {
+			position108, tokenIndex108, depth108 := position, tokenIndex, depth
+			{
+				position109 := position
+				depth++
+				if buffer[position] != rune('(') {
+					goto l108
+				}
+				position++
+			l110:
+				{
+					position111, tokenIndex111, depth111 := position, tokenIndex, depth
+					if buffer[position] != rune(' ') {
+						goto l111
+					}
+					position++
+					goto l110
+				l111:
+					position, tokenIndex, depth = position111, tokenIndex111, depth111
+				}
+				if !_rules[ruleexpression]() {
+					goto l108
+				}
+			l112:
+				{
+					position113, tokenIndex113, depth113 := position, tokenIndex, depth
+					if buffer[position] != rune(' ') {
+						goto l113
+					}
+					position++
+					goto l112
+				l113:
+					position, tokenIndex, depth = position113, tokenIndex113, depth113
+				}
+				if buffer[position] != rune(')') {
+					goto l108
+				}
+				position++
+				depth--
+				add(rulegroup, position109)
+			}
+			return true
+		l108:
+			position, tokenIndex, depth = position108, tokenIndex108, depth108
+			return false
+		},
+		/* 5 condition <- <(tag ' '* ((le ' '* ((&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number))) / (ge ' '* ((&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number))) / ((&('E' | 'e') exists) | (&('=') (equal ' '* ((&('\'') value) | (&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number)))) | (&('>') (g ' '* ((&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number)))) | (&('<') (l ' '* ((&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number)))) | (&('C' | 'c') (contains ' '* value)))))> */
+		nil,
+		/* 6 tag <- <<(!((&('<') '<') | (&('>') '>') | (&('=') '=') | (&('\'') '\'') | (&('"') '"') | (&(')') ')') | (&('(') '(') | (&('\\') '\\') | (&('\r') '\r') | (&('\n') '\n') | (&('\t') '\t') | (&(' ') ' ')) .)+>> */
 		nil,
-		/* 3 value <- <<('\'' (!('"' / '\'') .)* '\'')>> */
+		/* 7 value <- <<('\'' (!('"' / '\'') .)* '\'')>> */
 		func() bool {
-			position85, tokenIndex85, depth85 := position, tokenIndex, depth
+			position116, tokenIndex116, depth116 := position, tokenIndex, depth
 			{
-				position86 := position
+				position117 := position
 				depth++
 				{
-					position87 := position
+					position118 := position
 					depth++
 					if buffer[position] != rune('\'') {
-						goto l85
+						goto l116
 					}
 					position++
-				l88:
+				l119:
 					{
-						position89, tokenIndex89, depth89 := position, tokenIndex, depth
+						position120, tokenIndex120, depth120 := position, tokenIndex, depth
 						{
-							position90, tokenIndex90, depth90 := position, tokenIndex, depth
+							position121, tokenIndex121, depth121 := position, tokenIndex, depth
 							{
-								position91, tokenIndex91, depth91 := position, tokenIndex, depth
+								position122, tokenIndex122, depth122 := position, tokenIndex, depth
 								if buffer[position] != rune('"') {
-									goto l92
+									goto l123
 								}
 								position++
-								goto l91
-							l92:
-								position, tokenIndex, depth = position91, tokenIndex91, depth91
+								goto l122
+							l123:
+								position, tokenIndex, depth = position122, tokenIndex122, depth122
 								if buffer[position] != rune('\'') {
-									goto l90
+									goto l121
 								}
 								position++
 							}
-						l91:
-							goto l89
-						l90:
-							position, tokenIndex, depth = position90, tokenIndex90, depth90
+						l122:
+							goto l120
+						l121:
+							position, tokenIndex, depth = position121, tokenIndex121, depth121
 						}
 						if !matchDot() {
-							goto l89
+							goto l120
 						}
-						goto l88
-					l89:
-						position, tokenIndex, depth = position89, tokenIndex89, depth89
+						goto l119
+					l120:
+						position, tokenIndex, depth = position120, tokenIndex120, depth120
 					}
 					if buffer[position] != rune('\'') {
-						goto l85
+						goto l116
 					}
 					position++
 					depth--
-					add(rulePegText, position87)
+					add(rulePegText, position118)
 				}
 				depth--
-				add(rulevalue, position86)
+				add(rulevalue, position117)
 			}
 			return true
-		l85:
-			position, tokenIndex, depth = position85, tokenIndex85, depth85
+		l116:
+			position, tokenIndex, depth = position116, tokenIndex116, depth116
 			return false
 		},
-		/* 4 number <- <<('0' / ([1-9] digit* ('.' digit*)?))>> */
+		/* 8 number <- <<('0' / ([1-9] digit* ('.' digit*)?))>> */
 		func() bool {
-			position93, tokenIndex93, depth93 := position, tokenIndex, depth
+			position124, tokenIndex124, depth124 := position, tokenIndex, depth
 			{
-				position94 := position
+				position125 := position
 				depth++
 				{
-					position95 := position
+					position126 := position
 					depth++
 					{
-						position96, tokenIndex96, depth96 := position, tokenIndex, depth
+						position127, tokenIndex127, depth127 := position, tokenIndex, depth
 						if buffer[position] != rune('0') {
-							goto l97
+							goto l128
 						}
 						position++
-						goto l96
-					l97:
-						position, tokenIndex, depth = position96, tokenIndex96, depth96
+						goto l127
+					l128:
+						position, tokenIndex, depth = position127, tokenIndex127, depth127
 						if c := buffer[position]; c < rune('1') || c > rune('9') {
-							goto l93
+							goto l124
 						}
 						position++
-					l98:
+					l129:
 						{
-							position99, tokenIndex99, depth99 := position, tokenIndex, depth
+							position130, tokenIndex130, depth130 := position, tokenIndex, depth
 							if !_rules[ruledigit]() {
-								goto l99
+								goto l130
 							}
-							goto l98
-						l99:
-							position, tokenIndex, depth = position99, tokenIndex99, depth99
+							goto l129
+						l130:
+							position, tokenIndex, depth = position130, tokenIndex130, depth130
 						}
 						{
-							position100, tokenIndex100, depth100 := position, tokenIndex, depth
+							position131, tokenIndex131, depth131 := position, tokenIndex, depth
 							if buffer[position] != rune('.') {
-								goto l100
+								goto l131
 							}
 							position++
-						l102:
+						l133:
 							{
-								position103, tokenIndex103, depth103 := position, tokenIndex, depth
+								position134, tokenIndex134, depth134 := position, tokenIndex, depth
 								if !_rules[ruledigit]() {
-									goto l103
+									goto l134
 								}
-								goto l102
-							l103:
-								position, tokenIndex, depth = position103, tokenIndex103, depth103
+								goto l133
+							l134:
+								position, tokenIndex, depth = position134, tokenIndex134, depth134
 							}
-							goto l101
-						l100:
-							position, tokenIndex, depth = position100, tokenIndex100, depth100
+							goto l132
+						l131:
+							position, tokenIndex, depth = position131, tokenIndex131, depth131
 						}
-					l101:
+					l132:
 					}
-				l96:
+				l127:
 					depth--
-					add(rulePegText, position95)
+					add(rulePegText, position126)
 				}
 				depth--
-				add(rulenumber, position94)
+				add(rulenumber, position125)
 			}
 			return true
-		l93:
-			position, tokenIndex, depth = position93, tokenIndex93, depth93
+		l124:
+			position, tokenIndex, depth = position124, tokenIndex124, depth124
 			return false
 		},
-		/* 5 digit <- <[0-9]> */
+		/* 9 digit <- <[0-9]> */
 		func() bool {
-			position104, tokenIndex104, depth104 := position, tokenIndex, depth
+			position135, tokenIndex135, depth135 := position, tokenIndex, depth
 			{
-				position105 := position
+				position136 := position
 				depth++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l104
+					goto l135
 				}
 				position++
 				depth--
-				add(ruledigit, position105)
+				add(ruledigit, position136)
 			}
 			return true
-		l104:
-			position, tokenIndex, depth = position104, tokenIndex104, depth104
+		l135:
+			position, tokenIndex, depth = position135, tokenIndex135, depth135
 			return false
 		},
-		/* 6 time <- <(('t' / 'T') ('i' / 'I') ('m' / 'M') ('e' / 'E') ' ' <(year '-' month '-' day 'T' digit digit ':' digit digit ':' digit digit ((('-' / '+') digit digit ':' digit digit) / 'Z'))>)> */
+		/* 10 time <- <(('t' / 'T') ('i' / 'I') ('m' / 'M') ('e' / 'E') ' ' <(year '-' month '-' day 'T' digit digit ':' digit digit ':' digit digit ((('-' / '+') digit digit ':' digit digit) / 'Z'))>)> */
 		func() bool {
-			position106, tokenIndex106, depth106 := position, tokenIndex, depth
+			position137, tokenIndex137, depth137 := position, tokenIndex, depth
 			{
-				position107 := position
+				position138 := position
 				depth++
 				{
-					position108, tokenIndex108, depth108 := position, tokenIndex, depth
+					position139, tokenIndex139, depth139 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l109
+						goto l140
 					}
 					position++
-					goto l108
-				l109:
-					position, tokenIndex, depth = position108, tokenIndex108, depth108
+					goto l139
+				l140:
+					position, tokenIndex, depth = position139, tokenIndex139, depth139
 					if buffer[position] != rune('T') {
-						goto l106
+						goto l137
 					}
 					position++
 				}
-			l108:
+			l139:
 				{
-					position110, tokenIndex110, depth110 := position, tokenIndex, depth
+					position141, tokenIndex141, depth141 := position, tokenIndex, depth
 					if buffer[position] != rune('i') {
-						goto l111
+						goto l142
 					}
 					position++
-					goto l110
-				l111:
-					position, tokenIndex, depth = position110, tokenIndex110, depth110
+					goto l141
+				l142:
+					position, tokenIndex, depth = position141, tokenIndex141, depth141
 					if buffer[position] != rune('I') {
-						goto l106
+						goto l137
 					}
 					position++
 				}
-			l110:
+			l141:
 				{
-					position112, tokenIndex112, depth112 := position, tokenIndex, depth
+					position143, tokenIndex143, depth143 := position, tokenIndex, depth
 					if buffer[position] != rune('m') {
-						goto l113
+						goto l144
 					}
 					position++
-					goto l112
-				l113:
-					position, tokenIndex, depth = position112, tokenIndex112, depth112
+					goto l143
+				l144:
+					position, tokenIndex, depth = position143, tokenIndex143, depth143
 					if buffer[position] != rune('M') {
-						goto l106
+						goto l137
 					}
 					position++
 				}
-			l112:
+			l143:
 				{
-					position114, tokenIndex114, depth114 := position, tokenIndex, depth
+					position145, tokenIndex145, depth145 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l115
+						goto l146
 					}
 					position++
-					goto l114
-				l115:
-					position, tokenIndex, depth = position114, tokenIndex114, depth114
+					goto l145
+				l146:
+					position, tokenIndex, depth = position145, tokenIndex145, depth145
 					if buffer[position] != rune('E') {
-						goto l106
+						goto l137
 					}
 					position++
 				}
-			l114:
+			l145:
 				if buffer[position] != rune(' ') {
-					goto l106
+					goto l137
 				}
 				position++
 				{
-					position116 := position
+					position147 := position
 					depth++
 					if !_rules[ruleyear]() {
-						goto l106
+						goto l137
 					}
 					if buffer[position] != rune('-') {
-						goto l106
+						goto l137
 					}
 					position++
 					if !_rules[rulemonth]() {
-						goto l106
+						goto l137
 					}
 					if buffer[position] != rune('-') {
-						goto l106
+						goto l137
 					}
 					position++
 					if !_rules[ruleday]() {
-						goto l106
+						goto l137
 					}
 					if buffer[position] != rune('T') {
-						goto l106
+						goto l137
 					}
 					position++
 					if !_rules[ruledigit]() {
-						goto l106
+						goto l137
 					}
 					if !_rules[ruledigit]() {
-						goto l106
+						goto l137
 					}
 					if buffer[position] != rune(':') {
-						goto l106
+						goto l137
 					}
 					position++
 					if !_rules[ruledigit]() {
-						goto l106
+						goto l137
 					}
 					if !_rules[ruledigit]() {
-						goto l106
+						goto l137
 					}
 					if buffer[position] != rune(':') {
-						goto l106
+						goto l137
 					}
 					position++
 					if !_rules[ruledigit]() {
-						goto l106
+						goto l137
 					}
 					if !_rules[ruledigit]() {
-						goto l106
+						goto l137
 					}
 					{
-						position117, tokenIndex117, depth117 := position, tokenIndex, depth
+						position148, tokenIndex148, depth148 := position, tokenIndex, depth
 						{
-							position119, tokenIndex119, depth119 := position, tokenIndex, depth
+							position150, tokenIndex150, depth150 := position, tokenIndex, depth
 							if buffer[position] != rune('-') {
-								goto l120
+								goto l151
 							}
 							position++
-							goto l119
-						l120:
-							position, tokenIndex, depth = position119, tokenIndex119, depth119
+							goto l150
+						l151:
+							position, tokenIndex, depth = position150, tokenIndex150, depth150
 							if buffer[position] != rune('+') {
-								goto l118
+								goto l149
 							}
 							position++
 						}
-					l119:
+					l150:
 						if !_rules[ruledigit]() {
-							goto l118
+							goto l149
 						}
 						if !_rules[ruledigit]() {
-							goto l118
+							goto l149
 						}
 						if buffer[position] != rune(':') {
-							goto l118
+							goto l149
 						}
 						position++
 						if !_rules[ruledigit]() {
-							goto l118
+							goto l149
 						}
 						if !_rules[ruledigit]() {
-							goto l118
+							goto l149
 						}
-						goto l117
-					l118:
-						position, tokenIndex, depth = position117, tokenIndex117, depth117
+						goto l148
+					l149:
+						position, tokenIndex, depth = position148, tokenIndex148, depth148
 						if buffer[position] != rune('Z') {
-							goto l106
+							goto l137
 						}
 						position++
 					}
-				l117:
+				l148:
 					depth--
-					add(rulePegText, position116)
+					add(rulePegText, position147)
 				}
 				depth--
-				add(ruletime, position107)
+				add(ruletime, position138)
 			}
 			return true
-		l106:
-			position, tokenIndex, depth = position106, tokenIndex106, depth106
+		l137:
+			position, tokenIndex, depth = position137, tokenIndex137, depth137
 			return false
 		},
-		/* 7 date <- <(('d' / 'D') ('a' / 'A') ('t' / 'T') ('e' / 'E') ' ' <(year '-' month '-' day)>)> */
+		/* 11 date <- <(('d' / 'D') ('a' / 'A') ('t' / 'T') ('e' / 'E') ' ' <(year '-' month '-' day)>)> */
 		func() bool {
-			position121, tokenIndex121, depth121 := position, tokenIndex, depth
+			position152, tokenIndex152, depth152 := position, tokenIndex, depth
 			{
-				position122 := position
+				position153 := position
 				depth++
 				{
-					position123, tokenIndex123, depth123 := position, tokenIndex, depth
+					position154, tokenIndex154, depth154 := position, tokenIndex, depth
 					if buffer[position] != rune('d') {
-						goto l124
+						goto l155
 					}
 					position++
-					goto l123
-				l124:
-					position, tokenIndex, depth = position123, tokenIndex123, depth123
+					goto l154
+				l155:
+					position, tokenIndex, depth = position154, tokenIndex154, depth154
 					if buffer[position] != rune('D') {
-						goto l121
+						goto l152
 					}
 					position++
 				}
-			l123:
+			l154:
 				{
-					position125, tokenIndex125, depth125 := position, tokenIndex, depth
+					position156, tokenIndex156, depth156 := position, tokenIndex, depth
 					if buffer[position] != rune('a') {
-						goto l126
+						goto l157
 					}
 					position++
-					goto l125
-				l126:
-					position, tokenIndex, depth = position125, tokenIndex125, depth125
+					goto l156
+				l157:
+					position, tokenIndex, depth = position156, tokenIndex156, depth156
 					if buffer[position] != rune('A') {
-						goto l121
+						goto l152
 					}
 					position++
 				}
-			l125:
+			l156:
 				{
-					position127, tokenIndex127, depth127 := position, tokenIndex, depth
+					position158, tokenIndex158, depth158 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l128
+						goto l159
 					}
 					position++
-					goto l127
-				l128:
-					position, tokenIndex, depth = position127, tokenIndex127, depth127
+					goto l158
+				l159:
+					position, tokenIndex, depth = position158, tokenIndex158, depth158
 					if buffer[position] != rune('T') {
-						goto l121
+						goto l152
 					}
 					position++
 				}
-			l127:
+			l158:
 				{
-					position129, tokenIndex129, depth129 := position, tokenIndex, depth
+					position160, tokenIndex160, depth160 := position, tokenIndex, depth
 					if buffer[position] != rune('e') {
-						goto l130
+						goto l161
 					}
 					position++
-					goto l129
-				l130:
-					position, tokenIndex, depth = position129, tokenIndex129, depth129
+					goto l160
+				l161:
+					position, tokenIndex, depth = position160, tokenIndex160, depth160
 					if buffer[position] != rune('E') {
-						goto l121
+						goto l152
 					}
 					position++
 				}
-			l129:
+			l160:
 				if buffer[position] != rune(' ') {
-					goto l121
+					goto l152
 				}
 				position++
 				{
-					position131 := position
+					position162 := position
 					depth++
 					if !_rules[ruleyear]() {
-						goto l121
+						goto l152
 					}
 					if buffer[position] != rune('-') {
-						goto l121
+						goto l152
 					}
 					position++
 					if !_rules[rulemonth]() {
-						goto l121
+						goto l152
 					}
 					if buffer[position] != rune('-') {
-						goto l121
+						goto l152
 					}
 					position++
 					if !_rules[ruleday]() {
-						goto l121
+						goto l152
 					}
 					depth--
-					add(rulePegText, position131)
+					add(rulePegText, position162)
 				}
 				depth--
-				add(ruledate, position122)
+				add(ruledate, position153)
 			}
 			return true
-		l121:
-			position, tokenIndex, depth = position121, tokenIndex121, depth121
+		l152:
+			position, tokenIndex, depth = position152, tokenIndex152, depth152
 			return false
 		},
-		/* 8 year <- <(('1' / '2') digit digit digit)> */
+		/* 12 year <- <(('1' / '2') digit digit digit)> */
 		func() bool {
-			position132, tokenIndex132, depth132 := position, tokenIndex, depth
+			position163, tokenIndex163, depth163 := position, tokenIndex, depth
 			{
-				position133 := position
+				position164 := position
 				depth++
 				{
-					position134, tokenIndex134, depth134 := position, tokenIndex, depth
+					position165, tokenIndex165, depth165 := position, tokenIndex, depth
 					if buffer[position] != rune('1') {
-						goto l135
+						goto l166
 					}
 					position++
-					goto l134
-				l135:
-					position, tokenIndex, depth = position134, tokenIndex134, depth134
+					goto l165
+				l166:
+					position, tokenIndex, depth = position165, tokenIndex165, depth165
 					if buffer[position] != rune('2') {
-						goto l132
+						goto l163
 					}
 					position++
 				}
-			l134:
+			l165:
 				if !_rules[ruledigit]() {
-					goto l132
+					goto l163
 				}
 				if !_rules[ruledigit]() {
-					goto l132
+					goto l163
 				}
 				if !_rules[ruledigit]() {
-					goto l132
+					goto l163
 				}
 				depth--
-				add(ruleyear, position133)
+				add(ruleyear, position164)
 			}
 			return true
-		l132:
-			position, tokenIndex, depth = position132, tokenIndex132, depth132
+		l163:
+			position, tokenIndex, depth = position163, tokenIndex163, depth163
 			return false
 		},
-		/* 9 month <- <(('0' / '1') digit)> */
+		/* 13 month <- <(('0' / '1') digit)> */
 		func() bool {
-			position136, tokenIndex136, depth136 := position, tokenIndex, depth
+			position167, tokenIndex167, depth167 := position, tokenIndex, depth
 			{
-				position137 := position
+				position168 := position
 				depth++
 				{
-					position138, tokenIndex138, depth138 := position, tokenIndex, depth
+					position169, tokenIndex169, depth169 := position, tokenIndex, depth
 					if buffer[position] != rune('0') {
-						goto l139
+						goto l170
 					}
 					position++
-					goto l138
-				l139:
-					position, tokenIndex, depth = position138, tokenIndex138, depth138
+					goto l169
+				l170:
+					position, tokenIndex, depth = position169, tokenIndex169, depth169
 					if buffer[position] != rune('1') {
-						goto l136
+						goto l167
 					}
 					position++
 				}
-			l138:
+			l169:
 				if !_rules[ruledigit]() {
-					goto l136
+					goto l167
 				}
 				depth--
-				add(rulemonth, position137)
+				add(rulemonth, position168)
 			}
 			return true
-		l136:
-			position, tokenIndex, depth = position136, tokenIndex136, depth136
+		l167:
+			position, tokenIndex, depth = position167, tokenIndex167, depth167
 			return false
 		},
-		/* 10 day <- <(((&('3') '3') | (&('2') '2') | (&('1') '1') | (&('0') '0')) digit)> */
+		/* 14 day <- <(((&('3') '3') | (&('2') '2') | (&('1') '1') | (&('0') '0')) digit)> */
 		func() bool {
-			position140, tokenIndex140, depth140 := position, tokenIndex, depth
+			position171, tokenIndex171, depth171 := position, tokenIndex, depth
 			{
-				position141 := position
+				position172 := position
 				depth++
 				{
 					switch buffer[position] {
 					case '3':
 						if buffer[position] != rune('3') {
-							goto l140
+							goto l171
 						}
 						position++
 						break
 					case '2':
 						if buffer[position] != rune('2') {
-							goto l140
+							goto l171
 						}
 						position++
 						break
 					case '1':
 						if buffer[position] != rune('1') {
-							goto l140
+							goto l171
 						}
 						position++
 						break
 					default:
 						if buffer[position] != rune('0') {
-							goto l140
+							goto l171
 						}
 						position++
 						break
@@ -1839,31 +2070,92 @@ func (p *QueryParser) Init() {
 				}
 
 				if !_rules[ruledigit]() {
-					goto l140
+					goto l171
 				}
 				depth--
-				add(ruleday, position141)
+				add(ruleday, position172)
 			}
 			return true
-		l140:
-			position, tokenIndex, depth = position140, tokenIndex140, depth140
+		l171:
+			position, tokenIndex, depth = position171, tokenIndex171, depth171
 			return false
 		},
-		/* 11 and <- <(('a' / 'A') ('n' / 'N') ('d' / 'D'))> */
+		/* 15 and <- <(('a' / 'A') ('n' / 'N') ('d' / 'D'))> */
+		nil,
+		/* 16 or <- <(('o' / 'O') ('r' / 'R'))> */
 		nil,
-		/* 12 equal <- <'='> */
+		/* 17 not <- <(('n' / 'N') ('o' / 'O') ('t' / 'T'))> */
+		func() bool {
+			position176, tokenIndex176, depth176 := position, tokenIndex, depth
+			{
+				position177 := position
+				depth++
+				{
+					position178, tokenIndex178, depth178 := position, tokenIndex, depth
+					if buffer[position] != rune('n') {
+						goto l179
+					}
+					position++
+					goto l178
+				l179:
+					position, tokenIndex, depth = position178, tokenIndex178, depth178
+					if buffer[position] != rune('N') {
+						goto l176
+					}
+					position++
+				}
+			l178:
+				{
+					position180, tokenIndex180, depth180 := position, tokenIndex, depth
+					if buffer[position] != rune('o') {
+						goto l181
+					}
+					position++
+					goto l180
+				l181:
+					position, tokenIndex, depth = position180, tokenIndex180, depth180
+					if buffer[position] != rune('O') {
+						goto l176
+					}
+					position++
+				}
+			l180:
+				{
+					position182, tokenIndex182, depth182 := position, tokenIndex, depth
+					if buffer[position] != rune('t') {
+						goto l183
+					}
+					position++
+					goto l182
+				l183:
+					position, tokenIndex, depth = position182, tokenIndex182, depth182
+					if buffer[position] != rune('T') {
+						goto l176
+					}
+					position++
+				}
+			l182:
+				depth--
+				add(rulenot, position177)
+			}
+			return true
+		l176:
+			position, tokenIndex, depth = position176, tokenIndex176, depth176
+			return false
+		},
+		/* 18 equal <- <'='> */
 		nil,
-		/* 13 contains <- <(('c' / 'C') ('o' / 'O') ('n' / 'N') ('t' / 'T') ('a' / 'A') ('i' / 'I') ('n' / 'N') ('s' / 'S'))> */
+		/* 19 contains <- <(('c' / 'C') ('o' / 'O') ('n' / 'N') ('t' / 'T') ('a' / 'A') ('i' / 'I') ('n' / 'N') ('s' / 'S'))> */
 		nil,
-		/* 14 exists <- <(('e' / 'E') ('x' / 'X') ('i' / 'I') ('s' / 'S') ('t' / 'T') ('s' / 'S'))> */
+		/* 20 exists <- <(('e' / 'E') ('x' / 'X') ('i' / 'I') ('s' / 'S') ('t' / 'T') ('s' / 'S'))> */
 		nil,
-		/* 15 le <- <('<' '=')> */
+		/* 21 le <- <('<' '=')> */
 		nil,
-		/* 16 ge <- <('>' '=')> */
+		/* 22 ge <- <('>' '=')> */
 		nil,
-		/* 17 l <- <'<'> */
+		/* 23 l <- <'<'> */
 		nil,
-		/* 18 g <- <'>'> */
+		/* 24 g <- <'>'> */
 		nil,
 		nil,
 	}
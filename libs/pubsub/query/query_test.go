@@ -150,6 +150,48 @@ func TestMatches(t *testing.T) {
 			false,
 			false,
 		},
+		{"tm.events.type='NewBlock' OR tm.events.type='Tx'", map[string][]string{"tm.events.type": {"Tx"}}, false, true, false},
+		{"tm.events.type='NewBlock' OR tm.events.type='Tx'", map[string][]string{"tm.events.type": {"Vote"}}, false, false, false},
+		{"NOT tm.events.type='NewBlock'", map[string][]string{"tm.events.type": {"Tx"}}, false, true, false},
+		{"NOT tm.events.type='NewBlock'", map[string][]string{"tm.events.type": {"NewBlock"}}, false, false, false},
+		{"NOT slash EXISTS", map[string][]string{"transfer.sender": {"Igor"}}, false, true, false},
+		{
+			// AND binds tighter than OR
+			"tx.gas > 7 AND tx.gas < 9 OR tx.gas = 100",
+			map[string][]string{"tx.gas": {"100"}},
+			false,
+			true,
+			false,
+		},
+		{
+			"tx.gas > 7 AND (tx.gas < 9 OR tx.gas = 100)",
+			map[string][]string{"tx.gas": {"100"}},
+			false,
+			true,
+			false,
+		},
+		{
+			"tx.gas > 7 AND (tx.gas < 9 OR tx.gas = 100)",
+			map[string][]string{"tx.gas": {"5"}},
+			false,
+			false,
+			false,
+		},
+		{
+			"transfer.sender = 'Igor' AND NOT (transfer.recipient = 'Ivan' OR transfer.amount > 100)",
+			map[string][]string{"transfer.sender": {"Igor"}, "transfer.recipient": {"Pavel"}, "transfer.amount": {"10"}},
+			false,
+			true,
+			false,
+		},
+		{
+			"transfer.sender = 'Igor' AND NOT (transfer.recipient = 'Ivan' OR transfer.amount > 100)",
+			map[string][]string{"transfer.sender": {"Igor"}, "transfer.recipient": {"Pavel"}, "transfer.amount": {"1000"}},
+			false,
+			false,
+			false,
+		},
+		{"tx.gas > 7 OR tx.date = DATE 2017-01-01", map[string][]string{"tx.gas": {"gas"}}, false, false, true},
 	}
 
 	for _, tc := range testCases {
@@ -219,4 +261,39 @@ func TestConditions(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, tc.conditions, c)
 	}
+
+	for _, s := range []string{"tx.gas > 7 OR tx.gas < 9", "NOT tx.gas > 7", "tx.gas > 7 AND (tx.gas < 9 OR tx.gas = 100)"} {
+		q, err := query.New(s)
+		require.NoError(t, err)
+		_, err = q.Conditions()
+		assert.Error(t, err, s)
+	}
+}
+
+func TestExpression(t *testing.T) {
+	q, err := query.New("tx.height = 3 AND (tm.event = 'Tx' OR NOT slashing EXISTS) AND tx.gas < 9 OR tx.hash = 'AB'")
+	require.NoError(t, err)
+
+	var (
+		height = query.Expression{Condition: query.Condition{CompositeKey: "tx.height", Op: query.OpEqual, Operand: int64(3)}}
+		event  = query.Expression{Condition: query.Condition{CompositeKey: "tm.event", Op: query.OpEqual, Operand: "Tx"}}
+		slash  = query.Expression{Condition: query.Condition{CompositeKey: "slashing", Op: query.OpExists}}
+		gas    = query.Expression{Condition: query.Condition{CompositeKey: "tx.gas", Op: query.OpLess, Operand: int64(9)}}
+		hash   = query.Expression{Condition: query.Condition{CompositeKey: "tx.hash", Op: query.OpEqual, Operand: "AB"}}
+	)
+	expected := query.Expression{Op: query.ExprOr, Operands: []query.Expression{
+		{Op: query.ExprAnd, Operands: []query.Expression{
+			height,
+			{Op: query.ExprOr, Operands: []query.Expression{
+				event,
+				{Op: query.ExprNot, Operands: []query.Expression{slash}},
+			}},
+			gas,
+		}},
+		hash,
+	}}
+
+	e, err := q.Expression()
+	require.NoError(t, err)
+	assert.Equal(t, expected, e)
 }
@@ -0,0 +1,170 @@
+package pubsub
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	tmjson "github.com/arcology-network/consensus-engine/libs/json"
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
+)
+
+const spillFilePattern = "subscription-*.spill"
+
+// spilledMessage is the encoding of a Message on disk. The data must be of a
+// type registered with libs/json.
+type spilledMessage struct {
+	Data   interface{}         `json:"data"`
+	Events map[string][]string `json:"events"`
+}
+
+// spillQueue is a FIFO queue of messages stored in a file. The messages are
+// prefixed with their length and appended to the file; once all of them have
+// been delivered, the file is truncated.
+//
+// pending counts the messages which haven't been delivered yet, including the
+// one read last, so that a new message goes to the queue as long as an older
+// one may still be on its way to the subscriber.
+type spillQueue struct {
+	mtx      tmsync.Mutex
+	file     *os.File
+	maxBytes int64
+	readOff  int64
+	writeOff int64
+	pending  int
+	err      error // set if a message couldn't be read back
+
+	notify chan struct{}
+}
+
+func newSpillQueue(dir string, maxBytes int64) (*spillQueue, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	file, err := os.CreateTemp(dir, spillFilePattern)
+	if err != nil {
+		return nil, err
+	}
+	return &spillQueue{
+		file:     file,
+		maxBytes: maxBytes,
+		notify:   make(chan struct{}, 1),
+	}, nil
+}
+
+// removeSpillFiles removes the queues left behind by a previous run.
+func removeSpillFiles(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, spillFilePattern))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := os.Remove(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// size returns the number of messages, which haven't been delivered yet.
+func (q *spillQueue) size() int {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return q.pending
+}
+
+// push appends msg to the queue. It returns ErrOutOfCapacity if the file
+// would grow over maxBytes.
+func (q *spillQueue) push(msg Message) error {
+	bz, err := tmjson.Marshal(spilledMessage{Data: msg.data, Events: msg.events})
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	record := make([]byte, binary.MaxVarintLen64+len(bz))
+	n := binary.PutUvarint(record, uint64(len(bz)))
+	record = append(record[:n], bz...)
+
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	if q.err != nil {
+		return q.err
+	}
+	if q.writeOff+int64(len(record)) > q.maxBytes {
+		return ErrOutOfCapacity
+	}
+	if _, err := q.file.WriteAt(record, q.writeOff); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	q.writeOff += int64(len(record))
+	q.pending++
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// next reads the oldest message, which hasn't been read yet. ok is false if
+// there is none. The message remains pending until done is called.
+func (q *spillQueue) next() (msg Message, ok bool, err error) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	if q.readOff == q.writeOff {
+		return Message{}, false, nil
+	}
+	defer func() {
+		if err != nil {
+			q.err = err
+		}
+	}()
+
+	header := make([]byte, binary.MaxVarintLen64)
+	if remaining := q.writeOff - q.readOff; remaining < int64(len(header)) {
+		header = header[:remaining]
+	}
+	if _, err := q.file.ReadAt(header, q.readOff); err != nil && err != io.EOF {
+		return Message{}, false, err
+	}
+	size, n := binary.Uvarint(header)
+	if n <= 0 || q.readOff+int64(n)+int64(size) > q.writeOff {
+		return Message{}, false, errors.New("corrupted spill file")
+	}
+	bz := make([]byte, size)
+	if _, err := q.file.ReadAt(bz, q.readOff+int64(n)); err != nil {
+		return Message{}, false, err
+	}
+	var spilled spilledMessage
+	if err := tmjson.Unmarshal(bz, &spilled); err != nil {
+		return Message{}, false, fmt.Errorf("failed to decode message: %w", err)
+	}
+	q.readOff += int64(n) + int64(size)
+	return NewMessage(spilled.Data, spilled.Events), true, nil
+}
+
+// done marks the message returned by next as delivered.
+func (q *spillQueue) done() {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.pending--
+	if q.pending == 0 {
+		q.readOff, q.writeOff = 0, 0
+		if err := q.file.Truncate(0); err != nil {
+			q.err = err
+		}
+	}
+}
+
+// close closes and removes the file.
+func (q *spillQueue) close() {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.file.Close()           // nolint: errcheck
+	os.Remove(q.file.Name()) // nolint: errcheck
+	if q.err == nil {
+		q.err = ErrUnsubscribed
+	}
+}
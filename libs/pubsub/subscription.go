@@ -2,6 +2,8 @@ package pubsub
 
 import (
 	"errors"
+	"fmt"
+	"sync/atomic"
 
 	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
 )
@@ -15,6 +17,35 @@ var (
 	ErrOutOfCapacity = errors.New("client is not pulling messages fast enough")
 )
 
+// OverflowPolicy tells the server what to do when a client is not pulling
+// messages fast enough and the channel returned by Subscription#Out is full.
+type OverflowPolicy string
+
+const (
+	// OverflowCancel terminates the subscription with ErrOutOfCapacity.
+	OverflowCancel OverflowPolicy = "cancel"
+	// OverflowDropOldest drops the oldest message from the channel to make
+	// room for the new one.
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+	// OverflowDropNewest drops the new message.
+	OverflowDropNewest OverflowPolicy = "drop_newest"
+	// OverflowSpill writes the messages to disk until the client catches up.
+	// The subscription is terminated with ErrOutOfCapacity if the spill file
+	// grows too large. See SpillDirectory.
+	OverflowSpill OverflowPolicy = "spill"
+)
+
+// ValidateBasic returns an error if the policy is unknown.
+func (p OverflowPolicy) ValidateBasic() error {
+	switch p {
+	case OverflowCancel, OverflowDropOldest, OverflowDropNewest, OverflowSpill:
+		return nil
+	default:
+		return fmt.Errorf("unknown overflow policy %q, must be one of %q, %q, %q or %q",
+			string(p), OverflowCancel, OverflowDropOldest, OverflowDropNewest, OverflowSpill)
+	}
+}
+
 // A Subscription represents a client subscription for a particular query and
 // consists of three things:
 // 1) channel onto which messages and events are published
@@ -23,15 +54,21 @@ var (
 type Subscription struct {
 	out chan Message
 
+	policy  OverflowPolicy
+	dropped uint64      // accessed atomically
+	spill   *spillQueue // set if policy is OverflowSpill
+
 	cancelled chan struct{}
 	mtx       tmsync.RWMutex
 	err       error
 }
 
-// NewSubscription returns a new subscription with the given outCapacity.
+// NewSubscription returns a new subscription with the given outCapacity,
+// which is cancelled if the client is not pulling messages fast enough.
 func NewSubscription(outCapacity int) *Subscription {
 	return &Subscription{
 		out:       make(chan Message, outCapacity),
+		policy:    OverflowCancel,
 		cancelled: make(chan struct{}),
 	}
 }
@@ -49,11 +86,34 @@ func (s *Subscription) Cancelled() <-chan struct{} {
 	return s.cancelled
 }
 
+// Policy returns what happens when the client is not pulling messages fast
+// enough.
+func (s *Subscription) Policy() OverflowPolicy {
+	return s.policy
+}
+
+// Dropped returns the number of messages dropped because the client was not
+// pulling messages fast enough.
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Buffered returns the number of messages waiting to be pulled by the client,
+// including the ones spilled to disk.
+func (s *Subscription) Buffered() int {
+	n := len(s.out)
+	if s.spill != nil {
+		n += s.spill.size()
+	}
+	return n
+}
+
 // Err returns nil if the channel returned by Cancelled is not yet closed.
 // If the channel is closed, Err returns a non-nil error explaining why:
 //   - ErrUnsubscribed if the subscriber choose to unsubscribe,
 //   - ErrOutOfCapacity if the subscriber is not pulling messages fast enough
-//     and the channel returned by Out became full,
+//     and the channel returned by Out became full (or the spill file grew too
+//     large, see OverflowSpill),
 //
 // After Err returns a non-nil error, successive calls to Err return the same
 // error.
@@ -70,6 +130,33 @@ func (s *Subscription) cancel(err error) {
 	close(s.cancelled)
 }
 
+// drainSpill moves the spilled messages to the channel returned by Out as the
+// client pulls them, until the subscription is cancelled.
+func (s *Subscription) drainSpill() {
+	defer s.spill.close()
+	for {
+		msg, ok, err := s.spill.next()
+		if err != nil {
+			// the next message pushed will cancel the subscription
+			return
+		}
+		if !ok {
+			select {
+			case <-s.spill.notify:
+				continue
+			case <-s.cancelled:
+				return
+			}
+		}
+		select {
+		case s.out <- msg:
+			s.spill.done()
+		case <-s.cancelled:
+			return
+		}
+	}
+}
+
 // Message glues data and events together.
 type Message struct {
 	data   interface{}
@@ -348,6 +348,52 @@ func (c *Client) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBl
 	return res, nil
 }
 
+// Header fetches and verifies the header directly via the light client.
+func (c *Client) Header(ctx context.Context, height *int64) (*ctypes.ResultHeader, error) {
+	// Update the light client if we're behind and retrieve the light block at the requested height
+	// or at the latest height if no height is provided.
+	l, err := c.updateLightClientIfNeededTo(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ctypes.ResultHeader{Header: l.Header}, nil
+}
+
+// HeaderByHash calls rpcclient#HeaderByHash and updates the client if it's
+// falling behind.
+func (c *Client) HeaderByHash(ctx context.Context, hash []byte) (*ctypes.ResultHeader, error) {
+	res, err := c.next.HeaderByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if res.Header == nil {
+		return nil, errors.New("header not found")
+	}
+	if err := res.Header.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if hH := res.Header.Hash(); !bytes.Equal(hH, hash) {
+		return nil, fmt.Errorf("header %X does not match with hash %X", hH, hash)
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Header.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify header.
+	if hH, tH := res.Header.Hash(), l.Hash(); !bytes.Equal(hH, tH) {
+		return nil, fmt.Errorf("header %X does not match with trusted header %X",
+			hH, tH)
+	}
+
+	return res, nil
+}
+
 // BlockResults returns the block results for the given height. If no height is
 // provided, the results of the block preceding the latest are returned.
 func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
@@ -454,6 +500,43 @@ func (c *Client) TxSearch(ctx context.Context, query string, prove bool, page, p
 	return c.next.TxSearch(ctx, query, prove, page, perPage, orderBy)
 }
 
+// BlockSearch calls rpcclient#BlockSearch and then verifies every block found.
+func (c *Client) BlockSearch(ctx context.Context, query string, page, perPage *int, orderBy string) (
+	*ctypes.ResultBlockSearch, error) {
+	res, err := c.next.BlockSearch(ctx, query, page, perPage, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range res.Blocks {
+		// Validate b.
+		if err := b.BlockID.ValidateBasic(); err != nil {
+			return nil, err
+		}
+		if err := b.Block.ValidateBasic(); err != nil {
+			return nil, err
+		}
+		if bmH, bH := b.BlockID.Hash, b.Block.Hash(); !bytes.Equal(bmH, bH) {
+			return nil, fmt.Errorf("blockID %X does not match with block %X",
+				bmH, bH)
+		}
+
+		// Update the light client if we're behind.
+		l, err := c.updateLightClientIfNeededTo(ctx, &b.Block.Height)
+		if err != nil {
+			return nil, err
+		}
+
+		// Verify block.
+		if bH, tH := b.Block.Hash(), l.Hash(); !bytes.Equal(bH, tH) {
+			return nil, fmt.Errorf("block header %X does not match with trusted header %X",
+				bH, tH)
+		}
+	}
+
+	return res, nil
+}
+
 // Validators fetches and verifies validators.
 func (c *Client) Validators(ctx context.Context, height *int64, pagePtr, perPagePtr *int) (*ctypes.ResultValidators,
 	error) {
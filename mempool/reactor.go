@@ -6,6 +6,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/arcology-network/consensus-engine/behaviour"
 	cfg "github.com/arcology-network/consensus-engine/config"
 	"github.com/arcology-network/consensus-engine/libs/log"
 	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
@@ -25,6 +26,11 @@ const (
 	UnknownPeerID uint16 = 0
 
 	maxActiveIDs = math.MaxUint16
+
+	// goodTxsReportInterval is the minimum interval between two good
+	// behaviour reports for the same peer, so a peer can't build trust just by
+	// flooding us with txs.
+	goodTxsReportInterval = 10 * time.Second
 )
 
 // Reactor handles mempool tx broadcasting amongst peers.
@@ -32,10 +38,18 @@ const (
 // peers you received it from.
 type Reactor struct {
 	p2p.BaseReactor
-	config  *cfg.MempoolConfig
-	mempool *CListMempool
-	ids     *mempoolIDs
-	backend monaco.BackendProxy
+	config   *cfg.MempoolConfig
+	mempool  *CListMempool
+	ids      *mempoolIDs
+	backend  monaco.BackendProxy
+	reporter behaviour.Reporter
+
+	// seen holds the recently received txs. Only peers sending txs we
+	// haven't seen yet are reported as behaving well.
+	seen txCache
+
+	mtx         tmsync.Mutex
+	lastGoodTxs map[p2p.ID]time.Time // peer ID -> last good behaviour report
 }
 
 type mempoolIDs struct {
@@ -116,14 +130,28 @@ func newMempoolIDs() *mempoolIDs {
 // NewReactor returns a new Reactor with the given config and mempool.
 func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool) *Reactor {
 	memR := &Reactor{
-		config:  config,
-		mempool: mempool,
-		ids:     newMempoolIDs(),
+		config:   config,
+		mempool:  mempool,
+		ids:      newMempoolIDs(),
+		reporter: behaviour.NewMockReporter(),
+
+		lastGoodTxs: make(map[p2p.ID]time.Time),
+	}
+	if config.CacheSize > 0 {
+		memR.seen = newMapTxCache(config.CacheSize)
+	} else {
+		memR.seen = nopTxCache{}
 	}
 	memR.BaseReactor = *p2p.NewBaseReactor("Mempool", memR)
 	return memR
 }
 
+// SetSwitch implements Reactor by also reporting peer behaviour to the switch.
+func (memR *Reactor) SetSwitch(sw *p2p.Switch) {
+	memR.BaseReactor.SetSwitch(sw)
+	memR.reporter = behaviour.NewSwitchReporter(sw)
+}
+
 func (memR *Reactor) SetBackendProxy(backend monaco.BackendProxy) {
 	memR.backend = backend
 }
@@ -186,6 +214,7 @@ func (memR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			Priority:            5,
 			SendQueueCapacity:   1024,
 			RecvMessageCapacity: batchMsg.Size(),
+			MessageType:         &protomem.Message{},
 		},
 	}
 }
@@ -201,6 +230,10 @@ func (memR *Reactor) AddPeer(peer p2p.Peer) {
 // RemovePeer implements Reactor.
 func (memR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
 	memR.ids.Reclaim(peer)
+
+	memR.mtx.Lock()
+	delete(memR.lastGoodTxs, peer.ID())
+	memR.mtx.Unlock()
 	// broadcast routine checks if peer is gone and returns
 }
 
@@ -210,7 +243,7 @@ func (memR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	msg, err := memR.decodeMsg(msgBytes)
 	if err != nil {
 		memR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
-		memR.Switch.StopPeerForError(src, err)
+		_ = memR.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 		return
 	}
 	// memR.Logger.Debug("Receive", "src", src, "chId", chID, "msg", msg)
@@ -221,13 +254,34 @@ func (memR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	}
 
 	txs := make([][]byte, len(msg.Txs))
+	useful := false
 	for i := range txs {
 		txs[i] = msg.Txs[i]
+		if memR.seen.Push(txs[i]) {
+			useful = true
+		}
 	}
 	memR.backend.AddToMempool(txs, string(src.ID()))
+	if useful && memR.shouldReportGoodTxs(src.ID()) {
+		_ = memR.reporter.Report(behaviour.ValidMessage(src.ID(), "txs"))
+	}
 	// broadcasting happens from go routines per peer
 }
 
+// shouldReportGoodTxs returns true if the peer hasn't been reported as
+// behaving well within the last goodTxsReportInterval.
+func (memR *Reactor) shouldReportGoodTxs(id p2p.ID) bool {
+	memR.mtx.Lock()
+	defer memR.mtx.Unlock()
+
+	now := time.Now()
+	if last, ok := memR.lastGoodTxs[id]; ok && now.Sub(last) < goodTxsReportInterval {
+		return false
+	}
+	memR.lastGoodTxs[id] = now
+	return true
+}
+
 // PeerState describes the state of a peer.
 type PeerState interface {
 	GetHeight() int64
@@ -6,6 +6,8 @@ import (
 	"github.com/arcology-network/consensus-engine/libs/log"
 )
 
+// BackendMock is a BackendProxy for tests. It reaps a fixed transaction and
+// keeps no blocks or state of its own.
 type BackendMock struct {
 	logger log.Logger
 	txs    [][]byte
@@ -22,7 +24,9 @@ func (bm *BackendMock) SetLogger(logger log.Logger) {
 	bm.logger = logger
 }
 
-func (bm *BackendMock) Reap(int64, int64) ([][]byte, [][]byte) {
+var _ BackendProxy = (*BackendMock)(nil)
+
+func (bm *BackendMock) Reap(int64, int64, int64) ([][]byte, [][]byte) {
 	return [][]byte{
 			{1, 2, 3},
 		}, [][]byte{
@@ -47,3 +51,17 @@ func (bm *BackendMock) GetLocalTxsChan() chan [][]byte {
 func (bm *BackendMock) GetTxsOnBlock(height uint64) ([][]byte, error) {
 	return nil, nil
 }
+
+// CreateBlockStore returns nil; the tests keep their own block store.
+func (bm *BackendMock) CreateBlockStore() BlockStore {
+	return nil
+}
+
+// CreateStateStore returns nil; the tests keep their own state store.
+func (bm *BackendMock) CreateStateStore() interface{} {
+	return nil
+}
+
+func (bm *BackendMock) UpdateMaxPeerHeight(height uint64) {}
+
+func (bm *BackendMock) SwitchToConsensus() {}
@@ -8,6 +8,9 @@ import (
 	"net"
 	"net/http"
 	_ "net/http/pprof" // nolint: gosec // securely exposed on separate, optional port
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,20 +32,28 @@ import (
 	"github.com/arcology-network/consensus-engine/libs/log"
 	tmpubsub "github.com/arcology-network/consensus-engine/libs/pubsub"
 	"github.com/arcology-network/consensus-engine/libs/service"
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
 	"github.com/arcology-network/consensus-engine/light"
 	mempl "github.com/arcology-network/consensus-engine/mempool"
 	"github.com/arcology-network/consensus-engine/monaco"
 	"github.com/arcology-network/consensus-engine/p2p"
 	"github.com/arcology-network/consensus-engine/p2p/pex"
+	"github.com/arcology-network/consensus-engine/p2p/trust"
+	"github.com/arcology-network/consensus-engine/p2p/upnp"
 	"github.com/arcology-network/consensus-engine/privval"
+	tmgrpc "github.com/arcology-network/consensus-engine/privval/grpc"
 	"github.com/arcology-network/consensus-engine/proxy"
 	rpccore "github.com/arcology-network/consensus-engine/rpc/core"
 	grpccore "github.com/arcology-network/consensus-engine/rpc/grpc"
 	rpcserver "github.com/arcology-network/consensus-engine/rpc/jsonrpc/server"
 	sm "github.com/arcology-network/consensus-engine/state"
+	"github.com/arcology-network/consensus-engine/state/indexer"
+	blockidxkv "github.com/arcology-network/consensus-engine/state/indexer/block/kv"
+	blockidxnull "github.com/arcology-network/consensus-engine/state/indexer/block/null"
 	"github.com/arcology-network/consensus-engine/state/txindex"
 	"github.com/arcology-network/consensus-engine/state/txindex/kv"
 	"github.com/arcology-network/consensus-engine/state/txindex/null"
+	"github.com/arcology-network/consensus-engine/state/valstats"
 	"github.com/arcology-network/consensus-engine/statesync"
 	"github.com/arcology-network/consensus-engine/store"
 	"github.com/arcology-network/consensus-engine/types"
@@ -94,7 +105,11 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 	}
 
 	return NewNode(config,
-		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
+		privval.LoadOrGenFilePVWithPassphrase(
+			config.PrivValidatorKeyFile(),
+			config.PrivValidatorStateFile(),
+			privval.DefaultPassphrase(config.PrivValidatorKeyPassphraseFile()),
+		),
 		nodeKey,
 		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
 		DefaultGenesisDocProviderFunc(config),
@@ -177,12 +192,13 @@ type Node struct {
 	privValidator types.PrivValidator // local node's validator key
 
 	// network
-	transport   *p2p.MultiplexTransport
-	sw          *p2p.Switch  // p2p connections
-	addrBook    pex.AddrBook // known peers
-	nodeInfo    p2p.NodeInfo
-	nodeKey     *p2p.NodeKey // our node privkey
-	isListening bool
+	transport     nodeTransport
+	sw            *p2p.Switch  // p2p connections
+	addrBook      pex.AddrBook // known peers
+	nodeKey       *p2p.NodeKey // our node privkey
+	isListening   bool
+	addrDiscovery *p2p.ExternalAddrDiscovery // learns the external IP from peers, if enabled
+	listenAddrMtx tmsync.Mutex               // guards the address advertised in the NodeInfo
 
 	// services
 	eventBus          *types.EventBus // pub/sub for services
@@ -202,8 +218,16 @@ type Node struct {
 	proxyApp          proxy.AppConns          // connection to the application
 	rpcListeners      []net.Listener          // rpc servers
 	txIndexer         txindex.TxIndexer
+	blockIndexer      indexer.BlockIndexer
 	indexerService    *txindex.IndexerService
+	validatorStats    *valstats.Tracker      // nil if disabled
+	responseCache     *rpccore.ResponseCache // nil if disabled
 	prometheusSrv     *http.Server
+	consensusTrace    *os.File // consensus trace file, if enabled
+	peerScorer        *p2p.PeerScorer
+	banStore          *p2p.BanStore
+	crawlDB           *pex.CrawlDB // crawled nodes, in seed mode
+	crawlerSrv        *http.Server
 }
 
 func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.BlockStore, stateDB dbm.DB, err error) {
@@ -231,8 +255,18 @@ func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.L
 	return proxyApp, nil
 }
 
-func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
-	eventBus := types.NewEventBus()
+func createAndStartEventBus(config *cfg.Config, chainID string, logger log.Logger) (*types.EventBus, error) {
+	metrics := tmpubsub.NopMetrics()
+	if config.Instrumentation.Prometheus {
+		metrics = tmpubsub.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", chainID)
+	}
+	options := []tmpubsub.Option{tmpubsub.WithMetrics(metrics)}
+	if config.RPC.SubscriptionSpillMaxBytes > 0 {
+		options = append(options, tmpubsub.SpillDirectory(
+			filepath.Join(config.DBDir(), "pubsub_spill"), config.RPC.SubscriptionSpillMaxBytes))
+	}
+
+	eventBus := types.NewEventBusWithOptions(options...)
 	eventBus.SetLogger(logger.With("module", "events"))
 	if err := eventBus.Start(); err != nil {
 		return nil, err
@@ -240,27 +274,80 @@ func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
 	return eventBus, nil
 }
 
-func createAndStartIndexerService(config *cfg.Config, dbProvider DBProvider,
-	eventBus *types.EventBus, logger log.Logger) (*txindex.IndexerService, txindex.TxIndexer, error) {
+func createAndStartIndexerService(
+	config *cfg.Config,
+	dbProvider DBProvider,
+	eventBus *types.EventBus,
+	logger log.Logger,
+) (*txindex.IndexerService, txindex.TxIndexer, indexer.BlockIndexer, error) {
 
-	var txIndexer txindex.TxIndexer
+	var (
+		txIndexer    txindex.TxIndexer
+		blockIndexer indexer.BlockIndexer
+	)
 	switch config.TxIndex.Indexer {
 	case "kv":
 		store, err := dbProvider(&DBContext{"tx_index", config})
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 		txIndexer = kv.NewTxIndex(store)
+		blockIndexer = blockidxkv.NewBlockIndex(dbm.NewPrefixDB(store, []byte("block_events")))
 	default:
 		txIndexer = &null.TxIndex{}
+		blockIndexer = &blockidxnull.BlockIndex{}
 	}
 
-	indexerService := txindex.NewIndexerService(txIndexer, eventBus)
+	indexerService := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus)
 	indexerService.SetLogger(logger.With("module", "txindex"))
 	if err := indexerService.Start(); err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	return indexerService, txIndexer, nil
+	return indexerService, txIndexer, blockIndexer, nil
+}
+
+func createAndStartValidatorStatsTracker(
+	config *cfg.Config,
+	dbProvider DBProvider,
+	stateStore sm.Store,
+	blockStore valstats.BlockStore,
+	pubKey crypto.PubKey,
+	chainID string,
+	logger log.Logger,
+) (*valstats.Tracker, error) {
+	if !config.ValidatorStats.IsEnabled() {
+		return nil, nil
+	}
+
+	store, err := dbProvider(&DBContext{"valstats", config})
+	if err != nil {
+		return nil, err
+	}
+	metrics := valstats.NopMetrics()
+	if config.Instrumentation.Prometheus {
+		metrics = valstats.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", chainID)
+	}
+
+	tracker := valstats.NewTracker(store, stateStore, blockStore, config.ValidatorStats.RetainBlocks,
+		valstats.WithMetrics(metrics),
+		valstats.WithValidatorAddress(pubKey.Address()))
+	tracker.SetLogger(logger.With("module", "valstats"))
+	if err := tracker.Start(); err != nil {
+		return nil, err
+	}
+	return tracker, nil
+}
+
+func createResponseCache(config *cfg.Config, chainID string) *rpccore.ResponseCache {
+	if config.RPC.ResponseCacheSize == 0 {
+		return nil
+	}
+
+	metrics := rpccore.NopMetrics()
+	if config.Instrumentation.Prometheus {
+		metrics = rpccore.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", chainID)
+	}
+	return rpccore.NewResponseCache(config.RPC.ResponseCacheSize, metrics)
 }
 
 func doHandshake(
@@ -398,6 +485,19 @@ func createBlockchainReactorEx(config *cfg.Config,
 	return bcReactor, nil
 }
 
+// createConsensusTracer opens the consensus trace file and returns a Tracer
+// writing to it, or nils if tracing is disabled.
+func createConsensusTracer(config *cfg.Config) (*os.File, *cs.Tracer, error) {
+	if config.Consensus.TracePath == "" {
+		return nil, nil, nil
+	}
+	f, err := os.OpenFile(config.Consensus.TraceFile(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not open consensus trace file: %w", err)
+	}
+	return f, cs.NewTracer(f), nil
+}
+
 func createConsensusReactor(config *cfg.Config,
 	state sm.State,
 	blockExec *sm.BlockExecutor,
@@ -408,7 +508,8 @@ func createConsensusReactor(config *cfg.Config,
 	csMetrics *cs.Metrics,
 	waitSync bool,
 	eventBus *types.EventBus,
-	consensusLogger log.Logger) (*cs.Reactor, *cs.State) {
+	consensusLogger log.Logger,
+	options ...cs.StateOption) (*cs.Reactor, *cs.State) {
 
 	consensusState := cs.NewState(
 		config.Consensus,
@@ -417,7 +518,7 @@ func createConsensusReactor(config *cfg.Config,
 		blockStore,
 		mempool,
 		evidencePool,
-		cs.StateMetrics(csMetrics),
+		append([]cs.StateOption{cs.StateMetrics(csMetrics)}, options...)...,
 	)
 	consensusState.SetLogger(consensusLogger)
 	if privValidator != nil {
@@ -431,18 +532,29 @@ func createConsensusReactor(config *cfg.Config,
 	return consensusReactor, consensusState
 }
 
+// nodeTransport is a p2p.Transport the node can listen on and close.
+type nodeTransport interface {
+	p2p.Transport
+	Listen(p2p.NetAddress) error
+	Close() error
+	SetNodeInfo(p2p.NodeInfo)
+}
+
 func createTransport(
 	config *cfg.Config,
 	nodeInfo p2p.NodeInfo,
 	nodeKey *p2p.NodeKey,
 	proxyApp proxy.AppConns,
+	banStore *p2p.BanStore,
+	sentryIDs []string,
+	addrDiscovery *p2p.ExternalAddrDiscovery,
 ) (
-	*p2p.MultiplexTransport,
+	nodeTransport,
 	[]p2p.PeerFilterFunc,
+	error,
 ) {
 	var (
 		mConnConfig = p2p.MConnConfig(config.P2P)
-		transport   = p2p.NewMultiplexTransport(nodeInfo, *nodeKey, mConnConfig)
 		connFilters = []p2p.ConnFilterFunc{}
 		peerFilters = []p2p.PeerFilterFunc{}
 	)
@@ -451,6 +563,24 @@ func createTransport(
 		connFilters = append(connFilters, p2p.ConnDuplicateIPFilter())
 	}
 
+	// Reject banned IPs and IDs.
+	connFilters = append(connFilters, banStore.ConnFilter())
+	peerFilters = append(peerFilters, banStore.PeerFilter())
+
+	// A validator behind sentries only accepts its sentries.
+	if config.P2P.SentryMode == cfg.SentryModeValidator {
+		sentries := make(map[p2p.ID]bool, len(sentryIDs))
+		for _, id := range sentryIDs {
+			sentries[p2p.ID(id)] = true
+		}
+		peerFilters = append(peerFilters, func(_ p2p.IPeerSet, p p2p.Peer) error {
+			if !sentries[p.ID()] {
+				return fmt.Errorf("peer %v is not a sentry", p.ID())
+			}
+			return nil
+		})
+	}
+
 	// Filter peers by addr or pubkey with an ABCI query.
 	// If the query return code is OK, add peer.
 	if config.FilterPeers {
@@ -491,19 +621,99 @@ func createTransport(
 		)
 	}
 
-	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
-
 	// Limit the number of incoming connections.
-	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
+	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " ")) +
+		len(sentryIDs)
+
+	if config.P2P.Transport == "quic" {
+		transport, err := p2p.NewQUICTransport(nodeInfo, *nodeKey, mConnConfig,
+			p2p.QUICTransportConnFilters(connFilters...),
+			p2p.QUICTransportMaxIncomingConnections(max),
+			p2p.QUICTransportExternalAddrDiscovery(addrDiscovery),
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not create QUIC transport: %w", err)
+		}
+		return transport, peerFilters, nil
+	}
+
+	transport := p2p.NewMultiplexTransport(nodeInfo, *nodeKey, mConnConfig)
+	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
 	p2p.MultiplexTransportMaxIncomingConnections(max)(transport)
+	p2p.MultiplexTransportExternalAddrDiscovery(addrDiscovery)(transport)
+
+	return transport, peerFilters, nil
+}
+
+// createExternalAddrDiscovery returns the discovery of the external IP from
+// the peers, or nil if the external address is configured or discovery is off.
+func createExternalAddrDiscovery(config *cfg.Config) *p2p.ExternalAddrDiscovery {
+	if config.P2P.ExternalAddress != "" || !config.P2P.DiscoverExternalAddress {
+		return nil
+	}
+	return p2p.NewExternalAddrDiscovery(config.P2P.AddrBookStrict)
+}
+
+// sentryPeers returns the addresses and IDs of the sentries of a validator,
+// or of the validators behind a sentry.
+func sentryPeers(config *cfg.P2PConfig) (addrs []string, ids []string, err error) {
+	switch config.SentryMode {
+	case cfg.SentryModeValidator:
+		addrs = splitAndTrimEmpty(config.SentryPeers, ",", " ")
+	case cfg.SentryModeSentry:
+		addrs = splitAndTrimEmpty(config.SentryValidators, ",", " ")
+	default:
+		return nil, nil, nil
+	}
 
-	return transport, peerFilters
+	netAddrs, errs := p2p.NewNetAddressStrings(addrs)
+	if len(errs) > 0 {
+		return nil, nil, fmt.Errorf("invalid sentry address: %w", errs[0])
+	}
+	for _, addr := range netAddrs {
+		ids = append(ids, string(addr.ID))
+	}
+	return addrs, ids, nil
+}
+
+// setupSentryPeers makes the sentries of a validator, or the validators behind
+// a sentry, persistent and unconditional peers, so they are redialed when the
+// connection drops and never count against the peer limits.
+func setupSentryPeers(config *cfg.P2PConfig, sw *p2p.Switch, addrs, ids []string) error {
+	if err := sw.AddPersistentPeers(addrs); err != nil {
+		return fmt.Errorf("could not add sentry peers: %w", err)
+	}
+	if err := sw.AddUnconditionalPeerIDs(ids); err != nil {
+		return fmt.Errorf("could not add sentry peers: %w", err)
+	}
+	if config.SentryMode == cfg.SentryModeSentry {
+		return sw.AddValidatorPeerIDs(ids)
+	}
+	return nil
+}
+
+func createPeerScorer(
+	config *cfg.Config,
+	dbProvider DBProvider,
+	banStore *p2p.BanStore,
+	logger log.Logger,
+) (*p2p.PeerScorer, error) {
+	trustHistoryDB, err := dbProvider(&DBContext{"trusthistory", config})
+	if err != nil {
+		return nil, err
+	}
+	trustMetricStore := trust.NewTrustMetricStore(trustHistoryDB, trust.DefaultConfig())
+	peerScorer := p2p.NewPeerScorer(trustMetricStore, banStore, config.P2P.PeerBanThreshold, config.P2P.PeerBanDuration)
+	peerScorer.SetLogger(logger)
+	return peerScorer, nil
 }
 
 func createSwitch(config *cfg.Config,
 	transport p2p.Transport,
 	p2pMetrics *p2p.Metrics,
 	peerFilters []p2p.PeerFilterFunc,
+	peerScorer *p2p.PeerScorer,
+	bandwidthLimits p2p.BandwidthLimits,
 	mempoolReactor *mempl.Reactor,
 	bcReactor p2p.Reactor,
 	stateSyncReactor *statesync.Reactor,
@@ -518,6 +728,8 @@ func createSwitch(config *cfg.Config,
 		transport,
 		p2p.WithMetrics(p2pMetrics),
 		p2p.SwitchPeerFilters(peerFilters...),
+		p2p.SwitchPeerScorer(peerScorer),
+		p2p.SwitchBandwidthLimits(bandwidthLimits),
 	)
 	sw.SetLogger(p2pLogger)
 	sw.AddReactor("MEMPOOL", mempoolReactor)
@@ -533,11 +745,17 @@ func createSwitch(config *cfg.Config,
 	return sw
 }
 
-func createAddrBookAndSetOnSwitch(config *cfg.Config, sw *p2p.Switch,
-	p2pLogger log.Logger, nodeKey *p2p.NodeKey) (pex.AddrBook, error) {
+func createAddrBookAndSetOnSwitch(config *cfg.Config, dbProvider DBProvider, sw *p2p.Switch,
+	banStore *p2p.BanStore, p2pLogger log.Logger, nodeKey *p2p.NodeKey) (pex.AddrBook, error) {
 
-	addrBook := pex.NewAddrBook(config.P2P.AddrBookFile(), config.P2P.AddrBookStrict)
-	addrBook.SetLogger(p2pLogger.With("book", config.P2P.AddrBookFile()))
+	addrBookDB, err := dbProvider(&DBContext{"addrbook", config})
+	if err != nil {
+		return nil, err
+	}
+	// the JSON address book of earlier versions is imported on first start
+	addrBook := pex.NewDBAddrBook(addrBookDB, config.P2P.AddrBookFile(), config.P2P.AddrBookStrict)
+	addrBook.SetLogger(p2pLogger.With("book", "addrbook"))
+	addrBook.SetBanStore(banStore)
 
 	// Add ourselves to addrbook to prevent dialing ourselves
 	if config.P2P.ExternalAddress != "" {
@@ -560,7 +778,15 @@ func createAddrBookAndSetOnSwitch(config *cfg.Config, sw *p2p.Switch,
 	return addrBook, nil
 }
 
-func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
+func createCrawlDB(config *cfg.Config, dbProvider DBProvider) (*pex.CrawlDB, error) {
+	crawlDB, err := dbProvider(&DBContext{"crawl", config})
+	if err != nil {
+		return nil, err
+	}
+	return pex.NewCrawlDB(crawlDB)
+}
+
+func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, crawlDB *pex.CrawlDB, config *cfg.Config,
 	sw *p2p.Switch, logger log.Logger) *pex.Reactor {
 
 	// TODO persistent peers ? so we can have their DNS addrs saved
@@ -568,6 +794,7 @@ func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
 		&pex.ReactorConfig{
 			Seeds:    splitAndTrimEmpty(config.P2P.Seeds, ",", " "),
 			SeedMode: config.P2P.SeedMode,
+			CrawlDB:  crawlDB,
 			// See consensus/reactor.go: blocksToContributeToBecomeGoodPeer 10000
 			// blocks assuming 10s blocks ~ 28 hours.
 			// TODO (melekes): make it dynamic based on the actual block latencies
@@ -575,6 +802,7 @@ func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
 			// https://github.com/arcology-network/consensus-engine/issues/3523
 			SeedDisconnectWaitPeriod:     28 * time.Hour,
 			PersistentPeersMaxDialPeriod: config.P2P.PersistentPeersMaxDialPeriod,
+			SentryValidators:             splitAndTrimEmpty(config.P2P.SentryValidators, ",", " "),
 		})
 	pexReactor.SetLogger(logger.With("module", "pex"))
 	sw.AddReactor("PEX", pexReactor)
@@ -670,13 +898,13 @@ func NewNode(config *cfg.Config,
 	// we might need to index the txs of the replayed block as this might not have happened
 	// when the node stopped last time (i.e. the node stopped after it saved the block
 	// but before it indexed the txs, or, endblocker panicked)
-	eventBus, err := createAndStartEventBus(logger)
+	eventBus, err := createAndStartEventBus(config, genDoc.ChainID, logger)
 	if err != nil {
 		return nil, err
 	}
 
 	// Transaction indexing
-	indexerService, txIndexer, err := createAndStartIndexerService(config, dbProvider, eventBus, logger)
+	indexerService, txIndexer, blockIndexer, err := createAndStartIndexerService(config, dbProvider, eventBus, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -691,11 +919,33 @@ func NewNode(config *cfg.Config,
 		}
 	}
 
+	// If a gRPC address is provided, dial the external signing process.
+	if config.PrivValidatorGRPCListenAddr != "" {
+		privValidator, err = createPrivValidatorGRPCClient(config, genDoc.ChainID, logger)
+		if err != nil {
+			return nil, fmt.Errorf("error with private validator grpc client: %w", err)
+		}
+	}
+
+	if len(config.PrivValidatorMultiSignerAddrs) > 0 {
+		privValidator, err = createPrivValidatorMultiSigner(config, genDoc.ChainID, logger)
+		if err != nil {
+			return nil, fmt.Errorf("error with private validator multi signer: %w", err)
+		}
+	}
+
 	pubKey, err := privValidator.GetPubKey()
 	if err != nil {
 		return nil, fmt.Errorf("can't get pubkey: %w", err)
 	}
 
+	validatorStats, err := createAndStartValidatorStatsTracker(config, dbProvider, stateStore, blockStore,
+		pubKey, genDoc.ChainID, logger)
+	if err != nil {
+		return nil, err
+	}
+	responseCache := createResponseCache(config, genDoc.ChainID)
+
 	// Determine whether we should attempt state sync.
 	stateSync := config.StateSync.Enable && !onlyValidatorIsUs(state, pubKey)
 	if stateSync && state.LastBlockHeight > 0 {
@@ -760,9 +1010,18 @@ func NewNode(config *cfg.Config,
 	} else if fastSync {
 		csMetrics.FastSyncing.Set(1)
 	}
+	// Optionally, trace the consensus inputs
+	consensusTrace, tracer, err := createConsensusTracer(config)
+	if err != nil {
+		return nil, err
+	}
+	var csOptions []cs.StateOption
+	if tracer != nil {
+		csOptions = append(csOptions, cs.StateTracer(tracer))
+	}
 	consensusReactor, consensusState := createConsensusReactor(
 		config, state, blockExec, blockStore, mempool, evidencePool,
-		privValidator, csMetrics, stateSync || fastSync, eventBus, consensusLogger,
+		privValidator, csMetrics, stateSync || fastSync, eventBus, consensusLogger, csOptions...,
 	)
 
 	// Set up state sync reactor, and schedule a sync if requested.
@@ -779,12 +1038,37 @@ func NewNode(config *cfg.Config,
 	}
 
 	// Setup Transport.
-	transport, peerFilters := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	banDB, err := dbProvider(&DBContext{"banlist", config})
+	if err != nil {
+		return nil, err
+	}
+	banStore, err := p2p.NewBanStore(banDB)
+	if err != nil {
+		return nil, fmt.Errorf("could not load peer ban list: %w", err)
+	}
+	sentryAddrs, sentryIDs, err := sentryPeers(config.P2P)
+	if err != nil {
+		return nil, err
+	}
+	addrDiscovery := createExternalAddrDiscovery(config)
+	transport, peerFilters, err := createTransport(config, nodeInfo, nodeKey, proxyApp, banStore, sentryIDs,
+		addrDiscovery)
+	if err != nil {
+		return nil, err
+	}
 
 	// Setup Switch.
 	p2pLogger := logger.With("module", "p2p")
+	peerScorer, err := createPeerScorer(config, dbProvider, banStore, p2pLogger)
+	if err != nil {
+		return nil, err
+	}
+	bandwidthLimits, err := p2p.NewBandwidthLimits(config.P2P)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bandwidth limits: %w", err)
+	}
 	sw := createSwitch(
-		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
+		config, transport, p2pMetrics, peerFilters, peerScorer, bandwidthLimits, mempoolReactor, bcReactor,
 		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger,
 	)
 
@@ -798,7 +1082,17 @@ func NewNode(config *cfg.Config,
 		return nil, fmt.Errorf("could not add peer ids from unconditional_peer_ids field: %w", err)
 	}
 
-	addrBook, err := createAddrBookAndSetOnSwitch(config, sw, p2pLogger, nodeKey)
+	err = sw.AddValidatorPeerIDs(splitAndTrimEmpty(config.P2P.ValidatorPeerIDs, ",", " "))
+	if err != nil {
+		return nil, fmt.Errorf("could not add peer ids from validator_peer_ids field: %w", err)
+	}
+
+	err = setupSentryPeers(config.P2P, sw, sentryAddrs, sentryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	addrBook, err := createAddrBookAndSetOnSwitch(config, dbProvider, sw, banStore, p2pLogger, nodeKey)
 	if err != nil {
 		return nil, fmt.Errorf("could not create addrbook: %w", err)
 	}
@@ -815,9 +1109,21 @@ func NewNode(config *cfg.Config,
 	//
 	// If PEX is on, it should handle dialing the seeds. Otherwise the switch does it.
 	// Note we currently use the addrBook regardless at least for AddOurAddress
-	var pexReactor *pex.Reactor
-	if config.P2P.PexReactor {
-		pexReactor = createPEXReactorAndAddToSwitch(addrBook, config, sw, logger)
+	//
+	// A validator behind sentries runs without it, so it never learns about
+	// nor dials any other peers.
+	var (
+		pexReactor *pex.Reactor
+		crawlDB    *pex.CrawlDB
+	)
+	if config.P2P.PexReactor && config.P2P.SentryMode != cfg.SentryModeValidator {
+		if config.P2P.SeedMode {
+			crawlDB, err = createCrawlDB(config, dbProvider)
+			if err != nil {
+				return nil, fmt.Errorf("could not create crawl DB: %w", err)
+			}
+		}
+		pexReactor = createPEXReactorAndAddToSwitch(addrBook, crawlDB, config, sw, logger)
 	}
 
 	if config.RPC.PprofListenAddress != "" {
@@ -832,11 +1138,11 @@ func NewNode(config *cfg.Config,
 		genesisDoc:    genDoc,
 		privValidator: privValidator,
 
-		transport: transport,
-		sw:        sw,
-		addrBook:  addrBook,
-		nodeInfo:  nodeInfo,
-		nodeKey:   nodeKey,
+		transport:     transport,
+		sw:            sw,
+		addrBook:      addrBook,
+		nodeKey:       nodeKey,
+		addrDiscovery: addrDiscovery,
 
 		stateStore:       stateStore,
 		blockStore:       blockStore,
@@ -852,8 +1158,15 @@ func NewNode(config *cfg.Config,
 		evidencePool:     evidencePool,
 		proxyApp:         proxyApp,
 		txIndexer:        txIndexer,
+		blockIndexer:     blockIndexer,
 		indexerService:   indexerService,
+		validatorStats:   validatorStats,
+		responseCache:    responseCache,
 		eventBus:         eventBus,
+		consensusTrace:   consensusTrace,
+		peerScorer:       peerScorer,
+		banStore:         banStore,
+		crawlDB:          crawlDB,
 	}
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 
@@ -899,13 +1212,13 @@ func NewNodeEx(config *cfg.Config,
 	// we might need to index the txs of the replayed block as this might not have happened
 	// when the node stopped last time (i.e. the node stopped after it saved the block
 	// but before it indexed the txs, or, endblocker panicked)
-	eventBus, err := createAndStartEventBus(logger)
+	eventBus, err := createAndStartEventBus(config, genDoc.ChainID, logger)
 	if err != nil {
 		return nil, err
 	}
 
 	// Transaction indexing
-	indexerService, txIndexer, err := createAndStartIndexerService(config, dbProvider, eventBus, logger)
+	indexerService, txIndexer, blockIndexer, err := createAndStartIndexerService(config, dbProvider, eventBus, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -920,11 +1233,33 @@ func NewNodeEx(config *cfg.Config,
 		}
 	}
 
+	// If a gRPC address is provided, dial the external signing process.
+	if config.PrivValidatorGRPCListenAddr != "" {
+		privValidator, err = createPrivValidatorGRPCClient(config, genDoc.ChainID, logger)
+		if err != nil {
+			return nil, fmt.Errorf("error with private validator grpc client: %w", err)
+		}
+	}
+
+	if len(config.PrivValidatorMultiSignerAddrs) > 0 {
+		privValidator, err = createPrivValidatorMultiSigner(config, genDoc.ChainID, logger)
+		if err != nil {
+			return nil, fmt.Errorf("error with private validator multi signer: %w", err)
+		}
+	}
+
 	pubKey, err := privValidator.GetPubKey()
 	if err != nil {
 		return nil, fmt.Errorf("can't get pubkey: %w", err)
 	}
 
+	validatorStats, err := createAndStartValidatorStatsTracker(config, dbProvider, stateStore, blockStore,
+		pubKey, genDoc.ChainID, logger)
+	if err != nil {
+		return nil, err
+	}
+	responseCache := createResponseCache(config, genDoc.ChainID)
+
 	// Determine whether we should attempt state sync.
 	stateSync := config.StateSync.Enable && !onlyValidatorIsUs(state, pubKey)
 	if stateSync && state.LastBlockHeight > 0 {
@@ -977,7 +1312,18 @@ func NewNodeEx(config *cfg.Config,
 		evidencePool,
 		sm.BlockExecutorWithMetrics(smMetrics),
 	)
-	blockExec.SetBackendProxy(backend)
+	// Optionally, trace the consensus inputs and backend calls
+	consensusTrace, tracer, err := createConsensusTracer(config)
+	if err != nil {
+		return nil, err
+	}
+	var csOptions []cs.StateOption
+	if tracer != nil {
+		csOptions = append(csOptions, cs.StateTracer(tracer))
+		blockExec.SetBackendProxy(cs.NewTracingBackend(backend, tracer))
+	} else {
+		blockExec.SetBackendProxy(backend)
+	}
 
 	// Make BlockchainReactor. Don't start fast sync if we're doing a state sync first.
 	bcReactor, err := createBlockchainReactorEx(config, state, blockExec, blockStore, fastSync && !stateSync, backend, logger)
@@ -994,7 +1340,7 @@ func NewNodeEx(config *cfg.Config,
 	}
 	consensusReactor, consensusState := createConsensusReactor(
 		config, state, blockExec, blockStore, mempool, evidencePool,
-		privValidator, csMetrics, stateSync || fastSync, eventBus, consensusLogger,
+		privValidator, csMetrics, stateSync || fastSync, eventBus, consensusLogger, csOptions...,
 	)
 	consensusReactor.SetBackendProxy(backend)
 
@@ -1012,12 +1358,37 @@ func NewNodeEx(config *cfg.Config,
 	}
 
 	// Setup Transport.
-	transport, peerFilters := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	banDB, err := dbProvider(&DBContext{"banlist", config})
+	if err != nil {
+		return nil, err
+	}
+	banStore, err := p2p.NewBanStore(banDB)
+	if err != nil {
+		return nil, fmt.Errorf("could not load peer ban list: %w", err)
+	}
+	sentryAddrs, sentryIDs, err := sentryPeers(config.P2P)
+	if err != nil {
+		return nil, err
+	}
+	addrDiscovery := createExternalAddrDiscovery(config)
+	transport, peerFilters, err := createTransport(config, nodeInfo, nodeKey, proxyApp, banStore, sentryIDs,
+		addrDiscovery)
+	if err != nil {
+		return nil, err
+	}
 
 	// Setup Switch.
 	p2pLogger := logger.With("module", "p2p")
+	peerScorer, err := createPeerScorer(config, dbProvider, banStore, p2pLogger)
+	if err != nil {
+		return nil, err
+	}
+	bandwidthLimits, err := p2p.NewBandwidthLimits(config.P2P)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bandwidth limits: %w", err)
+	}
 	sw := createSwitch(
-		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
+		config, transport, p2pMetrics, peerFilters, peerScorer, bandwidthLimits, mempoolReactor, bcReactor,
 		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger,
 	)
 
@@ -1031,7 +1402,17 @@ func NewNodeEx(config *cfg.Config,
 		return nil, fmt.Errorf("could not add peer ids from unconditional_peer_ids field: %w", err)
 	}
 
-	addrBook, err := createAddrBookAndSetOnSwitch(config, sw, p2pLogger, nodeKey)
+	err = sw.AddValidatorPeerIDs(splitAndTrimEmpty(config.P2P.ValidatorPeerIDs, ",", " "))
+	if err != nil {
+		return nil, fmt.Errorf("could not add peer ids from validator_peer_ids field: %w", err)
+	}
+
+	err = setupSentryPeers(config.P2P, sw, sentryAddrs, sentryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	addrBook, err := createAddrBookAndSetOnSwitch(config, dbProvider, sw, banStore, p2pLogger, nodeKey)
 	if err != nil {
 		return nil, fmt.Errorf("could not create addrbook: %w", err)
 	}
@@ -1048,9 +1429,21 @@ func NewNodeEx(config *cfg.Config,
 	//
 	// If PEX is on, it should handle dialing the seeds. Otherwise the switch does it.
 	// Note we currently use the addrBook regardless at least for AddOurAddress
-	var pexReactor *pex.Reactor
-	if config.P2P.PexReactor {
-		pexReactor = createPEXReactorAndAddToSwitch(addrBook, config, sw, logger)
+	//
+	// A validator behind sentries runs without it, so it never learns about
+	// nor dials any other peers.
+	var (
+		pexReactor *pex.Reactor
+		crawlDB    *pex.CrawlDB
+	)
+	if config.P2P.PexReactor && config.P2P.SentryMode != cfg.SentryModeValidator {
+		if config.P2P.SeedMode {
+			crawlDB, err = createCrawlDB(config, dbProvider)
+			if err != nil {
+				return nil, fmt.Errorf("could not create crawl DB: %w", err)
+			}
+		}
+		pexReactor = createPEXReactorAndAddToSwitch(addrBook, crawlDB, config, sw, logger)
 	}
 
 	if config.RPC.PprofListenAddress != "" {
@@ -1065,11 +1458,11 @@ func NewNodeEx(config *cfg.Config,
 		genesisDoc:    genDoc,
 		privValidator: privValidator,
 
-		transport: transport,
-		sw:        sw,
-		addrBook:  addrBook,
-		nodeInfo:  nodeInfo,
-		nodeKey:   nodeKey,
+		transport:     transport,
+		sw:            sw,
+		addrBook:      addrBook,
+		nodeKey:       nodeKey,
+		addrDiscovery: addrDiscovery,
 
 		stateStore:       stateStore,
 		blockStore:       blockStore,
@@ -1085,8 +1478,15 @@ func NewNodeEx(config *cfg.Config,
 		evidencePool:     evidencePool,
 		proxyApp:         proxyApp,
 		txIndexer:        txIndexer,
+		blockIndexer:     blockIndexer,
 		indexerService:   indexerService,
+		validatorStats:   validatorStats,
+		responseCache:    responseCache,
 		eventBus:         eventBus,
+		consensusTrace:   consensusTrace,
+		peerScorer:       peerScorer,
+		banStore:         banStore,
+		crawlDB:          crawlDB,
 	}
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 
@@ -1106,8 +1506,17 @@ func (n *Node) OnStart() error {
 		time.Sleep(genTime.Sub(now))
 	}
 
+	sentryAddrs, sentryIDs, err := sentryPeers(n.config.P2P)
+	if err != nil {
+		return err
+	}
+
 	// Add private IDs to addrbook to block those peers being added
 	n.addrBook.AddPrivateIDs(splitAndTrimEmpty(n.config.P2P.PrivatePeerIDs, ",", " "))
+	if n.config.P2P.SentryMode == cfg.SentryModeSentry {
+		// The validators behind a sentry are never gossiped.
+		n.addrBook.AddPrivateIDs(sentryIDs)
+	}
 
 	// Start the RPC server before the P2P server
 	// so we can eg. receive txs for the first block
@@ -1124,6 +1533,10 @@ func (n *Node) OnStart() error {
 		n.prometheusSrv = n.startPrometheusServer(n.config.Instrumentation.PrometheusListenAddr)
 	}
 
+	if n.crawlDB != nil && n.config.P2P.CrawlerListenAddress != "" {
+		n.crawlerSrv = n.startCrawlerServer(n.config.P2P.CrawlerListenAddress)
+	}
+
 	// Start the transport.
 	addr, err := p2p.NewNetAddressString(p2p.IDAddressString(n.nodeKey.ID(), n.config.P2P.ListenAddress))
 	if err != nil {
@@ -1135,6 +1548,11 @@ func (n *Node) OnStart() error {
 
 	n.isListening = true
 
+	if n.config.P2P.ExternalAddress == "" {
+		// discovering the gateway may take a while, so the node doesn't wait
+		go n.startNATTraversal(int(addr.Port))
+	}
+
 	if n.config.Mempool.WalEnabled() {
 		err = n.mempool.InitWAL()
 		if err != nil {
@@ -1142,6 +1560,13 @@ func (n *Node) OnStart() error {
 		}
 	}
 
+	// Start the peer scorer before the switch, so peers are scored from the
+	// first message on.
+	err = n.peerScorer.Start()
+	if err != nil {
+		return err
+	}
+
 	// Start the switch (the P2P server).
 	err = n.sw.Start()
 	if err != nil {
@@ -1154,6 +1579,13 @@ func (n *Node) OnStart() error {
 		return fmt.Errorf("could not dial peers from persistent_peers field: %w", err)
 	}
 
+	// Connect to the sentries of a validator, or to the validators behind a
+	// sentry. The pex reactor of a sentry keeps redialing its validators.
+	err = n.sw.DialPeersAsync(sentryAddrs)
+	if err != nil {
+		return fmt.Errorf("could not dial sentry peers: %w", err)
+	}
+
 	// Run state sync
 	if n.stateSync {
 		bcR, ok := n.bcReactor.(fastSyncReactor)
@@ -1183,17 +1615,31 @@ func (n *Node) OnStop() {
 	if err := n.indexerService.Stop(); err != nil {
 		n.Logger.Error("Error closing indexerService", "err", err)
 	}
+	if n.validatorStats != nil {
+		if err := n.validatorStats.Stop(); err != nil {
+			n.Logger.Error("Error closing validator stats tracker", "err", err)
+		}
+	}
 
 	// now stop the reactors
 	if err := n.sw.Stop(); err != nil {
 		n.Logger.Error("Error closing switch", "err", err)
 	}
+	if err := n.peerScorer.Stop(); err != nil {
+		n.Logger.Error("Error closing peer scorer", "err", err)
+	}
 
 	// stop mempool WAL
 	if n.config.Mempool.WalEnabled() {
 		n.mempool.CloseWAL()
 	}
 
+	if n.consensusTrace != nil {
+		if err := n.consensusTrace.Close(); err != nil {
+			n.Logger.Error("Error closing consensus trace", "err", err)
+		}
+	}
+
 	if err := n.transport.Close(); err != nil {
 		n.Logger.Error("Error closing transport", "err", err)
 	}
@@ -1214,12 +1660,30 @@ func (n *Node) OnStop() {
 		}
 	}
 
+	if pvsc, ok := n.privValidator.(*tmgrpc.SignerClient); ok {
+		if err := pvsc.Close(); err != nil {
+			n.Logger.Error("Error closing private validator", "err", err)
+		}
+	}
+
+	if mspv, ok := n.privValidator.(*privval.MultiSignerPV); ok {
+		if err := mspv.Close(); err != nil {
+			n.Logger.Error("Error closing private validator", "err", err)
+		}
+	}
+
 	if n.prometheusSrv != nil {
 		if err := n.prometheusSrv.Shutdown(context.Background()); err != nil {
 			// Error from closing listeners, or context timeout:
 			n.Logger.Error("Prometheus HTTP server Shutdown", "err", err)
 		}
 	}
+
+	if n.crawlerSrv != nil {
+		if err := n.crawlerSrv.Shutdown(context.Background()); err != nil {
+			n.Logger.Error("Crawler HTTP server Shutdown", "err", err)
+		}
+	}
 }
 
 // ConfigureRPC makes sure RPC has all the objects it needs to operate.
@@ -1238,10 +1702,15 @@ func (n *Node) ConfigureRPC() error {
 		ConsensusState: n.consensusState,
 		P2PPeers:       n.sw,
 		P2PTransport:   n,
+		P2PBanStore:    n.banStore,
+		P2PAddrBook:    n.addrBook,
 
 		PubKey:           pubKey,
 		GenDoc:           n.genesisDoc,
 		TxIndexer:        n.txIndexer,
+		BlockIndexer:     n.blockIndexer,
+		ValidatorStats:   n.validatorStats,
+		ResponseCache:    n.responseCache,
 		ConsensusReactor: n.consensusReactor,
 		EventBus:         n.eventBus,
 		Mempool:          n.mempool,
@@ -1276,13 +1745,35 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
 
+	methodTimeouts, err := n.config.RPC.MethodTimeoutsByName()
+	if err != nil {
+		return nil, err
+	}
+	// Likewise, unless it's set explicitly, make sure broadcast_tx_commit
+	// doesn't time out before the tx is committed.
+	if _, ok := methodTimeouts["broadcast_tx_commit"]; !ok && n.config.RPC.MethodTimeout > 0 &&
+		n.config.RPC.MethodTimeout <= n.config.RPC.TimeoutBroadcastTxCommit {
+		methodTimeouts["broadcast_tx_commit"] = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
+	}
+	routes := rpcserver.WithTimeouts(rpccore.Routes, n.config.RPC.MethodTimeout, methodTimeouts)
+
+	// access control is shared by all listeners, so that keys are limited
+	// across them
+	var accessControl *rpcserver.AccessControl
+	if n.config.RPC.IsAccessControlEnabled() {
+		accessControl, err = n.newRPCAccessControl()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// we may expose the rpc over both a unix and tcp socket
 	listeners := make([]net.Listener, len(listenAddrs))
 	for i, listenAddr := range listenAddrs {
 		mux := http.NewServeMux()
 		rpcLogger := n.Logger.With("module", "rpc-server")
 		wmLogger := rpcLogger.With("protocol", "websocket")
-		wm := rpcserver.NewWebsocketManager(rpccore.Routes,
+		wm := rpcserver.NewWebsocketManager(routes,
 			rpcserver.OnDisconnect(func(remoteAddr string) {
 				err := n.eventBus.UnsubscribeAll(context.Background(), remoteAddr)
 				if err != nil && err != tmpubsub.ErrSubscriptionNotFound {
@@ -1290,10 +1781,14 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 				}
 			}),
 			rpcserver.ReadLimit(config.MaxBodyBytes),
+			rpcserver.RequestConcurrency(n.config.RPC.WebsocketConcurrency),
 		)
 		wm.SetLogger(wmLogger)
 		mux.HandleFunc("/websocket", wm.WebsocketHandler)
-		rpcserver.RegisterRPCFuncs(mux, rpccore.Routes, rpcLogger)
+		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger,
+			rpcserver.MaxBatchSize(n.config.RPC.MaxBatchSize),
+			rpcserver.BatchConcurrency(n.config.RPC.BatchConcurrency),
+		)
 		listener, err := rpcserver.Listen(
 			listenAddr,
 			config,
@@ -1303,13 +1798,16 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		}
 
 		var rootHandler http.Handler = mux
+		if accessControl != nil {
+			rootHandler = accessControl.Handler(rootHandler)
+		}
 		if n.config.RPC.IsCorsEnabled() {
 			corsMiddleware := cors.New(cors.Options{
 				AllowedOrigins: n.config.RPC.CORSAllowedOrigins,
 				AllowedMethods: n.config.RPC.CORSAllowedMethods,
 				AllowedHeaders: n.config.RPC.CORSAllowedHeaders,
 			})
-			rootHandler = corsMiddleware.Handler(mux)
+			rootHandler = corsMiddleware.Handler(rootHandler)
 		}
 		if n.config.RPC.IsTLSEnabled() {
 			go func() {
@@ -1371,6 +1869,38 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 
 }
 
+// newRPCAccessControl creates the authentication and rate limiting middleware
+// of the RPC server from the config.
+func (n *Node) newRPCAccessControl() (*rpcserver.AccessControl, error) {
+	var keys []rpcserver.APIKey
+	if n.config.RPC.AuthKeysFile != "" {
+		var err error
+		keys, err = rpcserver.LoadAPIKeys(n.config.RPC.AuthKeysPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load RPC auth keys: %w", err)
+		}
+	}
+
+	metrics := rpcserver.NopMetrics()
+	if n.config.Instrumentation.Prometheus {
+		metrics = rpcserver.PrometheusMetrics(n.config.Instrumentation.Namespace, "chain_id", n.genesisDoc.ChainID)
+	}
+
+	accessControl, err := rpcserver.NewAccessControl(rpcserver.AccessConfig{
+		Keys:         keys,
+		AuthRequired: n.config.RPC.AuthRequired,
+		KeyRate:      n.config.RPC.RateLimitPerKey,
+		KeyBurst:     n.config.RPC.RateLimitPerKeyBurst,
+		IPRate:       n.config.RPC.RateLimitPerIP,
+		IPBurst:      n.config.RPC.RateLimitPerIPBurst,
+	}, metrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC access control: %w", err)
+	}
+	accessControl.SetLogger(n.Logger.With("module", "rpc-server"))
+	return accessControl, nil
+}
+
 // startPrometheusServer starts a Prometheus HTTP server, listening for metrics
 // collectors on addr.
 func (n *Node) startPrometheusServer(addr string) *http.Server {
@@ -1392,6 +1922,22 @@ func (n *Node) startPrometheusServer(addr string) *http.Server {
 	return srv
 }
 
+// startCrawlerServer starts an HTTP server serving the statistics of the
+// crawled network on addr.
+func (n *Node) startCrawlerServer(addr string) *http.Server {
+	srv := &http.Server{
+		Addr:    addr,
+		Handler: pex.NewCrawlStatsHandler(n.crawlDB),
+	}
+	go func() {
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			// Error starting or closing listener:
+			n.Logger.Error("Crawler HTTP server ListenAndServe", "err", err)
+		}
+	}()
+	return srv
+}
+
 // Switch returns the Node's Switch.
 func (n *Node) Switch() *p2p.Switch {
 	return n.sw
@@ -1453,6 +1999,129 @@ func (n *Node) ProxyApp() proxy.AppConns {
 	return n.proxyApp
 }
 
+const (
+	natMappingDescription = "Tendermint"
+
+	// natMappingRenewInterval is how often the port mapping on the NAT gateway
+	// is renewed. NAT-PMP/PCP mappings are added for two hours.
+	natMappingRenewInterval = 30 * time.Minute
+)
+
+// startNATTraversal learns the external address to advertise to peers, and
+// advertises it once it's known. With UPnP or NAT-PMP/PCP, it maps the p2p
+// port on the gateway and keeps renewing the mapping. Without, or if that
+// fails, the external IP is learned from the addresses peers observe our
+// connections from. It blocks while discovering the gateway.
+func (n *Node) startNATTraversal(port int) {
+	protocol := "tcp"
+	if n.config.P2P.Transport == "quic" {
+		protocol = "udp"
+	}
+
+	var (
+		nat upnp.NAT
+		err error
+	)
+	switch {
+	case n.config.P2P.UPNP:
+		nat, err = upnp.Discover()
+	case n.config.P2P.NATPMP:
+		nat, err = upnp.DiscoverPMP(n.config.P2P.NATGateway)
+	}
+	if err != nil {
+		n.Logger.Error("Failed to discover the NAT gateway", "err", err)
+	}
+	if nat != nil {
+		extPort, err := nat.AddPortMapping(protocol, port, port, natMappingDescription, 0)
+		if err == nil {
+			var extIP net.IP
+			extIP, err = nat.GetExternalAddress()
+			if err == nil {
+				n.setListenAddr(net.JoinHostPort(extIP.String(), strconv.Itoa(extPort)))
+				go n.natRoutine(nat, protocol, port, extPort)
+				return
+			}
+			n.deletePortMapping(nat, protocol, port, extPort)
+		}
+		n.Logger.Error("Failed to map the p2p port on the NAT gateway", "err", err)
+	}
+
+	if n.addrDiscovery != nil {
+		advertise := func(ip net.IP) {
+			n.setListenAddr(net.JoinHostPort(ip.String(), strconv.Itoa(port)))
+		}
+		n.addrDiscovery.SetOnDiscovered(advertise)
+		// the IP may have been discovered while looking for the gateway
+		if ip := n.addrDiscovery.IP(); ip != nil {
+			advertise(ip)
+		}
+	}
+}
+
+// natRoutine renews the port mapping on the NAT gateway until the node stops,
+// and deletes it then.
+func (n *Node) natRoutine(nat upnp.NAT, protocol string, port, extPort int) {
+	ticker := time.NewTicker(natMappingRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			extPort = n.renewPortMapping(nat, protocol, port, extPort)
+		case <-n.Quit():
+			n.deletePortMapping(nat, protocol, port, extPort)
+			return
+		}
+	}
+}
+
+// renewPortMapping renews the port mapping on the NAT gateway and returns the
+// external port. If the gateway mapped another external port, or the external
+// IP changed, the new address is advertised.
+func (n *Node) renewPortMapping(nat upnp.NAT, protocol string, port, extPort int) int {
+	mappedPort, err := nat.AddPortMapping(protocol, port, port, natMappingDescription, 0)
+	if err != nil {
+		n.Logger.Error("Failed to renew the port mapping on the NAT gateway", "err", err)
+		return extPort
+	}
+	if mappedPort != extPort {
+		n.Logger.Info("NAT gateway mapped another external port", "old", extPort, "new", mappedPort)
+	}
+	extIP, err := nat.GetExternalAddress()
+	if err != nil {
+		n.Logger.Error("Failed to get the external address from the NAT gateway", "err", err)
+		return mappedPort
+	}
+	n.setListenAddr(net.JoinHostPort(extIP.String(), strconv.Itoa(mappedPort)))
+	return mappedPort
+}
+
+func (n *Node) deletePortMapping(nat upnp.NAT, protocol string, port, extPort int) {
+	if err := nat.DeletePortMapping(protocol, extPort, port); err != nil {
+		n.Logger.Error("Failed to delete the port mapping on the NAT gateway", "err", err)
+	}
+}
+
+// setListenAddr sets the address advertised to peers in our NodeInfo.
+func (n *Node) setListenAddr(addr string) {
+	n.listenAddrMtx.Lock()
+	defer n.listenAddrMtx.Unlock()
+
+	nodeInfo := n.sw.NodeInfo().(p2p.DefaultNodeInfo)
+	if nodeInfo.ListenAddr == addr {
+		return
+	}
+	nodeInfo.ListenAddr = addr
+	n.transport.SetNodeInfo(nodeInfo)
+	n.sw.SetNodeInfo(nodeInfo)
+
+	// don't dial ourselves
+	if netAddr, err := nodeInfo.NetAddress(); err == nil && n.addrBook != nil {
+		n.addrBook.AddOurAddress(netAddr)
+	}
+	n.Logger.Info("Advertising external address", "addr", addr)
+}
+
 // Config returns the Node's config.
 func (n *Node) Config() *cfg.Config {
 	return n.config
@@ -1472,7 +2141,7 @@ func (n *Node) IsListening() bool {
 
 // NodeInfo returns the Node's Info from the Switch.
 func (n *Node) NodeInfo() p2p.NodeInfo {
-	return n.nodeInfo
+	return n.sw.NodeInfo()
 }
 
 func makeNodeInfo(
@@ -1657,6 +2326,71 @@ func createAndStartPrivValidatorSocketClient(
 	return pvscWithRetries, nil
 }
 
+func createPrivValidatorGRPCClient(
+	config *cfg.Config,
+	chainID string,
+	logger log.Logger,
+) (types.PrivValidator, error) {
+	pvsc, err := tmgrpc.DialRemoteSigner(
+		config.PrivValidatorGRPCListenAddr,
+		chainID,
+		tmgrpc.TLSFiles{
+			CertFile:   config.PrivValidatorGRPCCertFile(),
+			KeyFile:    config.PrivValidatorGRPCKeyFile(),
+			RootCAFile: config.PrivValidatorGRPCRootCAFile(),
+		},
+		logger.With("module", "privval"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start private validator: %w", err)
+	}
+
+	// try to get a pubkey from private validate first time
+	_, err = pvsc.GetPubKey()
+	if err != nil {
+		return nil, fmt.Errorf("can't get pubkey: %w", err)
+	}
+
+	return pvsc, nil
+}
+
+// createPrivValidatorMultiSigner dials every gRPC signer listed in
+// priv_validator_multi_signer_addrs and coordinates them via a MultiSignerPV.
+func createPrivValidatorMultiSigner(
+	config *cfg.Config,
+	chainID string,
+	logger log.Logger,
+) (types.PrivValidator, error) {
+	logger = logger.With("module", "privval")
+	files := tmgrpc.TLSFiles{
+		CertFile:   config.PrivValidatorGRPCCertFile(),
+		KeyFile:    config.PrivValidatorGRPCKeyFile(),
+		RootCAFile: config.PrivValidatorGRPCRootCAFile(),
+	}
+
+	backends := make([]privval.SignerBackend, 0, len(config.PrivValidatorMultiSignerAddrs))
+	closeAll := func() {
+		for _, backend := range backends {
+			_ = backend.(*tmgrpc.SignerClient).Close()
+		}
+	}
+	for _, addr := range config.PrivValidatorMultiSignerAddrs {
+		pvsc, err := tmgrpc.DialRemoteSigner(addr, chainID, files, logger.With("signer", addr))
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+		backends = append(backends, pvsc)
+	}
+
+	pv, err := privval.NewMultiSignerPV(backends, config.PrivValidatorMultiSignerQuorum(), logger)
+	if err != nil {
+		closeAll()
+		return nil, err
+	}
+	return pv, nil
+}
+
 // splitAndTrimEmpty slices s into all subslices separated by sep and returns a
 // slice of the string s with all leading and trailing Unicode code points
 // contained in cutset removed. If sep is empty, SplitAndTrim splits after each
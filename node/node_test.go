@@ -128,7 +128,71 @@ func TestNodeSetAppVersion(t *testing.T) {
 	assert.Equal(t, state.Version.Consensus.App, appVersion)
 
 	// check version is set in node info
-	assert.Equal(t, n.nodeInfo.(p2p.DefaultNodeInfo).ProtocolVersion.App, appVersion)
+	assert.Equal(t, n.NodeInfo().(p2p.DefaultNodeInfo).ProtocolVersion.App, appVersion)
+}
+
+func TestNodeDiscoversExternalAddress(t *testing.T) {
+	config := cfg.ResetTestRoot("node_external_address_test")
+	defer os.RemoveAll(config.RootDir)
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NotNil(t, n.addrDiscovery)
+
+	// no port forwarding, so the external IP is learned from the peers
+	n.startNATTraversal(26656)
+	for i := 0; i < 3; i++ {
+		n.addrDiscovery.Observe(p2p.PubKeyToID(ed25519.GenPrivKey().PubKey()), fmt.Sprintf("1.2.3.4:%d", 40000+i))
+	}
+
+	assert.Equal(t, "1.2.3.4:26656", n.NodeInfo().(p2p.DefaultNodeInfo).ListenAddr)
+	assert.Equal(t, "1.2.3.4:26656", n.transport.(*p2p.MultiplexTransport).NodeInfo().(p2p.DefaultNodeInfo).ListenAddr)
+
+	// an IP discovered before the traversal started is advertised too
+	n, err = DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		n.addrDiscovery.Observe(p2p.PubKeyToID(ed25519.GenPrivKey().PubKey()), fmt.Sprintf("1.2.3.5:%d", 40000+i))
+	}
+	n.startNATTraversal(26656)
+	assert.Equal(t, "1.2.3.5:26656", n.NodeInfo().(p2p.DefaultNodeInfo).ListenAddr)
+
+	// a configured external address is always advertised
+	config.P2P.ExternalAddress = "5.6.7.8:26656"
+	n, err = DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	assert.Nil(t, n.addrDiscovery)
+}
+
+// fakeNAT is a NAT gateway mapping the ports to extPort.
+type fakeNAT struct {
+	extIP   net.IP
+	extPort int
+}
+
+func (nat *fakeNAT) GetExternalAddress() (net.IP, error) { return nat.extIP, nil }
+
+func (nat *fakeNAT) AddPortMapping(protocol string, extPort, intPort int, desc string, timeout int) (int, error) {
+	return nat.extPort, nil
+}
+
+func (nat *fakeNAT) DeletePortMapping(protocol string, extPort, intPort int) error { return nil }
+
+func TestNodeRenewPortMapping(t *testing.T) {
+	config := cfg.ResetTestRoot("node_renew_port_mapping_test")
+	defer os.RemoveAll(config.RootDir)
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+
+	nat := &fakeNAT{extIP: net.ParseIP("1.2.3.4"), extPort: 30000}
+	assert.Equal(t, 30000, n.renewPortMapping(nat, "tcp", 26656, 30000))
+	assert.Equal(t, "1.2.3.4:30000", n.NodeInfo().(p2p.DefaultNodeInfo).ListenAddr)
+
+	// the gateway lost the mapping and mapped another port
+	nat.extPort = 30001
+	assert.Equal(t, 30001, n.renewPortMapping(nat, "tcp", 26656, 30000))
+	assert.Equal(t, "1.2.3.4:30001", n.NodeInfo().(p2p.DefaultNodeInfo).ListenAddr)
 }
 
 func TestNodeSetPrivValTCP(t *testing.T) {
@@ -0,0 +1,248 @@
+package p2p
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
+
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
+)
+
+var (
+	banIDKeyPrefix = []byte("banID:")
+	banIPKeyPrefix = []byte("banIP:")
+)
+
+// BanEntry is a banned node ID or IP address.
+type BanEntry struct {
+	ID        ID        `json:"id,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	Reason    string    `json:"reason"`
+	BannedAt  time.Time `json:"banned_at"`
+	ExpiresAt time.Time `json:"expires_at"` // zero if the ban never expires
+}
+
+// Expired returns true if the ban has expired at the given time.
+func (e BanEntry) Expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && !now.Before(e.ExpiresAt)
+}
+
+func (e BanEntry) key() []byte {
+	if e.ID != "" {
+		return append(append([]byte{}, banIDKeyPrefix...), e.ID...)
+	}
+	return append(append([]byte{}, banIPKeyPrefix...), e.IP...)
+}
+
+// BanStore keeps the banned node IDs and IP addresses in a DB, so bans
+// survive restarts. It holds both the bans set through the RPC and the ones
+// decided by the PeerScorer. It is consulted by the transport through
+// ConnFilter, by the switch through PeerFilter and before dialing, and by the
+// address book, which drops banned addresses.
+type BanStore struct {
+	mtx tmsync.RWMutex
+	db  dbm.DB
+	ids map[ID]BanEntry
+	ips map[string]BanEntry
+}
+
+// NewBanStore returns a BanStore loaded from db.
+func NewBanStore(db dbm.DB) (*BanStore, error) {
+	bs := &BanStore{
+		db:  db,
+		ids: make(map[ID]BanEntry),
+		ips: make(map[string]BanEntry),
+	}
+
+	iter, err := db.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var e BanEntry
+		if err := json.Unmarshal(iter.Value(), &e); err != nil {
+			return nil, fmt.Errorf("failed to decode ban entry %q: %w", iter.Key(), err)
+		}
+		if e.ID != "" {
+			bs.ids[e.ID] = e
+		} else {
+			bs.ips[e.IP] = e
+		}
+	}
+	return bs, iter.Error()
+}
+
+// Ban bans target, which is either a node ID or an IP address, for the given
+// duration. A zero duration bans it forever.
+func (bs *BanStore) Ban(target string, reason string, duration time.Duration) (BanEntry, error) {
+	if duration < 0 {
+		return BanEntry{}, fmt.Errorf("negative ban duration %v", duration)
+	}
+
+	now := time.Now().UTC()
+	e := BanEntry{Reason: reason, BannedAt: now}
+	if duration > 0 {
+		e.ExpiresAt = now.Add(duration)
+	}
+	if ip := net.ParseIP(target); ip != nil {
+		e.IP = ip.String()
+	} else {
+		if err := validateID(ID(target)); err != nil {
+			return BanEntry{}, fmt.Errorf("%q is neither an IP address nor a valid node ID: %w", target, err)
+		}
+		e.ID = ID(target)
+	}
+
+	bz, err := json.Marshal(e)
+	if err != nil {
+		return BanEntry{}, err
+	}
+
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+
+	if err := bs.db.SetSync(e.key(), bz); err != nil {
+		return BanEntry{}, err
+	}
+	if e.ID != "" {
+		bs.ids[e.ID] = e
+	} else {
+		bs.ips[e.IP] = e
+	}
+	return e, nil
+}
+
+// Unban lifts the ban of target, which is either a node ID or an IP address.
+// It returns false if target wasn't banned.
+func (bs *BanStore) Unban(target string) (bool, error) {
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+
+	var (
+		e  BanEntry
+		ok bool
+	)
+	if ip := net.ParseIP(target); ip != nil {
+		if e, ok = bs.ips[ip.String()]; ok {
+			delete(bs.ips, e.IP)
+		}
+	} else if e, ok = bs.ids[ID(target)]; ok {
+		delete(bs.ids, e.ID)
+	}
+	if !ok {
+		return false, nil
+	}
+	return true, bs.db.DeleteSync(e.key())
+}
+
+// IsIDBanned returns true if the node ID is banned.
+func (bs *BanStore) IsIDBanned(id ID) bool {
+	bs.mtx.RLock()
+	e, ok := bs.ids[id]
+	bs.mtx.RUnlock()
+
+	return ok && !bs.expire(e)
+}
+
+// IsIPBanned returns true if the IP address is banned.
+func (bs *BanStore) IsIPBanned(ip net.IP) bool {
+	bs.mtx.RLock()
+	e, ok := bs.ips[ip.String()]
+	bs.mtx.RUnlock()
+
+	return ok && !bs.expire(e)
+}
+
+// IsAddrBanned returns true if either the node ID or the IP address of addr
+// is banned.
+func (bs *BanStore) IsAddrBanned(addr *NetAddress) bool {
+	if addr.ID != "" && bs.IsIDBanned(addr.ID) {
+		return true
+	}
+	return addr.IP != nil && bs.IsIPBanned(addr.IP)
+}
+
+// List returns the active bans.
+func (bs *BanStore) List() []BanEntry {
+	bs.mtx.RLock()
+	entries := make([]BanEntry, 0, len(bs.ids)+len(bs.ips))
+	for _, e := range bs.ids {
+		entries = append(entries, e)
+	}
+	for _, e := range bs.ips {
+		entries = append(entries, e)
+	}
+	bs.mtx.RUnlock()
+
+	active := entries[:0]
+	for _, e := range entries {
+		if !bs.expire(e) {
+			active = append(active, e)
+		}
+	}
+	return active
+}
+
+// expire removes the entry if it has expired and reports whether it did.
+func (bs *BanStore) expire(e BanEntry) bool {
+	if !e.Expired(time.Now()) {
+		return false
+	}
+
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+
+	// the target may have been banned again in the meantime
+	var (
+		cur BanEntry
+		ok  bool
+	)
+	if e.ID != "" {
+		cur, ok = bs.ids[e.ID]
+	} else {
+		cur, ok = bs.ips[e.IP]
+	}
+	if !ok {
+		return true
+	}
+	if !cur.BannedAt.Equal(e.BannedAt) {
+		return false
+	}
+
+	if e.ID != "" {
+		delete(bs.ids, e.ID)
+	} else {
+		delete(bs.ips, e.IP)
+	}
+	_ = bs.db.Delete(e.key())
+	return true
+}
+
+// ConnFilter returns a ConnFilterFunc which rejects connections from banned
+// IP addresses.
+func (bs *BanStore) ConnFilter() ConnFilterFunc {
+	return func(_ ConnSet, _ net.Conn, ips []net.IP) error {
+		for _, ip := range ips {
+			if bs.IsIPBanned(ip) {
+				return fmt.Errorf("IP<%v> is banned", ip)
+			}
+		}
+		return nil
+	}
+}
+
+// PeerFilter returns a PeerFilterFunc which rejects banned node IDs. Banned
+// IP addresses are already rejected by ConnFilter.
+func (bs *BanStore) PeerFilter() PeerFilterFunc {
+	return func(_ IPeerSet, p Peer) error {
+		if bs.IsIDBanned(p.ID()) {
+			return fmt.Errorf("ID<%v> is banned", p.ID())
+		}
+		return nil
+	}
+}
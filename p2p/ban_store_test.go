@@ -0,0 +1,96 @@
+package p2p
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/arcology-network/consensus-engine/crypto/ed25519"
+)
+
+func TestBanStorePersistsBans(t *testing.T) {
+	db := dbm.NewMemDB()
+	bs, err := NewBanStore(db)
+	require.NoError(t, err)
+
+	id := PubKeyToID(ed25519.GenPrivKey().PubKey())
+	ban, err := bs.Ban(string(id), "spam", 0)
+	require.NoError(t, err)
+	assert.Equal(t, id, ban.ID)
+	assert.Equal(t, "spam", ban.Reason)
+	assert.True(t, ban.ExpiresAt.IsZero())
+
+	_, err = bs.Ban("1.2.3.4", "", time.Hour)
+	require.NoError(t, err)
+
+	// bans survive a restart
+	bs, err = NewBanStore(db)
+	require.NoError(t, err)
+	assert.True(t, bs.IsIDBanned(id))
+	assert.True(t, bs.IsIPBanned(net.ParseIP("1.2.3.4")))
+	assert.False(t, bs.IsIPBanned(net.ParseIP("1.2.3.5")))
+	assert.Len(t, bs.List(), 2)
+
+	ok, err := bs.Unban(string(id))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = bs.Unban(string(id))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	bs, err = NewBanStore(db)
+	require.NoError(t, err)
+	assert.False(t, bs.IsIDBanned(id))
+	assert.Len(t, bs.List(), 1)
+}
+
+func TestBanStoreBanExpires(t *testing.T) {
+	db := dbm.NewMemDB()
+	bs, err := NewBanStore(db)
+	require.NoError(t, err)
+
+	_, err = bs.Ban("1.2.3.4", "spam", 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, bs.IsIPBanned(net.ParseIP("1.2.3.4")))
+
+	time.Sleep(20 * time.Millisecond)
+	assert.False(t, bs.IsIPBanned(net.ParseIP("1.2.3.4")))
+	assert.Empty(t, bs.List())
+
+	bs, err = NewBanStore(db)
+	require.NoError(t, err)
+	assert.Empty(t, bs.List())
+}
+
+func TestBanStoreInvalidTarget(t *testing.T) {
+	bs, err := NewBanStore(dbm.NewMemDB())
+	require.NoError(t, err)
+
+	_, err = bs.Ban("not-a-peer", "", 0)
+	assert.Error(t, err)
+	_, err = bs.Ban("1.2.3.4", "", -time.Second)
+	assert.Error(t, err)
+}
+
+func TestBanStoreFilters(t *testing.T) {
+	bs, err := NewBanStore(dbm.NewMemDB())
+	require.NoError(t, err)
+
+	_, err = bs.Ban("127.0.0.1", "", 0)
+	require.NoError(t, err)
+
+	filter := bs.ConnFilter()
+	assert.Error(t, filter(NewConnSet(), nil, []net.IP{net.ParseIP("127.0.0.1")}))
+	assert.NoError(t, filter(NewConnSet(), nil, []net.IP{net.ParseIP("127.0.0.2")}))
+
+	// a peer with a banned ID is rejected by the peer filter
+	p := CreateRandomPeer(false)
+	assert.NoError(t, bs.PeerFilter()(NewPeerSet(), p))
+	_, err = bs.Ban(string(p.ID()), "", 0)
+	require.NoError(t, err)
+	assert.Error(t, bs.PeerFilter()(NewPeerSet(), p))
+}
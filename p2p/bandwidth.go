@@ -0,0 +1,149 @@
+package p2p
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/arcology-network/consensus-engine/config"
+)
+
+// PeerClass is a class of peers which share the same connection rate limits.
+type PeerClass string
+
+const (
+	// PeerClassDefault is the class of peers which are in no other class.
+	PeerClassDefault PeerClass = "default"
+	// PeerClassValidator is the class of the peers listed as validators.
+	PeerClassValidator PeerClass = "validator"
+	// PeerClassUnconditional is the class of the unconditional peers.
+	PeerClassUnconditional PeerClass = "unconditional"
+	// PeerClassPersistent is the class of the persistent peers.
+	PeerClassPersistent PeerClass = "persistent"
+)
+
+// ValidatePeerClass returns an error if class is unknown.
+func ValidatePeerClass(class PeerClass) error {
+	switch class {
+	case PeerClassDefault, PeerClassValidator, PeerClassUnconditional, PeerClassPersistent:
+		return nil
+	default:
+		return fmt.Errorf("unknown peer class %q", class)
+	}
+}
+
+// RateLimit is a send and a receive rate, in bytes per second. A rate of 0 is
+// unlimited.
+type RateLimit struct {
+	SendRate int64 `json:"send_rate"`
+	RecvRate int64 `json:"recv_rate"`
+}
+
+// ValidateBasic returns an error if a rate is negative.
+func (l RateLimit) ValidateBasic() error {
+	if l.SendRate < 0 || l.RecvRate < 0 {
+		return fmt.Errorf("negative rate in %d:%d", l.SendRate, l.RecvRate)
+	}
+	return nil
+}
+
+// BandwidthLimits are the rate limits of the peer connections.
+type BandwidthLimits struct {
+	// Connection rate limits per peer class. A peer in a class without limits
+	// gets the limits of PeerClassDefault.
+	Classes map[PeerClass]RateLimit `json:"classes"`
+
+	// Rate limits per channel, within the connection rate limits. Channels are
+	// either named after their reactor (e.g. "mempool"), which covers all the
+	// channels of the reactor, or given by ID in hex (e.g. "0x30"), which takes
+	// precedence over the reactor name.
+	Channels map[string]RateLimit `json:"channels"`
+}
+
+// NewBandwidthLimits returns the BandwidthLimits configured in cfg.
+func NewBandwidthLimits(cfg *config.P2PConfig) (BandwidthLimits, error) {
+	limits := BandwidthLimits{
+		Classes: map[PeerClass]RateLimit{
+			PeerClassDefault: {SendRate: cfg.SendRate, RecvRate: cfg.RecvRate},
+		},
+	}
+
+	classes, err := ParseRateLimits(cfg.PeerRateLimits)
+	if err != nil {
+		return BandwidthLimits{}, fmt.Errorf("peer_rate_limits: %w", err)
+	}
+	for class, limit := range classes {
+		if err := ValidatePeerClass(PeerClass(class)); err != nil {
+			return BandwidthLimits{}, fmt.Errorf("peer_rate_limits: %w", err)
+		}
+		limits.Classes[PeerClass(class)] = limit
+	}
+
+	limits.Channels, err = ParseRateLimits(cfg.ChannelRateLimits)
+	if err != nil {
+		return BandwidthLimits{}, fmt.Errorf("channel_rate_limits: %w", err)
+	}
+	return limits, nil
+}
+
+// ParseRateLimits parses a comma separated list of name=send_rate:recv_rate.
+func ParseRateLimits(s string) (map[string]RateLimit, error) {
+	limits := make(map[string]RateLimit)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, rates := splitPair(item, "=")
+		sendRate, recvRate := splitPair(rates, ":")
+		if name == "" || sendRate == "" || recvRate == "" {
+			return nil, fmt.Errorf("invalid rate limit %q, expected name=send_rate:recv_rate", item)
+		}
+
+		var (
+			l   RateLimit
+			err error
+		)
+		if l.SendRate, err = strconv.ParseInt(sendRate, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid send rate in %q: %w", item, err)
+		}
+		if l.RecvRate, err = strconv.ParseInt(recvRate, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid receive rate in %q: %w", item, err)
+		}
+		if err := l.ValidateBasic(); err != nil {
+			return nil, err
+		}
+		limits[strings.ToLower(name)] = l
+	}
+	return limits, nil
+}
+
+func splitPair(s, sep string) (string, string) {
+	parts := strings.SplitN(s, sep, 2)
+	if len(parts) != 2 {
+		return "", ""
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+}
+
+func (l BandwidthLimits) copy() BandwidthLimits {
+	c := BandwidthLimits{
+		Classes:  make(map[PeerClass]RateLimit, len(l.Classes)),
+		Channels: make(map[string]RateLimit, len(l.Channels)),
+	}
+	for class, limit := range l.Classes {
+		c.Classes[class] = limit
+	}
+	for ch, limit := range l.Channels {
+		c.Channels[ch] = limit
+	}
+	return c
+}
+
+// rateLimitedPeer is implemented by peers whose connection can be rate
+// limited.
+type rateLimitedPeer interface {
+	SetRateLimits(sendRate, recvRate int64)
+	SetChannelRateLimits(chID byte, sendRate, recvRate int64) bool
+}
@@ -0,0 +1,46 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arcology-network/consensus-engine/config"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	limits, err := ParseRateLimits(" Mempool=512000:0, 0x20 = 0:1024 ,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]RateLimit{
+		"mempool": {SendRate: 512000, RecvRate: 0},
+		"0x20":    {SendRate: 0, RecvRate: 1024},
+	}, limits)
+
+	limits, err = ParseRateLimits("")
+	require.NoError(t, err)
+	assert.Empty(t, limits)
+
+	for _, s := range []string{"mempool", "mempool=1", "=1:1", "mempool=a:1", "mempool=1:-1"} {
+		_, err := ParseRateLimits(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestNewBandwidthLimits(t *testing.T) {
+	cfg := config.DefaultP2PConfig()
+	cfg.PeerRateLimits = "validator=0:0"
+	cfg.ChannelRateLimits = "mempool=512000:0"
+
+	limits, err := NewBandwidthLimits(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, map[PeerClass]RateLimit{
+		PeerClassDefault:   {SendRate: cfg.SendRate, RecvRate: cfg.RecvRate},
+		PeerClassValidator: {},
+	}, limits.Classes)
+	assert.Equal(t, map[string]RateLimit{"mempool": {SendRate: 512000}}, limits.Channels)
+
+	cfg.PeerRateLimits = "observer=0:0"
+	_, err = NewBandwidthLimits(cfg)
+	assert.Error(t, err)
+}
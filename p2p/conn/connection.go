@@ -32,6 +32,14 @@ const (
 	minWriteBufferSize = 65536
 	updateStats        = 2 * time.Second
 
+	// how long to wait before sending again if all pending channels are
+	// throttled; the sampling period of flow.Monitor
+	channelThrottleRetry = 100 * time.Millisecond
+
+	// a channel with messages pending gets to send after this many packets
+	// of channels of higher classes, so that it isn't starved
+	maxStarvedPackets = 10
+
 	// some of these defaults are written in the user config
 	// flushThrottle, sendRate, recvRate
 	// TODO: remove values present in config
@@ -50,6 +58,23 @@ const (
 type receiveCbFunc func(chID byte, msgBytes []byte)
 type errorCbFunc func(interface{})
 
+// PriorityClass is the strict priority class of a channel. Channels of a
+// higher class send before the channels of lower classes, which only get to
+// send every maxStarvedPackets packets while higher classes have messages
+// pending. Channels of the same class share the connection by Priority.
+type PriorityClass uint8
+
+const (
+	// PriorityClassNormal is the class of the bulk of the channels.
+	PriorityClassNormal PriorityClass = iota
+	// PriorityClassHigh is the class of the channels which are time sensitive.
+	PriorityClassHigh
+	// PriorityClassCritical is the class of the channels which are the most
+	// time sensitive. Their messages preempt the messages of other channels
+	// and are flushed as soon as they are written.
+	PriorityClassCritical
+)
+
 /*
 Each peer has one `MConnection` (multiplex connection) instance.
 
@@ -58,8 +83,8 @@ several messages along a single channel of communication.
 
 Each `MConnection` handles message transmission on multiple abstract communication
 `Channel`s.  Each channel has a globally unique byte id.
-The byte id and the relative priorities of each `Channel` are configured upon
-initialization of the connection.
+The byte id, the priority class and the relative priorities of each `Channel`
+are configured upon initialization of the connection.
 
 There are two methods for sending messages:
 
@@ -87,6 +112,7 @@ type MConnection struct {
 	pong          chan struct{}
 	channels      []*Channel
 	channelsIdx   map[byte]*Channel
+	pending       []*Channel // used by sendRoutine only
 	onReceive     receiveCbFunc
 	onError       errorCbFunc
 	errored       uint32
@@ -104,12 +130,15 @@ type MConnection struct {
 	// are safe to call concurrently.
 	stopMtx tmsync.Mutex
 
-	flushTimer *timer.ThrottleTimer // flush writes as necessary but throttled.
-	pingTimer  *time.Ticker         // send pings periodically
+	flushTimer    *timer.ThrottleTimer // flush writes as necessary but throttled.
+	throttleTimer *timer.ThrottleTimer // wake up sendRoutine when throttled channels may send again.
+	pingTimer     *time.Ticker         // send pings periodically
 
 	// close conn if pong is not received in pongTimeout
 	pongTimer     *time.Timer
 	pongTimeoutCh chan bool // true - timeout, false - peer sent pong
+	pingSent      time.Time // when the last ping was sent, used by sendRoutine only
+	latency       int64     // round-trip time of the last ping, in nanoseconds
 
 	chStatsTimer *time.Ticker // update channel stats periodically
 
@@ -134,6 +163,14 @@ type MConnConfig struct {
 
 	// Maximum wait time for pongs
 	PongTimeout time.Duration `mapstructure:"pong_timeout"`
+
+	// OnSent, if set, is called with the time every message spent in the
+	// send queue of its channel until it was written to the connection.
+	OnSent func(chID byte, queued time.Duration) `mapstructure:"-"`
+
+	// OnReceived, if set, is called with the type (see MessageType) and the
+	// size of every message received, before it is passed to onReceive.
+	OnReceived func(chID byte, msgType string, size int) `mapstructure:"-"`
 }
 
 // DefaultMConnConfig returns the default config.
@@ -222,6 +259,7 @@ func (c *MConnection) OnStart() error {
 		return err
 	}
 	c.flushTimer = timer.NewThrottleTimer("flush", c.config.FlushThrottle)
+	c.throttleTimer = timer.NewThrottleTimer("throttle", channelThrottleRetry)
 	c.pingTimer = time.NewTicker(c.config.PingInterval)
 	c.pongTimeoutCh = make(chan bool, 1)
 	c.chStatsTimer = time.NewTicker(updateStats)
@@ -256,6 +294,7 @@ func (c *MConnection) stopServices() (alreadyStopped bool) {
 
 	c.BaseService.OnStop()
 	c.flushTimer.Stop()
+	c.throttleTimer.Stop()
 	c.pingTimer.Stop()
 	c.chStatsTimer.Stop()
 
@@ -447,6 +486,7 @@ FOR_LOOP:
 				break SELECTION
 			}
 			c.sendMonitor.Update(_n)
+			c.pingSent = time.Now()
 			c.Logger.Debug("Starting pong timer", "dur", c.config.PongTimeout)
 			c.pongTimer = time.AfterFunc(c.config.PongTimeout, func() {
 				select {
@@ -461,6 +501,10 @@ FOR_LOOP:
 				err = errors.New("pong timeout")
 			} else {
 				c.stopPongTimer()
+				if !c.pingSent.IsZero() {
+					atomic.StoreInt64(&c.latency, int64(time.Since(c.pingSent)))
+					c.pingSent = time.Time{}
+				}
 			}
 		case <-c.pong:
 			c.Logger.Debug("Send Pong")
@@ -473,6 +517,11 @@ FOR_LOOP:
 			c.flush()
 		case <-c.quitSendRoutine:
 			break FOR_LOOP
+		case <-c.throttleTimer.Ch:
+			select {
+			case c.send <- struct{}{}:
+			default:
+			}
 		case <-c.send:
 			// Send some PacketMsgs
 			eof := c.sendSomePacketMsgs()
@@ -520,14 +569,34 @@ func (c *MConnection) sendSomePacketMsgs() bool {
 // Returns true if messages from channels were exhausted.
 func (c *MConnection) sendPacketMsg() bool {
 	// Choose a channel to create a PacketMsg from.
-	// The chosen channel will be the one whose recentlySent/priority is the least.
+	// The chosen channel will be the most starved one if any has been starved
+	// for maxStarvedPackets, or else the one of the highest class whose
+	// recentlySent/priority is the least.
 	var leastRatio float32 = math.MaxFloat32
-	var leastChannel *Channel
+	var leastChannel, starvedChannel *Channel
+	var throttled bool
+	pending := c.pending[:0]
 	for _, channel := range c.channels {
 		// If nothing to send, skip this channel
 		if !channel.isSendPending() {
 			continue
 		}
+		// If the channel has used up its own send rate, skip it for now
+		if channel.sendThrottled() {
+			throttled = true
+			continue
+		}
+		pending = append(pending, channel)
+		if channel.starved >= maxStarvedPackets &&
+			(starvedChannel == nil || channel.starved > starvedChannel.starved) {
+			starvedChannel = channel
+		}
+		if leastChannel != nil && channel.desc.Class < leastChannel.desc.Class {
+			continue
+		}
+		if leastChannel != nil && channel.desc.Class > leastChannel.desc.Class {
+			leastRatio = math.MaxFloat32
+		}
 		// Get ratio, and keep track of lowest ratio.
 		ratio := float32(channel.recentlySent) / float32(channel.desc.Priority)
 		if ratio < leastRatio {
@@ -535,22 +604,40 @@ func (c *MConnection) sendPacketMsg() bool {
 			leastChannel = channel
 		}
 	}
+	c.pending = pending
 
 	// Nothing to send?
 	if leastChannel == nil {
+		if throttled {
+			// Try again once the throttled channels may send.
+			c.throttleTimer.Set()
+		}
 		return true
 	}
+	if starvedChannel != nil {
+		leastChannel = starvedChannel
+	}
+	for _, channel := range pending {
+		if channel.desc.Class < leastChannel.desc.Class {
+			channel.starved++
+		}
+	}
+	leastChannel.starved = 0
 	// c.Logger.Info("Found a msgPacket to send")
 
 	// Make & send a PacketMsg from this channel
-	_n, err := leastChannel.writePacketMsgTo(c.bufConnWriter)
+	_n, eof, err := leastChannel.writePacketMsgTo(c.bufConnWriter)
 	if err != nil {
 		c.Logger.Error("Failed to write PacketMsg", "err", err)
 		c.stopForError(err)
 		return true
 	}
 	c.sendMonitor.Update(_n)
-	c.flushTimer.Set()
+	if eof && leastChannel.desc.Class == PriorityClassCritical {
+		c.flush()
+	} else {
+		c.flushTimer.Set()
+	}
 	return false
 }
 
@@ -647,6 +734,10 @@ FOR_LOOP:
 				// NOTE: This means the reactor.Receive runs in the same thread as the p2p recv routine
 				c.onReceive(byte(pkt.PacketMsg.ChannelID), msgBytes)
 			}
+
+			// Block until the channel's own recvMonitor says we can read.
+			channel.recvMonitor.Update(_n)
+			channel.recvMonitor.Limit(c._maxPacketMsgSize, atomic.LoadInt64(&channel.recvRate), true)
 		default:
 			err := fmt.Errorf("unknown message type %v", reflect.TypeOf(packet))
 			c.Logger.Error("Connection failed @ recvRoutine", "conn", c, "err", err)
@@ -670,6 +761,34 @@ func (c *MConnection) stopPongTimer() {
 	}
 }
 
+// SetRateLimits changes the send and receive rates of the connection, in
+// bytes per second. A rate of 0 is unlimited.
+// Goroutine-safe.
+func (c *MConnection) SetRateLimits(sendRate, recvRate int64) {
+	atomic.StoreInt64(&c.config.SendRate, sendRate)
+	atomic.StoreInt64(&c.config.RecvRate, recvRate)
+}
+
+// SetChannelRateLimits limits the send and receive rates of a single channel,
+// in bytes per second, within the rates of the connection. A rate of 0 leaves
+// the channel limited by the connection rates only. It returns false if the
+// channel doesn't exist.
+//
+// Sending is limited per channel, so other channels can use the rest of the
+// connection rate. Receiving can only be throttled for the connection as a
+// whole: once a channel exceeds its receive rate, reading from the connection
+// pauses until the channel is back within its rate.
+// Goroutine-safe.
+func (c *MConnection) SetChannelRateLimits(chID byte, sendRate, recvRate int64) bool {
+	channel, ok := c.channelsIdx[chID]
+	if !ok {
+		return false
+	}
+	atomic.StoreInt64(&channel.sendRate, sendRate)
+	atomic.StoreInt64(&channel.recvRate, recvRate)
+	return true
+}
+
 // maxPacketMsgSize returns a maximum size of PacketMsg
 func (c *MConnection) maxPacketMsgSize() int {
 	bz, err := proto.Marshal(mustWrapPacket(&tmp2p.PacketMsg{
@@ -685,6 +804,7 @@ func (c *MConnection) maxPacketMsgSize() int {
 
 type ConnectionStatus struct {
 	Duration    time.Duration
+	Latency     time.Duration // round-trip time of the last ping, 0 if unknown
 	SendMonitor flow.Status
 	RecvMonitor flow.Status
 	Channels    []ChannelStatus
@@ -694,13 +814,18 @@ type ChannelStatus struct {
 	ID                byte
 	SendQueueCapacity int
 	SendQueueSize     int
+	Class             PriorityClass
 	Priority          int
 	RecentlySent      int64
+	SendRate          int64
+	RecvRate          int64
+	RecvMessages      map[string]int64 // number of messages received by type
 }
 
 func (c *MConnection) Status() ConnectionStatus {
 	var status ConnectionStatus
 	status.Duration = time.Since(c.created)
+	status.Latency = time.Duration(atomic.LoadInt64(&c.latency))
 	status.SendMonitor = c.sendMonitor.Status()
 	status.RecvMonitor = c.recvMonitor.Status()
 	status.Channels = make([]ChannelStatus, len(c.channels))
@@ -709,8 +834,12 @@ func (c *MConnection) Status() ConnectionStatus {
 			ID:                channel.desc.ID,
 			SendQueueCapacity: cap(channel.sendQueue),
 			SendQueueSize:     int(atomic.LoadInt32(&channel.sendQueueSize)),
+			Class:             channel.desc.Class,
 			Priority:          channel.desc.Priority,
 			RecentlySent:      atomic.LoadInt64(&channel.recentlySent),
+			SendRate:          atomic.LoadInt64(&channel.sendRate),
+			RecvRate:          atomic.LoadInt64(&channel.recvRate),
+			RecvMessages:      channel.recvMsgCounts(),
 		}
 	}
 	return status
@@ -724,6 +853,14 @@ type ChannelDescriptor struct {
 	SendQueueCapacity   int
 	RecvBufferCapacity  int
 	RecvMessageCapacity int
+
+	// Class is the strict priority class of the channel, PriorityClassNormal
+	// if unset.
+	Class PriorityClass
+
+	// MessageType is the proto message the channel's messages are encoded as.
+	// It is used to count the received messages by type.
+	MessageType proto.Message
 }
 
 func (chDesc ChannelDescriptor) FillDefaults() (filled ChannelDescriptor) {
@@ -740,16 +877,31 @@ func (chDesc ChannelDescriptor) FillDefaults() (filled ChannelDescriptor) {
 	return
 }
 
+// queuedMsg is a message in the send queue of a channel.
+type queuedMsg struct {
+	bytes  []byte
+	queued time.Time
+}
+
 // TODO: lowercase.
 // NOTE: not goroutine-safe.
 type Channel struct {
 	conn          *MConnection
 	desc          ChannelDescriptor
-	sendQueue     chan []byte
+	sendQueue     chan queuedMsg
 	sendQueueSize int32 // atomic.
 	recving       []byte
 	sending       []byte
-	recentlySent  int64 // exponential moving average
+	sendingQueued time.Time // when the message being sent was queued
+	recentlySent  int64     // exponential moving average
+	starved       int       // packets of higher classes sent while pending
+	sendMonitor   *flow.Monitor
+	recvMonitor   *flow.Monitor
+	sendRate      int64 // atomic. 0 if limited by the connection only.
+	recvRate      int64 // atomic. 0 if limited by the connection only.
+
+	recvMsgsMtx tmsync.Mutex
+	recvMsgs    map[string]int64 // number of messages received by type
 
 	maxPacketMsgPayloadSize int
 
@@ -764,8 +916,11 @@ func newChannel(conn *MConnection, desc ChannelDescriptor) *Channel {
 	return &Channel{
 		conn:                    conn,
 		desc:                    desc,
-		sendQueue:               make(chan []byte, desc.SendQueueCapacity),
+		sendQueue:               make(chan queuedMsg, desc.SendQueueCapacity),
 		recving:                 make([]byte, 0, desc.RecvBufferCapacity),
+		sendMonitor:             flow.New(0, 0),
+		recvMonitor:             flow.New(0, 0),
+		recvMsgs:                make(map[string]int64),
 		maxPacketMsgPayloadSize: conn.config.MaxPacketMsgPayloadSize,
 	}
 }
@@ -779,7 +934,7 @@ func (ch *Channel) SetLogger(l log.Logger) {
 // Times out (and returns false) after defaultSendTimeout
 func (ch *Channel) sendBytes(bytes []byte) bool {
 	select {
-	case ch.sendQueue <- bytes:
+	case ch.sendQueue <- queuedMsg{bytes, time.Now()}:
 		atomic.AddInt32(&ch.sendQueueSize, 1)
 		return true
 	case <-time.After(defaultSendTimeout):
@@ -792,7 +947,7 @@ func (ch *Channel) sendBytes(bytes []byte) bool {
 // Goroutine-safe
 func (ch *Channel) trySendBytes(bytes []byte) bool {
 	select {
-	case ch.sendQueue <- bytes:
+	case ch.sendQueue <- queuedMsg{bytes, time.Now()}:
 		atomic.AddInt32(&ch.sendQueueSize, 1)
 		return true
 	default:
@@ -819,7 +974,8 @@ func (ch *Channel) isSendPending() bool {
 		if len(ch.sendQueue) == 0 {
 			return false
 		}
-		ch.sending = <-ch.sendQueue
+		msg := <-ch.sendQueue
+		ch.sending, ch.sendingQueued = msg.bytes, msg.queued
 	}
 	return true
 }
@@ -834,6 +990,9 @@ func (ch *Channel) nextPacketMsg() tmp2p.PacketMsg {
 		packet.EOF = true
 		ch.sending = nil
 		atomic.AddInt32(&ch.sendQueueSize, -1) // decrement sendQueueSize
+		if onSent := ch.conn.config.OnSent; onSent != nil {
+			onSent(ch.desc.ID, time.Since(ch.sendingQueued))
+		}
 	} else {
 		packet.EOF = false
 		ch.sending = ch.sending[tmmath.MinInt(maxSize, len(ch.sending)):]
@@ -841,13 +1000,22 @@ func (ch *Channel) nextPacketMsg() tmp2p.PacketMsg {
 	return packet
 }
 
-// Writes next PacketMsg to w and updates c.recentlySent.
+// Writes next PacketMsg to w and updates c.recentlySent. eof is true if the
+// packet is the last one of its message.
 // Not goroutine-safe
-func (ch *Channel) writePacketMsgTo(w io.Writer) (n int, err error) {
+func (ch *Channel) writePacketMsgTo(w io.Writer) (n int, eof bool, err error) {
 	packet := ch.nextPacketMsg()
 	n, err = protoio.NewDelimitedWriter(w).WriteMsg(mustWrapPacket(&packet))
 	atomic.AddInt64(&ch.recentlySent, int64(n))
-	return
+	ch.sendMonitor.Update(n)
+	return n, packet.EOF, err
+}
+
+// Returns true if the channel has used up its send rate for now.
+// Goroutine-safe
+func (ch *Channel) sendThrottled() bool {
+	rate := atomic.LoadInt64(&ch.sendRate)
+	return rate > 0 && ch.sendMonitor.Limit(ch.maxPacketMsgPayloadSize, rate, false) == 0
 }
 
 // Handles incoming PacketMsgs. It returns a message bytes if message is
@@ -862,6 +1030,7 @@ func (ch *Channel) recvPacketMsg(packet tmp2p.PacketMsg) ([]byte, error) {
 	ch.recving = append(ch.recving, packet.Data...)
 	if packet.EOF {
 		msgBytes := ch.recving
+		ch.countRecvMsg(msgBytes)
 
 		// clear the slice without re-allocating.
 		// http://stackoverflow.com/questions/16971741/how-do-you-clear-a-slice-in-go
@@ -873,6 +1042,33 @@ func (ch *Channel) recvPacketMsg(packet tmp2p.PacketMsg) ([]byte, error) {
 	return nil, nil
 }
 
+// countRecvMsg counts a received message by its type. The type is decoded
+// once here for both the counts and the OnReceived callback.
+func (ch *Channel) countRecvMsg(msgBytes []byte) {
+	msgType := MessageType(ch.desc.MessageType, msgBytes)
+
+	ch.recvMsgsMtx.Lock()
+	ch.recvMsgs[msgType]++
+	ch.recvMsgsMtx.Unlock()
+
+	if onReceived := ch.conn.config.OnReceived; onReceived != nil {
+		onReceived(ch.desc.ID, msgType, len(msgBytes))
+	}
+}
+
+// recvMsgCounts returns a copy of the received message counts.
+// Goroutine-safe
+func (ch *Channel) recvMsgCounts() map[string]int64 {
+	ch.recvMsgsMtx.Lock()
+	defer ch.recvMsgsMtx.Unlock()
+
+	counts := make(map[string]int64, len(ch.recvMsgs))
+	for msgType, n := range ch.recvMsgs {
+		counts[msgType] = n
+	}
+	return counts
+}
+
 // Call this periodically to update stats for throttling purposes.
 // Not goroutine-safe
 func (ch *Channel) updateStats() {
@@ -1,6 +1,8 @@
 package conn
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/hex"
 	"net"
 	"testing"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/arcology-network/consensus-engine/libs/log"
 	"github.com/arcology-network/consensus-engine/libs/protoio"
+	"github.com/arcology-network/consensus-engine/libs/timer"
 	tmp2p "github.com/arcology-network/consensus-engine/proto/tendermint/p2p"
 	"github.com/arcology-network/consensus-engine/proto/tendermint/types"
 )
@@ -146,6 +149,49 @@ func TestMConnectionReceive(t *testing.T) {
 	case <-time.After(500 * time.Millisecond):
 		t.Fatalf("Did not receive %s message in 500ms", msg)
 	}
+
+	// the channel has no message type, so the message is counted as unknown
+	assert.EqualValues(t, map[string]int64{UnknownMessageType: 1}, mconn1.Status().Channels[0].RecvMessages)
+}
+
+func TestMConnectionOnReceived(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	type received struct {
+		chID    byte
+		msgType string
+		size    int
+	}
+	receivedCh := make(chan received, 1)
+	cfg := DefaultMConnConfig()
+	cfg.OnReceived = func(chID byte, msgType string, size int) {
+		receivedCh <- received{chID, msgType, size}
+	}
+	chDescs := []*ChannelDescriptor{{ID: 0x01, Priority: 1, SendQueueCapacity: 1, MessageType: &tmp2p.Message{}}}
+	onReceive := func(chID byte, msgBytes []byte) {}
+	onError := func(r interface{}) {}
+	mconn1 := NewMConnectionWithConfig(client, chDescs, onReceive, onError, cfg)
+	mconn1.SetLogger(log.TestingLogger())
+	require.NoError(t, mconn1.Start())
+	defer mconn1.Stop() // nolint:errcheck // ignore for tests
+
+	mconn2 := createTestMConnection(server)
+	require.NoError(t, mconn2.Start())
+	defer mconn2.Stop() // nolint:errcheck // ignore for tests
+
+	msg, err := proto.Marshal(&tmp2p.Message{Sum: &tmp2p.Message_PexRequest{PexRequest: &tmp2p.PexRequest{}}})
+	require.NoError(t, err)
+	assert.True(t, mconn2.Send(0x01, msg))
+
+	select {
+	case r := <-receivedCh:
+		assert.Equal(t, received{0x01, "PexRequest", len(msg)}, r)
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("Did not receive the message in 500ms")
+	}
+	assert.EqualValues(t, map[string]int64{"PexRequest": 1}, mconn1.Status().Channels[0].RecvMessages)
 }
 
 func TestMConnectionStatus(t *testing.T) {
@@ -364,6 +410,37 @@ func TestMConnectionPingPongs(t *testing.T) {
 	}
 }
 
+func TestMConnectionLatency(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	mconn := createMConnectionWithCallbacks(client, func(byte, []byte) {}, func(interface{}) {})
+	err := mconn.Start()
+	require.Nil(t, err)
+	defer mconn.Stop() // nolint:errcheck // ignore for tests
+
+	assert.Zero(t, mconn.Status().Latency)
+
+	delay := 20 * time.Millisecond
+	go func() {
+		var pkt tmp2p.Packet
+		// read ping
+		_, err := protoio.NewDelimitedReader(server, maxPingPongPacketSize).ReadMsg(&pkt)
+		if err != nil {
+			return
+		}
+		// respond with pong after a delay
+		time.Sleep(delay)
+		_, _ = protoio.NewDelimitedWriter(server).WriteMsg(mustWrapPacket(&tmp2p.PacketPong{}))
+	}()
+
+	assert.Eventually(t, func() bool {
+		return mconn.Status().Latency >= delay
+	}, time.Second, 5*time.Millisecond)
+	assert.Less(t, int64(mconn.Status().Latency), int64(mconn.config.PongTimeout))
+}
+
 func TestMConnectionStopsAndReturnsError(t *testing.T) {
 	server, client := NetPipe()
 	defer server.Close()
@@ -564,6 +641,162 @@ func TestMConnectionTrySend(t *testing.T) {
 	assert.Equal(t, "TrySend", <-resultCh)
 }
 
+func TestMConnectionChannelSendRateLimit(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 1, SendQueueCapacity: 10},
+		{ID: 0x02, Priority: 1, SendQueueCapacity: 10},
+	}
+	receivedCh := make(chan byte, 10)
+	onReceive := func(chID byte, msgBytes []byte) {
+		receivedCh <- chID
+	}
+	onError := func(r interface{}) {}
+
+	mconnClient := NewMConnectionWithConfig(client, chDescs, onReceive, onError, DefaultMConnConfig())
+	mconnClient.SetLogger(log.TestingLogger().With("module", "client"))
+	mconnServer := NewMConnectionWithConfig(server, chDescs, onReceive, onError, DefaultMConnConfig())
+	mconnServer.SetLogger(log.TestingLogger().With("module", "server"))
+
+	// at most one packet per sampling period on 0x01
+	require.True(t, mconnClient.SetChannelRateLimits(0x01, 1, 0))
+	require.False(t, mconnClient.SetChannelRateLimits(0x05, 1, 0))
+
+	require.NoError(t, mconnClient.Start())
+	defer mconnClient.Stop() // nolint:errcheck // ignore for tests
+	require.NoError(t, mconnServer.Start())
+	defer mconnServer.Stop() // nolint:errcheck // ignore for tests
+
+	const numMsgs = 5
+	start := time.Now()
+	for i := 0; i < numMsgs; i++ {
+		require.True(t, mconnClient.Send(0x01, []byte("flood")))
+	}
+	require.True(t, mconnClient.Send(0x02, []byte("vote")))
+
+	// the message on 0x02 overtakes the throttled messages on 0x01, which are
+	// still delivered, one per sampling period
+	var (
+		flood int
+		vote  bool
+	)
+	for flood < numMsgs {
+		select {
+		case chID := <-receivedCh:
+			if chID == 0x02 {
+				assert.Less(t, flood, numMsgs-1)
+				vote = true
+				continue
+			}
+			flood++
+		case <-time.After(3 * time.Second):
+			t.Fatal("timed out waiting for throttled msgs")
+		}
+	}
+	assert.True(t, vote)
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64((numMsgs-2)*channelThrottleRetry))
+
+	status := mconnClient.Status()
+	assert.EqualValues(t, 1, status.Channels[0].SendRate)
+	assert.EqualValues(t, 0, status.Channels[1].SendRate)
+}
+
+func TestMConnectionPriorityClasses(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 10, SendQueueCapacity: 50},
+		{ID: 0x02, Class: PriorityClassHigh, Priority: 1, SendQueueCapacity: 50},
+		{ID: 0x03, Class: PriorityClassCritical, Priority: 1, SendQueueCapacity: 50},
+	}
+	mconn := NewMConnectionWithConfig(client, chDescs, func(byte, []byte) {}, func(interface{}) {}, DefaultMConnConfig())
+	mconn.SetLogger(log.TestingLogger())
+
+	// write the packets to a buffer rather than running the send routine
+	var buf bytes.Buffer
+	mconn.bufConnWriter = bufio.NewWriter(&buf)
+	mconn.flushTimer = timer.NewThrottleTimer("flush", time.Hour)
+	defer mconn.flushTimer.Stop()
+
+	for i := 0; i < 40; i++ {
+		require.True(t, mconn.channelsIdx[0x01].sendBytes([]byte("tx")))
+		require.True(t, mconn.channelsIdx[0x02].sendBytes([]byte("part")))
+	}
+	for i := 0; i < 2; i++ {
+		require.True(t, mconn.channelsIdx[0x03].sendBytes([]byte("vote")))
+	}
+
+	const numPackets = 3 * (maxStarvedPackets + 1)
+	for i := 0; i < numPackets; i++ {
+		require.False(t, mconn.sendPacketMsg())
+	}
+	mconn.flush()
+
+	var sent []byte
+	protoReader := protoio.NewDelimitedReader(&buf, mconn._maxPacketMsgSize)
+	for i := 0; i < numPackets; i++ {
+		var packet tmp2p.Packet
+		_, err := protoReader.ReadMsg(&packet)
+		require.NoError(t, err)
+		sent = append(sent, byte(packet.GetPacketMsg().ChannelID))
+	}
+
+	// the votes go first, then the lower classes get a packet every
+	// maxStarvedPackets packets
+	assert.Equal(t, []byte{0x03, 0x03}, sent[:2])
+	assert.EqualValues(t, 0x01, sent[maxStarvedPackets])
+	assert.Equal(t, 3, bytes.Count(sent, []byte{0x01}))
+	assert.Equal(t, numPackets-5, bytes.Count(sent, []byte{0x02}))
+}
+
+func TestMConnectionCriticalClassFlush(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 1},
+		{ID: 0x02, Class: PriorityClassCritical, Priority: 1},
+	}
+	receivedCh := make(chan byte, 1)
+	onReceive := func(chID byte, msgBytes []byte) {
+		receivedCh <- chID
+	}
+	onError := func(r interface{}) {}
+
+	sentCh := make(chan byte, 1)
+	cfg := DefaultMConnConfig()
+	cfg.FlushThrottle = time.Hour
+	cfg.OnSent = func(chID byte, queued time.Duration) {
+		assert.GreaterOrEqual(t, int64(queued), int64(0))
+		sentCh <- chID
+	}
+	mconnClient := NewMConnectionWithConfig(client, chDescs, onReceive, onError, cfg)
+	mconnClient.SetLogger(log.TestingLogger().With("module", "client"))
+	mconnServer := NewMConnectionWithConfig(server, chDescs, onReceive, onError, DefaultMConnConfig())
+	mconnServer.SetLogger(log.TestingLogger().With("module", "server"))
+
+	require.NoError(t, mconnClient.Start())
+	defer mconnClient.Stop() // nolint:errcheck // ignore for tests
+	require.NoError(t, mconnServer.Start())
+	defer mconnServer.Stop() // nolint:errcheck // ignore for tests
+
+	// the first message is flushed right away, despite the flush throttle
+	require.True(t, mconnClient.Send(0x02, []byte("vote")))
+	select {
+	case chID := <-receivedCh:
+		assert.EqualValues(t, 0x02, chID)
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for the critical msg")
+	}
+	assert.EqualValues(t, 0x02, <-sentCh)
+}
+
 // nolint:lll //ignore line length for tests
 func TestConnVectors(t *testing.T) {
 
@@ -0,0 +1,87 @@
+package conn
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// UnknownMessageType is the type of the messages on channels without a
+// MessageType, and of messages which don't match it.
+const UnknownMessageType = "unknown"
+
+// messageTypes holds the names of the messages of one proto type.
+type messageTypes struct {
+	name  string            // name of the message itself
+	oneof map[uint64]string // names of the wrapped messages by field number
+}
+
+// oneofMessage is implemented by gogoproto messages with a oneof.
+type oneofMessage interface {
+	XXX_OneofWrappers() []interface{}
+}
+
+var messageTypesCache sync.Map // reflect.Type -> *messageTypes
+
+func newMessageTypes(msg proto.Message) *messageTypes {
+	mt := &messageTypes{name: typeName(reflect.TypeOf(msg))}
+	om, ok := msg.(oneofMessage)
+	if !ok {
+		return mt
+	}
+	mt.oneof = make(map[uint64]string)
+	for _, wrapper := range om.XXX_OneofWrappers() {
+		// A wrapper is a struct with the wrapped message as its only field,
+		// tagged like `protobuf:"bytes,1,opt,name=new_round_step,proto3,oneof"`.
+		field := reflect.TypeOf(wrapper).Elem().Field(0)
+		tag := strings.Split(field.Tag.Get("protobuf"), ",")
+		if len(tag) < 2 {
+			continue
+		}
+		num, err := strconv.ParseUint(tag[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		mt.oneof[num] = typeName(field.Type)
+	}
+	return mt
+}
+
+func typeName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// MessageType returns the name of the message in msgBytes, which is encoded
+// as msg. If msg wraps its messages in a oneof, as the messages of most
+// reactors do, it is the name of the wrapped message. Only the first field
+// tag of msgBytes is decoded, so this is cheap enough to call for every
+// message received.
+func MessageType(msg proto.Message, msgBytes []byte) string {
+	if msg == nil {
+		return UnknownMessageType
+	}
+	t := reflect.TypeOf(msg)
+	v, ok := messageTypesCache.Load(t)
+	if !ok {
+		v, _ = messageTypesCache.LoadOrStore(t, newMessageTypes(msg))
+	}
+	mt := v.(*messageTypes)
+	if mt.oneof == nil {
+		return mt.name
+	}
+
+	tag, n := proto.DecodeVarint(msgBytes)
+	if n == 0 {
+		return UnknownMessageType
+	}
+	if name, ok := mt.oneof[tag>>3]; ok {
+		return name
+	}
+	return UnknownMessageType
+}
@@ -0,0 +1,47 @@
+package conn
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmp2p "github.com/arcology-network/consensus-engine/proto/tendermint/p2p"
+)
+
+func TestMessageType(t *testing.T) {
+	mustMarshal := func(msg proto.Message) []byte {
+		bz, err := proto.Marshal(msg)
+		require.NoError(t, err)
+		return bz
+	}
+
+	testCases := map[string]struct {
+		msgType  proto.Message
+		msgBytes []byte
+		expected string
+	}{
+		"oneof": {
+			&tmp2p.Message{},
+			mustMarshal(&tmp2p.Message{Sum: &tmp2p.Message_PexRequest{PexRequest: &tmp2p.PexRequest{}}}),
+			"PexRequest",
+		},
+		"oneof second field": {
+			&tmp2p.Message{},
+			mustMarshal(&tmp2p.Message{Sum: &tmp2p.Message_PexAddrs{PexAddrs: &tmp2p.PexAddrs{}}}),
+			"PexAddrs",
+		},
+		"oneof unset":         {&tmp2p.Message{}, []byte{}, UnknownMessageType},
+		"oneof unknown field": {&tmp2p.Message{}, []byte{0x7a, 0x00}, UnknownMessageType},
+		"no oneof":            {&tmp2p.PacketPing{}, []byte{}, "PacketPing"},
+		"no message type":     {nil, []byte{0x0a, 0x00}, UnknownMessageType},
+	}
+
+	for name, tc := range testCases {
+		tc := tc // appease linter
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MessageType(tc.msgType, tc.msgBytes))
+		})
+	}
+}
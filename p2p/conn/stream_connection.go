@@ -0,0 +1,189 @@
+package conn
+
+import (
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+
+	flow "github.com/arcology-network/consensus-engine/libs/flowrate"
+	"github.com/arcology-network/consensus-engine/libs/log"
+	"github.com/arcology-network/consensus-engine/libs/service"
+)
+
+// StreamConnection sends every channel over a stream of its own, each with an
+// MConnection of its own, so a channel moving a lot of data can't hold back
+// the other channels. It is used by transports with native streams, like QUIC.
+//
+// The connection rate limits apply to all the streams together: they share
+// the monitors throttling the connection. Only the channel rate limits apply
+// to a single stream.
+type StreamConnection struct {
+	service.BaseService
+
+	conns       []*MConnection
+	connsIdx    map[byte]*MConnection
+	sendMonitor *flow.Monitor
+	recvMonitor *flow.Monitor
+	remoteAddr  net.Addr
+	onError     errorCbFunc
+	errored     uint32
+	created     time.Time
+}
+
+// NewStreamConnection returns a StreamConnection which sends every channel
+// in chDescs over the stream for its channel ID in streams. Channels without
+// a stream are left out.
+func NewStreamConnection(
+	remoteAddr net.Addr,
+	streams map[byte]net.Conn,
+	chDescs []*ChannelDescriptor,
+	onReceive receiveCbFunc,
+	onError errorCbFunc,
+	config MConnConfig,
+) *StreamConnection {
+	sc := &StreamConnection{
+		connsIdx:    make(map[byte]*MConnection),
+		sendMonitor: flow.New(0, 0),
+		recvMonitor: flow.New(0, 0),
+		remoteAddr:  remoteAddr,
+		onError:     onError,
+		created:     time.Now(),
+	}
+
+	for _, desc := range chDescs {
+		stream, ok := streams[desc.ID]
+		if !ok {
+			continue
+		}
+		mconn := NewMConnectionWithConfig(stream, []*ChannelDescriptor{desc}, onReceive, sc.stopForError, config)
+		mconn.sendMonitor = sc.sendMonitor
+		mconn.recvMonitor = sc.recvMonitor
+		sc.conns = append(sc.conns, mconn)
+		sc.connsIdx[desc.ID] = mconn
+	}
+
+	sc.BaseService = *service.NewBaseService(nil, "StreamConnection", sc)
+	return sc
+}
+
+// SetLogger sets the logger of the connection and its streams.
+func (sc *StreamConnection) SetLogger(l log.Logger) {
+	sc.BaseService.SetLogger(l)
+	for _, mconn := range sc.conns {
+		mconn.SetLogger(l)
+	}
+}
+
+// OnStart implements BaseService
+func (sc *StreamConnection) OnStart() error {
+	for i, mconn := range sc.conns {
+		if err := mconn.Start(); err != nil {
+			for _, started := range sc.conns[:i] {
+				_ = started.Stop()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// FlushStop stops the streams like OnStop, but first flushes the messages
+// queued on every stream.
+func (sc *StreamConnection) FlushStop() {
+	for _, mconn := range sc.conns {
+		mconn.FlushStop()
+	}
+}
+
+// OnStop implements BaseService
+func (sc *StreamConnection) OnStop() {
+	for _, mconn := range sc.conns {
+		if err := mconn.Stop(); err != nil && err != service.ErrAlreadyStopped {
+			sc.Logger.Error("Error stopping stream", "conn", mconn, "err", err)
+		}
+	}
+}
+
+func (sc *StreamConnection) String() string {
+	return fmt.Sprintf("StreamConn{%v}", sc.remoteAddr)
+}
+
+// stopForError stops all the streams once one of them fails.
+func (sc *StreamConnection) stopForError(r interface{}) {
+	if err := sc.Stop(); err != nil && err != service.ErrAlreadyStopped {
+		sc.Logger.Error("Error stopping connection", "err", err)
+	}
+	if atomic.CompareAndSwapUint32(&sc.errored, 0, 1) {
+		if sc.onError != nil {
+			sc.onError(r)
+		}
+	}
+}
+
+// Send queues a message to be sent to channel.
+func (sc *StreamConnection) Send(chID byte, msgBytes []byte) bool {
+	mconn, ok := sc.connsIdx[chID]
+	if !ok {
+		sc.Logger.Error(fmt.Sprintf("Cannot send bytes, unknown channel %X", chID))
+		return false
+	}
+	return mconn.Send(chID, msgBytes)
+}
+
+// TrySend queues a message to be sent to channel, without blocking.
+func (sc *StreamConnection) TrySend(chID byte, msgBytes []byte) bool {
+	mconn, ok := sc.connsIdx[chID]
+	if !ok {
+		sc.Logger.Error(fmt.Sprintf("Cannot send bytes, unknown channel %X", chID))
+		return false
+	}
+	return mconn.TrySend(chID, msgBytes)
+}
+
+// CanSend returns true if you can send more data onto the chID, false
+// otherwise.
+func (sc *StreamConnection) CanSend(chID byte) bool {
+	mconn, ok := sc.connsIdx[chID]
+	if !ok {
+		sc.Logger.Error(fmt.Sprintf("Unknown channel %X", chID))
+		return false
+	}
+	return mconn.CanSend(chID)
+}
+
+// SetRateLimits changes the send and receive rates of the connection, in bytes
+// per second, shared by all the streams.
+func (sc *StreamConnection) SetRateLimits(sendRate, recvRate int64) {
+	for _, mconn := range sc.conns {
+		mconn.SetRateLimits(sendRate, recvRate)
+	}
+}
+
+// SetChannelRateLimits limits the send and receive rates of a single channel.
+// It returns false if the channel doesn't exist.
+func (sc *StreamConnection) SetChannelRateLimits(chID byte, sendRate, recvRate int64) bool {
+	mconn, ok := sc.connsIdx[chID]
+	if !ok {
+		return false
+	}
+	return mconn.SetChannelRateLimits(chID, sendRate, recvRate)
+}
+
+// Status returns the combined status of the streams.
+func (sc *StreamConnection) Status() ConnectionStatus {
+	status := ConnectionStatus{
+		Duration:    time.Since(sc.created),
+		SendMonitor: sc.sendMonitor.Status(),
+		RecvMonitor: sc.recvMonitor.Status(),
+		Channels:    make([]ChannelStatus, 0, len(sc.conns)),
+	}
+	for _, mconn := range sc.conns {
+		s := mconn.Status()
+		status.Channels = append(status.Channels, s.Channels...)
+		if s.Latency > status.Latency {
+			status.Latency = s.Latency
+		}
+	}
+	return status
+}
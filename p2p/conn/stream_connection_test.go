@@ -0,0 +1,67 @@
+package conn
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arcology-network/consensus-engine/libs/log"
+)
+
+func TestStreamConnectionSharesConnectionRate(t *testing.T) {
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 1, SendQueueCapacity: 10},
+		{ID: 0x02, Priority: 1, SendQueueCapacity: 10},
+	}
+	clientStreams := make(map[byte]net.Conn)
+	serverStreams := make(map[byte]net.Conn)
+	for _, desc := range chDescs {
+		server, client := NetPipe()
+		defer server.Close()
+		defer client.Close()
+		serverStreams[desc.ID] = server
+		clientStreams[desc.ID] = client
+	}
+
+	receivedCh := make(chan byte, 10)
+	onReceive := func(chID byte, msgBytes []byte) {
+		receivedCh <- chID
+	}
+	onError := func(r interface{}) {}
+
+	scClient := NewStreamConnection(nil, clientStreams, chDescs, onReceive, onError, DefaultMConnConfig())
+	scClient.SetLogger(log.TestingLogger().With("module", "client"))
+	scServer := NewStreamConnection(nil, serverStreams, chDescs, onReceive, onError, DefaultMConnConfig())
+	scServer.SetLogger(log.TestingLogger().With("module", "server"))
+
+	// the streams are throttled together
+	for _, mconn := range scClient.conns {
+		assert.Same(t, scClient.sendMonitor, mconn.sendMonitor)
+		assert.Same(t, scClient.recvMonitor, mconn.recvMonitor)
+	}
+
+	require.NoError(t, scClient.Start())
+	defer scClient.Stop() // nolint:errcheck // ignore for tests
+	require.NoError(t, scServer.Start())
+	defer scServer.Stop() // nolint:errcheck // ignore for tests
+
+	require.True(t, scClient.Send(0x01, []byte("block")))
+	require.True(t, scClient.Send(0x02, []byte("vote")))
+	for i := 0; i < 2; i++ {
+		select {
+		case <-receivedCh:
+		case <-time.After(3 * time.Second):
+			t.Fatal("timed out waiting for msgs")
+		}
+	}
+
+	// the status counts the bytes of every stream once
+	assert.Len(t, scClient.Status().Channels, 2)
+	assert.Eventually(t, func() bool {
+		sent := scClient.Status().SendMonitor.Bytes
+		return sent > 0 && sent == scServer.Status().RecvMonitor.Bytes
+	}, time.Second, 10*time.Millisecond)
+}
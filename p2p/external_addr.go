@@ -0,0 +1,119 @@
+package p2p
+
+import (
+	"net"
+
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
+)
+
+const (
+	// defaultExternalAddrQuorum is the number of peers which have to observe
+	// our connections from the same IP for it to be taken as our external IP.
+	defaultExternalAddrQuorum = 3
+
+	// externalAddrWindow is the number of most recent observations, each
+	// from a distinct peer, the external IP is picked from.
+	externalAddrWindow = 10
+)
+
+type addrObservation struct {
+	peer ID
+	ip   string
+}
+
+// ExternalAddrDiscovery learns the external IP of a node behind a NAT from
+// the addresses its peers observe its connections from, which they report in
+// the handshake. An IP is taken once a quorum of the most recent peers
+// observed it, and more of them did than any other IP, so a single peer
+// can't make the node advertise a wrong address.
+type ExternalAddrDiscovery struct {
+	mtx               tmsync.Mutex
+	quorum            int
+	routabilityStrict bool
+	observations      []addrObservation // oldest first
+	ip                net.IP            // nil until discovered
+	onDiscovered      func(net.IP)
+}
+
+// NewExternalAddrDiscovery returns a new ExternalAddrDiscovery. If
+// routabilityStrict is true, private and local IPs are ignored.
+func NewExternalAddrDiscovery(routabilityStrict bool) *ExternalAddrDiscovery {
+	return &ExternalAddrDiscovery{
+		quorum:            defaultExternalAddrQuorum,
+		routabilityStrict: routabilityStrict,
+	}
+}
+
+// SetOnDiscovered sets the function called with the external IP when it is
+// discovered, and every time it changes.
+func (d *ExternalAddrDiscovery) SetOnDiscovered(fn func(net.IP)) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.onDiscovered = fn
+}
+
+// IP returns the discovered external IP, or nil if there is none yet.
+func (d *ExternalAddrDiscovery) IP() net.IP {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.ip
+}
+
+// Observe records that the peer id observed our connection from addr, a
+// host:port. Only the most recent observation of every peer counts.
+func (d *ExternalAddrDiscovery) Observe(id ID, addr string) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return
+	}
+	// the ID only has to be valid for the checks
+	na := NewNetAddressIPPort(ip, 0)
+	na.ID = id
+	if na.Valid() != nil || (d.routabilityStrict && !na.Routable()) {
+		return
+	}
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	for i, o := range d.observations {
+		if o.peer == id {
+			d.observations = append(d.observations[:i], d.observations[i+1:]...)
+			break
+		}
+	}
+	d.observations = append(d.observations, addrObservation{peer: id, ip: ip.String()})
+	if len(d.observations) > externalAddrWindow {
+		d.observations = d.observations[len(d.observations)-externalAddrWindow:]
+	}
+
+	counts := make(map[string]int)
+	for _, o := range d.observations {
+		counts[o.ip]++
+	}
+	var (
+		best     string
+		bestN    int
+		bestTied bool
+	)
+	for ip, n := range counts {
+		switch {
+		case n > bestN:
+			best, bestN, bestTied = ip, n, false
+		case n == bestN:
+			bestTied = true
+		}
+	}
+	if bestN < d.quorum || bestTied || (d.ip != nil && d.ip.String() == best) {
+		return
+	}
+
+	d.ip = net.ParseIP(best)
+	if d.onDiscovered != nil {
+		d.onDiscovered(d.ip)
+	}
+}
@@ -0,0 +1,64 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/arcology-network/consensus-engine/crypto/ed25519"
+)
+
+func TestExternalAddrDiscovery(t *testing.T) {
+	d := NewExternalAddrDiscovery(true)
+
+	var discovered []net.IP
+	d.SetOnDiscovered(func(ip net.IP) { discovered = append(discovered, ip) })
+
+	ids := make([]ID, externalAddrWindow+1)
+	for i := range ids {
+		ids[i] = PubKeyToID(ed25519.GenPrivKey().PubKey())
+	}
+	observe := func(id ID, ip string) {
+		d.Observe(id, fmt.Sprintf("%v:%d", ip, 26656+len(discovered)))
+	}
+
+	// private IPs and garbage are ignored
+	observe(ids[0], "192.168.1.2")
+	observe(ids[1], "127.0.0.1")
+	d.Observe(ids[2], "not an address")
+	assert.Nil(t, d.IP())
+
+	// a peer has a single vote
+	for i := 0; i < defaultExternalAddrQuorum; i++ {
+		observe(ids[0], "1.2.3.4")
+	}
+	assert.Nil(t, d.IP())
+
+	observe(ids[1], "1.2.3.4")
+	observe(ids[2], "5.6.7.8")
+	assert.Nil(t, d.IP())
+	observe(ids[3], "1.2.3.4")
+	assert.Equal(t, "1.2.3.4", d.IP().String())
+
+	// a tie keeps the current IP
+	observe(ids[4], "5.6.7.8")
+	observe(ids[5], "5.6.7.8")
+	assert.Equal(t, "1.2.3.4", d.IP().String())
+
+	// the IP changes once more peers observe another one
+	observe(ids[6], "5.6.7.8")
+	assert.Equal(t, "5.6.7.8", d.IP().String())
+
+	// only the most recent observations are kept
+	for _, id := range ids[7:] {
+		observe(id, "5.6.7.8")
+	}
+	assert.Len(t, d.observations, externalAddrWindow)
+
+	if assert.Len(t, discovered, 2) {
+		assert.Equal(t, "1.2.3.4", discovered[0].String())
+		assert.Equal(t, "5.6.7.8", discovered[1].String())
+	}
+}
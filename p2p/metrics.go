@@ -23,6 +23,12 @@ type Metrics struct {
 	PeerSendBytesTotal metrics.Counter
 	// Pending bytes to be sent to a given peer.
 	PeerPendingSendBytes metrics.Gauge
+	// Round-trip time of the last ping to a given peer.
+	PeerLatency metrics.Gauge
+	// Number of messages received, by channel and message type.
+	MessageReceiveTotal metrics.Counter
+	// Time messages spent in the send queue of their channel, by channel.
+	ChannelSendQueueLatency metrics.Histogram
 	// Number of transactions submitted by each peer.
 	NumTxs metrics.Gauge
 }
@@ -60,6 +66,25 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "peer_pending_send_bytes",
 			Help:      "Number of pending bytes to be sent to a given peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerLatency: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_latency_seconds",
+			Help:      "Round-trip time of the last ping to a given peer.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+		MessageReceiveTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "message_receive_total",
+			Help:      "Number of messages received, by channel and message type.",
+		}, append(labels, "chID", "message_type")).With(labelsAndValues...),
+		ChannelSendQueueLatency: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "channel_send_queue_latency_seconds",
+			Help:      "Time messages spent in the send queue of their channel, by channel.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.0001, 4, 9),
+		}, append(labels, "chID")).With(labelsAndValues...),
 		NumTxs: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -72,10 +97,13 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 // NopMetrics returns no-op Metrics.
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Peers:                 discard.NewGauge(),
-		PeerReceiveBytesTotal: discard.NewCounter(),
-		PeerSendBytesTotal:    discard.NewCounter(),
-		PeerPendingSendBytes:  discard.NewGauge(),
-		NumTxs:                discard.NewGauge(),
+		Peers:                   discard.NewGauge(),
+		PeerReceiveBytesTotal:   discard.NewCounter(),
+		PeerSendBytesTotal:      discard.NewCounter(),
+		PeerPendingSendBytes:    discard.NewGauge(),
+		PeerLatency:             discard.NewGauge(),
+		MessageReceiveTotal:     discard.NewCounter(),
+		ChannelSendQueueLatency: discard.NewHistogram(),
+		NumTxs:                  discard.NewGauge(),
 	}
 }
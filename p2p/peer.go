@@ -49,6 +49,9 @@ type peerConn struct {
 	persistent bool
 	conn       net.Conn // source connection
 
+	// streams per channel, for transports with native streams
+	streams map[byte]net.Conn
+
 	socketAddr *NetAddress
 
 	// cached RemoteIP()
@@ -96,6 +99,22 @@ func (pc peerConn) RemoteIP() net.IP {
 	return pc.ip
 }
 
+// mConnection is the connection of a peer, either a single MConnection
+// multiplexing all the channels or a StreamConnection with a stream per
+// channel.
+type mConnection interface {
+	service.Service
+	FlushStop()
+
+	Send(byte, []byte) bool
+	TrySend(byte, []byte) bool
+	CanSend(byte) bool
+
+	Status() tmconn.ConnectionStatus
+	SetRateLimits(sendRate, recvRate int64)
+	SetChannelRateLimits(chID byte, sendRate, recvRate int64) bool
+}
+
 type msg struct {
 	channel byte
 	bytes   []byte
@@ -109,7 +128,7 @@ type peer struct {
 
 	// raw peerConn and the multiplex connection
 	peerConn
-	mconn *tmconn.MConnection
+	mconn mConnection
 
 	// peer's node info and the channel it knows about
 	// channels = nodeInfo.Channels
@@ -150,7 +169,7 @@ func newPeer(
 	}
 
 	p.mconn = createMConnection(
-		pc.conn,
+		pc,
 		p,
 		reactorsByCh,
 		chDescs,
@@ -205,11 +224,6 @@ func (p *peer) OnStart() error {
 				// which does onPeerError.
 				panic(fmt.Sprintf("Unknown channel %X", chID))
 			}
-			labels := []string{
-				"peer_id", string(p.ID()),
-				"chID", fmt.Sprintf("%#x", chID),
-			}
-			p.metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(len(msgBytes)))
 			reactor.Receive(chID, p, msgBytes)
 		}
 	}()
@@ -272,6 +286,17 @@ func (p *peer) Status() tmconn.ConnectionStatus {
 	return p.mconn.Status()
 }
 
+// SetRateLimits changes the send and receive rates of the peer connection.
+func (p *peer) SetRateLimits(sendRate, recvRate int64) {
+	p.mconn.SetRateLimits(sendRate, recvRate)
+}
+
+// SetChannelRateLimits limits the send and receive rates of a single channel
+// of the peer connection.
+func (p *peer) SetChannelRateLimits(chID byte, sendRate, recvRate int64) bool {
+	return p.mconn.SetChannelRateLimits(chID, sendRate, recvRate)
+}
+
 // Send msg bytes to the channel identified by chID byte. Returns false if the
 // send queue is full after timeout, specified by MConnection.
 func (p *peer) Send(chID byte, msgBytes []byte) bool {
@@ -388,24 +413,34 @@ func (p *peer) metricsReporter() {
 			}
 
 			p.metrics.PeerPendingSendBytes.With("peer_id", string(p.ID())).Set(sendQueueSize)
+			if status.Latency > 0 {
+				p.metrics.PeerLatency.With("peer_id", string(p.ID())).Set(status.Latency.Seconds())
+			}
 		case <-p.Quit():
 			return
 		}
 	}
 }
 
+// recordReceive updates the metrics of a message received on chID.
+func (p *peer) recordReceive(chID byte, msgType string, size int) {
+	chIDLabel := fmt.Sprintf("%#x", chID)
+	p.metrics.PeerReceiveBytesTotal.With("peer_id", string(p.ID()), "chID", chIDLabel).Add(float64(size))
+	p.metrics.MessageReceiveTotal.With("chID", chIDLabel, "message_type", msgType).Add(1)
+}
+
 //------------------------------------------------------------------
 // helper funcs
 
 func createMConnection(
-	conn net.Conn,
+	pc peerConn,
 	p *peer,
 	reactorsByCh map[byte]Reactor,
 	chDescs []*tmconn.ChannelDescriptor,
 	onPeerError func(Peer, interface{}),
 	config tmconn.MConnConfig,
 	msgChan chan msg,
-) *tmconn.MConnection {
+) mConnection {
 
 	onReceive := func(chID byte, msgBytes []byte) {
 		if chID == byte(0x30) {
@@ -415,11 +450,6 @@ func createMConnection(
 				// which does onPeerError.
 				panic(fmt.Sprintf("Unknown channel %X", chID))
 			}
-			labels := []string{
-				"peer_id", string(p.ID()),
-				"chID", fmt.Sprintf("%#x", chID),
-			}
-			p.metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(len(msgBytes)))
 			reactor.Receive(chID, p, msgBytes)
 		} else {
 			bytesCopy := make([]byte, len(msgBytes))
@@ -435,8 +465,24 @@ func createMConnection(
 		onPeerError(p, r)
 	}
 
+	config.OnSent = func(chID byte, queued time.Duration) {
+		p.metrics.ChannelSendQueueLatency.With("chID", fmt.Sprintf("%#x", chID)).Observe(queued.Seconds())
+	}
+	config.OnReceived = p.recordReceive
+
+	if pc.streams != nil {
+		return tmconn.NewStreamConnection(
+			pc.conn.RemoteAddr(),
+			pc.streams,
+			chDescs,
+			onReceive,
+			onError,
+			config,
+		)
+	}
+
 	return tmconn.NewMConnectionWithConfig(
-		conn,
+		pc.conn,
 		chDescs,
 		onReceive,
 		onError,
@@ -0,0 +1,142 @@
+package p2p
+
+import (
+	"time"
+
+	"github.com/arcology-network/consensus-engine/libs/service"
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
+	"github.com/arcology-network/consensus-engine/p2p/trust"
+)
+
+// banAfterStrikes is the number of bad events a peer may cause while its
+// trust score is below the ban threshold before it gets banned.
+const banAfterStrikes = 3
+
+// lowScoreBanReason is the reason recorded for the bans decided by the
+// PeerScorer.
+const lowScoreBanReason = "trust score below the ban threshold"
+
+// PeerScorer keeps a trust score for every peer, based on the good and bad
+// behaviour reported by the reactors, and bans peers which keep misbehaving.
+//
+// Scores are backed by a trust.MetricStore and bans by a BanStore, so both
+// survive restarts. The BanStore is shared with the bans set through the RPC.
+type PeerScorer struct {
+	service.BaseService
+
+	store        *trust.MetricStore
+	bans         *BanStore
+	banThreshold int
+	banDuration  time.Duration
+
+	mtx     tmsync.Mutex
+	strikes map[ID]int
+}
+
+// NewPeerScorer returns a PeerScorer that keeps the scores in store and the
+// bans in bans. Peers whose score stays below banThreshold are banned for
+// banDuration.
+func NewPeerScorer(
+	store *trust.MetricStore,
+	bans *BanStore,
+	banThreshold int,
+	banDuration time.Duration,
+) *PeerScorer {
+	ps := &PeerScorer{
+		store:        store,
+		bans:         bans,
+		banThreshold: banThreshold,
+		banDuration:  banDuration,
+		strikes:      make(map[ID]int),
+	}
+	ps.BaseService = *service.NewBaseService(nil, "PeerScorer", ps)
+	return ps
+}
+
+// OnStart implements service.Service by starting the metric store.
+func (ps *PeerScorer) OnStart() error {
+	ps.store.SetLogger(ps.Logger)
+	return ps.store.Start()
+}
+
+// OnStop implements service.Service by stopping the metric store, which saves
+// the trust history.
+func (ps *PeerScorer) OnStop() {
+	if err := ps.store.Stop(); err != nil {
+		ps.Logger.Error("Error stopping trust metric store", "err", err)
+	}
+}
+
+// Score returns the trust score of the peer, between 0 and 100. Peers we
+// know nothing about have a perfect score.
+func (ps *PeerScorer) Score(id ID) int {
+	if !ps.store.HasPeerTrustMetric(string(id)) {
+		return 100
+	}
+	return ps.store.GetPeerTrustMetric(string(id)).TrustScore()
+}
+
+// MarkGood records a good event for the peer. Once the score of the peer is
+// back above the ban threshold, its previous strikes are forgotten.
+func (ps *PeerScorer) MarkGood(id ID) {
+	tm := ps.store.GetPeerTrustMetric(string(id))
+	tm.GoodEvents(1)
+
+	if tm.TrustScore() >= ps.banThreshold {
+		ps.mtx.Lock()
+		delete(ps.strikes, id)
+		ps.mtx.Unlock()
+	}
+}
+
+// MarkBad records a bad event for the peer. It returns true if the peer
+// should be banned as a result, which is left to the caller, so peers exempt
+// from bans are never written to the ban store.
+func (ps *PeerScorer) MarkBad(id ID) (ban bool) {
+	tm := ps.store.GetPeerTrustMetric(string(id))
+	tm.BadEvents(1)
+
+	if tm.TrustScore() >= ps.banThreshold {
+		return false
+	}
+
+	ps.mtx.Lock()
+	ps.strikes[id]++
+	if ps.strikes[id] < banAfterStrikes {
+		ps.mtx.Unlock()
+		return false
+	}
+	delete(ps.strikes, id)
+	ps.mtx.Unlock()
+
+	return true
+}
+
+// Ban bans the peer for the configured ban duration.
+func (ps *PeerScorer) Ban(id ID) {
+	if _, err := ps.bans.Ban(string(id), lowScoreBanReason, ps.banDuration); err != nil {
+		ps.Logger.Error("Failed to ban peer", "peer", id, "err", err)
+	}
+}
+
+// IsBanned returns true if the peer is banned, either by the PeerScorer or
+// through the RPC.
+func (ps *PeerScorer) IsBanned(id ID) bool {
+	return ps.bans.IsIDBanned(id)
+}
+
+// IsAddrBanned returns true if the node ID or the IP address of addr is
+// banned.
+func (ps *PeerScorer) IsAddrBanned(addr *NetAddress) bool {
+	return ps.bans.IsAddrBanned(addr)
+}
+
+// BanDuration returns how long a banned peer stays banned.
+func (ps *PeerScorer) BanDuration() time.Duration {
+	return ps.banDuration
+}
+
+// PeerDisconnected pauses the trust metric of the peer.
+func (ps *PeerScorer) PeerDisconnected(id ID) {
+	ps.store.PeerDisconnected(string(id))
+}
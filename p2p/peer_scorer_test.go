@@ -0,0 +1,121 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/arcology-network/consensus-engine/crypto/ed25519"
+	"github.com/arcology-network/consensus-engine/libs/log"
+	"github.com/arcology-network/consensus-engine/p2p/trust"
+)
+
+func newTestPeerScorer(t *testing.T, banDuration time.Duration) *PeerScorer {
+	bs, err := NewBanStore(dbm.NewMemDB())
+	require.NoError(t, err)
+	return newTestPeerScorerWithBans(t, bs, banDuration)
+}
+
+func newTestPeerScorerWithBans(t *testing.T, bs *BanStore, banDuration time.Duration) *PeerScorer {
+	ps := NewPeerScorer(trust.NewTrustMetricStore(dbm.NewMemDB(), trust.DefaultConfig()), bs, 20, banDuration)
+	ps.SetLogger(log.TestingLogger())
+	require.NoError(t, ps.Start())
+	t.Cleanup(func() {
+		if err := ps.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	return ps
+}
+
+func TestPeerScorerBansAfterStrikes(t *testing.T) {
+	ps := newTestPeerScorer(t, time.Hour)
+	id := PubKeyToID(ed25519.GenPrivKey().PubKey())
+
+	assert.Equal(t, 100, ps.Score(id))
+
+	for i := 0; i < banAfterStrikes-1; i++ {
+		assert.False(t, ps.MarkBad(id))
+		assert.False(t, ps.IsBanned(id))
+	}
+	assert.Less(t, ps.Score(id), 20)
+
+	// the ban is left to the caller
+	assert.True(t, ps.MarkBad(id))
+	assert.False(t, ps.IsBanned(id))
+	ps.Ban(id)
+	assert.True(t, ps.IsBanned(id))
+	assert.False(t, ps.IsBanned(PubKeyToID(ed25519.GenPrivKey().PubKey())))
+}
+
+func TestPeerScorerBanExpires(t *testing.T) {
+	ps := newTestPeerScorer(t, 10*time.Millisecond)
+	id := PubKeyToID(ed25519.GenPrivKey().PubKey())
+
+	ps.Ban(id)
+	assert.True(t, ps.IsBanned(id))
+
+	time.Sleep(20 * time.Millisecond)
+	assert.False(t, ps.IsBanned(id))
+}
+
+func TestPeerScorerGoodEventsResetStrikes(t *testing.T) {
+	ps := newTestPeerScorer(t, time.Hour)
+	id := PubKeyToID(ed25519.GenPrivKey().PubKey())
+
+	for i := 0; i < banAfterStrikes-1; i++ {
+		assert.False(t, ps.MarkBad(id))
+	}
+
+	// recover the score
+	for i := 0; i < 100 && ps.Score(id) < 20; i++ {
+		ps.MarkGood(id)
+	}
+	require.GreaterOrEqual(t, ps.Score(id), 20)
+
+	// the previous strikes are forgotten
+	for i := 0; i < banAfterStrikes-1; i++ {
+		assert.False(t, ps.MarkBad(id))
+	}
+	assert.False(t, ps.IsBanned(id))
+}
+
+func TestPeerScorerSharesBanStore(t *testing.T) {
+	db := dbm.NewMemDB()
+	bs, err := NewBanStore(db)
+	require.NoError(t, err)
+	ps := newTestPeerScorerWithBans(t, bs, time.Hour)
+	id := PubKeyToID(ed25519.GenPrivKey().PubKey())
+
+	for i := 0; i < banAfterStrikes; i++ {
+		if ps.MarkBad(id) {
+			ps.Ban(id)
+		}
+	}
+	require.True(t, ps.IsBanned(id))
+
+	// automatic bans are listed with the others and survive a restart
+	bans := bs.List()
+	require.Len(t, bans, 1)
+	assert.Equal(t, id, bans[0].ID)
+	assert.Equal(t, lowScoreBanReason, bans[0].Reason)
+
+	restarted, err := NewBanStore(db)
+	require.NoError(t, err)
+	assert.True(t, restarted.IsIDBanned(id))
+
+	// and can be lifted
+	ok, err := bs.Unban(string(id))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.False(t, ps.IsBanned(id))
+
+	// bans set elsewhere are seen by the scorer
+	other := PubKeyToID(ed25519.GenPrivKey().PubKey())
+	_, err = bs.Ban(string(other), "manual", 0)
+	require.NoError(t, err)
+	assert.True(t, ps.IsBanned(other))
+}
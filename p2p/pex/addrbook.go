@@ -11,10 +11,12 @@ import (
 	"math"
 	"math/rand"
 	"net"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/minio/highwayhash"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/arcology-network/consensus-engine/crypto"
 	tmmath "github.com/arcology-network/consensus-engine/libs/math"
@@ -66,6 +68,10 @@ type AddrBook interface {
 	// Add bad peers back to addrBook
 	ReinstateBadPeers()
 
+	// Record what was learned from a connection to a peer
+	MarkConnected(p2p.ID)
+	SetMetadata(p2p.ID, PeerMetadata)
+
 	IsGood(*p2p.NetAddress) bool
 	IsBanned(*p2p.NetAddress) bool
 
@@ -76,8 +82,14 @@ type AddrBook interface {
 
 	Size() int
 
+	// List the addresses in the book
+	Addresses() []AddrInfo
+
 	// Persist to disk
 	Save()
+
+	// Drop the addresses banned in the given BanStore
+	SetBanStore(*p2p.BanStore)
 }
 
 var _ AddrBook = (*addrBook)(nil)
@@ -94,13 +106,15 @@ type addrBook struct {
 	privateIDs map[p2p.ID]struct{}
 	addrLookup map[p2p.ID]*knownAddress // new & old
 	badPeers   map[p2p.ID]*knownAddress // blacklisted peers
+	bans       *p2p.BanStore            // nil if there is no ban list
 	bucketsOld []map[string]*knownAddress
 	bucketsNew []map[string]*knownAddress
 	nOld       int
 	nNew       int
 
 	// immutable after creation
-	filePath          string
+	filePath          string // JSON file, or file imported into db if db is set
+	db                dbm.DB
 	key               string // random prefix for bucket placement
 	routabilityStrict bool
 	hashKey           []byte
@@ -132,6 +146,25 @@ func NewAddrBook(filePath string, routabilityStrict bool) AddrBook {
 	return am
 }
 
+// NewDBAddrBook creates a new address book persisted in db. If db holds no
+// address book when it starts, the JSON address book at importFilePath is
+// imported, if there is one.
+// Use Start to begin processing asynchronous address updates.
+func NewDBAddrBook(db dbm.DB, importFilePath string, routabilityStrict bool) AddrBook {
+	am := NewAddrBook(importFilePath, routabilityStrict).(*addrBook)
+	am.db = db
+	return am
+}
+
+// SetBanStore implements AddrBook. Addresses banned in bs are refused and
+// dropped from the book instead of being dialed.
+func (a *addrBook) SetBanStore(bs *p2p.BanStore) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	a.bans = bs
+}
+
 // Initialize the buckets.
 // When modifying this, don't forget to update loadFromFile()
 func (a *addrBook) init() {
@@ -153,7 +186,18 @@ func (a *addrBook) OnStart() error {
 	if err := a.BaseService.OnStart(); err != nil {
 		return err
 	}
-	a.loadFromFile(a.filePath)
+	if a.db == nil {
+		a.loadFromFile(a.filePath)
+	} else {
+		loaded, err := a.loadFromDB()
+		if err != nil {
+			return fmt.Errorf("failed to load address book: %w", err)
+		}
+		if !loaded && a.loadFromFile(a.filePath) {
+			a.Logger.Info("Imported AddrBook from file", "file", a.filePath, "size", a.Size())
+			a.saveToDB()
+		}
+	}
 
 	// wg.Add to ensure that any invocation of .Wait()
 	// later on will wait for saveRoutine to terminate.
@@ -309,6 +353,10 @@ func (a *addrBook) PickAddress(biasTowardsNewAddrs int) *p2p.NetAddress {
 	randIndex := a.rand.Intn(len(bucket))
 	for _, ka := range bucket {
 		if randIndex == 0 {
+			if a.isBanned(ka.Addr) {
+				a.removeAddress(ka.Addr)
+				return nil
+			}
 			return ka.Addr
 		}
 		randIndex--
@@ -334,6 +382,32 @@ func (a *addrBook) MarkGood(id p2p.ID) {
 	}
 }
 
+// MarkConnected implements AddrBook - it records that a connection to the
+// peer was established.
+func (a *addrBook) MarkConnected(id p2p.ID) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	ka := a.addrLookup[id]
+	if ka == nil {
+		return
+	}
+	ka.markConnected()
+}
+
+// SetMetadata implements AddrBook - it updates the metadata of the peer's
+// address. A zero latency or empty version leaves the previous value.
+func (a *addrBook) SetMetadata(id p2p.ID, md PeerMetadata) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	ka := a.addrLookup[id]
+	if ka == nil {
+		return
+	}
+	ka.setMetadata(md)
+}
+
 // MarkAttempt implements AddrBook - it marks that an attempt was made to connect to the address.
 func (a *addrBook) MarkAttempt(addr *p2p.NetAddress) {
 	a.mtx.Lock()
@@ -483,11 +557,30 @@ func (a *addrBook) size() int {
 	return a.nNew + a.nOld
 }
 
+// Addresses implements AddrBook. It returns the addresses in the book
+// sorted by ID.
+func (a *addrBook) Addresses() []AddrInfo {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	infos := make([]AddrInfo, 0, len(a.addrLookup))
+	for _, ka := range a.addrLookup {
+		infos = append(infos, ka.info())
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Addr.ID < infos[j].Addr.ID })
+	return infos
+}
+
 //----------------------------------------------------------
 
 // Save persists the address book to disk.
 func (a *addrBook) Save() {
-	a.saveToFile(a.filePath) // thread safe
+	// thread safe
+	if a.db != nil {
+		a.saveToDB()
+	} else {
+		a.saveToFile(a.filePath)
+	}
 }
 
 func (a *addrBook) saveRoutine() {
@@ -498,13 +591,13 @@ out:
 	for {
 		select {
 		case <-saveFileTicker.C:
-			a.saveToFile(a.filePath)
+			a.Save()
 		case <-a.Quit():
 			break out
 		}
 	}
 	saveFileTicker.Stop()
-	a.saveToFile(a.filePath)
+	a.Save()
 }
 
 //----------------------------------------------------------
@@ -650,6 +743,10 @@ func (a *addrBook) addAddress(addr, src *p2p.NetAddress) error {
 		return ErrAddressBanned{addr}
 	}
 
+	if a.isBanned(addr) {
+		return ErrAddressBanned{addr}
+	}
+
 	if _, ok := a.privateIDs[addr.ID]; ok {
 		return ErrAddrBookPrivate{addr}
 	}
@@ -805,6 +902,11 @@ func (a *addrBook) removeAddress(addr *p2p.NetAddress) {
 	a.removeFromAllBuckets(ka)
 }
 
+// isBanned returns true if addr is banned in the ban list.
+func (a *addrBook) isBanned(addr *p2p.NetAddress) bool {
+	return a.bans != nil && a.bans.IsAddrBanned(addr)
+}
+
 func (a *addrBook) addBadPeer(addr *p2p.NetAddress, banTime time.Duration) bool {
 	// check it exists in addrbook
 	ka := a.addrLookup[addr.ID]
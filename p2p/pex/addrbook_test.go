@@ -12,6 +12,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/arcology-network/consensus-engine/libs/log"
 	tmmath "github.com/arcology-network/consensus-engine/libs/math"
@@ -93,6 +94,87 @@ func TestAddrBookSaveLoad(t *testing.T) {
 	assert.Equal(t, 100, book.Size())
 }
 
+func TestAddrBookSaveLoadDB(t *testing.T) {
+	db := dbm.NewMemDB()
+
+	book := NewDBAddrBook(db, "", true)
+	book.SetLogger(log.TestingLogger())
+	require.NoError(t, book.Start())
+	assert.True(t, book.Empty())
+
+	randAddrs := randNetAddressPairs(t, 100)
+	for _, addrSrc := range randAddrs {
+		require.NoError(t, book.AddAddress(addrSrc.addr, addrSrc.src))
+	}
+	id := randAddrs[0].addr.ID
+	book.MarkGood(id)
+	book.MarkConnected(id)
+	book.SetMetadata(id, PeerMetadata{
+		Latency:     15 * time.Millisecond,
+		NodeVersion: "0.34.0",
+		ChainID:     "test-chain",
+		Score:       42,
+	})
+	book.Save()
+
+	// removed addresses are deleted from the DB on the next save
+	book.RemoveAddress(randAddrs[1].addr)
+	book.Save()
+	require.NoError(t, book.Stop())
+
+	book = NewDBAddrBook(db, "", true)
+	book.SetLogger(log.TestingLogger())
+	require.NoError(t, book.Start())
+	defer book.Stop() // nolint:errcheck // ignore for tests
+
+	assert.Equal(t, 99, book.Size())
+	assert.False(t, book.HasAddress(randAddrs[1].addr))
+	assert.True(t, book.IsGood(randAddrs[0].addr))
+
+	infos := book.Addresses()
+	require.Len(t, infos, 99)
+	for _, info := range infos {
+		if info.Addr.ID != id {
+			continue
+		}
+		assert.Equal(t, "old", info.Bucket)
+		assert.False(t, info.LastConnected.IsZero())
+		assert.Equal(t, 15*time.Millisecond, info.Latency)
+		assert.Equal(t, "0.34.0", info.NodeVersion)
+		assert.Equal(t, "test-chain", info.ChainID)
+		assert.Equal(t, 42, info.Score)
+	}
+}
+
+func TestAddrBookImportIntoDB(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	for _, addrSrc := range randNetAddressPairs(t, 50) {
+		require.NoError(t, book.AddAddress(addrSrc.addr, addrSrc.src))
+	}
+	book.Save()
+
+	db := dbm.NewMemDB()
+	book = NewDBAddrBook(db, fname, true)
+	book.SetLogger(log.TestingLogger())
+	require.NoError(t, book.Start())
+	assert.Equal(t, 50, book.Size())
+	require.NoError(t, book.Stop())
+
+	// the file is only imported into an empty DB
+	empty := NewAddrBook(fname, true)
+	empty.SetLogger(log.TestingLogger())
+	empty.Save()
+	book = NewDBAddrBook(db, fname, true)
+	book.SetLogger(log.TestingLogger())
+	require.NoError(t, book.Start())
+	defer book.Stop() // nolint:errcheck // ignore for tests
+	assert.Equal(t, 50, book.Size())
+}
+
 func TestAddrBookLookup(t *testing.T) {
 	fname := createTempFileName("addrbook_test")
 	defer deleteTempFile(fname)
@@ -440,6 +522,39 @@ func TestBanBadPeers(t *testing.T) {
 	assert.False(t, book.IsGood(addr))
 }
 
+func TestAddrBookDropsBannedAddresses(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	bans, err := p2p.NewBanStore(dbm.NewMemDB())
+	require.NoError(t, err)
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	book.SetBanStore(bans)
+
+	addr := randIPv4Address(t)
+	require.NoError(t, book.AddAddress(addr, addr))
+
+	// banned addresses are dropped instead of being picked
+	_, err = bans.Ban(string(addr.ID), "", 0)
+	require.NoError(t, err)
+	assert.Nil(t, book.PickAddress(50))
+	assert.False(t, book.HasAddress(addr))
+
+	// and can't be added back, whether banned by ID or by IP
+	assert.Error(t, book.AddAddress(addr, addr))
+
+	other := randIPv4Address(t)
+	_, err = bans.Ban(other.IP.String(), "", 0)
+	require.NoError(t, err)
+	assert.Error(t, book.AddAddress(other, other))
+
+	_, err = bans.Unban(string(addr.ID))
+	require.NoError(t, err)
+	assert.NoError(t, book.AddAddress(addr, addr))
+}
+
 func TestAddrBookEmpty(t *testing.T) {
 	fname := createTempFileName("addrbook_test")
 	defer deleteTempFile(fname)
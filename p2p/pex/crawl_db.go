@@ -0,0 +1,324 @@
+package pex
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
+
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
+	"github.com/arcology-network/consensus-engine/p2p"
+)
+
+const (
+	// crawlRecordExpiry is how long a node stays in the crawl DB after it was
+	// last seen or dialed, or after we first heard of it if it was never
+	// dialed.
+	crawlRecordExpiry = 7 * 24 * time.Hour
+
+	// maxCrawlRecords is the maximum number of nodes in the crawl DB. Beyond
+	// it, the nodes only heard of are not recorded and the least recently
+	// active nodes are pruned.
+	maxCrawlRecords = 10000
+
+	// unknownVersion is the version of the nodes never connected to.
+	unknownVersion = "unknown"
+)
+
+var crawlRecordKeyPrefix = []byte("node:")
+
+func crawlRecordKey(id p2p.ID) []byte {
+	return append(append([]byte{}, crawlRecordKeyPrefix...), id...)
+}
+
+// CrawlRecord is what a seed node learned about a node while crawling the
+// network.
+type CrawlRecord struct {
+	Addr        *p2p.NetAddress `json:"addr"`
+	Moniker     string          `json:"moniker,omitempty"`
+	Version     string          `json:"version,omitempty"`
+	ChainID     string          `json:"chain_id,omitempty"`
+	Reachable   bool            `json:"reachable"`    // the last dial succeeded
+	LastAttempt time.Time       `json:"last_attempt"` // zero if never dialed
+	LastSeen    time.Time       `json:"last_seen"`    // zero if never connected
+	FirstHeard  time.Time       `json:"first_heard"`  // when the record was created
+	Peers       []p2p.ID        `json:"peers"`        // the nodes it told us about
+}
+
+// lastActive returns when the node was last seen or dialed, or when we first
+// heard of it if it was never dialed.
+func (rec *CrawlRecord) lastActive() time.Time {
+	if rec.heardOnly() {
+		return rec.FirstHeard
+	}
+	if rec.LastSeen.After(rec.LastAttempt) {
+		return rec.LastSeen
+	}
+	return rec.LastAttempt
+}
+
+// heardOnly returns true if the node was never dialed nor connected to.
+func (rec *CrawlRecord) heardOnly() bool {
+	return rec.LastAttempt.IsZero() && rec.LastSeen.IsZero()
+}
+
+// CrawlStats are statistics of the crawled network.
+type CrawlStats struct {
+	Nodes     int            `json:"nodes"`
+	Reachable int            `json:"reachable"`
+	Versions  map[string]int `json:"versions"`  // number of nodes by version
+	ChainIDs  map[string]int `json:"chain_ids"` // number of nodes by chain ID
+}
+
+// CrawlDB keeps the nodes a seed node finds while crawling the network in a
+// DB, so its view of the network survives restarts. Nodes not active for a
+// week are pruned, and the DB holds at most maxCrawlRecords nodes.
+type CrawlDB struct {
+	mtx   tmsync.RWMutex
+	db    dbm.DB
+	nodes map[p2p.ID]*CrawlRecord
+}
+
+// NewCrawlDB returns a CrawlDB loaded from db.
+func NewCrawlDB(db dbm.DB) (*CrawlDB, error) {
+	c := &CrawlDB{
+		db:    db,
+		nodes: make(map[p2p.ID]*CrawlRecord),
+	}
+
+	iter, err := db.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		rec := new(CrawlRecord)
+		if err := json.Unmarshal(iter.Value(), rec); err != nil {
+			return nil, fmt.Errorf("failed to decode crawl record %q: %w", iter.Key(), err)
+		}
+		// the records made before FirstHeard existed expire a week from now
+		if rec.FirstHeard.IsZero() {
+			rec.FirstHeard = time.Now()
+		}
+		c.nodes[rec.Addr.ID] = rec
+	}
+	return c, iter.Error()
+}
+
+// RecordDial records an attempt to dial addr, which failed if err is not nil.
+func (c *CrawlDB) RecordDial(addr *p2p.NetAddress, err error) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	rec := c.record(addr)
+	rec.LastAttempt = time.Now()
+	rec.Reachable = err == nil
+	if rec.Reachable {
+		rec.LastSeen = rec.LastAttempt
+	}
+	return c.save(rec)
+}
+
+// RecordPeer records the node info of a connected peer listening on addr.
+func (c *CrawlDB) RecordPeer(addr *p2p.NetAddress, nodeInfo p2p.NodeInfo) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	rec := c.record(addr)
+	rec.LastSeen = time.Now()
+	if ni, ok := nodeInfo.(p2p.DefaultNodeInfo); ok {
+		rec.Moniker = ni.Moniker
+		rec.Version = ni.Version
+		rec.ChainID = ni.Network
+	}
+	return c.save(rec)
+}
+
+// RecordAddrs records the addresses the node id told us about. They are the
+// edges of the peer graph.
+func (c *CrawlDB) RecordAddrs(id p2p.ID, addrs []*p2p.NetAddress) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	src, ok := c.nodes[id]
+	if !ok {
+		return nil
+	}
+	src.Peers = src.Peers[:0]
+	seen := make(map[p2p.ID]bool, len(addrs))
+	for _, addr := range addrs {
+		if addr.ID == id || seen[addr.ID] {
+			continue
+		}
+		seen[addr.ID] = true
+		src.Peers = append(src.Peers, addr.ID)
+
+		// nodes only heard of are in the DB too, so the graph is complete,
+		// unless the DB is full
+		if _, ok := c.nodes[addr.ID]; !ok && len(c.nodes) < maxCrawlRecords {
+			if err := c.save(c.record(addr)); err != nil {
+				return err
+			}
+		}
+	}
+	return c.save(src)
+}
+
+// Prune removes the nodes not active for a week: not seen nor dialed, or
+// only heard of. If there are still more than maxCrawlRecords nodes, the
+// nodes only heard of go first, then the least recently active ones.
+func (c *CrawlDB) Prune() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for id, rec := range c.nodes {
+		if time.Since(rec.lastActive()) < crawlRecordExpiry {
+			continue
+		}
+		if err := c.delete(id); err != nil {
+			return err
+		}
+	}
+
+	if len(c.nodes) <= maxCrawlRecords {
+		return nil
+	}
+	recs := make([]*CrawlRecord, 0, len(c.nodes))
+	for _, rec := range c.nodes {
+		recs = append(recs, rec)
+	}
+	sort.Slice(recs, func(i, j int) bool {
+		if recs[i].heardOnly() != recs[j].heardOnly() {
+			return recs[i].heardOnly()
+		}
+		return recs[i].lastActive().Before(recs[j].lastActive())
+	})
+	for _, rec := range recs[:len(recs)-maxCrawlRecords] {
+		if err := c.delete(rec.Addr.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Records returns the crawled nodes sorted by ID.
+func (c *CrawlDB) Records() []CrawlRecord {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	recs := make([]CrawlRecord, 0, len(c.nodes))
+	for _, rec := range c.nodes {
+		r := *rec
+		r.Peers = append([]p2p.ID{}, rec.Peers...)
+		recs = append(recs, r)
+	}
+	sort.Slice(recs, func(i, j int) bool { return recs[i].Addr.ID < recs[j].Addr.ID })
+	return recs
+}
+
+// Stats returns the number of crawled and reachable nodes and their version
+// and chain ID distribution.
+func (c *CrawlDB) Stats() CrawlStats {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	stats := CrawlStats{
+		Nodes:    len(c.nodes),
+		Versions: make(map[string]int),
+		ChainIDs: make(map[string]int),
+	}
+	for _, rec := range c.nodes {
+		if rec.Reachable {
+			stats.Reachable++
+		}
+		if rec.Version == "" {
+			stats.Versions[unknownVersion]++
+		} else {
+			stats.Versions[rec.Version]++
+		}
+		if rec.ChainID != "" {
+			stats.ChainIDs[rec.ChainID]++
+		}
+	}
+	return stats
+}
+
+// Graph returns the peer graph: for every node, the nodes it told us about.
+func (c *CrawlDB) Graph() map[p2p.ID][]p2p.ID {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	graph := make(map[p2p.ID][]p2p.ID, len(c.nodes))
+	for id, rec := range c.nodes {
+		graph[id] = append([]p2p.ID{}, rec.Peers...)
+	}
+	return graph
+}
+
+// record returns the record of addr, creating it if needed.
+func (c *CrawlDB) record(addr *p2p.NetAddress) *CrawlRecord {
+	rec, ok := c.nodes[addr.ID]
+	if !ok {
+		rec = &CrawlRecord{FirstHeard: time.Now(), Peers: []p2p.ID{}}
+		c.nodes[addr.ID] = rec
+	}
+	rec.Addr = addr
+	return rec
+}
+
+func (c *CrawlDB) delete(id p2p.ID) error {
+	if err := c.db.Delete(crawlRecordKey(id)); err != nil {
+		return err
+	}
+	delete(c.nodes, id)
+	return nil
+}
+
+func (c *CrawlDB) save(rec *CrawlRecord) error {
+	bz, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return c.db.Set(crawlRecordKey(rec.Addr.ID), bz)
+}
+
+//-----------------------------------------------------------------------------
+
+// NewCrawlStatsHandler returns an HTTP handler serving the crawled network as
+// JSON:
+//
+//	/       statistics: node count, reachable count, versions and chain IDs
+//	/nodes  the crawled nodes
+//	/graph  the peer graph
+func NewCrawlStatsHandler(c *CrawlDB) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, c.Stats())
+	})
+	mux.HandleFunc("/nodes", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, c.Records())
+	})
+	mux.HandleFunc("/graph", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, c.Graph())
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	bz, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(bz) // nolint: errcheck
+}
@@ -0,0 +1,132 @@
+package pex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/arcology-network/consensus-engine/p2p"
+)
+
+func TestCrawlDB(t *testing.T) {
+	db := dbm.NewMemDB()
+	c, err := NewCrawlDB(db)
+	require.NoError(t, err)
+
+	addrs := randNetAddressPairs(t, 3)
+	a, b, other := addrs[0].addr, addrs[1].addr, addrs[2].addr
+
+	require.NoError(t, c.RecordPeer(a, p2p.DefaultNodeInfo{DefaultNodeID: a.ID, Network: "chain", Version: "0.34.1"}))
+	require.NoError(t, c.RecordDial(a, nil))
+	require.NoError(t, c.RecordDial(b, errors.New("connection refused")))
+	require.NoError(t, c.RecordAddrs(a.ID, []*p2p.NetAddress{a, b, other, b}))
+
+	check := func(c *CrawlDB) {
+		assert.Equal(t, CrawlStats{
+			Nodes:     3,
+			Reachable: 1,
+			Versions:  map[string]int{"0.34.1": 1, unknownVersion: 2},
+			ChainIDs:  map[string]int{"chain": 1},
+		}, c.Stats())
+		assert.Equal(t, map[p2p.ID][]p2p.ID{
+			a.ID:     {b.ID, other.ID},
+			b.ID:     {},
+			other.ID: {},
+		}, c.Graph())
+	}
+	check(c)
+
+	// the records are loaded back from the DB
+	c, err = NewCrawlDB(db)
+	require.NoError(t, err)
+	check(c)
+
+	// only the nodes not active for a long time are pruned
+	c.nodes[b.ID].LastAttempt = time.Now().Add(-crawlRecordExpiry - time.Minute)
+	require.NoError(t, c.Prune())
+	assert.Len(t, c.Records(), 2)
+
+	c, err = NewCrawlDB(db)
+	require.NoError(t, err)
+	assert.Len(t, c.Records(), 2)
+
+	// so are the nodes only heard of for a long time
+	c.nodes[other.ID].FirstHeard = time.Now().Add(-crawlRecordExpiry - time.Minute)
+	require.NoError(t, c.Prune())
+	require.Len(t, c.Records(), 1)
+	assert.Equal(t, a.ID, c.Records()[0].Addr.ID)
+}
+
+func TestCrawlDBPruneMaxRecords(t *testing.T) {
+	c, err := NewCrawlDB(dbm.NewMemDB())
+	require.NoError(t, err)
+
+	now := time.Now()
+	dialed, heard := randIPv4Address(t), randIPv4Address(t)
+	for i := 0; i < maxCrawlRecords-1; i++ {
+		addr := &p2p.NetAddress{ID: p2p.ID(fmt.Sprintf("%040x", i))}
+		c.nodes[addr.ID] = &CrawlRecord{Addr: addr, LastAttempt: now.Add(time.Duration(i) * time.Second)}
+	}
+	c.nodes[dialed.ID] = &CrawlRecord{Addr: dialed, LastAttempt: now.Add(-time.Hour)}
+	c.nodes[heard.ID] = &CrawlRecord{Addr: heard, FirstHeard: now}
+
+	// the DB being full, new nodes only heard of are not recorded
+	require.NoError(t, c.RecordAddrs(dialed.ID, []*p2p.NetAddress{randIPv4Address(t)}))
+	assert.Len(t, c.nodes, maxCrawlRecords+1)
+
+	// the nodes only heard of go first
+	require.NoError(t, c.Prune())
+	assert.Len(t, c.nodes, maxCrawlRecords)
+	assert.NotContains(t, c.nodes, heard.ID)
+
+	// then the least recently active ones
+	addr := randIPv4Address(t)
+	require.NoError(t, c.RecordDial(addr, nil))
+	require.NoError(t, c.Prune())
+	assert.Len(t, c.nodes, maxCrawlRecords)
+	assert.NotContains(t, c.nodes, dialed.ID)
+	assert.Contains(t, c.nodes, addr.ID)
+}
+
+func TestCrawlStatsHandler(t *testing.T) {
+	c, err := NewCrawlDB(dbm.NewMemDB())
+	require.NoError(t, err)
+	addr := randIPv4Address(t)
+	require.NoError(t, c.RecordDial(addr, nil))
+
+	srv := httptest.NewServer(NewCrawlStatsHandler(c))
+	defer srv.Close()
+
+	get := func(path string, v interface{}) int {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if v != nil && resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+		}
+		return resp.StatusCode
+	}
+
+	var stats CrawlStats
+	require.Equal(t, http.StatusOK, get("/", &stats))
+	assert.Equal(t, 1, stats.Reachable)
+
+	var recs []CrawlRecord
+	require.Equal(t, http.StatusOK, get("/nodes", &recs))
+	require.Len(t, recs, 1)
+	assert.Equal(t, addr.ID, recs[0].Addr.ID)
+
+	var graph map[p2p.ID][]p2p.ID
+	require.Equal(t, http.StatusOK, get("/graph", &graph))
+	assert.Contains(t, graph, addr.ID)
+
+	assert.Equal(t, http.StatusNotFound, get("/foo", nil))
+}
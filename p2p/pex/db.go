@@ -0,0 +1,105 @@
+package pex
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/arcology-network/consensus-engine/p2p"
+)
+
+/* Loading & Saving to a DB */
+
+var (
+	addrBookKeyKey     = []byte("key")
+	addrBookAddrPrefix = []byte("addr:")
+	addrBookAddrEnd    = []byte("addr;") // addrBookAddrPrefix with the last byte incremented
+)
+
+func addrBookAddrKey(id p2p.ID) []byte {
+	return append(append([]byte{}, addrBookAddrPrefix...), id...)
+}
+
+// saveToDB writes the book to the DB in a single batch. Each address is
+// stored as JSON under its own key; addresses no longer in the book are
+// deleted.
+func (a *addrBook) saveToDB() {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	a.Logger.Info("Saving AddrBook to DB", "size", a.size())
+
+	if err := a.writeDB(); err != nil {
+		a.Logger.Error("Failed to save AddrBook to DB", "err", err)
+	}
+}
+
+func (a *addrBook) writeDB() error {
+	batch := a.db.NewBatch()
+	defer batch.Close()
+
+	iter, err := a.db.Iterator(addrBookAddrPrefix, addrBookAddrEnd)
+	if err != nil {
+		return err
+	}
+	for ; iter.Valid(); iter.Next() {
+		id := p2p.ID(iter.Key()[len(addrBookAddrPrefix):])
+		if _, ok := a.addrLookup[id]; !ok {
+			if err := batch.Delete(addrBookAddrKey(id)); err != nil {
+				iter.Close()
+				return err
+			}
+		}
+	}
+	if err := iter.Error(); err != nil {
+		iter.Close()
+		return err
+	}
+	iter.Close()
+
+	if err := batch.Set(addrBookKeyKey, []byte(a.key)); err != nil {
+		return err
+	}
+	for id, ka := range a.addrLookup {
+		bz, err := json.Marshal(ka)
+		if err != nil {
+			return err
+		}
+		if err := batch.Set(addrBookAddrKey(id), bz); err != nil {
+			return err
+		}
+	}
+	return batch.WriteSync()
+}
+
+// loadFromDB restores the book from the DB. It returns false if the DB holds
+// no address book.
+func (a *addrBook) loadFromDB() (bool, error) {
+	key, err := a.db.Get(addrBookKeyKey)
+	if err != nil {
+		return false, err
+	}
+	if key == nil {
+		return false, nil
+	}
+
+	iter, err := a.db.Iterator(addrBookAddrPrefix, addrBookAddrEnd)
+	if err != nil {
+		return false, err
+	}
+	defer iter.Close()
+
+	var addrs []*knownAddress
+	for ; iter.Valid(); iter.Next() {
+		ka := new(knownAddress)
+		if err := json.Unmarshal(iter.Value(), ka); err != nil {
+			return false, fmt.Errorf("failed to decode address %q: %w", iter.Key(), err)
+		}
+		addrs = append(addrs, ka)
+	}
+	if err := iter.Error(); err != nil {
+		return false, err
+	}
+
+	a.restore(string(key), addrs)
+	return true, nil
+}
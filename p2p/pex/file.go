@@ -63,11 +63,17 @@ func (a *addrBook) loadFromFile(filePath string) bool {
 		panic(fmt.Sprintf("Error reading file %s: %v", filePath, err))
 	}
 
+	a.restore(aJSON.Key, aJSON.Addrs)
+	return true
+}
+
+// restore puts the loaded key and addresses back into the book.
+func (a *addrBook) restore(key string, addrs []*knownAddress) {
 	// Restore all the fields...
 	// Restore the key
-	a.key = aJSON.Key
+	a.key = key
 	// Restore .bucketsNew & .bucketsOld
-	for _, ka := range aJSON.Addrs {
+	for _, ka := range addrs {
 		for _, bucketIndex := range ka.Buckets {
 			bucket := a.getBucket(ka.BucketType, bucketIndex)
 			bucket[ka.Addr.String()] = ka
@@ -79,5 +85,4 @@ func (a *addrBook) loadFromFile(filePath string) bool {
 			a.nOld++
 		}
 	}
-	return true
 }
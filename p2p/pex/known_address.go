@@ -17,6 +17,37 @@ type knownAddress struct {
 	LastAttempt time.Time       `json:"last_attempt"`
 	LastSuccess time.Time       `json:"last_success"`
 	LastBanTime time.Time       `json:"last_ban_time"`
+
+	// metadata learned from connections to the address
+	LastConnected time.Time     `json:"last_connected"`
+	Latency       time.Duration `json:"latency"`
+	NodeVersion   string        `json:"node_version"`
+	ChainID       string        `json:"chain_id"`
+	Score         int           `json:"score"`
+}
+
+// PeerMetadata is what a connection to a peer tells about its address.
+type PeerMetadata struct {
+	Latency     time.Duration // 0 if not measured yet
+	NodeVersion string
+	ChainID     string
+	Score       int
+}
+
+// AddrInfo describes an address in the book. It is returned to operators
+// querying the book.
+type AddrInfo struct {
+	Addr          *p2p.NetAddress `json:"addr"`
+	Src           *p2p.NetAddress `json:"src"`
+	Bucket        string          `json:"bucket"` // "new" or "old"
+	Attempts      int32           `json:"attempts"`
+	LastAttempt   time.Time       `json:"last_attempt"`
+	LastSuccess   time.Time       `json:"last_success"`
+	LastConnected time.Time       `json:"last_connected"`
+	Latency       time.Duration   `json:"latency"`
+	NodeVersion   string          `json:"node_version"`
+	ChainID       string          `json:"chain_id"`
+	Score         int             `json:"score"`
 }
 
 func newKnownAddress(addr *p2p.NetAddress, src *p2p.NetAddress) *knownAddress {
@@ -55,6 +86,43 @@ func (ka *knownAddress) markGood() {
 	ka.LastSuccess = now
 }
 
+func (ka *knownAddress) markConnected() {
+	ka.LastConnected = time.Now()
+}
+
+func (ka *knownAddress) setMetadata(md PeerMetadata) {
+	if md.Latency > 0 {
+		ka.Latency = md.Latency
+	}
+	if md.NodeVersion != "" {
+		ka.NodeVersion = md.NodeVersion
+	}
+	if md.ChainID != "" {
+		ka.ChainID = md.ChainID
+	}
+	ka.Score = md.Score
+}
+
+func (ka *knownAddress) info() AddrInfo {
+	bucket := "new"
+	if ka.isOld() {
+		bucket = "old"
+	}
+	return AddrInfo{
+		Addr:          ka.Addr,
+		Src:           ka.Src,
+		Bucket:        bucket,
+		Attempts:      ka.Attempts,
+		LastAttempt:   ka.LastAttempt,
+		LastSuccess:   ka.LastSuccess,
+		LastConnected: ka.LastConnected,
+		Latency:       ka.Latency,
+		NodeVersion:   ka.NodeVersion,
+		ChainID:       ka.ChainID,
+		Score:         ka.Score,
+	}
+}
+
 func (ka *knownAddress) ban(banTime time.Duration) {
 	if ka.LastBanTime.Before(time.Now().Add(banTime)) {
 		ka.LastBanTime = time.Now().Add(banTime)
@@ -3,11 +3,13 @@ package pex
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
 
+	"github.com/arcology-network/consensus-engine/behaviour"
 	"github.com/arcology-network/consensus-engine/libs/cmap"
 	tmmath "github.com/arcology-network/consensus-engine/libs/math"
 	tmrand "github.com/arcology-network/consensus-engine/libs/rand"
@@ -52,6 +54,15 @@ const (
 
 	// if a peer is marked bad, it will be banned for at least this time period
 	defaultBanTime = 24 * time.Hour
+
+	// Sentry mode constants
+
+	// check the validators behind this sentry every this
+	defaultValidatorCheckPeriod = 10 * time.Second
+
+	// a validator we received nothing from (not even a pong) for this long is
+	// considered unhealthy and is reconnected
+	validatorIdleTimeout = 3 * time.Minute
 )
 
 type errMaxAttemptsToDial struct {
@@ -87,6 +98,7 @@ type Reactor struct {
 	book              AddrBook
 	config            *ReactorConfig
 	ensurePeersPeriod time.Duration // TODO: should go in the config
+	reporter          behaviour.Reporter
 
 	// maps to prevent abuse
 	requestsSent         *cmap.CMap // ID->struct{}: unanswered send requests
@@ -98,6 +110,11 @@ type Reactor struct {
 
 	// seed/crawled mode fields
 	crawlPeerInfos map[p2p.ID]crawlPeerInfo
+
+	// sentry mode fields
+	validatorAddrs       []*p2p.NetAddress
+	validatorIDs         map[p2p.ID]struct{}
+	validatorCheckPeriod time.Duration
 }
 
 func (r *Reactor) minReceiveRequestInterval() time.Duration {
@@ -111,6 +128,10 @@ type ReactorConfig struct {
 	// Seed/Crawler mode
 	SeedMode bool
 
+	// CrawlDB records the nodes found while crawling in seed mode. It may be
+	// nil.
+	CrawlDB *CrawlDB
+
 	// We want seeds to only advertise good peers. Therefore they should wait at
 	// least as long as we expect it to take for a peer to become good before
 	// disconnecting.
@@ -122,6 +143,11 @@ type ReactorConfig struct {
 	// Seeds is a list of addresses reactor may use
 	// if it can't connect to peers in the addrbook.
 	Seeds []string
+
+	// Sentry mode: addresses of the validators this node is a sentry for. The
+	// reactor keeps them connected, reconnects them if they stop responding
+	// and never gossips their addresses.
+	SentryValidators []string
 }
 
 type _attemptsToDial struct {
@@ -138,11 +164,20 @@ func NewReactor(b AddrBook, config *ReactorConfig) *Reactor {
 		requestsSent:         cmap.NewCMap(),
 		lastReceivedRequests: cmap.NewCMap(),
 		crawlPeerInfos:       make(map[p2p.ID]crawlPeerInfo),
+		reporter:             behaviour.NewMockReporter(),
+		validatorIDs:         make(map[p2p.ID]struct{}),
+		validatorCheckPeriod: defaultValidatorCheckPeriod,
 	}
 	r.BaseReactor = *p2p.NewBaseReactor("PEX", r)
 	return r
 }
 
+// SetSwitch implements Reactor by also reporting peer behaviour to the switch.
+func (r *Reactor) SetSwitch(sw *p2p.Switch) {
+	r.BaseReactor.SetSwitch(sw)
+	r.reporter = behaviour.NewSwitchReporter(sw)
+}
+
 // OnStart implements BaseService
 func (r *Reactor) OnStart() error {
 	err := r.book.Start()
@@ -159,6 +194,10 @@ func (r *Reactor) OnStart() error {
 
 	r.seedAddrs = seedAddrs
 
+	if err := r.setValidators(); err != nil {
+		return err
+	}
+
 	// Check if this node should run
 	// in seed/crawler mode
 	if r.config.SeedMode {
@@ -166,6 +205,9 @@ func (r *Reactor) OnStart() error {
 	} else {
 		go r.ensurePeersRoutine()
 	}
+	if len(r.validatorAddrs) > 0 {
+		go r.validatorsRoutine()
+	}
 	return nil
 }
 
@@ -184,6 +226,7 @@ func (r *Reactor) GetChannels() []*conn.ChannelDescriptor {
 			Priority:            1,
 			SendQueueCapacity:   10,
 			RecvMessageCapacity: maxMsgSize,
+			MessageType:         &tmp2p.Message{},
 		},
 	}
 }
@@ -191,6 +234,10 @@ func (r *Reactor) GetChannels() []*conn.ChannelDescriptor {
 // AddPeer implements Reactor by adding peer to the address book (if inbound)
 // or by requesting more addresses (if outbound).
 func (r *Reactor) AddPeer(p Peer) {
+	if r.isValidator(p.ID()) {
+		// Validators behind this sentry neither run PEX nor go in the book.
+		return
+	}
 	if p.IsOutbound() {
 		// For outbound peers, the address is already in the books -
 		// either via DialPeersAsync or r.Receive.
@@ -214,6 +261,21 @@ func (r *Reactor) AddPeer(p Peer) {
 		err = r.book.AddAddress(addr, src)
 		r.logErrAddrBook(err)
 	}
+
+	// the rest of the metadata is recorded by ensurePeers once the latency
+	// has been measured
+	r.book.MarkConnected(p.ID())
+
+	if r.crawling() {
+		addr := p.SocketAddr()
+		if !p.IsOutbound() {
+			var err error
+			if addr, err = p.NodeInfo().NetAddress(); err != nil {
+				return
+			}
+		}
+		r.logErrCrawlDB(r.config.CrawlDB.RecordPeer(addr, p.NodeInfo()))
+	}
 }
 
 // RemovePeer implements Reactor by resetting peer's requests info.
@@ -221,6 +283,35 @@ func (r *Reactor) RemovePeer(p Peer, reason interface{}) {
 	id := string(p.ID())
 	r.requestsSent.Delete(id)
 	r.lastReceivedRequests.Delete(id)
+
+	if !r.isValidator(p.ID()) {
+		r.updateMetadata(p)
+	}
+}
+
+// crawling returns true if the crawled nodes are recorded.
+func (r *Reactor) crawling() bool {
+	return r.config.SeedMode && r.config.CrawlDB != nil
+}
+
+func (r *Reactor) logErrCrawlDB(err error) {
+	if err != nil {
+		r.Logger.Error("Failed to update crawl DB", "err", err)
+	}
+}
+
+// updateMetadata records the latency, version, chain ID and score of a
+// connected peer in the book.
+func (r *Reactor) updateMetadata(p Peer) {
+	md := PeerMetadata{
+		Latency: p.Status().Latency,
+		Score:   r.Switch.PeerScore(p.ID()),
+	}
+	if ni, ok := p.NodeInfo().(p2p.DefaultNodeInfo); ok {
+		md.NodeVersion = ni.Version
+		md.ChainID = ni.Network
+	}
+	r.book.SetMetadata(p.ID(), md)
 }
 
 func (r *Reactor) logErrAddrBook(err error) {
@@ -240,7 +331,7 @@ func (r *Reactor) Receive(chID byte, src Peer, msgBytes []byte) {
 	msg, err := decodeMsg(msgBytes)
 	if err != nil {
 		r.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
-		r.Switch.StopPeerForError(src, err)
+		_ = r.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 		return
 	}
 	r.Logger.Debug("Received message", "src", src, "chId", chID, "msg", msg)
@@ -276,7 +367,7 @@ func (r *Reactor) Receive(chID byte, src Peer, msgBytes []byte) {
 		} else {
 			// Check we're not receiving requests too frequently.
 			if err := r.receiveRequest(src); err != nil {
-				r.Switch.StopPeerForError(src, err)
+				_ = r.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 				r.book.MarkBad(src.SocketAddr(), defaultBanTime)
 				return
 			}
@@ -287,18 +378,22 @@ func (r *Reactor) Receive(chID byte, src Peer, msgBytes []byte) {
 		// If we asked for addresses, add them to the book
 		addrs, err := p2p.NetAddressesFromProto(msg.Addrs)
 		if err != nil {
-			r.Switch.StopPeerForError(src, err)
+			_ = r.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 			r.book.MarkBad(src.SocketAddr(), defaultBanTime)
 			return
 		}
 		err = r.ReceiveAddrs(addrs, src)
 		if err != nil {
-			r.Switch.StopPeerForError(src, err)
+			_ = r.reporter.Report(behaviour.BadMessage(src.ID(), err.Error()))
 			if err == ErrUnsolicitedList {
 				r.book.MarkBad(src.SocketAddr(), defaultBanTime)
 			}
 			return
 		}
+		if r.crawling() {
+			r.logErrCrawlDB(r.config.CrawlDB.RecordAddrs(src.ID(), addrs))
+		}
+		_ = r.reporter.Report(behaviour.ValidMessage(src.ID(), "addrs"))
 
 	default:
 		r.Logger.Error(fmt.Sprintf("Unknown message type %T", msg))
@@ -375,6 +470,10 @@ func (r *Reactor) ReceiveAddrs(addrs []*p2p.NetAddress, src Peer) error {
 	}
 
 	for _, netAddr := range addrs {
+		if r.isValidator(netAddr.ID) {
+			continue
+		}
+
 		// NOTE: we check netAddr validity and routability in book#AddAddress.
 		err = r.book.AddAddress(netAddr, srcAddr)
 		if err != nil {
@@ -405,8 +504,18 @@ func (r *Reactor) ReceiveAddrs(addrs []*p2p.NetAddress, src Peer) error {
 	return nil
 }
 
-// SendAddrs sends addrs to the peer.
+// SendAddrs sends addrs to the peer, leaving out the validators behind this
+// sentry.
 func (r *Reactor) SendAddrs(p Peer, netAddrs []*p2p.NetAddress) {
+	if len(r.validatorIDs) > 0 {
+		filtered := make([]*p2p.NetAddress, 0, len(netAddrs))
+		for _, addr := range netAddrs {
+			if !r.isValidator(addr.ID) {
+				filtered = append(filtered, addr)
+			}
+		}
+		netAddrs = filtered
+	}
 	p.Send(PexChannel, mustEncode(&tmp2p.PexAddrs{Addrs: p2p.NetAddressesToProto(netAddrs)}))
 }
 
@@ -464,6 +573,12 @@ func (r *Reactor) ensurePeers() {
 		"numToDial", numToDial,
 	)
 
+	for _, p := range r.Switch.Peers().List() {
+		if !r.isValidator(p.ID()) {
+			r.updateMetadata(p)
+		}
+	}
+
 	if numToDial <= 0 {
 		return
 	}
@@ -473,11 +588,15 @@ func (r *Reactor) ensurePeers() {
 	// NOTE: range here is [10, 90]. Too high ?
 	newBias := tmmath.MinInt(out, 8)*10 + 10
 
+	// Pick twice as many candidates as we need, so the ones with the best
+	// score can be dialed first.
+	numCandidates := numToDial * 2
 	toDial := make(map[p2p.ID]*p2p.NetAddress)
-	// Try maxAttempts times to pick numToDial addresses to dial
-	maxAttempts := numToDial * 3
+	candidates := make([]*p2p.NetAddress, 0, numCandidates)
+	// Try maxAttempts times to pick numCandidates addresses to dial
+	maxAttempts := numCandidates * 3
 
-	for i := 0; i < maxAttempts && len(toDial) < numToDial; i++ {
+	for i := 0; i < maxAttempts && len(toDial) < numCandidates; i++ {
 		try := r.book.PickAddress(newBias)
 		if try == nil {
 			continue
@@ -491,12 +610,20 @@ func (r *Reactor) ensurePeers() {
 		// TODO: consider moving some checks from toDial into here
 		// so we don't even consider dialing peers that we want to wait
 		// before dialling again, or have dialed too many times already
-		r.Logger.Info("Will dial address", "addr", try)
 		toDial[try.ID] = try
+		candidates = append(candidates, try)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return r.Switch.PeerScore(candidates[i].ID) > r.Switch.PeerScore(candidates[j].ID)
+	})
+	if len(candidates) > numToDial {
+		candidates = candidates[:numToDial]
 	}
 
 	// Dial picked addresses
-	for _, addr := range toDial {
+	for _, addr := range candidates {
+		r.Logger.Info("Will dial address", "addr", addr)
 		go func(addr *p2p.NetAddress) {
 			err := r.dialPeer(addr)
 			if err != nil {
@@ -651,6 +778,79 @@ func (r *Reactor) AttemptsToDial(addr *p2p.NetAddress) int {
 
 //----------------------------------------------------------
 
+// setValidators parses the addresses of the validators behind this sentry.
+func (r *Reactor) setValidators() error {
+	if len(r.config.SentryValidators) == 0 {
+		return nil
+	}
+	netAddrs, errs := p2p.NewNetAddressStrings(r.config.SentryValidators)
+	if len(errs) > 0 {
+		return fmt.Errorf("sentry validator configuration has error: %w", errs[0])
+	}
+	r.validatorAddrs = netAddrs
+	for _, addr := range netAddrs {
+		r.validatorIDs[addr.ID] = struct{}{}
+	}
+	return nil
+}
+
+func (r *Reactor) isValidator(id p2p.ID) bool {
+	_, ok := r.validatorIDs[id]
+	return ok
+}
+
+// SetValidatorCheckPeriod sets how often the validators behind this sentry are
+// checked.
+func (r *Reactor) SetValidatorCheckPeriod(d time.Duration) {
+	r.validatorCheckPeriod = d
+}
+
+// Keeps the validators behind this sentry connected and healthy. (continuous)
+func (r *Reactor) validatorsRoutine() {
+	r.checkValidators()
+
+	ticker := time.NewTicker(r.validatorCheckPeriod)
+	for {
+		select {
+		case <-ticker.C:
+			r.checkValidators()
+		case <-r.Quit():
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// checkValidators dials the validators which aren't connected and reconnects
+// the ones which stopped responding. Unlike other peers, validators are dialed
+// without backoff and never given up on. (once)
+func (r *Reactor) checkValidators() {
+	for _, addr := range r.validatorAddrs {
+		peer := r.Switch.Peers().Get(addr.ID)
+		if peer == nil {
+			if r.Switch.IsDialingOrExistingAddress(addr) {
+				continue
+			}
+			r.Logger.Info("Dialing validator", "addr", addr)
+			go func(addr *p2p.NetAddress) {
+				err := r.Switch.DialPeerWithAddress(addr)
+				switch err.(type) {
+				case nil, p2p.ErrCurrentlyDialingOrExistingAddress:
+				default:
+					r.Logger.Error("Dialing validator failed", "addr", addr, "err", err)
+				}
+			}(addr)
+			continue
+		}
+
+		if idle := peer.Status().RecvMonitor.Idle; idle > validatorIdleTimeout {
+			r.Switch.StopPeerForError(peer, fmt.Errorf("validator unresponsive for %v", idle))
+		}
+	}
+}
+
+//----------------------------------------------------------
+
 // Explores the network searching for more peers. (continuous)
 // Seed/Crawler Mode causes this node to quickly disconnect
 // from peers, except other seed nodes.
@@ -672,6 +872,9 @@ func (r *Reactor) crawlPeersRoutine() {
 			r.attemptDisconnects()
 			r.crawlPeers(r.book.GetSelection())
 			r.cleanupCrawlPeerInfos()
+			if r.crawling() {
+				r.logErrCrawlDB(r.config.CrawlDB.Prune())
+			}
 		case <-r.Quit():
 			return
 		}
@@ -718,9 +921,15 @@ func (r *Reactor) crawlPeers(addrs []*p2p.NetAddress) {
 				r.Logger.Debug(err.Error(), "addr", addr)
 			default:
 				r.Logger.Error(err.Error(), "addr", addr)
+				if r.crawling() {
+					r.logErrCrawlDB(r.config.CrawlDB.RecordDial(addr, err))
+				}
 			}
 			continue
 		}
+		if r.crawling() {
+			r.logErrCrawlDB(r.config.CrawlDB.RecordDial(addr, nil))
+		}
 
 		peer := r.Switch.Peers().Get(addr.ID)
 		if peer != nil {
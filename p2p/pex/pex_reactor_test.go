@@ -12,11 +12,15 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/arcology-network/consensus-engine/config"
+	"github.com/arcology-network/consensus-engine/crypto/ed25519"
 	"github.com/arcology-network/consensus-engine/libs/log"
 	"github.com/arcology-network/consensus-engine/p2p"
+	"github.com/arcology-network/consensus-engine/p2p/conn"
 	"github.com/arcology-network/consensus-engine/p2p/mock"
+	"github.com/arcology-network/consensus-engine/p2p/mocks"
 	tmp2p "github.com/arcology-network/consensus-engine/proto/tendermint/p2p"
 )
 
@@ -41,6 +45,7 @@ func TestPEXReactorBasic(t *testing.T) {
 func TestPEXReactorAddRemovePeer(t *testing.T) {
 	r, book := createReactor(&ReactorConfig{})
 	defer teardownReactor(book)
+	createSwitchAndAddReactors(r)
 
 	size := book.Size()
 	peer := p2p.CreateRandomPeer(false)
@@ -333,6 +338,46 @@ func TestPEXReactorSeedMode(t *testing.T) {
 	assert.Equal(t, 0, sw.Peers().Size())
 }
 
+func TestPEXReactorSeedModeRecordsCrawledNodes(t *testing.T) {
+	// directory to store address books
+	dir, err := ioutil.TempDir("", "pex_reactor")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	crawlDB, err := NewCrawlDB(dbm.NewMemDB())
+	require.NoError(t, err)
+
+	pexR, book := createReactor(&ReactorConfig{SeedMode: true, CrawlDB: crawlDB})
+	defer teardownReactor(book)
+
+	sw := createSwitchAndAddReactors(pexR)
+	sw.SetAddrBook(book)
+	require.NoError(t, sw.Start())
+	defer sw.Stop() // nolint:errcheck // ignore for tests
+
+	peerSwitch := testCreateDefaultPeer(dir, 1)
+	require.NoError(t, peerSwitch.Start())
+	defer peerSwitch.Stop() // nolint:errcheck // ignore for tests
+
+	unreachable, err := p2p.NewNetAddressString(
+		p2p.IDAddressString(p2p.PubKeyToID(ed25519.GenPrivKey().PubKey()), "127.0.0.1:1"))
+	require.NoError(t, err)
+
+	pexR.crawlPeers([]*p2p.NetAddress{peerSwitch.NetAddress(), unreachable})
+
+	stats := crawlDB.Stats()
+	assert.Equal(t, 2, stats.Nodes)
+	assert.Equal(t, 1, stats.Reachable)
+	assert.Equal(t, 1, stats.Versions[peerSwitch.NodeInfo().(p2p.DefaultNodeInfo).Version])
+	assert.Equal(t, 1, stats.Versions[unknownVersion])
+
+	// the addresses a node sends are the edges of the peer graph
+	peer := sw.Peers().Get(peerSwitch.NodeInfo().ID())
+	require.NotNil(t, peer)
+	pexR.Receive(PexChannel, peer, mustEncode(&tmp2p.PexAddrs{Addrs: []tmp2p.NetAddress{unreachable.ToProto()}}))
+	assert.Equal(t, []p2p.ID{unreachable.ID}, crawlDB.Graph()[peer.ID()])
+}
+
 func TestPEXReactorDoesNotDisconnectFromPersistentPeerInSeedMode(t *testing.T) {
 	// directory to store address books
 	dir, err := ioutil.TempDir("", "pex_reactor")
@@ -476,6 +521,37 @@ func TestPEXReactorSeedModeFlushStop(t *testing.T) {
 	}
 }
 
+func TestPEXReactorRecordsPeerMetadata(t *testing.T) {
+	r, book := createReactor(&ReactorConfig{})
+	defer teardownReactor(book)
+	createSwitchAndAddReactors(r)
+
+	addr := p2p.CreateRandomPeer(false).SocketAddr()
+	require.NoError(t, book.AddAddress(addr, addr))
+
+	peer := &mocks.Peer{}
+	peer.On("ID").Return(addr.ID)
+	peer.On("IsOutbound").Return(true)
+	peer.On("Send", PexChannel, mustEncode(&tmp2p.PexRequest{})).Return(true)
+	peer.On("NodeInfo").Return(p2p.DefaultNodeInfo{
+		DefaultNodeID: addr.ID,
+		Network:       "test-chain",
+		Version:       "0.34.0",
+	})
+	peer.On("Status").Return(conn.ConnectionStatus{Latency: 25 * time.Millisecond})
+
+	r.AddPeer(peer)
+	r.RemovePeer(peer, "peer not available")
+
+	infos := book.Addresses()
+	require.Len(t, infos, 1)
+	assert.False(t, infos[0].LastConnected.IsZero())
+	assert.Equal(t, 25*time.Millisecond, infos[0].Latency)
+	assert.Equal(t, "test-chain", infos[0].ChainID)
+	assert.Equal(t, "0.34.0", infos[0].NodeVersion)
+	assert.Equal(t, 100, infos[0].Score)
+}
+
 func TestPEXReactorDoesNotAddPrivatePeersToAddrBook(t *testing.T) {
 	peer := p2p.CreateRandomPeer(false)
 
@@ -495,6 +571,73 @@ func TestPEXReactorDoesNotAddPrivatePeersToAddrBook(t *testing.T) {
 	assert.Equal(t, size, book.Size())
 }
 
+func TestPEXReactorSentryDoesNotGossipValidators(t *testing.T) {
+	var (
+		validator = p2p.CreateRandomPeer(false)
+		peer      = p2p.CreateRandomPeer(false)
+		other     = p2p.CreateRandomPeer(false)
+	)
+
+	pexR, book := createReactor(&ReactorConfig{
+		SentryValidators: []string{validator.SocketAddr().String()},
+	})
+	defer teardownReactor(book)
+	require.NoError(t, pexR.setValidators())
+
+	// the validator doesn't go in the book when it connects
+	pexR.AddPeer(validator)
+	assert.True(t, book.Empty())
+
+	// nor when a peer sends its address
+	pexR.RequestAddrs(peer)
+	msg := mustEncode(&tmp2p.PexAddrs{Addrs: []tmp2p.NetAddress{
+		validator.SocketAddr().ToProto(),
+		other.SocketAddr().ToProto(),
+	}})
+	pexR.Receive(PexChannel, peer, msg)
+	assert.Equal(t, 1, book.Size())
+	assert.False(t, book.HasAddress(validator.SocketAddr()))
+
+	// and it is left out of the addresses sent to peers
+	mockPeer := &mocks.Peer{}
+	mockPeer.On("Send", PexChannel, mustEncode(&tmp2p.PexAddrs{Addrs: []tmp2p.NetAddress{
+		other.SocketAddr().ToProto(),
+	}})).Return(true)
+	pexR.SendAddrs(mockPeer, []*p2p.NetAddress{validator.SocketAddr(), other.SocketAddr()})
+	mockPeer.AssertExpectations(t)
+}
+
+func TestPEXReactorSentryReconnectsValidator(t *testing.T) {
+	// directory to store address books
+	dir, err := ioutil.TempDir("", "pex_reactor")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	validator := p2p.MakeSwitch(cfg, 0, "127.0.0.1", "123.123.123", func(i int, sw *p2p.Switch) *p2p.Switch { return sw })
+	validator.SetLogger(log.TestingLogger())
+	require.NoError(t, validator.Start())
+	defer validator.Stop() // nolint:errcheck // ignore for tests
+
+	sentry := testCreatePeerWithConfig(dir, 1, &ReactorConfig{
+		SentryValidators: []string{validator.NetAddress().String()},
+	})
+	sentry.Reactor("pex").(*Reactor).SetValidatorCheckPeriod(100 * time.Millisecond)
+	require.NoError(t, sentry.Start())
+	defer sentry.Stop() // nolint:errcheck // ignore for tests
+
+	// the sentry dials the validator on its own
+	validatorID := validator.NodeInfo().ID()
+	require.Eventually(t, func() bool { return sentry.Peers().Has(validatorID) }, 3*time.Second, 10*time.Millisecond)
+	first := sentry.Peers().Get(validatorID)
+
+	// and dials it again once the connection is lost
+	validator.StopPeerGracefully(validator.Peers().Get(sentry.NodeInfo().ID()))
+	require.Eventually(t, func() bool {
+		p := sentry.Peers().Get(validatorID)
+		return p != nil && p != first
+	}, 3*time.Second, 10*time.Millisecond)
+}
+
 func TestPEXReactorDialPeer(t *testing.T) {
 	pexR, book := createReactor(&ReactorConfig{})
 	defer teardownReactor(book)
@@ -3,6 +3,8 @@ package p2p
 import (
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/arcology-network/consensus-engine/libs/cmap"
 	"github.com/arcology-network/consensus-engine/libs/rand"
 	"github.com/arcology-network/consensus-engine/libs/service"
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
 	"github.com/arcology-network/consensus-engine/p2p/conn"
 )
 
@@ -50,6 +53,7 @@ type AddrBook interface {
 	AddOurAddress(*NetAddress)
 	OurAddress(*NetAddress) bool
 	MarkGood(ID)
+	MarkBad(*NetAddress, time.Duration)
 	RemoveAddress(*NetAddress)
 	HasAddress(*NetAddress) bool
 	Save()
@@ -75,12 +79,14 @@ type Switch struct {
 	peers        *PeerSet
 	dialing      *cmap.CMap
 	reconnecting *cmap.CMap
+	nodeInfoMtx  tmsync.RWMutex
 	nodeInfo     NodeInfo // our node info
 	nodeKey      *NodeKey // our node privkey
 	addrBook     AddrBook
 	// peers addresses with whom we'll maintain constant connection
 	persistentPeersAddrs []*NetAddress
 	unconditionalPeerIDs map[ID]struct{}
+	validatorPeerIDs     map[ID]struct{}
 
 	transport Transport
 
@@ -89,6 +95,11 @@ type Switch struct {
 
 	rng *rand.Rand // seed for randomizing dial times and orders
 
+	scorer *PeerScorer
+
+	bandwidthMtx tmsync.RWMutex
+	bandwidth    BandwidthLimits
+
 	metrics *Metrics
 }
 
@@ -120,6 +131,13 @@ func NewSwitch(
 		filterTimeout:        defaultFilterTimeout,
 		persistentPeersAddrs: make([]*NetAddress, 0),
 		unconditionalPeerIDs: make(map[ID]struct{}),
+		validatorPeerIDs:     make(map[ID]struct{}),
+		bandwidth: BandwidthLimits{
+			Classes: map[PeerClass]RateLimit{
+				PeerClassDefault: {SendRate: cfg.SendRate, RecvRate: cfg.RecvRate},
+			},
+			Channels: make(map[string]RateLimit),
+		},
 	}
 
 	// Ensure we have a completely undeterministic PRNG.
@@ -134,6 +152,21 @@ func NewSwitch(
 	return sw
 }
 
+// SwitchPeerScorer sets the PeerScorer used to score and ban peers.
+func SwitchPeerScorer(ps *PeerScorer) SwitchOption {
+	return func(sw *Switch) { sw.scorer = ps }
+}
+
+// SwitchBandwidthLimits sets the rate limits of the peer connections.
+func SwitchBandwidthLimits(limits BandwidthLimits) SwitchOption {
+	return func(sw *Switch) {
+		sw.bandwidth = limits.copy()
+		if _, ok := sw.bandwidth.Classes[PeerClassDefault]; !ok {
+			sw.bandwidth.Classes[PeerClassDefault] = RateLimit{SendRate: sw.config.SendRate, RecvRate: sw.config.RecvRate}
+		}
+	}
+}
+
 // SwitchFilterTimeout sets the timeout used for peer filters.
 func SwitchFilterTimeout(timeout time.Duration) SwitchOption {
 	return func(sw *Switch) { sw.filterTimeout = timeout }
@@ -199,14 +232,16 @@ func (sw *Switch) Reactor(name string) Reactor {
 }
 
 // SetNodeInfo sets the switch's NodeInfo for checking compatibility and handshaking with other nodes.
-// NOTE: Not goroutine safe.
 func (sw *Switch) SetNodeInfo(nodeInfo NodeInfo) {
+	sw.nodeInfoMtx.Lock()
+	defer sw.nodeInfoMtx.Unlock()
 	sw.nodeInfo = nodeInfo
 }
 
 // NodeInfo returns the switch's NodeInfo.
-// NOTE: Not goroutine safe.
 func (sw *Switch) NodeInfo() NodeInfo {
+	sw.nodeInfoMtx.RLock()
+	defer sw.nodeInfoMtx.RUnlock()
 	return sw.nodeInfo
 }
 
@@ -221,6 +256,12 @@ func (sw *Switch) SetNodeKey(nodeKey *NodeKey) {
 
 // OnStart implements BaseService. It starts all the reactors and peers.
 func (sw *Switch) OnStart() error {
+	for ch := range sw.bandwidth.Channels {
+		if _, err := sw.channelIDs(ch); err != nil {
+			return err
+		}
+	}
+
 	// Start reactors
 	for _, reactor := range sw.reactors {
 		err := reactor.Start()
@@ -370,6 +411,10 @@ func (sw *Switch) stopAndRemovePeer(peer Peer, reason interface{}) {
 	if sw.peers.Remove(peer) {
 		sw.metrics.Peers.Add(float64(-1))
 	}
+
+	if sw.scorer != nil {
+		sw.scorer.PeerDisconnected(peer.ID())
+	}
 }
 
 // reconnectToPeer tries to reconnect to the addr, first repeatedly
@@ -440,6 +485,48 @@ func (sw *Switch) MarkPeerAsGood(peer Peer) {
 	if sw.addrBook != nil {
 		sw.addrBook.MarkGood(peer.ID())
 	}
+	if sw.scorer != nil {
+		sw.scorer.MarkGood(peer.ID())
+	}
+}
+
+// MarkPeerAsBad lowers the score of the given peer after it misbehaved. If the
+// peer keeps misbehaving, it is banned: it gets disconnected, its address is
+// marked as bad in the address book and it can't reconnect until the ban
+// expires. Unconditional and persistent peers are never banned.
+func (sw *Switch) MarkPeerAsBad(peer Peer, reason interface{}) {
+	if sw.scorer == nil {
+		return
+	}
+	if !sw.scorer.MarkBad(peer.ID()) {
+		return
+	}
+	if sw.IsPeerUnconditional(peer.ID()) || peer.IsPersistent() {
+		sw.Logger.Info("Not banning unconditional or persistent peer", "peer", peer, "err", reason)
+		return
+	}
+
+	sw.Logger.Info("Banning peer", "peer", peer, "duration", sw.scorer.BanDuration(), "err", reason)
+	sw.scorer.Ban(peer.ID())
+	if sw.addrBook != nil {
+		if addr, err := peer.NodeInfo().NetAddress(); err == nil {
+			sw.addrBook.MarkBad(addr, sw.scorer.BanDuration())
+		}
+	}
+	sw.StopPeerForError(peer, reason)
+}
+
+// PeerScore returns the trust score of the peer, between 0 and 100. Without a
+// PeerScorer, every peer has a perfect score.
+func (sw *Switch) PeerScore(id ID) int {
+	if sw.scorer == nil {
+		return 100
+	}
+	return sw.scorer.Score(id)
+}
+
+func (sw *Switch) isPeerBanned(id ID) bool {
+	return sw.scorer != nil && sw.scorer.IsBanned(id)
 }
 
 //---------------------------------------------------------------------
@@ -538,6 +625,10 @@ func (sw *Switch) DialPeerWithAddress(addr *NetAddress) error {
 		return ErrCurrentlyDialingOrExistingAddress{addr.String()}
 	}
 
+	if sw.scorer != nil && sw.scorer.IsAddrBanned(addr) && !sw.IsPeerUnconditional(addr.ID) {
+		return ErrRejected{id: addr.ID, isBanned: true}
+	}
+
 	sw.dialing.Set(string(addr.ID), addr)
 	defer sw.dialing.Delete(string(addr.ID))
 
@@ -591,6 +682,20 @@ func (sw *Switch) AddUnconditionalPeerIDs(ids []string) error {
 	return nil
 }
 
+// AddValidatorPeerIDs marks the given node IDs as validators, whose
+// connections get the rate limits of PeerClassValidator.
+func (sw *Switch) AddValidatorPeerIDs(ids []string) error {
+	sw.Logger.Info("Adding validator peer ids", "ids", ids)
+	for i, id := range ids {
+		err := validateID(ID(id))
+		if err != nil {
+			return fmt.Errorf("wrong ID #%d: %w", i, err)
+		}
+		sw.validatorPeerIDs[ID(id)] = struct{}{}
+	}
+	return nil
+}
+
 func (sw *Switch) AddPrivatePeerIDs(ids []string) error {
 	validIDs := make([]string, 0, len(ids))
 	for i, id := range ids {
@@ -765,6 +870,11 @@ func (sw *Switch) filterPeer(p Peer) error {
 		return ErrRejected{id: p.ID(), isDuplicate: true}
 	}
 
+	// Reject banned peers
+	if sw.isPeerBanned(p.ID()) && !sw.IsPeerUnconditional(p.ID()) {
+		return ErrRejected{id: p.ID(), isBanned: true}
+	}
+
 	errc := make(chan error, len(sw.peerFilters))
 
 	for _, f := range sw.peerFilters {
@@ -809,6 +919,8 @@ func (sw *Switch) addPeer(p Peer) error {
 		p = reactor.InitPeer(p)
 	}
 
+	sw.applyBandwidthLimits(p)
+
 	// Start the peer's send/recv routines.
 	// Must start it before adding it to the peer set
 	// to prevent Start and Stop from being called concurrently.
@@ -836,3 +948,138 @@ func (sw *Switch) addPeer(p Peer) error {
 
 	return nil
 }
+
+//------------------------------------------------------------------------------------
+// Bandwidth limits
+
+// PeerClass returns the class of the peer, which determines its connection
+// rate limits. A validator is never throttled as an unconditional or
+// persistent peer.
+func (sw *Switch) PeerClass(p Peer) PeerClass {
+	switch {
+	case sw.isValidatorPeer(p.ID()):
+		return PeerClassValidator
+	case sw.IsPeerUnconditional(p.ID()):
+		return PeerClassUnconditional
+	case p.IsPersistent():
+		return PeerClassPersistent
+	default:
+		return PeerClassDefault
+	}
+}
+
+func (sw *Switch) isValidatorPeer(id ID) bool {
+	_, ok := sw.validatorPeerIDs[id]
+	return ok
+}
+
+// BandwidthLimits returns the rate limits of the peer connections.
+func (sw *Switch) BandwidthLimits() BandwidthLimits {
+	sw.bandwidthMtx.RLock()
+	defer sw.bandwidthMtx.RUnlock()
+	return sw.bandwidth.copy()
+}
+
+// SetPeerRateLimit changes the connection rate limits of a peer class and
+// applies them to the connected peers.
+func (sw *Switch) SetPeerRateLimit(class PeerClass, limit RateLimit) error {
+	if err := ValidatePeerClass(class); err != nil {
+		return err
+	}
+	if err := limit.ValidateBasic(); err != nil {
+		return err
+	}
+
+	sw.bandwidthMtx.Lock()
+	sw.bandwidth.Classes[class] = limit
+	sw.bandwidthMtx.Unlock()
+
+	for _, p := range sw.peers.List() {
+		sw.applyBandwidthLimits(p)
+	}
+	return nil
+}
+
+// SetChannelRateLimit changes the rate limits of a channel, given by reactor
+// name or by ID in hex, and applies them to the connected peers.
+func (sw *Switch) SetChannelRateLimit(channel string, limit RateLimit) error {
+	channel = strings.ToLower(channel)
+	if _, err := sw.channelIDs(channel); err != nil {
+		return err
+	}
+	if err := limit.ValidateBasic(); err != nil {
+		return err
+	}
+
+	sw.bandwidthMtx.Lock()
+	sw.bandwidth.Channels[channel] = limit
+	sw.bandwidthMtx.Unlock()
+
+	for _, p := range sw.peers.List() {
+		sw.applyBandwidthLimits(p)
+	}
+	return nil
+}
+
+// applyBandwidthLimits sets the rate limits of the peer connection according
+// to the peer class and the channel limits.
+func (sw *Switch) applyBandwidthLimits(p Peer) {
+	rp, ok := p.(rateLimitedPeer)
+	if !ok {
+		return
+	}
+
+	sw.bandwidthMtx.RLock()
+	defer sw.bandwidthMtx.RUnlock()
+
+	limit, ok := sw.bandwidth.Classes[sw.PeerClass(p)]
+	if !ok {
+		limit = sw.bandwidth.Classes[PeerClassDefault]
+	}
+	rp.SetRateLimits(limit.SendRate, limit.RecvRate)
+
+	// reactor names first, so limits of single channels take precedence
+	for _, byID := range []bool{false, true} {
+		for ch, limit := range sw.bandwidth.Channels {
+			if strings.HasPrefix(ch, "0x") != byID {
+				continue
+			}
+			chIDs, err := sw.channelIDs(ch)
+			if err != nil {
+				sw.Logger.Error("Invalid channel rate limit", "channel", ch, "err", err)
+				continue
+			}
+			for _, chID := range chIDs {
+				rp.SetChannelRateLimits(chID, limit.SendRate, limit.RecvRate)
+			}
+		}
+	}
+}
+
+// channelIDs returns the IDs of the channel given by reactor name or by ID
+// in hex.
+func (sw *Switch) channelIDs(channel string) ([]byte, error) {
+	if strings.HasPrefix(channel, "0x") {
+		id, err := strconv.ParseUint(channel[2:], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid channel ID %q: %w", channel, err)
+		}
+		if _, ok := sw.reactorsByCh[byte(id)]; !ok {
+			return nil, fmt.Errorf("unknown channel %q", channel)
+		}
+		return []byte{byte(id)}, nil
+	}
+
+	for name, reactor := range sw.reactors {
+		if !strings.EqualFold(name, channel) {
+			continue
+		}
+		chDescs := reactor.GetChannels()
+		ids := make([]byte, len(chDescs))
+		for i, chDesc := range chDescs {
+			ids[i] = chDesc.ID
+		}
+		return ids, nil
+	}
+	return nil, fmt.Errorf("unknown channel %q", channel)
+}
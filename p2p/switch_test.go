@@ -17,12 +17,14 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/arcology-network/consensus-engine/config"
 	"github.com/arcology-network/consensus-engine/crypto/ed25519"
 	"github.com/arcology-network/consensus-engine/libs/log"
 	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
 	"github.com/arcology-network/consensus-engine/p2p/conn"
+	"github.com/arcology-network/consensus-engine/p2p/trust"
 )
 
 var (
@@ -446,6 +448,148 @@ func TestSwitchStopPeerForError(t *testing.T) {
 	assert.EqualValues(t, 0, peersMetricValue())
 }
 
+func TestSwitchBansMisbehavingPeer(t *testing.T) {
+	bs, err := NewBanStore(dbm.NewMemDB())
+	require.NoError(t, err)
+	ps := NewPeerScorer(trust.NewTrustMetricStore(dbm.NewMemDB(), trust.DefaultConfig()), bs, 20, time.Hour)
+	ps.SetLogger(log.TestingLogger())
+	require.NoError(t, ps.Start())
+	t.Cleanup(func() {
+		if err := ps.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc, SwitchPeerScorer(ps))
+	err = sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// simulate remote peer
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	t.Cleanup(rp.Stop)
+
+	err = sw.DialPeerWithAddress(rp.Addr())
+	require.NoError(t, err)
+	p := sw.Peers().Get(rp.ID())
+	require.NotNil(t, p)
+
+	for i := 0; i < banAfterStrikes; i++ {
+		sw.MarkPeerAsBad(p, "bad message")
+	}
+	assert.Less(t, sw.PeerScore(rp.ID()), 20)
+	assertNoPeersAfterTimeout(t, sw, 100*time.Millisecond)
+
+	// the peer can't reconnect while banned
+	err = sw.DialPeerWithAddress(rp.Addr())
+	if assert.Error(t, err) {
+		rejected, ok := err.(ErrRejected)
+		require.True(t, ok)
+		assert.True(t, rejected.IsBanned())
+	}
+}
+
+func TestSwitchNeverBansPersistentPeer(t *testing.T) {
+	bs, err := NewBanStore(dbm.NewMemDB())
+	require.NoError(t, err)
+	ps := NewPeerScorer(trust.NewTrustMetricStore(dbm.NewMemDB(), trust.DefaultConfig()), bs, 20, time.Hour)
+	ps.SetLogger(log.TestingLogger())
+	require.NoError(t, ps.Start())
+	t.Cleanup(func() {
+		if err := ps.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc, SwitchPeerScorer(ps))
+	err = sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// simulate remote peer
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	t.Cleanup(rp.Stop)
+	require.NoError(t, sw.AddPersistentPeers([]string{rp.Addr().String()}))
+
+	err = sw.DialPeerWithAddress(rp.Addr())
+	require.NoError(t, err)
+	p := sw.Peers().Get(rp.ID())
+	require.NotNil(t, p)
+	require.True(t, p.IsPersistent())
+
+	for i := 0; i < 2*banAfterStrikes; i++ {
+		sw.MarkPeerAsBad(p, "bad message")
+	}
+	assert.Less(t, sw.PeerScore(rp.ID()), 20)
+
+	// the peer stays connected and is never written to the ban store
+	assert.False(t, bs.IsIDBanned(rp.ID()))
+	assert.Empty(t, bs.List())
+	assert.NotNil(t, sw.Peers().Get(rp.ID()))
+}
+
+func TestSwitchBandwidthLimits(t *testing.T) {
+	limits := BandwidthLimits{
+		Classes: map[PeerClass]RateLimit{PeerClassValidator: {SendRate: 0, RecvRate: 0}},
+		Channels: map[string]RateLimit{
+			"foo":  {SendRate: 100, RecvRate: 0},
+			"0x01": {SendRate: 200, RecvRate: 0},
+		},
+	}
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc, SwitchBandwidthLimits(limits))
+	err := sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// simulate remote peer
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	t.Cleanup(rp.Stop)
+	require.NoError(t, sw.AddValidatorPeerIDs([]string{string(rp.ID())}))
+
+	err = sw.DialPeerWithAddress(rp.Addr())
+	require.NoError(t, err)
+	p := sw.Peers().Get(rp.ID())
+	require.NotNil(t, p)
+	assert.Equal(t, PeerClassValidator, sw.PeerClass(p))
+
+	channelSendRates := func() map[byte]int64 {
+		rates := make(map[byte]int64)
+		for _, ch := range p.Status().Channels {
+			rates[ch.ID] = ch.SendRate
+		}
+		return rates
+	}
+	// the channel ID takes precedence over the reactor name
+	assert.Equal(t, map[byte]int64{0x00: 100, 0x01: 200, 0x02: 0, 0x03: 0}, channelSendRates())
+
+	require.NoError(t, sw.SetChannelRateLimit("BAR", RateLimit{SendRate: 300}))
+	assert.Equal(t, map[byte]int64{0x00: 100, 0x01: 200, 0x02: 300, 0x03: 300}, channelSendRates())
+	assert.Equal(t, RateLimit{SendRate: 300}, sw.BandwidthLimits().Channels["bar"])
+	assert.Equal(t, RateLimit{SendRate: cfg.SendRate, RecvRate: cfg.RecvRate},
+		sw.BandwidthLimits().Classes[PeerClassDefault])
+
+	assert.Error(t, sw.SetChannelRateLimit("baz", RateLimit{}))
+	assert.Error(t, sw.SetChannelRateLimit("0x05", RateLimit{}))
+	assert.Error(t, sw.SetChannelRateLimit("foo", RateLimit{SendRate: -1}))
+	assert.Error(t, sw.SetPeerRateLimit("bogus", RateLimit{}))
+	assert.NoError(t, sw.SetPeerRateLimit(PeerClassPersistent, RateLimit{SendRate: 1000, RecvRate: 1000}))
+}
+
 func TestSwitchReconnectsToOutboundPersistentPeer(t *testing.T) {
 	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
 	err := sw.Start()
@@ -10,6 +10,7 @@ import (
 
 	"github.com/arcology-network/consensus-engine/crypto"
 	"github.com/arcology-network/consensus-engine/libs/protoio"
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
 	"github.com/arcology-network/consensus-engine/p2p/conn"
 	tmp2p "github.com/arcology-network/consensus-engine/proto/tendermint/p2p"
 )
@@ -124,6 +125,12 @@ func MultiplexTransportResolver(resolver IPResolver) MultiplexTransportOption {
 	return func(mt *MultiplexTransport) { mt.resolver = resolver }
 }
 
+// MultiplexTransportExternalAddrDiscovery reports the addresses peers observe
+// our connections from in the handshake to d.
+func MultiplexTransportExternalAddrDiscovery(d *ExternalAddrDiscovery) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.addrDiscovery = d }
+}
+
 // MultiplexTransportMaxIncomingConnections sets the maximum number of
 // simultaneous connections (incoming). Default: 0 (unlimited)
 func MultiplexTransportMaxIncomingConnections(n int) MultiplexTransportOption {
@@ -147,9 +154,11 @@ type MultiplexTransport struct {
 	dialTimeout      time.Duration
 	filterTimeout    time.Duration
 	handshakeTimeout time.Duration
+	nodeInfoMtx      tmsync.RWMutex
 	nodeInfo         NodeInfo
 	nodeKey          NodeKey
 	resolver         IPResolver
+	addrDiscovery    *ExternalAddrDiscovery
 
 	// TODO(xla): This config is still needed as we parameterise peerConn and
 	// peer currently. All relevant configuration should be refactored into options
@@ -186,6 +195,21 @@ func (mt *MultiplexTransport) NetAddress() NetAddress {
 	return mt.netAddr
 }
 
+// NodeInfo returns the NodeInfo sent to peers in the handshake.
+func (mt *MultiplexTransport) NodeInfo() NodeInfo {
+	mt.nodeInfoMtx.RLock()
+	defer mt.nodeInfoMtx.RUnlock()
+	return mt.nodeInfo
+}
+
+// SetNodeInfo sets the NodeInfo sent to peers in the handshake, e.g. when the
+// external address changes.
+func (mt *MultiplexTransport) SetNodeInfo(nodeInfo NodeInfo) {
+	mt.nodeInfoMtx.Lock()
+	defer mt.nodeInfoMtx.Unlock()
+	mt.nodeInfo = nodeInfo
+}
+
 // Accept implements Transport.
 func (mt *MultiplexTransport) Accept(cfg peerConfig) (Peer, error) {
 	select {
@@ -350,22 +374,34 @@ func (mt *MultiplexTransport) filterConn(c net.Conn) (err error) {
 		}
 	}()
 
+	return applyConnFilters(c, mt.conns, mt.resolver, mt.connFilters, mt.filterTimeout)
+}
+
+// applyConnFilters rejects c if it is already in conns or if one of the
+// filters rejects it. Otherwise c is added to conns.
+func applyConnFilters(
+	c net.Conn,
+	conns ConnSet,
+	resolver IPResolver,
+	filters []ConnFilterFunc,
+	filterTimeout time.Duration,
+) error {
 	// Reject if connection is already present.
-	if mt.conns.Has(c) {
+	if conns.Has(c) {
 		return ErrRejected{conn: c, isDuplicate: true}
 	}
 
 	// Resolve ips for incoming conn.
-	ips, err := resolveIPs(mt.resolver, c)
+	ips, err := resolveIPs(resolver, c)
 	if err != nil {
 		return err
 	}
 
-	errc := make(chan error, len(mt.connFilters))
+	errc := make(chan error, len(filters))
 
-	for _, f := range mt.connFilters {
+	for _, f := range filters {
 		go func(f ConnFilterFunc, c net.Conn, ips []net.IP, errc chan<- error) {
-			errc <- f(mt.conns, c, ips)
+			errc <- f(conns, c, ips)
 		}(f, c, ips, errc)
 	}
 
@@ -375,13 +411,13 @@ func (mt *MultiplexTransport) filterConn(c net.Conn) (err error) {
 			if err != nil {
 				return ErrRejected{conn: c, err: err, isFiltered: true}
 			}
-		case <-time.After(mt.filterTimeout):
+		case <-time.After(filterTimeout):
 			return ErrFilterTimeout{}
 		}
 
 	}
 
-	mt.conns.Set(c, ips)
+	conns.Set(c, ips)
 
 	return nil
 }
@@ -422,7 +458,8 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	nodeInfo, err = handshake(secretConn, mt.handshakeTimeout, mt.nodeInfo)
+	ourNodeInfo := mt.NodeInfo()
+	nodeInfo, observedAddr, err := handshake(secretConn, mt.handshakeTimeout, ourNodeInfo)
 	if err != nil {
 		return nil, nil, ErrRejected{
 			conn:          c,
@@ -431,8 +468,22 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
+	if err := checkPeerNodeInfo(c, connID, ourNodeInfo, nodeInfo); err != nil {
+		return nil, nil, err
+	}
+
+	if mt.addrDiscovery != nil {
+		mt.addrDiscovery.Observe(connID, observedAddr)
+	}
+
+	return secretConn, nodeInfo, nil
+}
+
+// checkPeerNodeInfo checks the NodeInfo the peer sent in the handshake over c,
+// where the peer authenticated as connID.
+func checkPeerNodeInfo(c net.Conn, connID ID, ourNodeInfo, nodeInfo NodeInfo) error {
 	if err := nodeInfo.Validate(); err != nil {
-		return nil, nil, ErrRejected{
+		return ErrRejected{
 			conn:              c,
 			err:               err,
 			isNodeInfoInvalid: true,
@@ -441,7 +492,7 @@ func (mt *MultiplexTransport) upgrade(
 
 	// Ensure connection key matches self reported key.
 	if connID != nodeInfo.ID() {
-		return nil, nil, ErrRejected{
+		return ErrRejected{
 			conn: c,
 			id:   connID,
 			err: fmt.Errorf(
@@ -454,8 +505,8 @@ func (mt *MultiplexTransport) upgrade(
 	}
 
 	// Reject self.
-	if mt.nodeInfo.ID() == nodeInfo.ID() {
-		return nil, nil, ErrRejected{
+	if ourNodeInfo.ID() == nodeInfo.ID() {
+		return ErrRejected{
 			addr:   *NewNetAddress(nodeInfo.ID(), c.RemoteAddr()),
 			conn:   c,
 			id:     nodeInfo.ID(),
@@ -463,8 +514,8 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	if err := mt.nodeInfo.CompatibleWith(nodeInfo); err != nil {
-		return nil, nil, ErrRejected{
+	if err := ourNodeInfo.CompatibleWith(nodeInfo); err != nil {
+		return ErrRejected{
 			conn:           c,
 			err:            err,
 			id:             nodeInfo.ID(),
@@ -472,7 +523,7 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	return secretConn, nodeInfo, nil
+	return nil
 }
 
 func (mt *MultiplexTransport) wrapPeer(
@@ -481,6 +532,19 @@ func (mt *MultiplexTransport) wrapPeer(
 	cfg peerConfig,
 	socketAddr *NetAddress,
 ) Peer {
+	return wrapPeer(c, nil, ni, cfg, socketAddr, mt.mConfig)
+}
+
+// wrapPeer returns a Peer for the upgraded connection c, or for the streams
+// per channel if the transport has native streams.
+func wrapPeer(
+	c net.Conn,
+	streams map[byte]net.Conn,
+	ni NodeInfo,
+	cfg peerConfig,
+	socketAddr *NetAddress,
+	mConfig conn.MConnConfig,
+) Peer {
 
 	persistent := false
 	if cfg.isPersistent != nil {
@@ -500,10 +564,11 @@ func (mt *MultiplexTransport) wrapPeer(
 		c,
 		socketAddr,
 	)
+	peerConn.streams = streams
 
 	p := newPeer(
 		peerConn,
-		mt.mConfig,
+		mConfig,
 		ni,
 		cfg.reactorsByCh,
 		cfg.chDescs,
@@ -514,13 +579,17 @@ func (mt *MultiplexTransport) wrapPeer(
 	return p
 }
 
+// handshake exchanges NodeInfo with the peer over c. Along with our NodeInfo,
+// it tells the peer the address we observe its connection from, and returns
+// the address the peer observed ours from, which is empty if the peer didn't
+// report it.
 func handshake(
 	c net.Conn,
 	timeout time.Duration,
 	nodeInfo NodeInfo,
-) (NodeInfo, error) {
+) (NodeInfo, string, error) {
 	if err := c.SetDeadline(time.Now().Add(timeout)); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	var (
@@ -532,7 +601,11 @@ func handshake(
 	)
 
 	go func(errc chan<- error, c net.Conn) {
-		_, err := protoio.NewDelimitedWriter(c).WriteMsg(ourNodeInfo.ToProto())
+		pbNodeInfo := ourNodeInfo.ToProto()
+		if addr := c.RemoteAddr(); addr != nil {
+			pbNodeInfo.ObservedAddr = addr.String()
+		}
+		_, err := protoio.NewDelimitedWriter(c).WriteMsg(pbNodeInfo)
 		errc <- err
 	}(errc, c)
 	go func(errc chan<- error, c net.Conn) {
@@ -544,16 +617,16 @@ func handshake(
 	for i := 0; i < cap(errc); i++ {
 		err := <-errc
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 	}
 
 	peerNodeInfo, err := DefaultNodeInfoFromToProto(&pbpeerNodeInfo)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return peerNodeInfo, c.SetDeadline(time.Time{})
+	return peerNodeInfo, pbpeerNodeInfo.ObservedAddr, c.SetDeadline(time.Time{})
 }
 
 func upgradeSecretConn(
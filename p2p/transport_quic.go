@@ -0,0 +1,576 @@
+package p2p
+
+import (
+	"context"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/arcology-network/consensus-engine/crypto/ed25519"
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
+	"github.com/arcology-network/consensus-engine/p2p/conn"
+)
+
+const (
+	// quicALPN is the application protocol negotiated in the TLS handshake.
+	quicALPN = "tendermint-p2p"
+
+	quicKeepAlivePeriod = 15 * time.Second
+	quicMaxIdleTimeout  = 45 * time.Second
+)
+
+// QUICTransportOption sets an optional parameter on the QUICTransport.
+type QUICTransportOption func(*QUICTransport)
+
+// QUICTransportConnFilters sets the filters for rejection new connections.
+func QUICTransportConnFilters(filters ...ConnFilterFunc) QUICTransportOption {
+	return func(qt *QUICTransport) { qt.connFilters = filters }
+}
+
+// QUICTransportFilterTimeout sets the timeout waited for filter calls to
+// return.
+func QUICTransportFilterTimeout(timeout time.Duration) QUICTransportOption {
+	return func(qt *QUICTransport) { qt.filterTimeout = timeout }
+}
+
+// QUICTransportExternalAddrDiscovery reports the addresses peers observe our
+// connections from in the handshake to d.
+func QUICTransportExternalAddrDiscovery(d *ExternalAddrDiscovery) QUICTransportOption {
+	return func(qt *QUICTransport) { qt.addrDiscovery = d }
+}
+
+// QUICTransportMaxIncomingConnections sets the maximum number of
+// simultaneous connections (incoming). Default: 0 (unlimited)
+func QUICTransportMaxIncomingConnections(n int) QUICTransportOption {
+	return func(qt *QUICTransport) { qt.maxIncomingConnections = n }
+}
+
+// QUICTransport accepts and dials QUIC connections and upgrades them to
+// peers, which send every channel over a QUIC stream of its own. Unlike with
+// the MultiplexTransport, a channel moving a lot of data, like the mempool,
+// doesn't hold back the messages of other channels, like consensus votes.
+//
+// Both ends authenticate in the TLS handshake with a self-signed certificate
+// for their node key, which has to be an ed25519 key, so the remote node ID
+// is as trustworthy as the one from a SecretConnection.
+//
+// A QUICTransport listens on the UDP port of the listen address, so it can
+// only reach peers which use QUIC as well.
+type QUICTransport struct {
+	netAddr                NetAddress
+	listener               *quic.Listener
+	maxIncomingConnections int
+	numIncomingConnections int32 // atomic
+
+	acceptc chan quicAccept
+	closec  chan struct{}
+
+	// Lookup table for duplicate ip and id checks.
+	conns       ConnSet
+	connFilters []ConnFilterFunc
+
+	dialTimeout      time.Duration
+	filterTimeout    time.Duration
+	handshakeTimeout time.Duration
+	nodeInfoMtx      tmsync.RWMutex
+	nodeInfo         NodeInfo
+	nodeKey          NodeKey
+	resolver         IPResolver
+	tlsConfig        *tls.Config
+	addrDiscovery    *ExternalAddrDiscovery
+
+	mConfig conn.MConnConfig
+}
+
+// quicAccept is the container to carry the upgraded connection and NodeInfo
+// from an asynchronously running routine to the Accept method.
+type quicAccept struct {
+	netAddr  *NetAddress
+	conn     *quicConn
+	streams  map[byte]net.Conn
+	nodeInfo NodeInfo
+	err      error
+}
+
+// Test QUICTransport for interface completeness.
+var _ Transport = (*QUICTransport)(nil)
+var _ transportLifecycle = (*QUICTransport)(nil)
+
+// NewQUICTransport returns a QUIC connected peer transport. It fails if the
+// node key isn't an ed25519 key.
+func NewQUICTransport(
+	nodeInfo NodeInfo,
+	nodeKey NodeKey,
+	mConfig conn.MConnConfig,
+	options ...QUICTransportOption,
+) (*QUICTransport, error) {
+	tlsConfig, err := quicTLSConfig(nodeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	qt := &QUICTransport{
+		acceptc:          make(chan quicAccept),
+		closec:           make(chan struct{}),
+		dialTimeout:      defaultDialTimeout,
+		filterTimeout:    defaultFilterTimeout,
+		handshakeTimeout: defaultHandshakeTimeout,
+		mConfig:          mConfig,
+		nodeInfo:         nodeInfo,
+		nodeKey:          nodeKey,
+		conns:            NewConnSet(),
+		resolver:         net.DefaultResolver,
+		tlsConfig:        tlsConfig,
+	}
+	for _, option := range options {
+		option(qt)
+	}
+	return qt, nil
+}
+
+// NetAddress implements Transport.
+func (qt *QUICTransport) NetAddress() NetAddress {
+	return qt.netAddr
+}
+
+// NodeInfo returns the NodeInfo sent to peers in the handshake.
+func (qt *QUICTransport) NodeInfo() NodeInfo {
+	qt.nodeInfoMtx.RLock()
+	defer qt.nodeInfoMtx.RUnlock()
+	return qt.nodeInfo
+}
+
+// SetNodeInfo sets the NodeInfo sent to peers in the handshake, e.g. when the
+// external address changes.
+func (qt *QUICTransport) SetNodeInfo(nodeInfo NodeInfo) {
+	qt.nodeInfoMtx.Lock()
+	defer qt.nodeInfoMtx.Unlock()
+	qt.nodeInfo = nodeInfo
+}
+
+// Accept implements Transport.
+func (qt *QUICTransport) Accept(cfg peerConfig) (Peer, error) {
+	select {
+	// This case should never have any side-effectful/blocking operations to
+	// ensure that quality peers are ready to be used.
+	case a := <-qt.acceptc:
+		if a.err != nil {
+			return nil, a.err
+		}
+
+		cfg.outbound = false
+
+		return wrapPeer(a.conn, a.streams, a.nodeInfo, cfg, a.netAddr, qt.mConfig), nil
+	case <-qt.closec:
+		return nil, ErrTransportClosed{}
+	}
+}
+
+// Dial implements Transport.
+func (qt *QUICTransport) Dial(
+	addr NetAddress,
+	cfg peerConfig,
+) (Peer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), qt.dialTimeout)
+	defer cancel()
+
+	qc, err := quic.DialAddr(ctx, addr.DialString(), qt.tlsConfig, qt.quicConfig())
+	if err != nil {
+		return nil, err
+	}
+	c := &quicConn{qc: qc}
+
+	// TODO(xla): Evaluate if we should apply filters if we explicitly dial.
+	if err := qt.filterConn(c); err != nil {
+		return nil, err
+	}
+
+	nodeInfo, streams, err := qt.upgrade(c, &addr)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.outbound = true
+
+	return wrapPeer(c, streams, nodeInfo, cfg, &addr, qt.mConfig), nil
+}
+
+// Close implements transportLifecycle.
+func (qt *QUICTransport) Close() error {
+	close(qt.closec)
+
+	if qt.listener != nil {
+		return qt.listener.Close()
+	}
+
+	return nil
+}
+
+// Listen implements transportLifecycle.
+func (qt *QUICTransport) Listen(addr NetAddress) error {
+	ln, err := quic.ListenAddr(addr.DialString(), qt.tlsConfig, qt.quicConfig())
+	if err != nil {
+		return err
+	}
+
+	if addr.Port == 0 {
+		addr.Port = uint16(ln.Addr().(*net.UDPAddr).Port)
+	}
+	qt.netAddr = addr
+	qt.listener = ln
+
+	go qt.acceptPeers()
+
+	return nil
+}
+
+func (qt *QUICTransport) acceptPeers() {
+	for {
+		qc, err := qt.listener.Accept(context.Background())
+		if err != nil {
+			// If Close() has been called, silently exit.
+			select {
+			case _, ok := <-qt.closec:
+				if !ok {
+					return
+				}
+			default:
+				// Transport is not closed
+			}
+
+			qt.acceptc <- quicAccept{err: err}
+			return
+		}
+
+		if qt.maxIncomingConnections > 0 {
+			if int(atomic.AddInt32(&qt.numIncomingConnections, 1)) > qt.maxIncomingConnections {
+				atomic.AddInt32(&qt.numIncomingConnections, -1)
+				_ = qc.CloseWithError(0, "too many connections")
+				continue
+			}
+			go func(qc quic.Connection) {
+				<-qc.Context().Done()
+				atomic.AddInt32(&qt.numIncomingConnections, -1)
+			}(qc)
+		}
+
+		// Connection upgrade and filtering should be asynchronous to avoid
+		// Head-of-line blocking, like in MultiplexTransport.
+		go func(c *quicConn) {
+			defer func() {
+				if r := recover(); r != nil {
+					err := ErrRejected{
+						conn:          c,
+						err:           fmt.Errorf("recovered from panic: %v", r),
+						isAuthFailure: true,
+					}
+					select {
+					case qt.acceptc <- quicAccept{err: err}:
+					case <-qt.closec:
+						// Give up if the transport was closed.
+						_ = c.Close()
+						return
+					}
+				}
+			}()
+
+			var (
+				nodeInfo NodeInfo
+				streams  map[byte]net.Conn
+				netAddr  *NetAddress
+			)
+
+			err := qt.filterConn(c)
+			if err == nil {
+				nodeInfo, streams, err = qt.upgrade(c, nil)
+				if err == nil {
+					netAddr = NewNetAddress(nodeInfo.ID(), c.RemoteAddr())
+				}
+			}
+
+			select {
+			case qt.acceptc <- quicAccept{netAddr, c, streams, nodeInfo, err}:
+				// Make the upgraded peer available.
+			case <-qt.closec:
+				// Give up if the transport was closed.
+				_ = c.Close()
+				return
+			}
+		}(&quicConn{qc: qc})
+	}
+}
+
+// Cleanup removes the given address from the connections set and
+// closes the connection.
+func (qt *QUICTransport) Cleanup(p Peer) {
+	qt.conns.RemoveAddr(p.RemoteAddr())
+	_ = p.CloseConn()
+}
+
+func (qt *QUICTransport) cleanup(c net.Conn) error {
+	qt.conns.Remove(c)
+
+	return c.Close()
+}
+
+func (qt *QUICTransport) filterConn(c net.Conn) (err error) {
+	defer func() {
+		if err != nil {
+			_ = c.Close()
+		}
+	}()
+
+	return applyConnFilters(c, qt.conns, qt.resolver, qt.connFilters, qt.filterTimeout)
+}
+
+// upgrade authenticates the peer, exchanges the NodeInfo over the first
+// stream of the connection and then sets up a stream for every channel both
+// ends have.
+func (qt *QUICTransport) upgrade(
+	c *quicConn,
+	dialedAddr *NetAddress,
+) (nodeInfo NodeInfo, streams map[byte]net.Conn, err error) {
+	defer func() {
+		if err != nil {
+			_ = qt.cleanup(c)
+		}
+	}()
+
+	connID, err := quicRemoteID(c.qc)
+	if err != nil {
+		return nil, nil, ErrRejected{
+			conn:          c,
+			err:           err,
+			isAuthFailure: true,
+		}
+	}
+
+	// For outgoing conns, ensure connection key matches dialed key.
+	if dialedAddr != nil {
+		if dialedID := dialedAddr.ID; connID != dialedID {
+			return nil, nil, ErrRejected{
+				conn: c,
+				id:   connID,
+				err: fmt.Errorf(
+					"conn.ID (%v) dialed ID (%v) mismatch",
+					connID,
+					dialedID,
+				),
+				isAuthFailure: true,
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), qt.handshakeTimeout)
+	defer cancel()
+
+	// The dialer opens the handshake stream.
+	if dialedAddr != nil {
+		c.Stream, err = c.qc.OpenStreamSync(ctx)
+	} else {
+		c.Stream, err = c.qc.AcceptStream(ctx)
+	}
+	if err != nil {
+		return nil, nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("handshake stream failed: %v", err),
+			isAuthFailure: true,
+		}
+	}
+
+	ourNodeInfo := qt.NodeInfo()
+	nodeInfo, observedAddr, err := handshake(c, qt.handshakeTimeout, ourNodeInfo)
+	if err != nil {
+		return nil, nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("handshake failed: %v", err),
+			isAuthFailure: true,
+		}
+	}
+
+	if err := checkPeerNodeInfo(c, connID, ourNodeInfo, nodeInfo); err != nil {
+		return nil, nil, err
+	}
+
+	if qt.addrDiscovery != nil {
+		qt.addrDiscovery.Observe(connID, observedAddr)
+	}
+
+	streams, err = qt.channelStreams(ctx, c, ourNodeInfo, nodeInfo, dialedAddr != nil)
+	if err != nil {
+		return nil, nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("channel streams failed: %v", err),
+			id:            connID,
+			isAuthFailure: true,
+		}
+	}
+
+	return nodeInfo, streams, nil
+}
+
+// channelStreams sets up a stream for every channel both ends have. The
+// dialer opens the streams and starts each with the channel ID.
+func (qt *QUICTransport) channelStreams(
+	ctx context.Context,
+	c *quicConn,
+	ourNodeInfo, nodeInfo NodeInfo,
+	outbound bool,
+) (map[byte]net.Conn, error) {
+	ours := make(map[byte]bool)
+	for _, chID := range ourNodeInfo.(DefaultNodeInfo).Channels {
+		ours[chID] = true
+	}
+	common := make(map[byte]bool)
+	for _, chID := range nodeInfo.(DefaultNodeInfo).Channels {
+		if ours[chID] {
+			common[chID] = true
+		}
+	}
+
+	streams := make(map[byte]net.Conn, len(common))
+	if outbound {
+		for chID := range common {
+			s, err := c.qc.OpenStreamSync(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := s.Write([]byte{chID}); err != nil {
+				return nil, err
+			}
+			streams[chID] = &streamConn{Stream: s, qc: c.qc}
+		}
+		return streams, nil
+	}
+
+	deadline, _ := ctx.Deadline()
+	for len(streams) < len(common) {
+		s, err := c.qc.AcceptStream(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.SetReadDeadline(deadline); err != nil {
+			return nil, err
+		}
+		var chID [1]byte
+		if _, err := s.Read(chID[:]); err != nil {
+			return nil, err
+		}
+		if err := s.SetReadDeadline(time.Time{}); err != nil {
+			return nil, err
+		}
+		if !common[chID[0]] {
+			return nil, fmt.Errorf("unexpected stream for channel %X", chID[0])
+		}
+		if _, ok := streams[chID[0]]; ok {
+			return nil, fmt.Errorf("duplicate stream for channel %X", chID[0])
+		}
+		streams[chID[0]] = &streamConn{Stream: s, qc: c.qc}
+	}
+	return streams, nil
+}
+
+func (qt *QUICTransport) quicConfig() *quic.Config {
+	return &quic.Config{
+		HandshakeIdleTimeout: qt.handshakeTimeout,
+		MaxIdleTimeout:       quicMaxIdleTimeout,
+		KeepAlivePeriod:      quicKeepAlivePeriod,
+	}
+}
+
+// quicConn is a QUIC connection as a net.Conn. It reads and writes the
+// handshake stream, which is only set once the connection is upgraded, and
+// closing it closes the whole connection.
+type quicConn struct {
+	quic.Stream
+	qc quic.Connection
+}
+
+func (c *quicConn) LocalAddr() net.Addr  { return c.qc.LocalAddr() }
+func (c *quicConn) RemoteAddr() net.Addr { return c.qc.RemoteAddr() }
+func (c *quicConn) Close() error         { return c.qc.CloseWithError(0, "") }
+
+// streamConn is a QUIC stream as a net.Conn.
+type streamConn struct {
+	quic.Stream
+	qc quic.Connection
+}
+
+func (s *streamConn) LocalAddr() net.Addr  { return s.qc.LocalAddr() }
+func (s *streamConn) RemoteAddr() net.Addr { return s.qc.RemoteAddr() }
+
+// Close closes both directions of the stream.
+func (s *streamConn) Close() error {
+	s.CancelRead(0)
+	return s.Stream.Close()
+}
+
+// quicTLSConfig returns a TLS config which authenticates both ends with a
+// self-signed certificate for their node key.
+func quicTLSConfig(nodeKey NodeKey) (*tls.Config, error) {
+	privKey, ok := nodeKey.PrivKey.(ed25519.PrivKey)
+	if !ok {
+		return nil, fmt.Errorf("QUIC transport requires an ed25519 node key, got %T", nodeKey.PrivKey)
+	}
+	key := stded25519.PrivateKey(privKey)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(100 * 365 * 24 * time.Hour),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create node key certificate: %w", err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{cert}, PrivateKey: key}},
+		ClientAuth:   tls.RequireAnyClientCert,
+		// There is no CA; the remote node ID is checked against the dialed one
+		// after the handshake instead.
+		InsecureSkipVerify:    true, // nolint:gosec
+		VerifyPeerCertificate: verifyNodeKeyCertificate,
+		NextProtos:            []string{quicALPN},
+		MinVersion:            tls.VersionTLS13,
+	}, nil
+}
+
+// verifyNodeKeyCertificate checks that the remote end presented a single
+// certificate, self-signed with an ed25519 node key.
+func verifyNodeKeyCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) != 1 {
+		return fmt.Errorf("expected one certificate, got %d", len(rawCerts))
+	}
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return err
+	}
+	if _, ok := cert.PublicKey.(stded25519.PublicKey); !ok {
+		return fmt.Errorf("expected an ed25519 certificate, got %T", cert.PublicKey)
+	}
+	return cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature)
+}
+
+// quicRemoteID returns the node ID of the certificate the remote end
+// authenticated with.
+func quicRemoteID(qc quic.Connection) (ID, error) {
+	certs := qc.ConnectionState().TLS.PeerCertificates
+	if len(certs) == 0 {
+		return "", errors.New("no peer certificate")
+	}
+	pubKey, ok := certs[0].PublicKey.(stded25519.PublicKey)
+	if !ok {
+		return "", fmt.Errorf("expected an ed25519 certificate, got %T", certs[0].PublicKey)
+	}
+	return PubKeyToID(ed25519.PubKey(pubKey)), nil
+}
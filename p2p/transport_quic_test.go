@@ -0,0 +1,108 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arcology-network/consensus-engine/crypto/ed25519"
+	"github.com/arcology-network/consensus-engine/crypto/secp256k1"
+	"github.com/arcology-network/consensus-engine/libs/log"
+)
+
+// makeQUICSwitch returns a started switch which uses a QUICTransport
+// listening on loopback.
+func makeQUICSwitch(t *testing.T, i int) *Switch {
+	nodeKey := NodeKey{PrivKey: ed25519.GenPrivKey()}
+	ni := testNodeInfo(nodeKey.ID(), "quic").(DefaultNodeInfo)
+	ni.Channels = nil
+
+	qt, err := NewQUICTransport(ni, nodeKey, MConnConfig(cfg))
+	require.NoError(t, err)
+
+	sw := initSwitchFunc(i, NewSwitch(cfg, qt))
+	sw.SetLogger(log.TestingLogger().With("switch", i))
+	sw.SetNodeKey(&nodeKey)
+	for ch := range sw.reactorsByCh {
+		ni.Channels = append(ni.Channels, ch)
+	}
+	qt.nodeInfo = ni
+	sw.SetNodeInfo(ni)
+
+	addr, err := NewNetAddressString(IDAddressString(nodeKey.ID(), "127.0.0.1:0"))
+	require.NoError(t, err)
+	require.NoError(t, qt.Listen(*addr))
+	t.Cleanup(func() { _ = qt.Close() })
+
+	require.NoError(t, sw.Start())
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	return sw
+}
+
+func TestQUICTransportSwitches(t *testing.T) {
+	s1 := makeQUICSwitch(t, 1)
+	s2 := makeQUICSwitch(t, 2)
+
+	addr := s2.NetAddress()
+	require.NoError(t, s1.DialPeerWithAddress(addr))
+	require.Eventually(t, func() bool { return s2.Peers().Size() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// every channel has a stream of its own
+	p := s1.Peers().Get(s2.NodeInfo().ID())
+	require.NotNil(t, p)
+	assert.Len(t, p.Status().Channels, 4)
+	assert.True(t, p.IsOutbound())
+
+	ch0Msg := []byte("channel zero")
+	ch1Msg := []byte("channel foo")
+	ch2Msg := []byte("channel bar")
+
+	s1.Broadcast(byte(0x00), ch0Msg)
+	s1.Broadcast(byte(0x01), ch1Msg)
+	s2.Broadcast(byte(0x02), ch2Msg)
+
+	assertMsgReceivedWithTimeout(t,
+		ch0Msg,
+		byte(0x00),
+		s2.Reactor("foo").(*TestReactor), 10*time.Millisecond, 5*time.Second)
+	assertMsgReceivedWithTimeout(t,
+		ch1Msg,
+		byte(0x01),
+		s2.Reactor("foo").(*TestReactor), 10*time.Millisecond, 5*time.Second)
+	assertMsgReceivedWithTimeout(t,
+		ch2Msg,
+		byte(0x02),
+		s1.Reactor("bar").(*TestReactor), 10*time.Millisecond, 5*time.Second)
+
+	// stopping the peer closes the connection on both ends
+	s1.StopPeerGracefully(p)
+	assertNoPeersAfterTimeout(t, s2, 5*time.Second)
+}
+
+func TestQUICTransportRejectsWrongID(t *testing.T) {
+	s1 := makeQUICSwitch(t, 1)
+	s2 := makeQUICSwitch(t, 2)
+
+	addr := *s2.NetAddress()
+	addr.ID = PubKeyToID(ed25519.GenPrivKey().PubKey())
+
+	err := s1.DialPeerWithAddress(&addr)
+	if assert.Error(t, err) {
+		rejected, ok := err.(ErrRejected)
+		require.True(t, ok, "%T", err)
+		assert.True(t, rejected.IsAuthFailure())
+	}
+	assert.Zero(t, s1.Peers().Size())
+}
+
+func TestQUICTransportRequiresEd25519Key(t *testing.T) {
+	nodeKey := NodeKey{PrivKey: secp256k1.GenPrivKey()}
+	_, err := NewQUICTransport(testNodeInfo(nodeKey.ID(), "quic"), nodeKey, MConnConfig(cfg))
+	assert.Error(t, err)
+}
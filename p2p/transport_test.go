@@ -305,7 +305,7 @@ func TestTransportMultiplexAcceptNonBlocking(t *testing.T) {
 			return
 		}
 
-		_, err = handshake(sc, 200*time.Millisecond,
+		_, _, err = handshake(sc, 200*time.Millisecond,
 			testNodeInfo(
 				PubKeyToID(ed25519.GenPrivKey().PubKey()),
 				"slow_peer",
@@ -463,6 +463,55 @@ func TestTransportMultiplexDialRejectWrongID(t *testing.T) {
 	}
 }
 
+func TestTransportMultiplexExternalAddrDiscovery(t *testing.T) {
+	mt := testSetupMultiplexTransport(t)
+	laddr := NewNetAddress(mt.nodeKey.ID(), mt.listener.Addr())
+
+	var (
+		pv     = ed25519.GenPrivKey()
+		dialer = newMultiplexTransport(
+			testNodeInfo(PubKeyToID(pv.PubKey()), defaultNodeName),
+			NodeKey{
+				PrivKey: pv,
+			},
+		)
+		dialerDiscovery   = NewExternalAddrDiscovery(false)
+		listenerDiscovery = NewExternalAddrDiscovery(false)
+	)
+	dialerDiscovery.quorum = 1
+	listenerDiscovery.quorum = 1
+	MultiplexTransportExternalAddrDiscovery(dialerDiscovery)(dialer)
+	MultiplexTransportExternalAddrDiscovery(listenerDiscovery)(mt)
+
+	discovered := make(chan net.IP, 1)
+	dialerDiscovery.SetOnDiscovered(func(ip net.IP) { discovered <- ip })
+
+	_, err := dialer.Dial(*laddr, peerConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// both ends report the address they see the other at
+	loopback := net.IPv4(127, 0, 0, 1)
+	select {
+	case ip := <-discovered:
+		if !ip.Equal(loopback) {
+			t.Errorf("have %v, want %v", ip, loopback)
+		}
+	default:
+		t.Fatal("external address not discovered")
+	}
+
+	p, err := mt.Accept(peerConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have := listenerDiscovery.IP(); !have.Equal(loopback) {
+		t.Errorf("have %v, want %v", have, loopback)
+	}
+	mt.Cleanup(p)
+}
+
 func TestTransportMultiplexRejectIncompatible(t *testing.T) {
 	mt := testSetupMultiplexTransport(t)
 
@@ -611,7 +660,7 @@ func TestTransportHandshake(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	ni, err := handshake(c, 20*time.Millisecond, emptyNodeInfo())
+	ni, _, err := handshake(c, 20*time.Millisecond, emptyNodeInfo())
 	if err != nil {
 		t.Fatal(err)
 	}
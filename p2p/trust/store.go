@@ -119,6 +119,15 @@ func (tms *MetricStore) GetPeerTrustMetric(key string) *Metric {
 	return tm
 }
 
+// HasPeerTrustMetric returns true if the store has a trust metric for the peer key
+func (tms *MetricStore) HasPeerTrustMetric(key string) bool {
+	tms.mtx.Lock()
+	defer tms.mtx.Unlock()
+
+	_, ok := tms.peerMetrics[key]
+	return ok
+}
+
 // PeerDisconnected pauses the trust metric associated with the peer identified by the key
 func (tms *MetricStore) PeerDisconnected(key string) {
 	tms.mtx.Lock()
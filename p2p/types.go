@@ -6,3 +6,10 @@ import (
 
 type ChannelDescriptor = conn.ChannelDescriptor
 type ConnectionStatus = conn.ConnectionStatus
+type PriorityClass = conn.PriorityClass
+
+const (
+	PriorityClassNormal   = conn.PriorityClassNormal
+	PriorityClassHigh     = conn.PriorityClassHigh
+	PriorityClassCritical = conn.PriorityClassCritical
+)
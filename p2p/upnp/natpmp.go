@@ -0,0 +1,363 @@
+package upnp
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NAT-PMP (RFC 6886) and its successor PCP (RFC 6887) share the gateway port
+// and the first two bytes of every message: the version, and the opcode,
+// which has the high bit set in responses.
+const (
+	pmpPort = 5351
+
+	natPMPVersion = 0
+	pcpVersion    = 2
+
+	natPMPOpExternalAddress = 0
+	natPMPOpMapUDP          = 1
+	natPMPOpMapTCP          = 2
+
+	pcpOpAnnounce = 0
+	pcpOpMap      = 1
+
+	pmpResponseBit = 0x80
+
+	pcpHeaderSize  = 24
+	pcpMapSize     = 36
+	pcpNonceSize   = 12
+	pcpProtocolTCP = 6
+	pcpProtocolUDP = 17
+
+	// pmpDefaultLifetime is the lifetime of the mappings added without one,
+	// as a zero lifetime deletes a mapping. They have to be renewed before it
+	// runs out.
+	pmpDefaultLifetime = 2 * 60 * 60 // seconds
+
+	// A request is resent with twice the timeout every time there is no
+	// response, as in RFC 6886 3.1, but with fewer tries.
+	pmpInitialTimeout = 250 * time.Millisecond
+	pmpTries          = 4
+)
+
+type pmpNAT struct {
+	gateway *net.UDPAddr
+	pcp     bool // false if the gateway only speaks NAT-PMP
+
+	mtx        sync.Mutex
+	externalIP net.IP                        // PCP: from the last mapping
+	nonces     map[string][pcpNonceSize]byte // PCP: by protocol and internal port
+}
+
+var _ NAT = (*pmpNAT)(nil)
+
+// DiscoverPMP returns the NAT of the gateway at gateway, a host or host:port,
+// which speaks PCP or NAT-PMP. PCP is preferred. If gateway is empty, the
+// default gateway is used, which is only found on Linux.
+func DiscoverPMP(gateway string) (NAT, error) {
+	addr, err := pmpGatewayAddr(gateway)
+	if err != nil {
+		return nil, err
+	}
+	nat := &pmpNAT{
+		gateway: addr,
+		nonces:  make(map[string][pcpNonceSize]byte),
+	}
+
+	// A PCP gateway answers an announcement, a NAT-PMP one tells it doesn't
+	// speak the version.
+	req, err := nat.pcpHeader(pcpOpAnnounce, 0)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := nat.call(req)
+	if err != nil {
+		return nil, fmt.Errorf("no NAT-PMP/PCP gateway at %v: %w", addr, err)
+	}
+	if resp[0] == pcpVersion {
+		if err := pcpResult(resp); err != nil {
+			return nil, err
+		}
+		nat.pcp = true
+		return nat, nil
+	}
+
+	if _, err := nat.GetExternalAddress(); err != nil {
+		return nil, err
+	}
+	return nat, nil
+}
+
+func pmpGatewayAddr(gateway string) (*net.UDPAddr, error) {
+	if gateway == "" {
+		ip, err := defaultGateway()
+		if err != nil {
+			return nil, fmt.Errorf("could not find the default gateway: %w", err)
+		}
+		return &net.UDPAddr{IP: ip, Port: pmpPort}, nil
+	}
+	if _, _, err := net.SplitHostPort(gateway); err != nil {
+		gateway = net.JoinHostPort(gateway, strconv.Itoa(pmpPort))
+	}
+	return net.ResolveUDPAddr("udp", gateway)
+}
+
+// defaultGateway returns the IPv4 default gateway from the Linux routing
+// table.
+func defaultGateway() (net.IP, error) {
+	f, err := os.Open("/proc/net/route")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// Iface Destination Gateway Flags ..., in little endian hex
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 3 || fields[1] != "00000000" {
+			continue
+		}
+		bz, err := hex.DecodeString(fields[2])
+		if err != nil || len(bz) != net.IPv4len {
+			continue
+		}
+		return net.IPv4(bz[3], bz[2], bz[1], bz[0]), nil
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("no default route")
+}
+
+// call sends req to the gateway and returns the response to it.
+func (n *pmpNAT) call(req []byte) ([]byte, error) {
+	conn, err := net.DialUDP("udp", nil, n.gateway)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	buf := make([]byte, 1100) // the maximum PCP message size
+	timeout := pmpInitialTimeout
+	for i := 0; i < pmpTries; i++ {
+		if _, err := conn.Write(req); err != nil {
+			return nil, err
+		}
+		if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return nil, err
+		}
+		for {
+			size, err := conn.Read(buf)
+			if err != nil {
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Timeout() {
+					break
+				}
+				return nil, err
+			}
+			// drop responses to other requests
+			if size >= 4 && buf[1] == req[1]|pmpResponseBit {
+				return append([]byte{}, buf[:size]...), nil
+			}
+		}
+		timeout *= 2
+	}
+	return nil, errors.New("no response")
+}
+
+// GetExternalAddress implements NAT. A PCP gateway only tells the external
+// address along with a mapping, so it is the one of the last mapping added.
+func (n *pmpNAT) GetExternalAddress() (net.IP, error) {
+	if n.pcp {
+		n.mtx.Lock()
+		defer n.mtx.Unlock()
+		if n.externalIP == nil {
+			return nil, errors.New("no port mapping added yet")
+		}
+		return n.externalIP, nil
+	}
+
+	resp, err := n.call([]byte{natPMPVersion, natPMPOpExternalAddress})
+	if err != nil {
+		return nil, err
+	}
+	if err := natPMPResult(resp, 12); err != nil {
+		return nil, err
+	}
+	return net.IPv4(resp[8], resp[9], resp[10], resp[11]), nil
+}
+
+// AddPortMapping implements NAT. A timeout of zero adds a mapping for
+// pmpDefaultLifetime, as neither protocol has permanent mappings.
+func (n *pmpNAT) AddPortMapping(
+	protocol string,
+	externalPort,
+	internalPort int,
+	description string,
+	timeout int) (int, error) {
+
+	if timeout <= 0 {
+		timeout = pmpDefaultLifetime
+	}
+	if n.pcp {
+		return n.pcpMap(protocol, externalPort, internalPort, timeout)
+	}
+	return n.natPMPMap(protocol, externalPort, internalPort, timeout)
+}
+
+// DeletePortMapping implements NAT.
+func (n *pmpNAT) DeletePortMapping(protocol string, externalPort, internalPort int) error {
+	var err error
+	if n.pcp {
+		_, err = n.pcpMap(protocol, 0, internalPort, 0)
+	} else {
+		_, err = n.natPMPMap(protocol, 0, internalPort, 0)
+	}
+	return err
+}
+
+func (n *pmpNAT) natPMPMap(protocol string, externalPort, internalPort, lifetime int) (int, error) {
+	var op byte
+	switch strings.ToLower(protocol) {
+	case "tcp":
+		op = natPMPOpMapTCP
+	case "udp":
+		op = natPMPOpMapUDP
+	default:
+		return 0, fmt.Errorf("unknown protocol %q", protocol)
+	}
+
+	req := make([]byte, 12)
+	req[0] = natPMPVersion
+	req[1] = op
+	binary.BigEndian.PutUint16(req[4:], uint16(internalPort))
+	binary.BigEndian.PutUint16(req[6:], uint16(externalPort))
+	binary.BigEndian.PutUint32(req[8:], uint32(lifetime))
+
+	resp, err := n.call(req)
+	if err != nil {
+		return 0, err
+	}
+	if err := natPMPResult(resp, 16); err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(resp[10:])), nil
+}
+
+func (n *pmpNAT) pcpMap(protocol string, externalPort, internalPort, lifetime int) (int, error) {
+	var proto byte
+	switch strings.ToLower(protocol) {
+	case "tcp":
+		proto = pcpProtocolTCP
+	case "udp":
+		proto = pcpProtocolUDP
+	default:
+		return 0, fmt.Errorf("unknown protocol %q", protocol)
+	}
+
+	// A mapping is renewed or deleted with the nonce it was added with.
+	key := fmt.Sprintf("%v/%d", protocol, internalPort)
+	n.mtx.Lock()
+	nonce, ok := n.nonces[key]
+	n.mtx.Unlock()
+	if !ok {
+		if _, err := rand.Read(nonce[:]); err != nil {
+			return 0, err
+		}
+	}
+
+	req, err := n.pcpHeader(pcpOpMap, lifetime)
+	if err != nil {
+		return 0, err
+	}
+	payload := make([]byte, pcpMapSize)
+	copy(payload, nonce[:])
+	payload[12] = proto
+	binary.BigEndian.PutUint16(payload[16:], uint16(internalPort))
+	binary.BigEndian.PutUint16(payload[18:], uint16(externalPort))
+	copy(payload[20:], net.IPv4zero.To16()) // no preference
+	req = append(req, payload...)
+
+	resp, err := n.call(req)
+	if err != nil {
+		return 0, err
+	}
+	if err := pcpResult(resp); err != nil {
+		return 0, err
+	}
+	if len(resp) < pcpHeaderSize+pcpMapSize {
+		return 0, errors.New("short PCP response")
+	}
+	payload = resp[pcpHeaderSize:]
+	if string(payload[:pcpNonceSize]) != string(nonce[:]) {
+		return 0, errors.New("PCP response nonce mismatch")
+	}
+
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	if lifetime == 0 {
+		delete(n.nonces, key)
+		return 0, nil
+	}
+	n.nonces[key] = nonce
+	n.externalIP = net.IP(append([]byte{}, payload[20:36]...))
+	if ip4 := n.externalIP.To4(); ip4 != nil {
+		n.externalIP = ip4
+	}
+	return int(binary.BigEndian.Uint16(payload[18:])), nil
+}
+
+// pcpHeader returns a PCP request header. It has our address as the gateway
+// sees it, so the gateway can tell if there is another NAT in between.
+func (n *pmpNAT) pcpHeader(op byte, lifetime int) ([]byte, error) {
+	conn, err := net.DialUDP("udp", nil, n.gateway)
+	if err != nil {
+		return nil, err
+	}
+	ourIP := conn.LocalAddr().(*net.UDPAddr).IP
+	conn.Close()
+
+	req := make([]byte, pcpHeaderSize)
+	req[0] = pcpVersion
+	req[1] = op
+	binary.BigEndian.PutUint32(req[4:], uint32(lifetime))
+	copy(req[8:], ourIP.To16())
+	return req, nil
+}
+
+func natPMPResult(resp []byte, size int) error {
+	if len(resp) < size {
+		return errors.New("short NAT-PMP response")
+	}
+	if resp[0] != natPMPVersion {
+		return fmt.Errorf("unexpected NAT-PMP version %d", resp[0])
+	}
+	if result := binary.BigEndian.Uint16(resp[2:]); result != 0 {
+		return fmt.Errorf("NAT-PMP request failed with result code %d", result)
+	}
+	return nil
+}
+
+func pcpResult(resp []byte) error {
+	if len(resp) < pcpHeaderSize {
+		return errors.New("short PCP response")
+	}
+	if resp[0] != pcpVersion {
+		return fmt.Errorf("unexpected PCP version %d", resp[0])
+	}
+	if result := resp[3]; result != 0 {
+		return fmt.Errorf("PCP request failed with result code %d", result)
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package upnp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testExternalIP = net.IPv4(203, 0, 113, 7)
+
+type testMapping struct {
+	externalPort int
+	lifetime     uint32
+	nonce        string // PCP only
+}
+
+// testGateway is a stand-in NAT-PMP or PCP gateway on loopback. It maps
+// every internal port to the port 1000 above.
+type testGateway struct {
+	conn *net.UDPConn
+	pcp  bool
+
+	mtx       sync.Mutex
+	dropFirst bool // don't answer the first request
+	requests  int
+	mappings  map[string]testMapping // by protocol and internal port
+}
+
+func newTestGateway(t *testing.T, pcp bool) *testGateway {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	g := &testGateway{
+		conn:     conn,
+		pcp:      pcp,
+		mappings: make(map[string]testMapping),
+	}
+	go func() {
+		buf := make([]byte, 1100)
+		for {
+			n, addr, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			if resp := g.handle(buf[:n]); resp != nil {
+				conn.WriteToUDP(resp, addr) // nolint: errcheck
+			}
+		}
+	}()
+	return g
+}
+
+func (g *testGateway) addr() string {
+	return g.conn.LocalAddr().String()
+}
+
+func (g *testGateway) mapping(key string) (testMapping, bool) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	m, ok := g.mappings[key]
+	return m, ok
+}
+
+func (g *testGateway) handle(req []byte) []byte {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	g.requests++
+	if g.dropFirst && g.requests == 1 {
+		return nil
+	}
+
+	switch {
+	case req[0] == pcpVersion && !g.pcp:
+		resp := make([]byte, 8)
+		resp[1] = req[1] | pmpResponseBit
+		binary.BigEndian.PutUint16(resp[2:], 1) // unsupported version
+		return resp
+
+	case req[0] == pcpVersion:
+		resp := make([]byte, pcpHeaderSize)
+		resp[0] = pcpVersion
+		resp[1] = req[1] | pmpResponseBit
+		copy(resp[4:8], req[4:8])
+		if req[1] != pcpOpMap {
+			return resp
+		}
+
+		payload := append([]byte{}, req[pcpHeaderSize:pcpHeaderSize+pcpMapSize]...)
+		protocol := map[byte]string{pcpProtocolTCP: "tcp", pcpProtocolUDP: "udp"}[payload[12]]
+		internalPort := int(binary.BigEndian.Uint16(payload[16:]))
+		key := fmt.Sprintf("%v/%d", protocol, internalPort)
+		nonce := string(payload[:pcpNonceSize])
+		if m, ok := g.mappings[key]; ok && m.nonce != nonce {
+			resp[3] = 2 // not authorized
+			return append(resp, payload...)
+		}
+
+		lifetime := binary.BigEndian.Uint32(req[4:])
+		if lifetime == 0 {
+			delete(g.mappings, key)
+		} else {
+			g.mappings[key] = testMapping{internalPort + 1000, lifetime, nonce}
+			binary.BigEndian.PutUint16(payload[18:], uint16(internalPort+1000))
+			copy(payload[20:], testExternalIP.To16())
+		}
+		return append(resp, payload...)
+
+	case req[1] == natPMPOpExternalAddress:
+		resp := make([]byte, 12)
+		resp[1] = req[1] | pmpResponseBit
+		copy(resp[8:], testExternalIP.To4())
+		return resp
+
+	default:
+		protocol := map[byte]string{natPMPOpMapTCP: "tcp", natPMPOpMapUDP: "udp"}[req[1]]
+		internalPort := int(binary.BigEndian.Uint16(req[4:]))
+		lifetime := binary.BigEndian.Uint32(req[8:])
+		key := fmt.Sprintf("%v/%d", protocol, internalPort)
+
+		resp := make([]byte, 16)
+		resp[1] = req[1] | pmpResponseBit
+		copy(resp[8:10], req[4:6])
+		copy(resp[12:16], req[8:12])
+		if lifetime == 0 {
+			delete(g.mappings, key)
+		} else {
+			g.mappings[key] = testMapping{externalPort: internalPort + 1000, lifetime: lifetime}
+			binary.BigEndian.PutUint16(resp[10:], uint16(internalPort+1000))
+		}
+		return resp
+	}
+}
+
+func TestPMPNAT(t *testing.T) {
+	for _, pcp := range []bool{false, true} {
+		pcp := pcp
+		name := "NAT-PMP"
+		if pcp {
+			name = "PCP"
+		}
+		t.Run(name, func(t *testing.T) {
+			g := newTestGateway(t, pcp)
+
+			nat, err := DiscoverPMP(g.addr())
+			require.NoError(t, err)
+			assert.Equal(t, pcp, nat.(*pmpNAT).pcp)
+
+			port, err := nat.AddPortMapping("tcp", 26656, 26656, "Tendermint", 0)
+			require.NoError(t, err)
+			assert.Equal(t, 27656, port)
+			m, ok := g.mapping("tcp/26656")
+			require.True(t, ok)
+			assert.EqualValues(t, pmpDefaultLifetime, m.lifetime)
+
+			ip, err := nat.GetExternalAddress()
+			require.NoError(t, err)
+			assert.Equal(t, testExternalIP.String(), ip.String())
+
+			// a mapping can be renewed
+			port, err = nat.AddPortMapping("tcp", 26656, 26656, "Tendermint", 60)
+			require.NoError(t, err)
+			assert.Equal(t, 27656, port)
+			m, _ = g.mapping("tcp/26656")
+			assert.EqualValues(t, 60, m.lifetime)
+
+			require.NoError(t, nat.DeletePortMapping("tcp", 27656, 26656))
+			_, ok = g.mapping("tcp/26656")
+			assert.False(t, ok)
+
+			_, err = nat.AddPortMapping("sctp", 26656, 26656, "Tendermint", 0)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPMPNATResendsRequests(t *testing.T) {
+	g := newTestGateway(t, false)
+	g.mtx.Lock()
+	g.dropFirst = true
+	g.mtx.Unlock()
+
+	_, err := DiscoverPMP(g.addr())
+	require.NoError(t, err)
+
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	assert.Equal(t, 3, g.requests) // the dropped one, the announcement and the external address
+}
+
+func TestPMPGatewayAddr(t *testing.T) {
+	addr, err := pmpGatewayAddr("127.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:5351", addr.String())
+
+	addr, err = pmpGatewayAddr("127.0.0.1:1234")
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:1234", addr.String())
+}
@@ -6,6 +6,8 @@ FilePV
 
 FilePV is the simplest implementation and developer default.
 It uses one file for the private key and another to store state.
+The key file may be encrypted with a passphrase (see EncryptFilePVKey), in
+which case it's ASCII armored and unlocked when the FilePV is loaded.
 
 SignerListenerEndpoint
 
@@ -25,5 +27,20 @@ SignerClient handles remote validator connections that provide signing services.
 In production, it's recommended to wrap it with RetrySignerClient to avoid
 termination in case of temporary errors.
 
+MultiSignerPV
+
+MultiSignerPV coordinates several SignerBackends holding the same key, such as
+FilePVs on separate disks. It only releases a signature once a majority of the
+backends agree on it, and replicates the last sign state across all of them.
+SignerClient and the gRPC SignerClient are SignerBackends as long as the
+remote signer serves one, so a node configured with
+priv_validator_multi_signer_addrs coordinates several remote signers this way.
+
+gRPC
+
+The grpc subpackage serves any PrivValidator over the PrivValidatorAPI gRPC
+service (SignerServer) and provides a matching client (SignerClient). Both
+sides authenticate each other using mutual TLS.
+
 */
 package privval
@@ -50,6 +50,19 @@ type FilePVKey struct {
 	PrivKey crypto.PrivKey `json:"priv_key"`
 
 	filePath string
+	// if set, the key is saved encrypted with this passphrase
+	passphrase []byte
+}
+
+// SetPassphrase makes Save encrypt the key with passphrase.
+// An empty passphrase saves the key as plain JSON.
+func (pvKey *FilePVKey) SetPassphrase(passphrase []byte) {
+	pvKey.passphrase = passphrase
+}
+
+// IsEncrypted returns true if Save encrypts the key.
+func (pvKey FilePVKey) IsEncrypted() bool {
+	return len(pvKey.passphrase) > 0
 }
 
 // Save persists the FilePVKey to its filePath.
@@ -59,11 +72,19 @@ func (pvKey FilePVKey) Save() {
 		panic("cannot save PrivValidator key: filePath not set")
 	}
 
-	jsonBytes, err := tmjson.MarshalIndent(pvKey, "", "  ")
+	var (
+		keyBytes []byte
+		err      error
+	)
+	if pvKey.IsEncrypted() {
+		keyBytes, err = EncryptFilePVKey(pvKey, pvKey.passphrase)
+	} else {
+		keyBytes, err = tmjson.MarshalIndent(pvKey, "", "  ")
+	}
 	if err != nil {
 		panic(err)
 	}
-	err = tempfile.WriteFileAtomic(outFile, jsonBytes, 0600)
+	err = tempfile.WriteFileAtomic(outFile, keyBytes, 0600)
 	if err != nil {
 		panic(err)
 	}
@@ -124,6 +145,17 @@ func (lss *FilePVLastSignState) CheckHRS(height int64, round int32, step int8) (
 	return false, nil
 }
 
+// isAhead returns true if lss is at a later height, round or step than other.
+func (lss *FilePVLastSignState) isAhead(other *FilePVLastSignState) bool {
+	if lss.Height != other.Height {
+		return lss.Height > other.Height
+	}
+	if lss.Round != other.Round {
+		return lss.Round > other.Round
+	}
+	return lss.Step > other.Step
+}
+
 // Save persists the FilePvLastSignState to its filePath.
 func (lss *FilePVLastSignState) Save() {
 	outFile := lss.filePath
@@ -177,26 +209,51 @@ func GenFilePV(keyFilePath, stateFilePath string) *FilePV {
 // LoadFilePV loads a FilePV from the filePaths.  The FilePV handles double
 // signing prevention by persisting data to the stateFilePath.  If either file path
 // does not exist, the program will exit.
+// An encrypted key file is unlocked with DefaultPassphrase("").
 func LoadFilePV(keyFilePath, stateFilePath string) *FilePV {
-	return loadFilePV(keyFilePath, stateFilePath, true)
+	return loadFilePV(keyFilePath, stateFilePath, true, DefaultPassphrase(""))
+}
+
+// LoadFilePVWithPassphrase is like LoadFilePV, but unlocks an encrypted key
+// file with the given passphrase.
+func LoadFilePVWithPassphrase(keyFilePath, stateFilePath string, passphrase PassphraseFunc) *FilePV {
+	return loadFilePV(keyFilePath, stateFilePath, true, passphrase)
 }
 
 // LoadFilePVEmptyState loads a FilePV from the given keyFilePath, with an empty LastSignState.
 // If the keyFilePath does not exist, the program will exit.
 func LoadFilePVEmptyState(keyFilePath, stateFilePath string) *FilePV {
-	return loadFilePV(keyFilePath, stateFilePath, false)
+	return loadFilePV(keyFilePath, stateFilePath, false, DefaultPassphrase(""))
+}
+
+// LoadFilePVEmptyStateWithPassphrase is like LoadFilePVEmptyState, but
+// unlocks an encrypted key file with the given passphrase.
+func LoadFilePVEmptyStateWithPassphrase(keyFilePath, stateFilePath string, passphrase PassphraseFunc) *FilePV {
+	return loadFilePV(keyFilePath, stateFilePath, false, passphrase)
 }
 
 // If loadState is true, we load from the stateFilePath. Otherwise, we use an empty LastSignState.
-func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
+// passphrase is only called if the key file is encrypted.
+func loadFilePV(keyFilePath, stateFilePath string, loadState bool, passphrase PassphraseFunc) *FilePV {
 	keyJSONBytes, err := ioutil.ReadFile(keyFilePath)
 	if err != nil {
 		tmos.Exit(err.Error())
 	}
 	pvKey := FilePVKey{}
-	err = tmjson.Unmarshal(keyJSONBytes, &pvKey)
-	if err != nil {
-		tmos.Exit(fmt.Sprintf("Error reading PrivValidator key from %v: %v\n", keyFilePath, err))
+	if IsEncryptedKey(keyJSONBytes) {
+		pass, err := passphrase()
+		if err != nil {
+			tmos.Exit(fmt.Sprintf("Error reading passphrase for PrivValidator key %v: %v\n", keyFilePath, err))
+		}
+		pvKey, err = DecryptFilePVKey(keyJSONBytes, pass)
+		if err != nil {
+			tmos.Exit(fmt.Sprintf("Error decrypting PrivValidator key from %v: %v\n", keyFilePath, err))
+		}
+	} else {
+		err = tmjson.Unmarshal(keyJSONBytes, &pvKey)
+		if err != nil {
+			tmos.Exit(fmt.Sprintf("Error reading PrivValidator key from %v: %v\n", keyFilePath, err))
+		}
 	}
 
 	// overwrite pubkey and address for convenience
@@ -238,6 +295,20 @@ func LoadOrGenFilePV(keyFilePath, stateFilePath string) *FilePV {
 	return pv
 }
 
+// LoadOrGenFilePVWithPassphrase is like LoadOrGenFilePV, but unlocks an
+// existing encrypted key file with the given passphrase. Generated keys are
+// saved as plain JSON.
+func LoadOrGenFilePVWithPassphrase(keyFilePath, stateFilePath string, passphrase PassphraseFunc) *FilePV {
+	var pv *FilePV
+	if tmos.FileExists(keyFilePath) {
+		pv = LoadFilePVWithPassphrase(keyFilePath, stateFilePath, passphrase)
+	} else {
+		pv = GenFilePV(keyFilePath, stateFilePath)
+		pv.Save()
+	}
+	return pv
+}
+
 func LoadOrGenFilePVEx(keyFilePath, stateFilePath string) *FilePV {
 	var pv *FilePV
 	if tmos.FileExists(keyFilePath) {
@@ -282,6 +353,36 @@ func (pv *FilePV) SignProposal(chainID string, proposal *tmproto.Proposal) error
 	return nil
 }
 
+// GetLastSignState returns a copy of the last sign state.
+// Implements SignerBackend.
+func (pv *FilePV) GetLastSignState() (FilePVLastSignState, error) {
+	lss := pv.LastSignState
+	lss.filePath = ""
+	return lss, nil
+}
+
+// ReplicateSignState fast-forwards the last sign state to lss and persists
+// it. States behind the current one are ignored. A state at the same
+// height/round/step is only accepted if it carries the same SignBytes, so
+// replication can never make the FilePV sign conflicting data.
+// Implements SignerBackend.
+func (pv *FilePV) ReplicateSignState(lss FilePVLastSignState) error {
+	cur := &pv.LastSignState
+	if !lss.isAhead(cur) {
+		if !cur.isAhead(&lss) && !bytes.Equal(cur.SignBytes, lss.SignBytes) {
+			return fmt.Errorf("conflicting sign state at height %v round %v step %v",
+				lss.Height, lss.Round, lss.Step)
+		}
+		return nil
+	}
+	if lss.SignBytes != nil && lss.Signature == nil {
+		return errors.New("sign state has SignBytes but no Signature")
+	}
+
+	pv.saveSigned(lss.Height, lss.Round, lss.Step, lss.SignBytes, lss.Signature)
+	return nil
+}
+
 // Save persists the FilePV to disk.
 func (pv *FilePV) Save() {
 	pv.Key.Save()
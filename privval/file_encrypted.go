@@ -0,0 +1,196 @@
+package privval
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+
+	"github.com/arcology-network/consensus-engine/crypto"
+	"github.com/arcology-network/consensus-engine/crypto/armor"
+	"github.com/arcology-network/consensus-engine/crypto/xsalsa20symmetric"
+	tmjson "github.com/arcology-network/consensus-engine/libs/json"
+)
+
+const (
+	// PassphraseEnvVar is the environment variable holding the passphrase of
+	// an encrypted priv_validator_key.json.
+	PassphraseEnvVar = "TM_PRIV_VALIDATOR_KEY_PASSPHRASE"
+
+	encryptedKeyBlockType = "TENDERMINT PRIVATE VALIDATOR KEY"
+
+	// scrypt parameters recommended for interactive logins (2017).
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLen      = 16
+
+	// Bounds of the scrypt parameters accepted from a key file header, so a
+	// crafted file can't make decryption trivial or exhaust memory and CPU.
+	minScryptN      = 1 << 10
+	maxScryptN      = 1 << 20
+	maxScryptR      = 32
+	maxScryptP      = 16
+	maxScryptMemory = 1 << 30 // bytes, 128 * n * r
+)
+
+// PassphraseFunc returns the passphrase of an encrypted key file.
+type PassphraseFunc func() ([]byte, error)
+
+// PassphraseFromEnv reads the passphrase from PassphraseEnvVar.
+func PassphraseFromEnv() PassphraseFunc {
+	return func() ([]byte, error) {
+		passphrase, ok := os.LookupEnv(PassphraseEnvVar)
+		if !ok {
+			return nil, fmt.Errorf("%s is not set", PassphraseEnvVar)
+		}
+		return []byte(passphrase), nil
+	}
+}
+
+// PassphraseFromFile reads the passphrase from the first line of path.
+func PassphraseFromFile(path string) PassphraseFunc {
+	return func() ([]byte, error) {
+		bz, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("can't read passphrase file: %w", err)
+		}
+		if i := bytes.IndexAny(bz, "\r\n"); i >= 0 {
+			bz = bz[:i]
+		}
+		return bz, nil
+	}
+}
+
+// PassphraseFromPrompt prints prompt to stderr and reads the passphrase from
+// the terminal without echoing it. It fails if stdin is not a terminal.
+func PassphraseFromPrompt(prompt string) PassphraseFunc {
+	return func() ([]byte, error) {
+		fd := int(os.Stdin.Fd())
+		if !term.IsTerminal(fd) {
+			return nil, errors.New("can't prompt for passphrase: stdin is not a terminal")
+		}
+		fmt.Fprint(os.Stderr, prompt)
+		defer fmt.Fprintln(os.Stderr)
+		return term.ReadPassword(fd)
+	}
+}
+
+// DefaultPassphrase reads the passphrase from passphraseFile if it's not
+// empty, from PassphraseEnvVar if it's set, or else prompts for it.
+func DefaultPassphrase(passphraseFile string) PassphraseFunc {
+	switch {
+	case passphraseFile != "":
+		return PassphraseFromFile(passphraseFile)
+	case os.Getenv(PassphraseEnvVar) != "":
+		return PassphraseFromEnv()
+	default:
+		return PassphraseFromPrompt("Enter passphrase for the private validator key: ")
+	}
+}
+
+// IsEncryptedKey returns true if keyBytes hold an encrypted FilePVKey, as
+// opposed to the plain JSON format.
+func IsEncryptedKey(keyBytes []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(keyBytes), []byte("-----BEGIN "+encryptedKeyBlockType))
+}
+
+// EncryptFilePVKey encrypts the JSON encoding of pvKey with a key derived from
+// passphrase using scrypt, and returns it ASCII armored.
+func EncryptFilePVKey(pvKey FilePVKey, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("empty passphrase")
+	}
+
+	jsonBytes, err := tmjson.Marshal(pvKey)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := crypto.CRandBytes(saltLen)
+	secret, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{
+		"kdf":  "scrypt",
+		"salt": strings.ToUpper(hex.EncodeToString(salt)),
+		"n":    strconv.Itoa(scryptN),
+		"r":    strconv.Itoa(scryptR),
+		"p":    strconv.Itoa(scryptP),
+	}
+	ciphertext := xsalsa20symmetric.EncryptSymmetric(jsonBytes, secret)
+	return []byte(armor.EncodeArmor(encryptedKeyBlockType, headers, ciphertext)), nil
+}
+
+// DecryptFilePVKey reverses EncryptFilePVKey. The returned key remembers the
+// passphrase, so saving it keeps the key file encrypted.
+func DecryptFilePVKey(keyBytes []byte, passphrase []byte) (FilePVKey, error) {
+	blockType, headers, ciphertext, err := armor.DecodeArmor(string(keyBytes))
+	if err != nil {
+		return FilePVKey{}, fmt.Errorf("can't decode armor: %w", err)
+	}
+	if blockType != encryptedKeyBlockType {
+		return FilePVKey{}, fmt.Errorf("unexpected block type %q", blockType)
+	}
+	if kdf := headers["kdf"]; kdf != "scrypt" {
+		return FilePVKey{}, fmt.Errorf("unsupported kdf %q", kdf)
+	}
+
+	salt, err := hex.DecodeString(headers["salt"])
+	if err != nil {
+		return FilePVKey{}, fmt.Errorf("invalid salt: %w", err)
+	}
+	var params [3]int
+	for i, name := range []string{"n", "r", "p"} {
+		if params[i], err = strconv.Atoi(headers[name]); err != nil {
+			return FilePVKey{}, fmt.Errorf("invalid scrypt parameter %s: %w", name, err)
+		}
+	}
+	if err := validateScryptParams(params[0], params[1], params[2]); err != nil {
+		return FilePVKey{}, err
+	}
+
+	secret, err := scrypt.Key(passphrase, salt, params[0], params[1], params[2], scryptKeyLen)
+	if err != nil {
+		return FilePVKey{}, err
+	}
+	jsonBytes, err := xsalsa20symmetric.DecryptSymmetric(ciphertext, secret)
+	if err != nil {
+		return FilePVKey{}, errors.New("invalid passphrase")
+	}
+
+	pvKey := FilePVKey{}
+	if err := tmjson.Unmarshal(jsonBytes, &pvKey); err != nil {
+		return FilePVKey{}, err
+	}
+	pvKey.passphrase = passphrase
+	return pvKey, nil
+}
+
+// validateScryptParams checks the scrypt parameters read from a key file.
+func validateScryptParams(n, r, p int) error {
+	if n < minScryptN || n > maxScryptN || n&(n-1) != 0 {
+		return fmt.Errorf("scrypt parameter n must be a power of 2 between %d and %d, got %d",
+			minScryptN, maxScryptN, n)
+	}
+	if r < 1 || r > maxScryptR {
+		return fmt.Errorf("scrypt parameter r must be between 1 and %d, got %d", maxScryptR, r)
+	}
+	if p < 1 || p > maxScryptP {
+		return fmt.Errorf("scrypt parameter p must be between 1 and %d, got %d", maxScryptP, p)
+	}
+	if 128*n*r > maxScryptMemory {
+		return fmt.Errorf("scrypt parameters n=%d and r=%d need more than %d bytes", n, r, maxScryptMemory)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package privval
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arcology-network/consensus-engine/crypto/armor"
+	"github.com/arcology-network/consensus-engine/crypto/tmhash"
+	tmrand "github.com/arcology-network/consensus-engine/libs/rand"
+	tmproto "github.com/arcology-network/consensus-engine/proto/tendermint/types"
+	"github.com/arcology-network/consensus-engine/types"
+)
+
+func TestEncryptDecryptFilePVKey(t *testing.T) {
+	pv := GenFilePV("", "")
+	passphrase := []byte("correct horse battery staple")
+
+	keyBytes, err := EncryptFilePVKey(pv.Key, passphrase)
+	require.NoError(t, err)
+	assert.True(t, IsEncryptedKey(keyBytes))
+	assert.NotContains(t, string(keyBytes), "priv_key")
+
+	pvKey, err := DecryptFilePVKey(keyBytes, passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, pv.Key.PrivKey, pvKey.PrivKey)
+	assert.Equal(t, pv.Key.Address, pvKey.Address)
+	assert.True(t, pvKey.IsEncrypted())
+
+	_, err = DecryptFilePVKey(keyBytes, []byte("wrong"))
+	assert.Error(t, err)
+
+	_, err = EncryptFilePVKey(pv.Key, nil)
+	assert.Error(t, err)
+}
+
+func TestDecryptFilePVKeyScryptBounds(t *testing.T) {
+	pv := GenFilePV("", "")
+	passphrase := []byte("correct horse battery staple")
+
+	keyBytes, err := EncryptFilePVKey(pv.Key, passphrase)
+	require.NoError(t, err)
+	blockType, headers, ciphertext, err := armor.DecodeArmor(string(keyBytes))
+	require.NoError(t, err)
+
+	testCases := map[string]string{
+		"n": "2",
+		"r": "1000",
+		"p": "0",
+	}
+	for name, value := range testCases {
+		tampered := make(map[string]string, len(headers))
+		for k, v := range headers {
+			tampered[k] = v
+		}
+		tampered[name] = value
+		bz := []byte(armor.EncodeArmor(blockType, tampered, ciphertext))
+
+		_, err := DecryptFilePVKey(bz, passphrase)
+		assert.Error(t, err, name)
+	}
+
+	// n is a power of 2 in range, but n*r needs too much memory
+	headers["n"] = "1048576"
+	headers["r"] = "32"
+	_, err = DecryptFilePVKey([]byte(armor.EncodeArmor(blockType, headers, ciphertext)), passphrase)
+	assert.Error(t, err)
+}
+
+func TestLoadEncryptedFilePV(t *testing.T) {
+	tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
+	require.NoError(t, err)
+	tempStateFile, err := ioutil.TempFile("", "priv_validator_state_")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		os.Remove(tempKeyFile.Name())
+		os.Remove(tempStateFile.Name())
+	})
+
+	passphrase := []byte("passphrase")
+	privVal := GenFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	privVal.Key.SetPassphrase(passphrase)
+	privVal.Save()
+
+	keyBytes, err := ioutil.ReadFile(tempKeyFile.Name())
+	require.NoError(t, err)
+	require.True(t, IsEncryptedKey(keyBytes))
+
+	loaded := LoadFilePVWithPassphrase(tempKeyFile.Name(), tempStateFile.Name(),
+		func() ([]byte, error) { return passphrase, nil })
+	assert.Equal(t, privVal.Key.PrivKey, loaded.Key.PrivKey)
+
+	// the key stays encrypted when the FilePV is saved again
+	randBytes := tmrand.Bytes(tmhash.Size)
+	blockID := types.BlockID{Hash: randBytes, PartSetHeader: types.PartSetHeader{}}
+	vote := newVote(loaded.Key.Address, 0, 10, 1, tmproto.PrevoteType, blockID)
+	require.NoError(t, loaded.SignVote("mychainid", vote.ToProto()))
+	loaded.Reset()
+
+	keyBytes, err = ioutil.ReadFile(tempKeyFile.Name())
+	require.NoError(t, err)
+	assert.True(t, IsEncryptedKey(keyBytes))
+}
+
+func TestPassphraseFromFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "passphrase_")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.Remove(f.Name()) })
+	_, err = f.WriteString("secret\nignored\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	passphrase, err := DefaultPassphrase(f.Name())()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), passphrase)
+
+	_, err = PassphraseFromFile(f.Name() + "_missing")()
+	assert.Error(t, err)
+}
+
+func TestPassphraseFromEnv(t *testing.T) {
+	os.Setenv(PassphraseEnvVar, "from-env")
+	t.Cleanup(func() { os.Unsetenv(PassphraseEnvVar) })
+
+	passphrase, err := DefaultPassphrase("")()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("from-env"), passphrase)
+
+	os.Unsetenv(PassphraseEnvVar)
+	_, err = PassphraseFromEnv()()
+	assert.Error(t, err)
+}
@@ -0,0 +1,155 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/arcology-network/consensus-engine/crypto"
+	cryptoenc "github.com/arcology-network/consensus-engine/crypto/encoding"
+	"github.com/arcology-network/consensus-engine/libs/log"
+	"github.com/arcology-network/consensus-engine/privval"
+	privvalproto "github.com/arcology-network/consensus-engine/proto/tendermint/privval"
+	tmproto "github.com/arcology-network/consensus-engine/proto/tendermint/types"
+	"github.com/arcology-network/consensus-engine/types"
+)
+
+// DefaultRequestTimeout is the time a SignerClient waits for the remote
+// signer to answer a single request.
+const DefaultRequestTimeout = 3 * time.Second
+
+// SignerClient implements PrivValidator.
+// Handles remote validator connections that provide signing services over gRPC.
+type SignerClient struct {
+	logger log.Logger
+
+	client  privvalproto.PrivValidatorAPIClient
+	conn    *grpc.ClientConn
+	chainID string
+	timeout time.Duration
+}
+
+var (
+	_ types.PrivValidator   = (*SignerClient)(nil)
+	_ privval.SignerBackend = (*SignerClient)(nil)
+)
+
+// NewSignerClient returns an instance of SignerClient using the given
+// connection.
+func NewSignerClient(conn *grpc.ClientConn, chainID string, logger log.Logger) *SignerClient {
+	return &SignerClient{
+		logger:  logger,
+		client:  privvalproto.NewPrivValidatorAPIClient(conn),
+		conn:    conn,
+		chainID: chainID,
+		timeout: DefaultRequestTimeout,
+	}
+}
+
+// SetRequestTimeout overrides DefaultRequestTimeout.
+func (sc *SignerClient) SetRequestTimeout(timeout time.Duration) {
+	sc.timeout = timeout
+}
+
+// Close closes the underlying connection
+func (sc *SignerClient) Close() error {
+	return sc.conn.Close()
+}
+
+//--------------------------------------------------------
+// Implement PrivValidator
+
+// GetPubKey retrieves a public key from a remote signer
+// returns an error if client is not able to provide the key
+func (sc *SignerClient) GetPubKey() (crypto.PubKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sc.timeout)
+	defer cancel()
+
+	resp, err := sc.client.GetPubKey(ctx, &privvalproto.PubKeyRequest{ChainId: sc.chainID})
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		sc.logger.Error("SignerClient::GetPubKey", "err", errStatus.Message())
+		return nil, errStatus.Err()
+	}
+
+	pk, err := cryptoenc.PubKeyFromProto(resp.PubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return pk, nil
+}
+
+// SignVote requests a remote signer to sign a vote
+func (sc *SignerClient) SignVote(chainID string, vote *tmproto.Vote) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sc.timeout)
+	defer cancel()
+
+	resp, err := sc.client.SignVote(ctx, &privvalproto.SignVoteRequest{ChainId: chainID, Vote: vote})
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		sc.logger.Error("SignerClient::SignVote", "err", errStatus.Message())
+		return errStatus.Err()
+	}
+
+	*vote = resp.Vote
+
+	return nil
+}
+
+// SignProposal requests a remote signer to sign a proposal
+func (sc *SignerClient) SignProposal(chainID string, proposal *tmproto.Proposal) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sc.timeout)
+	defer cancel()
+
+	resp, err := sc.client.SignProposal(
+		ctx, &privvalproto.SignProposalRequest{ChainId: chainID, Proposal: proposal})
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		sc.logger.Error("SignerClient::SignProposal", "err", errStatus.Message())
+		return errStatus.Err()
+	}
+
+	*proposal = resp.Proposal
+
+	return nil
+}
+
+//--------------------------------------------------------
+// Implement privval.SignerBackend
+
+// GetLastSignState requests the last sign state of the remote signer.
+func (sc *SignerClient) GetLastSignState() (privval.FilePVLastSignState, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sc.timeout)
+	defer cancel()
+
+	resp, err := sc.client.GetSignState(ctx, &privvalproto.SignStateRequest{ChainId: sc.chainID})
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		sc.logger.Error("SignerClient::GetLastSignState", "err", errStatus.Message())
+		return privval.FilePVLastSignState{}, errStatus.Err()
+	}
+
+	return privval.SignStateFromProto(resp.State)
+}
+
+// ReplicateSignState requests the remote signer to fast-forward its last sign
+// state to lss.
+func (sc *SignerClient) ReplicateSignState(lss privval.FilePVLastSignState) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sc.timeout)
+	defer cancel()
+
+	_, err := sc.client.ReplicateSignState(ctx, &privvalproto.ReplicateSignStateRequest{
+		ChainId: sc.chainID,
+		State:   privval.SignStateToProto(lss),
+	})
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		sc.logger.Error("SignerClient::ReplicateSignState", "err", errStatus.Message())
+		return errStatus.Err()
+	}
+
+	return nil
+}
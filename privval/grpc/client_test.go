@@ -0,0 +1,221 @@
+package grpc_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/arcology-network/consensus-engine/crypto"
+	"github.com/arcology-network/consensus-engine/crypto/ed25519"
+	"github.com/arcology-network/consensus-engine/crypto/tmhash"
+	"github.com/arcology-network/consensus-engine/libs/log"
+	tmrand "github.com/arcology-network/consensus-engine/libs/rand"
+	"github.com/arcology-network/consensus-engine/privval"
+	tmgrpc "github.com/arcology-network/consensus-engine/privval/grpc"
+	privvalproto "github.com/arcology-network/consensus-engine/proto/tendermint/privval"
+	tmproto "github.com/arcology-network/consensus-engine/proto/tendermint/types"
+	"github.com/arcology-network/consensus-engine/types"
+)
+
+const chainID = "chain-id"
+
+func dialer(pv types.PrivValidator, logger log.Logger) (*grpc.Server, func(context.Context, string) (net.Conn, error)) {
+	listener := bufconn.Listen(1024 * 1024)
+
+	server := grpc.NewServer()
+
+	s := tmgrpc.NewSignerServer(chainID, pv, logger)
+
+	privvalproto.RegisterPrivValidatorAPIServer(server, s)
+
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			panic(err)
+		}
+	}()
+
+	return server, func(context.Context, string) (net.Conn, error) {
+		return listener.Dial()
+	}
+}
+
+func newClient(t *testing.T, pv types.PrivValidator) *tmgrpc.SignerClient {
+	logger := log.TestingLogger()
+	srv, dialFn := dialer(pv, logger)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(dialFn),
+	)
+	require.NoError(t, err)
+
+	client := tmgrpc.NewSignerClient(conn, chainID, logger)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestSignerClient_GetPubKey(t *testing.T) {
+	mockPV := types.NewMockPV()
+	client := newClient(t, mockPV)
+
+	pk, err := client.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, mockPV.PrivKey.PubKey(), pk)
+}
+
+func TestSignerClient_SignVote(t *testing.T) {
+	mockPV := types.NewMockPV()
+	client := newClient(t, mockPV)
+
+	ts := time.Now()
+	hash := tmrand.Bytes(tmhash.Size)
+	valAddr := tmrand.Bytes(crypto.AddressSize)
+
+	want := &types.Vote{
+		Type:             tmproto.PrecommitType,
+		Height:           1,
+		Round:            2,
+		BlockID:          types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 2}},
+		Timestamp:        ts,
+		ValidatorAddress: valAddr,
+		ValidatorIndex:   1,
+	}
+
+	have := &types.Vote{
+		Type:             tmproto.PrecommitType,
+		Height:           1,
+		Round:            2,
+		BlockID:          types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 2}},
+		Timestamp:        ts,
+		ValidatorAddress: valAddr,
+		ValidatorIndex:   1,
+	}
+
+	pbHave := have.ToProto()
+	pbWant := want.ToProto()
+
+	require.NoError(t, mockPV.SignVote(chainID, pbWant))
+	require.NoError(t, client.SignVote(chainID, pbHave))
+
+	assert.Equal(t, pbWant.Signature, pbHave.Signature)
+}
+
+func TestSignerClient_SignProposal(t *testing.T) {
+	mockPV := types.NewMockPV()
+	client := newClient(t, mockPV)
+
+	ts := time.Now()
+	hash := tmrand.Bytes(tmhash.Size)
+
+	have := &types.Proposal{
+		Type:      tmproto.ProposalType,
+		Height:    1,
+		Round:     2,
+		POLRound:  2,
+		BlockID:   types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 2}},
+		Timestamp: ts,
+	}
+	want := &types.Proposal{
+		Type:      tmproto.ProposalType,
+		Height:    1,
+		Round:     2,
+		POLRound:  2,
+		BlockID:   types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 2}},
+		Timestamp: ts,
+	}
+
+	pbHave := have.ToProto()
+	pbWant := want.ToProto()
+
+	require.NoError(t, mockPV.SignProposal(chainID, pbWant))
+	require.NoError(t, client.SignProposal(chainID, pbHave))
+
+	assert.Equal(t, pbWant.Signature, pbHave.Signature)
+}
+
+func TestSignerClient_WrongChainID(t *testing.T) {
+	client := newClient(t, types.NewMockPV())
+
+	vote := &tmproto.Vote{Type: tmproto.PrecommitType, Height: 1}
+	require.Error(t, client.SignVote("other-chain", vote))
+	assert.Nil(t, vote.Signature)
+}
+
+func TestSignerClient_ErroringPV(t *testing.T) {
+	client := newClient(t, types.NewErroringMockPV())
+
+	vote := &tmproto.Vote{Type: tmproto.PrecommitType, Height: 1}
+	require.Error(t, client.SignVote(chainID, vote))
+
+	proposal := &tmproto.Proposal{Type: tmproto.ProposalType, Height: 1}
+	require.Error(t, client.SignProposal(chainID, proposal))
+}
+
+func TestSignerClient_SignState(t *testing.T) {
+	dir := t.TempDir()
+	filePV := privval.GenFilePV(filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json"))
+	client := newClient(t, filePV)
+
+	lss, err := client.GetLastSignState()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, lss.Height)
+
+	vote := &tmproto.Vote{Type: tmproto.PrecommitType, Height: 3, Round: 1}
+	require.NoError(t, filePV.SignVote(chainID, vote))
+	lss, err = client.GetLastSignState()
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, lss.Height)
+	assert.Equal(t, vote.Signature, lss.Signature)
+
+	// fast-forward
+	ahead := lss
+	ahead.Height = 4
+	require.NoError(t, client.ReplicateSignState(ahead))
+	assert.EqualValues(t, 4, filePV.LastSignState.Height)
+
+	// conflicting state at the same height/round/step
+	conflicting := ahead
+	conflicting.SignBytes = []byte("other")
+	assert.Error(t, client.ReplicateSignState(conflicting))
+
+	// not supported by the MockPV
+	_, err = newClient(t, types.NewMockPV()).GetLastSignState()
+	assert.Error(t, err)
+}
+
+func TestSignerClient_MultiSigner(t *testing.T) {
+	dir := t.TempDir()
+	privKey := ed25519.GenPrivKey()
+	backends := make([]privval.SignerBackend, 3)
+	filePVs := make([]*privval.FilePV, 3)
+	for i := range backends {
+		filePVs[i] = privval.NewFilePV(privKey,
+			filepath.Join(dir, fmt.Sprintf("key%d.json", i)), filepath.Join(dir, fmt.Sprintf("state%d.json", i)))
+		filePVs[i].Save()
+		backends[i] = newClient(t, filePVs[i])
+	}
+	// one signer is behind and must be brought up to date
+	require.NoError(t, filePVs[0].ReplicateSignState(privval.FilePVLastSignState{Height: 2, Step: 3}))
+
+	pv, err := privval.NewMultiSignerPV(backends, 2, log.TestingLogger())
+	require.NoError(t, err)
+	for _, filePV := range filePVs {
+		assert.EqualValues(t, 2, filePV.LastSignState.Height)
+	}
+
+	vote := &tmproto.Vote{Type: tmproto.PrecommitType, Height: 5, ValidatorAddress: privKey.PubKey().Address()}
+	require.NoError(t, pv.SignVote(chainID, vote))
+	assert.True(t, privKey.PubKey().VerifySignature(types.VoteSignBytes(chainID, vote), vote.Signature))
+	for _, filePV := range filePVs {
+		assert.EqualValues(t, 5, filePV.LastSignState.Height)
+	}
+}
@@ -0,0 +1,171 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/arcology-network/consensus-engine/crypto"
+	cryptoenc "github.com/arcology-network/consensus-engine/crypto/encoding"
+	"github.com/arcology-network/consensus-engine/libs/log"
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
+	"github.com/arcology-network/consensus-engine/privval"
+	privvalproto "github.com/arcology-network/consensus-engine/proto/tendermint/privval"
+	"github.com/arcology-network/consensus-engine/types"
+)
+
+// SignerServer implements PrivValidatorAPIServer, exposing any
+// types.PrivValidator over gRPC.
+type SignerServer struct {
+	logger  log.Logger
+	chainID string
+
+	mtx     tmsync.Mutex
+	privVal types.PrivValidator
+}
+
+var _ privvalproto.PrivValidatorAPIServer = (*SignerServer)(nil)
+
+// NewSignerServer returns a new SignerServer which signs on behalf of
+// privVal, refusing requests for any chain other than chainID.
+func NewSignerServer(chainID string, privVal types.PrivValidator, logger log.Logger) *SignerServer {
+	return &SignerServer{
+		logger:  logger,
+		chainID: chainID,
+		privVal: privVal,
+	}
+}
+
+// GetPubKey receives a request for the pubkey
+// returns the pubkey on success and error on failure
+func (ss *SignerServer) GetPubKey(ctx context.Context, req *privvalproto.PubKeyRequest) (
+	*privvalproto.PubKeyResponse, error) {
+	if req.ChainId != ss.chainID {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"want chainID: %s, got chainID: %s", ss.chainID, req.ChainId)
+	}
+
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+
+	var pubKey crypto.PubKey
+	pubKey, err := ss.privVal.GetPubKey()
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "error getting pubkey: %v", err)
+	}
+
+	pk, err := cryptoenc.PubKeyToProto(pubKey)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error transitioning pubkey to proto: %v", err)
+	}
+
+	ss.logger.Debug("SignerServer: GetPubKey Success")
+
+	return &privvalproto.PubKeyResponse{PubKey: pk}, nil
+}
+
+// SignVote receives a vote sign requests, attempts to sign it
+// returns SignedVoteResponse on success and error on failure
+func (ss *SignerServer) SignVote(ctx context.Context, req *privvalproto.SignVoteRequest) (
+	*privvalproto.SignedVoteResponse, error) {
+	if req.ChainId != ss.chainID {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"want chainID: %s, got chainID: %s", ss.chainID, req.ChainId)
+	}
+	if req.Vote == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing vote")
+	}
+
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+
+	vote := req.Vote
+	if err := ss.privVal.SignVote(req.ChainId, vote); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error signing vote: %v", err)
+	}
+
+	ss.logger.Debug("SignerServer: SignVote Success", "height", vote.Height, "round", vote.Round)
+
+	return &privvalproto.SignedVoteResponse{Vote: *vote}, nil
+}
+
+// SignProposal receives a proposal sign requests, attempts to sign it
+// returns SignedProposalResponse on success and error on failure
+func (ss *SignerServer) SignProposal(ctx context.Context, req *privvalproto.SignProposalRequest) (
+	*privvalproto.SignedProposalResponse, error) {
+	if req.ChainId != ss.chainID {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"want chainID: %s, got chainID: %s", ss.chainID, req.ChainId)
+	}
+	if req.Proposal == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing proposal")
+	}
+
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+
+	proposal := req.Proposal
+	if err := ss.privVal.SignProposal(req.ChainId, proposal); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error signing proposal: %v", err)
+	}
+
+	ss.logger.Debug("SignerServer: SignProposal Success", "height", proposal.Height, "round", proposal.Round)
+
+	return &privvalproto.SignedProposalResponse{Proposal: *proposal}, nil
+}
+
+// GetSignState returns the last sign state of the PrivValidator, which must
+// implement privval.SignerBackend.
+func (ss *SignerServer) GetSignState(ctx context.Context, req *privvalproto.SignStateRequest) (
+	*privvalproto.SignStateResponse, error) {
+	if req.ChainId != ss.chainID {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"want chainID: %s, got chainID: %s", ss.chainID, req.ChainId)
+	}
+	backend, ok := ss.privVal.(privval.SignerBackend)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "sign state is not supported")
+	}
+
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+
+	lss, err := backend.GetLastSignState()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting sign state: %v", err)
+	}
+
+	ss.logger.Debug("SignerServer: GetSignState Success", "height", lss.Height, "round", lss.Round)
+
+	return &privvalproto.SignStateResponse{State: privval.SignStateToProto(lss)}, nil
+}
+
+// ReplicateSignState fast-forwards the last sign state of the PrivValidator,
+// which must implement privval.SignerBackend.
+func (ss *SignerServer) ReplicateSignState(ctx context.Context, req *privvalproto.ReplicateSignStateRequest) (
+	*privvalproto.ReplicateSignStateResponse, error) {
+	if req.ChainId != ss.chainID {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"want chainID: %s, got chainID: %s", ss.chainID, req.ChainId)
+	}
+	backend, ok := ss.privVal.(privval.SignerBackend)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "sign state is not supported")
+	}
+	lss, err := privval.SignStateFromProto(req.State)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid sign state: %v", err)
+	}
+
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+
+	if err := backend.ReplicateSignState(lss); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "error replicating sign state: %v", err)
+	}
+
+	ss.logger.Debug("SignerServer: ReplicateSignState Success", "height", lss.Height, "round", lss.Round)
+
+	return &privvalproto.ReplicateSignStateResponse{}, nil
+}
@@ -0,0 +1,136 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"time"
+
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/arcology-network/consensus-engine/libs/log"
+	tmnet "github.com/arcology-network/consensus-engine/libs/net"
+	privvalproto "github.com/arcology-network/consensus-engine/proto/tendermint/privval"
+	"github.com/arcology-network/consensus-engine/types"
+)
+
+// DefaultDialTimeout is the time DialRemoteSigner waits for the connection
+// to the remote signer to become ready.
+const DefaultDialTimeout = 3 * time.Second
+
+// TLSFiles holds the paths to the PEM encoded certificate, private key and
+// root certificate authority used for mutual TLS.
+type TLSFiles struct {
+	CertFile   string
+	KeyFile    string
+	RootCAFile string
+}
+
+// loadTLSConfig reads the certificate key pair and the root CA pool
+// referenced by files.
+func loadTLSConfig(files TLSFiles) (*tls.Config, *x509.CertPool, error) {
+	if files.CertFile == "" || files.KeyFile == "" || files.RootCAFile == "" {
+		return nil, nil, errors.New("certificate, key and root CA files are required for mutual TLS")
+	}
+
+	certificate, err := tls.LoadX509KeyPair(files.CertFile, files.KeyFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load TLS key pair: %w", err)
+	}
+
+	ca, err := ioutil.ReadFile(files.RootCAFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read root CA: %w", err)
+	}
+	certPool := x509.NewCertPool()
+	if ok := certPool.AppendCertsFromPEM(ca); !ok {
+		return nil, nil, fmt.Errorf("failed to append root CA from %s", files.RootCAFile)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}, certPool, nil
+}
+
+// ClientTLS returns the transport credentials a SignerClient uses to
+// authenticate itself to, and verify, the remote signer.
+func ClientTLS(files TLSFiles) (credentials.TransportCredentials, error) {
+	tlsConfig, certPool, err := loadTLSConfig(files)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.RootCAs = certPool
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// ServerTLS returns the transport credentials a SignerServer uses. Clients
+// must present a certificate signed by the root CA.
+func ServerTLS(files TLSFiles) (credentials.TransportCredentials, error) {
+	tlsConfig, certPool, err := loadTLSConfig(files)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.ClientCAs = certPool
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// DialRemoteSigner dials the PrivValidatorAPI server at addr using mutual
+// TLS and returns a SignerClient for chainID. It blocks until the
+// connection is established or DefaultDialTimeout expires.
+func DialRemoteSigner(
+	addr string,
+	chainID string,
+	files TLSFiles,
+	logger log.Logger,
+) (*SignerClient, error) {
+	creds, err := ClientTLS(files)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultDialTimeout)
+	defer cancel()
+
+	_, address := tmnet.ProtocolAndAddress(addr)
+	conn, err := grpc.DialContext(ctx, address,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to remote signer %s: %w", addr, err)
+	}
+
+	return NewSignerClient(conn, chainID, logger), nil
+}
+
+// NewServer returns a gRPC server, secured with mutual TLS, serving
+// PrivValidatorAPI on behalf of privVal.
+func NewServer(
+	chainID string,
+	privVal types.PrivValidator,
+	files TLSFiles,
+	logger log.Logger,
+) (*grpc.Server, error) {
+	creds, err := ServerTLS(files)
+	if err != nil {
+		return nil, err
+	}
+
+	s := grpc.NewServer(grpc.Creds(creds))
+	privvalproto.RegisterPrivValidatorAPIServer(s, NewSignerServer(chainID, privVal, logger))
+	return s, nil
+}
+
+// Listen opens a TCP listener for the gRPC server on addr, which may carry
+// an optional tcp:// or grpc:// prefix.
+func Listen(addr string) (net.Listener, error) {
+	_, address := tmnet.ProtocolAndAddress(addr)
+	return net.Listen("tcp", address)
+}
@@ -0,0 +1,118 @@
+package grpc_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arcology-network/consensus-engine/libs/log"
+	tmgrpc "github.com/arcology-network/consensus-engine/privval/grpc"
+	"github.com/arcology-network/consensus-engine/types"
+)
+
+// writeCert signs a certificate for 127.0.0.1 with parent (or self-signs it
+// when parent is nil) and writes the PEM encoded cert and key into dir.
+func writeCert(
+	t *testing.T,
+	dir, name string,
+	parent *x509.Certificate,
+	parentKey *ecdsa.PrivateKey,
+) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name+".crt"),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name+".key"),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+
+	return cert, key
+}
+
+func TestDialRemoteSignerMutualTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "privval_grpc")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	ca, caKey := writeCert(t, dir, "ca", nil, nil)
+	writeCert(t, dir, "server", ca, caKey)
+	writeCert(t, dir, "client", ca, caKey)
+	// a client certificate issued by an unrelated CA
+	otherCA, otherKey := writeCert(t, dir, "other-ca", nil, nil)
+	writeCert(t, dir, "rogue", otherCA, otherKey)
+
+	files := func(name string) tmgrpc.TLSFiles {
+		return tmgrpc.TLSFiles{
+			CertFile:   filepath.Join(dir, name+".crt"),
+			KeyFile:    filepath.Join(dir, name+".key"),
+			RootCAFile: filepath.Join(dir, "ca.crt"),
+		}
+	}
+
+	logger := log.TestingLogger()
+	mockPV := types.NewMockPV()
+
+	srv, err := tmgrpc.NewServer(chainID, mockPV, files("server"), logger)
+	require.NoError(t, err)
+	ln, err := tmgrpc.Listen("grpc://127.0.0.1:0")
+	require.NoError(t, err)
+	go srv.Serve(ln) //nolint:errcheck
+	t.Cleanup(srv.Stop)
+
+	addr := "grpc://" + ln.Addr().String()
+
+	client, err := tmgrpc.DialRemoteSigner(addr, chainID, files("client"), logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	pk, err := client.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, mockPV.PrivKey.PubKey(), pk)
+
+	rogue, err := tmgrpc.DialRemoteSigner(addr, chainID, files("rogue"), logger)
+	if err == nil {
+		// the handshake may only fail on the first request
+		t.Cleanup(func() { rogue.Close() })
+		_, err = rogue.GetPubKey()
+	}
+	assert.Error(t, err)
+
+	_, err = tmgrpc.DialRemoteSigner(addr, chainID, tmgrpc.TLSFiles{}, logger)
+	assert.Error(t, err)
+}
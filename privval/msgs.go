@@ -32,6 +32,14 @@ func mustWrapMsg(pb proto.Message) privvalproto.Message {
 		msg.Sum = &privvalproto.Message_PingRequest{PingRequest: pb}
 	case *privvalproto.PingResponse:
 		msg.Sum = &privvalproto.Message_PingResponse{PingResponse: pb}
+	case *privvalproto.SignStateRequest:
+		msg.Sum = &privvalproto.Message_SignStateRequest{SignStateRequest: pb}
+	case *privvalproto.SignStateResponse:
+		msg.Sum = &privvalproto.Message_SignStateResponse{SignStateResponse: pb}
+	case *privvalproto.ReplicateSignStateRequest:
+		msg.Sum = &privvalproto.Message_ReplicateSignStateRequest{ReplicateSignStateRequest: pb}
+	case *privvalproto.ReplicateSignStateResponse:
+		msg.Sum = &privvalproto.Message_ReplicateSignStateResponse{ReplicateSignStateResponse: pb}
 	default:
 		panic(fmt.Errorf("unknown message type %T", pb))
 	}
@@ -0,0 +1,318 @@
+package privval
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/arcology-network/consensus-engine/crypto"
+	"github.com/arcology-network/consensus-engine/libs/log"
+	privvalproto "github.com/arcology-network/consensus-engine/proto/tendermint/privval"
+	tmproto "github.com/arcology-network/consensus-engine/proto/tendermint/types"
+	"github.com/arcology-network/consensus-engine/types"
+)
+
+// SignerBackend is a PrivValidator coordinated by MultiSignerPV. Every
+// backend holds the same key and keeps its own last sign state, which the
+// MultiSignerPV replicates across all backends.
+type SignerBackend interface {
+	types.PrivValidator
+
+	// GetLastSignState returns a copy of the backend's last sign state.
+	GetLastSignState() (FilePVLastSignState, error)
+	// ReplicateSignState persists lss if it is ahead of the backend's own last
+	// sign state, and must refuse a conflicting state for the same
+	// height/round/step.
+	ReplicateSignState(lss FilePVLastSignState) error
+}
+
+var (
+	_ SignerBackend = (*FilePV)(nil)
+	_ SignerBackend = (*SignerClient)(nil)
+	_ SignerBackend = (*RetrySignerClient)(nil)
+)
+
+// SignStateToProto converts lss to its protobuf representation, which remote
+// signers exchange.
+func SignStateToProto(lss FilePVLastSignState) privvalproto.SignState {
+	return privvalproto.SignState{
+		Height:    lss.Height,
+		Round:     lss.Round,
+		Step:      int32(lss.Step),
+		Signature: lss.Signature,
+		SignBytes: lss.SignBytes,
+	}
+}
+
+// SignStateFromProto converts a protobuf sign state back to
+// FilePVLastSignState.
+func SignStateFromProto(pb privvalproto.SignState) (FilePVLastSignState, error) {
+	if pb.Height < 0 || pb.Round < 0 {
+		return FilePVLastSignState{}, errors.New("negative height or round")
+	}
+	if pb.Step < int32(stepNone) || pb.Step > int32(stepPrecommit) {
+		return FilePVLastSignState{}, fmt.Errorf("invalid step %d", pb.Step)
+	}
+	return FilePVLastSignState{
+		Height:    pb.Height,
+		Round:     pb.Round,
+		Step:      int8(pb.Step),
+		Signature: pb.Signature,
+		SignBytes: pb.SignBytes,
+	}, nil
+}
+
+// MultiSignerPV implements PrivValidator by coordinating several
+// SignerBackends. A signature is only released once at least threshold
+// backends have produced it, and threshold must be a strict majority of the
+// backends. Any two quorums therefore share a backend which refuses to sign
+// conflicting data, so losing a minority of backends (or their sign state)
+// can't cause a double sign.
+//
+// After every signature the resulting sign state is replicated to the
+// backends that did not take part, so they can rejoin a later quorum.
+//
+// Backends are called concurrently and MultiSignerPV waits for all of them;
+// remote backends are expected to enforce their own timeouts.
+type MultiSignerPV struct {
+	logger    log.Logger
+	threshold int
+
+	mtx      sync.Mutex
+	backends []SignerBackend
+	pubKey   crypto.PubKey
+}
+
+var _ types.PrivValidator = (*MultiSignerPV)(nil)
+
+// NewMultiSignerPV returns a MultiSignerPV requiring threshold of the given
+// backends to agree on every signature. All backends must share the same
+// public key. The most recent sign state found on any backend is replicated
+// to the others before NewMultiSignerPV returns.
+func NewMultiSignerPV(backends []SignerBackend, threshold int, logger log.Logger) (*MultiSignerPV, error) {
+	if len(backends) == 0 {
+		return nil, errors.New("no signer backends")
+	}
+	if threshold <= len(backends)/2 || threshold > len(backends) {
+		return nil, fmt.Errorf("threshold must be a majority of the %d backends, got %d", len(backends), threshold)
+	}
+
+	var pubKey crypto.PubKey
+	for i, backend := range backends {
+		pk, err := backend.GetPubKey()
+		if err != nil {
+			return nil, fmt.Errorf("can't get pubkey of backend #%d: %w", i, err)
+		}
+		if pubKey == nil {
+			pubKey = pk
+		} else if !pubKey.Equals(pk) {
+			return nil, fmt.Errorf("backend #%d has pubkey %v, want %v", i, pk, pubKey)
+		}
+	}
+
+	pv := &MultiSignerPV{
+		logger:    logger,
+		threshold: threshold,
+		backends:  backends,
+		pubKey:    pubKey,
+	}
+
+	var (
+		latest FilePVLastSignState
+		from   = -1
+	)
+	for i, backend := range backends {
+		lss, err := backend.GetLastSignState()
+		if err != nil {
+			logger.Error("Can't get last sign state", "backend", i, "err", err)
+			continue
+		}
+		if from == -1 || lss.isAhead(&latest) {
+			latest, from = lss, i
+		}
+	}
+	if from == -1 {
+		return nil, errors.New("can't get last sign state from any backend")
+	}
+	pv.replicate(latest, map[int]bool{from: true})
+
+	return pv, nil
+}
+
+// GetPubKey returns the public key shared by all backends.
+// Implements PrivValidator.
+func (pv *MultiSignerPV) GetPubKey() (crypto.PubKey, error) {
+	return pv.pubKey, nil
+}
+
+// SignVote asks every backend to sign the vote and sets the signature (and
+// timestamp) once threshold backends agree. Implements PrivValidator.
+func (pv *MultiSignerPV) SignVote(chainID string, vote *tmproto.Vote) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+
+	results := make([]tmproto.Vote, len(pv.backends))
+	agreed, err := pv.quorum(func(i int, backend SignerBackend) ([]byte, error) {
+		results[i] = *vote
+		if err := backend.SignVote(chainID, &results[i]); err != nil {
+			return nil, err
+		}
+		return voteResultKey(&results[i]), nil
+	})
+	if err != nil {
+		return fmt.Errorf("error signing vote: %w", err)
+	}
+
+	vote.Signature = results[agreed[0]].Signature
+	vote.Timestamp = results[agreed[0]].Timestamp
+	pv.replicateFrom(agreed)
+	return nil
+}
+
+// SignProposal asks every backend to sign the proposal and sets the signature
+// (and timestamp) once threshold backends agree. Implements PrivValidator.
+func (pv *MultiSignerPV) SignProposal(chainID string, proposal *tmproto.Proposal) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+
+	results := make([]tmproto.Proposal, len(pv.backends))
+	agreed, err := pv.quorum(func(i int, backend SignerBackend) ([]byte, error) {
+		results[i] = *proposal
+		if err := backend.SignProposal(chainID, &results[i]); err != nil {
+			return nil, err
+		}
+		return proposalResultKey(&results[i]), nil
+	})
+	if err != nil {
+		return fmt.Errorf("error signing proposal: %w", err)
+	}
+
+	proposal.Signature = results[agreed[0]].Signature
+	proposal.Timestamp = results[agreed[0]].Timestamp
+	pv.replicateFrom(agreed)
+	return nil
+}
+
+// String returns a string representation of the MultiSignerPV.
+func (pv *MultiSignerPV) String() string {
+	return fmt.Sprintf("MultiSignerPV{%v %d/%d}", pv.pubKey.Address(), pv.threshold, len(pv.backends))
+}
+
+// Close closes every backend implementing io.Closer, such as the remote
+// signer clients, and returns the first error encountered.
+func (pv *MultiSignerPV) Close() error {
+	var firstErr error
+	for _, backend := range pv.backends {
+		if c, ok := backend.(io.Closer); ok {
+			if err := c.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// quorum runs sign on every backend concurrently. sign returns a key
+// identifying the signed result; the indexes of the largest group of
+// backends returning the same key are returned if it reaches the threshold.
+func (pv *MultiSignerPV) quorum(sign func(i int, backend SignerBackend) ([]byte, error)) ([]int, error) {
+	keys := make([][]byte, len(pv.backends))
+
+	var wg sync.WaitGroup
+	for i, backend := range pv.backends {
+		wg.Add(1)
+		go func(i int, backend SignerBackend) {
+			defer wg.Done()
+			key, err := sign(i, backend)
+			if err != nil {
+				pv.logger.Error("Signer backend refused to sign", "backend", i, "err", err)
+				return
+			}
+			keys[i] = key
+		}(i, backend)
+	}
+	wg.Wait()
+
+	var agreed []int
+	for i, key := range keys {
+		if key == nil {
+			continue
+		}
+		var group []int
+		for j := i; j < len(keys); j++ {
+			if keys[j] != nil && bytes.Equal(key, keys[j]) {
+				group = append(group, j)
+			}
+		}
+		if len(group) > len(agreed) {
+			agreed = group
+		}
+	}
+
+	if len(agreed) < pv.threshold {
+		return nil, fmt.Errorf("only %d of %d signer backends agreed, need %d",
+			len(agreed), len(pv.backends), pv.threshold)
+	}
+	return agreed, nil
+}
+
+// replicateFrom copies the sign state of the first agreeing backend to all
+// other backends.
+func (pv *MultiSignerPV) replicateFrom(agreed []int) {
+	lss, err := pv.backends[agreed[0]].GetLastSignState()
+	if err != nil {
+		pv.logger.Error("Can't get last sign state", "backend", agreed[0], "err", err)
+		return
+	}
+
+	skip := make(map[int]bool, len(agreed))
+	for _, i := range agreed {
+		skip[i] = true
+	}
+	pv.replicate(lss, skip)
+}
+
+// replicate pushes lss to every backend not in skip. Failures are logged: a
+// lagging backend is still protected by its own sign state.
+func (pv *MultiSignerPV) replicate(lss FilePVLastSignState, skip map[int]bool) {
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i, backend := range pv.backends {
+		if skip[i] {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, backend SignerBackend) {
+			defer wg.Done()
+			if err := backend.ReplicateSignState(lss); err != nil {
+				pv.logger.Error("Failed to replicate sign state", "backend", i,
+					"height", lss.Height, "round", lss.Round, "step", lss.Step, "err", err)
+			}
+		}(i, backend)
+	}
+	wg.Wait()
+
+	pv.logger.Debug("Replicated sign state", "height", lss.Height, "round", lss.Round,
+		"step", lss.Step, "took", time.Since(start))
+}
+
+// voteResultKey identifies the parts of a vote set by a backend.
+func voteResultKey(vote *tmproto.Vote) []byte {
+	return resultKey(vote.Timestamp, vote.Signature)
+}
+
+// proposalResultKey identifies the parts of a proposal set by a backend.
+func proposalResultKey(proposal *tmproto.Proposal) []byte {
+	return resultKey(proposal.Timestamp, proposal.Signature)
+}
+
+func resultKey(timestamp time.Time, signature []byte) []byte {
+	key := make([]byte, 8, 8+len(signature))
+	binary.BigEndian.PutUint64(key, uint64(timestamp.UnixNano()))
+	return append(key, signature...)
+}
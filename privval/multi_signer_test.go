@@ -0,0 +1,194 @@
+package privval
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arcology-network/consensus-engine/crypto"
+	"github.com/arcology-network/consensus-engine/crypto/ed25519"
+	"github.com/arcology-network/consensus-engine/crypto/tmhash"
+	"github.com/arcology-network/consensus-engine/libs/log"
+	tmrand "github.com/arcology-network/consensus-engine/libs/rand"
+	tmproto "github.com/arcology-network/consensus-engine/proto/tendermint/types"
+	"github.com/arcology-network/consensus-engine/types"
+)
+
+// offlineBackend wraps a SignerBackend and fails every call while offline.
+type offlineBackend struct {
+	SignerBackend
+	offline bool
+}
+
+var errOffline = errors.New("backend offline")
+
+func (b *offlineBackend) SignVote(chainID string, vote *tmproto.Vote) error {
+	if b.offline {
+		return errOffline
+	}
+	return b.SignerBackend.SignVote(chainID, vote)
+}
+
+func (b *offlineBackend) SignProposal(chainID string, proposal *tmproto.Proposal) error {
+	if b.offline {
+		return errOffline
+	}
+	return b.SignerBackend.SignProposal(chainID, proposal)
+}
+
+func (b *offlineBackend) ReplicateSignState(lss FilePVLastSignState) error {
+	if b.offline {
+		return errOffline
+	}
+	return b.SignerBackend.ReplicateSignState(lss)
+}
+
+func newFilePVBackends(t *testing.T, privKey crypto.PrivKey, n int) []*FilePV {
+	dir, err := ioutil.TempDir("", "multi_signer")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	pvs := make([]*FilePV, n)
+	for i := range pvs {
+		pvs[i] = NewFilePV(privKey,
+			filepath.Join(dir, tmrand.Str(8)+"_key.json"),
+			filepath.Join(dir, tmrand.Str(8)+"_state.json"))
+		pvs[i].Save()
+	}
+	return pvs
+}
+
+func newMultiSignerTestVote(pubKey crypto.PubKey, height int64, round int32) *tmproto.Vote {
+	randBytes := tmrand.Bytes(tmhash.Size)
+	blockID := types.BlockID{Hash: randBytes, PartSetHeader: types.PartSetHeader{Total: 1, Hash: randBytes}}
+	return newVote(pubKey.Address(), 0, height, round, tmproto.PrecommitType, blockID).ToProto()
+}
+
+func TestMultiSignerPVThreshold(t *testing.T) {
+	pvs := newFilePVBackends(t, ed25519.GenPrivKey(), 3)
+	backends := []SignerBackend{pvs[0], pvs[1], pvs[2]}
+
+	for _, threshold := range []int{0, 1, 4} {
+		_, err := NewMultiSignerPV(backends, threshold, log.TestingLogger())
+		assert.Error(t, err, "threshold %d", threshold)
+	}
+
+	// backends with different keys are rejected
+	other := newFilePVBackends(t, ed25519.GenPrivKey(), 1)[0]
+	_, err := NewMultiSignerPV([]SignerBackend{pvs[0], pvs[1], other}, 2, log.TestingLogger())
+	assert.Error(t, err)
+}
+
+func TestMultiSignerPVSignVote(t *testing.T) {
+	chainID := "mychainid"
+	privKey := ed25519.GenPrivKey()
+	pvs := newFilePVBackends(t, privKey, 3)
+	offline := &offlineBackend{SignerBackend: pvs[2], offline: true}
+
+	pv, err := NewMultiSignerPV([]SignerBackend{pvs[0], pvs[1], offline}, 2, log.TestingLogger())
+	require.NoError(t, err)
+
+	vote := newMultiSignerTestVote(privKey.PubKey(), 10, 1)
+	require.NoError(t, pv.SignVote(chainID, vote))
+	assert.True(t, privKey.PubKey().VerifySignature(types.VoteSignBytes(chainID, vote), vote.Signature))
+
+	// re-signing the same vote returns the same signature
+	again := *vote
+	again.Signature = nil
+	require.NoError(t, pv.SignVote(chainID, &again))
+	assert.Equal(t, vote.Signature, again.Signature)
+
+	// a conflicting vote for the same HRS is refused
+	conflicting := newMultiSignerTestVote(privKey.PubKey(), 10, 1)
+	assert.Error(t, pv.SignVote(chainID, conflicting))
+	assert.Nil(t, conflicting.Signature)
+
+	// the offline backend catches up once it is back
+	assert.Equal(t, int64(0), pvs[2].LastSignState.Height)
+	offline.offline = false
+	next := newMultiSignerTestVote(privKey.PubKey(), 11, 0)
+	require.NoError(t, pv.SignVote(chainID, next))
+	for _, fpv := range pvs {
+		assert.Equal(t, int64(11), fpv.LastSignState.Height)
+		assert.Equal(t, next.Signature, []byte(fpv.LastSignState.Signature))
+	}
+}
+
+func TestMultiSignerPVNoQuorum(t *testing.T) {
+	chainID := "mychainid"
+	privKey := ed25519.GenPrivKey()
+	pvs := newFilePVBackends(t, privKey, 3)
+	b1 := &offlineBackend{SignerBackend: pvs[1]}
+	b2 := &offlineBackend{SignerBackend: pvs[2]}
+
+	pv, err := NewMultiSignerPV([]SignerBackend{pvs[0], b1, b2}, 2, log.TestingLogger())
+	require.NoError(t, err)
+
+	b1.offline, b2.offline = true, true
+
+	vote := newMultiSignerTestVote(privKey.PubKey(), 10, 1)
+	assert.Error(t, pv.SignVote(chainID, vote))
+	assert.Nil(t, vote.Signature)
+
+	randBytes := tmrand.Bytes(tmhash.Size)
+	blockID := types.BlockID{Hash: randBytes, PartSetHeader: types.PartSetHeader{Total: 1, Hash: randBytes}}
+	proposal := newProposal(10, 2, blockID).ToProto()
+	assert.Error(t, pv.SignProposal(chainID, proposal))
+	assert.Nil(t, proposal.Signature)
+}
+
+func TestMultiSignerPVReplicatesOnStart(t *testing.T) {
+	chainID := "mychainid"
+	privKey := ed25519.GenPrivKey()
+	pvs := newFilePVBackends(t, privKey, 3)
+
+	randBytes := tmrand.Bytes(tmhash.Size)
+	blockID := types.BlockID{Hash: randBytes, PartSetHeader: types.PartSetHeader{Total: 1, Hash: randBytes}}
+	proposal := newProposal(5, 0, blockID).ToProto()
+	proposal.Timestamp = time.Now().UTC()
+	require.NoError(t, pvs[1].SignProposal(chainID, proposal))
+
+	_, err := NewMultiSignerPV([]SignerBackend{pvs[0], pvs[1], pvs[2]}, 2, log.TestingLogger())
+	require.NoError(t, err)
+
+	for _, fpv := range pvs {
+		assert.Equal(t, pvs[1].LastSignState.SignBytes, fpv.LastSignState.SignBytes)
+		assert.Equal(t, int64(5), fpv.LastSignState.Height)
+		assert.Equal(t, stepPropose, fpv.LastSignState.Step)
+	}
+
+	// the replicated state was persisted
+	loaded := LoadFilePV(pvs[0].Key.filePath, pvs[0].LastSignState.filePath)
+	assert.Equal(t, int64(5), loaded.LastSignState.Height)
+}
+
+func TestFilePVReplicateSignState(t *testing.T) {
+	chainID := "mychainid"
+	privKey := ed25519.GenPrivKey()
+	pvs := newFilePVBackends(t, privKey, 2)
+
+	vote := newMultiSignerTestVote(privKey.PubKey(), 10, 1)
+	require.NoError(t, pvs[0].SignVote(chainID, vote))
+	lss, err := pvs[0].GetLastSignState()
+	require.NoError(t, err)
+
+	require.NoError(t, pvs[1].ReplicateSignState(lss))
+	assert.Equal(t, lss.SignBytes, pvs[1].LastSignState.SignBytes)
+
+	// replicating an older state is a no-op
+	older := lss
+	older.Height = 9
+	require.NoError(t, pvs[1].ReplicateSignState(older))
+	assert.Equal(t, int64(10), pvs[1].LastSignState.Height)
+
+	// conflicting sign bytes for the same HRS are refused
+	conflicting := lss
+	conflicting.SignBytes = []byte("other")
+	assert.Error(t, pvs[1].ReplicateSignState(conflicting))
+}
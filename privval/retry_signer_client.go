@@ -94,3 +94,41 @@ func (sc *RetrySignerClient) SignProposal(chainID string, proposal *tmproto.Prop
 	}
 	return fmt.Errorf("exhausted all attempts to sign proposal: %w", err)
 }
+
+//--------------------------------------------------------
+// Implement SignerBackend
+
+func (sc *RetrySignerClient) GetLastSignState() (FilePVLastSignState, error) {
+	var (
+		lss FilePVLastSignState
+		err error
+	)
+	for i := 0; i < sc.retries || sc.retries == 0; i++ {
+		lss, err = sc.next.GetLastSignState()
+		if err == nil {
+			return lss, nil
+		}
+		// If remote signer errors, we don't retry.
+		if _, ok := err.(*RemoteSignerError); ok {
+			return lss, err
+		}
+		time.Sleep(sc.timeout)
+	}
+	return lss, fmt.Errorf("exhausted all attempts to get sign state: %w", err)
+}
+
+func (sc *RetrySignerClient) ReplicateSignState(lss FilePVLastSignState) error {
+	var err error
+	for i := 0; i < sc.retries || sc.retries == 0; i++ {
+		err = sc.next.ReplicateSignState(lss)
+		if err == nil {
+			return nil
+		}
+		// If remote signer errors, we don't retry.
+		if _, ok := err.(*RemoteSignerError); ok {
+			return err
+		}
+		time.Sleep(sc.timeout)
+	}
+	return fmt.Errorf("exhausted all attempts to replicate sign state: %w", err)
+}
@@ -131,3 +131,45 @@ func (sc *SignerClient) SignProposal(chainID string, proposal *tmproto.Proposal)
 
 	return nil
 }
+
+//--------------------------------------------------------
+// Implement SignerBackend
+
+// GetLastSignState requests the last sign state of the remote signer.
+func (sc *SignerClient) GetLastSignState() (FilePVLastSignState, error) {
+	response, err := sc.endpoint.SendRequest(mustWrapMsg(&privvalproto.SignStateRequest{ChainId: sc.chainID}))
+	if err != nil {
+		return FilePVLastSignState{}, err
+	}
+
+	resp := response.GetSignStateResponse()
+	if resp == nil {
+		return FilePVLastSignState{}, ErrUnexpectedResponse
+	}
+	if resp.Error != nil {
+		return FilePVLastSignState{}, &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+
+	return SignStateFromProto(resp.State)
+}
+
+// ReplicateSignState requests the remote signer to fast-forward its last sign
+// state to lss.
+func (sc *SignerClient) ReplicateSignState(lss FilePVLastSignState) error {
+	response, err := sc.endpoint.SendRequest(mustWrapMsg(
+		&privvalproto.ReplicateSignStateRequest{State: SignStateToProto(lss), ChainId: sc.chainID},
+	))
+	if err != nil {
+		return err
+	}
+
+	resp := response.GetReplicateSignStateResponse()
+	if resp == nil {
+		return ErrUnexpectedResponse
+	}
+	if resp.Error != nil {
+		return &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+
+	return nil
+}
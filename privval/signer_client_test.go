@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/arcology-network/consensus-engine/crypto"
+	"github.com/arcology-network/consensus-engine/crypto/ed25519"
 	"github.com/arcology-network/consensus-engine/crypto/tmhash"
 	tmrand "github.com/arcology-network/consensus-engine/libs/rand"
 	cryptoproto "github.com/arcology-network/consensus-engine/proto/tendermint/crypto"
@@ -437,3 +438,35 @@ func TestSignerUnexpectedResponse(t *testing.T) {
 		assert.EqualError(t, e, "empty response")
 	}
 }
+
+func TestSignerSignState(t *testing.T) {
+	for _, dtc := range getDialerTestCases(t) {
+		chainID := tmrand.Str(12)
+		filePV := newFilePVBackends(t, ed25519.GenPrivKey(), 1)[0]
+
+		sl, sd := getMockEndpoints(t, dtc.addr, dtc.dialer)
+		sc, err := NewSignerClient(sl, chainID)
+		require.NoError(t, err)
+		ss := NewSignerServer(sd, chainID, filePV)
+		require.NoError(t, ss.Start())
+		t.Cleanup(func() {
+			if err := ss.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		t.Cleanup(func() {
+			if err := sc.Close(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		lss := FilePVLastSignState{Height: 7, Round: 1, Step: stepPrevote}
+		require.NoError(t, sc.ReplicateSignState(lss))
+		got, err := sc.GetLastSignState()
+		require.NoError(t, err)
+		assert.Equal(t, lss, got)
+
+		lss.SignBytes = []byte("conflicting")
+		assert.Error(t, sc.ReplicateSignState(lss))
+	}
+}
@@ -86,6 +86,52 @@ func DefaultValidationRequestHandler(
 	case *privvalproto.Message_PingRequest:
 		err, res = nil, mustWrapMsg(&privvalproto.PingResponse{})
 
+	case *privvalproto.Message_SignStateRequest:
+		if r.SignStateRequest.GetChainId() != chainID {
+			res = mustWrapMsg(&privvalproto.SignStateResponse{
+				Error: &privvalproto.RemoteSignerError{Code: 0, Description: "unable to provide sign state"}})
+			return res, fmt.Errorf("want chainID: %s, got chainID: %s", r.SignStateRequest.GetChainId(), chainID)
+		}
+
+		backend, ok := privVal.(SignerBackend)
+		if !ok {
+			res = mustWrapMsg(&privvalproto.SignStateResponse{
+				Error: &privvalproto.RemoteSignerError{Code: 0, Description: "sign state is not supported"}})
+			break
+		}
+		lss, err := backend.GetLastSignState()
+		if err != nil {
+			res = mustWrapMsg(&privvalproto.SignStateResponse{
+				Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
+		} else {
+			res = mustWrapMsg(&privvalproto.SignStateResponse{State: SignStateToProto(lss)})
+		}
+
+	case *privvalproto.Message_ReplicateSignStateRequest:
+		if r.ReplicateSignStateRequest.GetChainId() != chainID {
+			res = mustWrapMsg(&privvalproto.ReplicateSignStateResponse{
+				Error: &privvalproto.RemoteSignerError{Code: 0, Description: "unable to replicate sign state"}})
+			return res, fmt.Errorf("want chainID: %s, got chainID: %s",
+				r.ReplicateSignStateRequest.GetChainId(), chainID)
+		}
+
+		backend, ok := privVal.(SignerBackend)
+		if !ok {
+			res = mustWrapMsg(&privvalproto.ReplicateSignStateResponse{
+				Error: &privvalproto.RemoteSignerError{Code: 0, Description: "sign state is not supported"}})
+			break
+		}
+		lss, err := SignStateFromProto(r.ReplicateSignStateRequest.State)
+		if err == nil {
+			err = backend.ReplicateSignState(lss)
+		}
+		if err != nil {
+			res = mustWrapMsg(&privvalproto.ReplicateSignStateResponse{
+				Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
+		} else {
+			res = mustWrapMsg(&privvalproto.ReplicateSignStateResponse{})
+		}
+
 	default:
 		err = fmt.Errorf("unknown msg: %v", r)
 	}
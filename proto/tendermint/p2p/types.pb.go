@@ -152,6 +152,9 @@ type DefaultNodeInfo struct {
 	Channels        []byte               `protobuf:"bytes,6,opt,name=channels,proto3" json:"channels,omitempty"`
 	Moniker         string               `protobuf:"bytes,7,opt,name=moniker,proto3" json:"moniker,omitempty"`
 	Other           DefaultNodeInfoOther `protobuf:"bytes,8,opt,name=other,proto3" json:"other"`
+	// address the sender observed the receiver's connection from, sent in the
+	// handshake only
+	ObservedAddr string `protobuf:"bytes,9,opt,name=observed_addr,json=observedAddr,proto3" json:"observed_addr,omitempty"`
 }
 
 func (m *DefaultNodeInfo) Reset()         { *m = DefaultNodeInfo{} }
@@ -243,6 +246,13 @@ func (m *DefaultNodeInfo) GetOther() DefaultNodeInfoOther {
 	return DefaultNodeInfoOther{}
 }
 
+func (m *DefaultNodeInfo) GetObservedAddr() string {
+	if m != nil {
+		return m.ObservedAddr
+	}
+	return ""
+}
+
 type DefaultNodeInfoOther struct {
 	TxIndex    string `protobuf:"bytes,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
 	RPCAddress string `protobuf:"bytes,2,opt,name=rpc_address,json=rpcAddress,proto3" json:"rpc_address,omitempty"`
@@ -305,39 +315,39 @@ func init() {
 func init() { proto.RegisterFile("tendermint/p2p/types.proto", fileDescriptor_c8a29e659aeca578) }
 
 var fileDescriptor_c8a29e659aeca578 = []byte{
-	// 502 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0xb1, 0x8e, 0xda, 0x40,
-	0x10, 0xc5, 0xc6, 0x07, 0xdc, 0x10, 0x8e, 0xcb, 0x0a, 0x45, 0x3e, 0x0a, 0x1b, 0xa1, 0x14, 0x34,
-	0xc1, 0x12, 0xa9, 0xd2, 0xe5, 0x08, 0x45, 0x68, 0x2e, 0xd6, 0xe6, 0x94, 0x22, 0x29, 0x10, 0x78,
-	0xf7, 0x60, 0x85, 0xd9, 0x5d, 0xed, 0x2e, 0x09, 0xf9, 0x8b, 0x7c, 0xd6, 0x95, 0x57, 0xa6, 0x42,
-	0x91, 0x29, 0xf3, 0x13, 0x91, 0xd7, 0xbe, 0x84, 0x43, 0xe9, 0xe6, 0xcd, 0xdb, 0x99, 0x37, 0xf3,
-	0x34, 0x0b, 0x5d, 0x43, 0x39, 0xa1, 0x6a, 0xc3, 0xb8, 0x89, 0xe4, 0x48, 0x46, 0xe6, 0xbb, 0xa4,
-	0x7a, 0x28, 0x95, 0x30, 0x02, 0x5d, 0xfc, 0xe3, 0x86, 0x72, 0x24, 0xbb, 0x9d, 0xa5, 0x58, 0x0a,
-	0x4b, 0x45, 0x79, 0x54, 0xbc, 0xea, 0xc7, 0x00, 0x37, 0xd4, 0x5c, 0x13, 0xa2, 0xa8, 0xd6, 0xe8,
-	0x05, 0xb8, 0x8c, 0xf8, 0x4e, 0xcf, 0x19, 0x9c, 0x8f, 0x6b, 0xd9, 0x3e, 0x74, 0xa7, 0x13, 0xec,
-	0x32, 0x62, 0xf3, 0xd2, 0x77, 0x8f, 0xf2, 0x31, 0x76, 0x99, 0x44, 0x08, 0x3c, 0x29, 0x94, 0xf1,
-	0xab, 0x3d, 0x67, 0xd0, 0xc2, 0x36, 0xee, 0xdf, 0x42, 0x3b, 0xce, 0x5b, 0x27, 0x22, 0xfd, 0x44,
-	0x95, 0x66, 0x82, 0xa3, 0x2b, 0xa8, 0xca, 0x91, 0xb4, 0x7d, 0xbd, 0x71, 0x3d, 0xdb, 0x87, 0xd5,
-	0x78, 0x14, 0xe3, 0x3c, 0x87, 0x3a, 0x70, 0xb6, 0x48, 0x45, 0xb2, 0xb6, 0xcd, 0x3d, 0x5c, 0x00,
-	0x74, 0x09, 0xd5, 0xb9, 0x94, 0xb6, 0xad, 0x87, 0xf3, 0xb0, 0xff, 0xdb, 0x85, 0xf6, 0x84, 0xde,
-	0xcd, 0xb7, 0xa9, 0xb9, 0x11, 0x84, 0x4e, 0xf9, 0x9d, 0x40, 0x31, 0x5c, 0xca, 0x52, 0x69, 0xf6,
-	0xb5, 0x90, 0xb2, 0x1a, 0xcd, 0x51, 0x38, 0x7c, 0xba, 0xfc, 0xf0, 0x64, 0xa2, 0xb1, 0x77, 0xbf,
-	0x0f, 0x2b, 0xb8, 0x2d, 0x4f, 0x06, 0x7d, 0x03, 0x6d, 0x52, 0x88, 0xcc, 0xb8, 0x20, 0x74, 0xc6,
-	0x48, 0xb9, 0xf4, 0xf3, 0x6c, 0x1f, 0xb6, 0x8e, 0xf5, 0x27, 0xb8, 0x45, 0x8e, 0x20, 0x41, 0x21,
-	0x34, 0x53, 0xa6, 0x0d, 0xe5, 0xb3, 0x39, 0x21, 0xca, 0x8e, 0x7e, 0x8e, 0xa1, 0x48, 0xe5, 0xf6,
-	0x22, 0x1f, 0xea, 0x9c, 0x9a, 0x6f, 0x42, 0xad, 0x7d, 0xcf, 0x92, 0x8f, 0x30, 0x67, 0x1e, 0xc7,
-	0x3f, 0x2b, 0x98, 0x12, 0xa2, 0x2e, 0x34, 0x92, 0xd5, 0x9c, 0x73, 0x9a, 0x6a, 0xbf, 0xd6, 0x73,
-	0x06, 0xcf, 0xf0, 0x5f, 0x9c, 0x57, 0x6d, 0x04, 0x67, 0x6b, 0xaa, 0xfc, 0x7a, 0x51, 0x55, 0x42,
-	0xf4, 0x16, 0xce, 0x84, 0x59, 0x51, 0xe5, 0x37, 0xac, 0x19, 0x2f, 0x4f, 0xcd, 0x38, 0xf1, 0xf1,
-	0x43, 0xfe, 0xb6, 0x74, 0xa4, 0x28, 0xec, 0x2f, 0xa0, 0xf3, 0xbf, 0x47, 0xe8, 0x0a, 0x1a, 0x66,
-	0x37, 0x63, 0x9c, 0xd0, 0x5d, 0x71, 0x25, 0xb8, 0x6e, 0x76, 0xd3, 0x1c, 0xa2, 0x08, 0x9a, 0x4a,
-	0x26, 0x76, 0x79, 0xaa, 0x75, 0x69, 0xdb, 0x45, 0xb6, 0x0f, 0x01, 0xc7, 0xef, 0xca, 0xfb, 0xc2,
-	0xa0, 0x64, 0x52, 0xc6, 0xe3, 0x2f, 0xf7, 0x59, 0xe0, 0x3c, 0x64, 0x81, 0xf3, 0x2b, 0x0b, 0x9c,
-	0x1f, 0x87, 0xa0, 0xf2, 0x70, 0x08, 0x2a, 0x3f, 0x0f, 0x41, 0xe5, 0xf3, 0xf5, 0x92, 0x99, 0xd5,
-	0x76, 0x31, 0x4c, 0xc4, 0x26, 0x7a, 0x1f, 0x4f, 0x3f, 0xde, 0xd2, 0x64, 0xc5, 0x45, 0x2a, 0x96,
-	0x8c, 0xea, 0x28, 0x11, 0x5c, 0x53, 0xae, 0xb7, 0xfa, 0x15, 0xe5, 0x4b, 0xc6, 0x69, 0x54, 0x9c,
-	0xf4, 0xd3, 0x8f, 0xb0, 0xa8, 0xd9, 0xec, 0xeb, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x4d, 0x88,
-	0xe5, 0xb1, 0x21, 0x03, 0x00, 0x00,
+	// 511 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0xc1, 0x6e, 0xda, 0x40,
+	0x10, 0xc5, 0xe0, 0x04, 0x18, 0x42, 0x48, 0x57, 0xa8, 0x72, 0x38, 0xd8, 0x88, 0xf6, 0xc0, 0x25,
+	0x58, 0xa2, 0xa7, 0xde, 0x1a, 0xca, 0x85, 0x4b, 0x6a, 0xad, 0xaa, 0x1e, 0xda, 0x03, 0x02, 0xef,
+	0x06, 0x2c, 0xcc, 0xee, 0x6a, 0x77, 0x49, 0xc9, 0x5f, 0xe4, 0xb3, 0x72, 0xcc, 0xb1, 0x27, 0x54,
+	0x99, 0x1f, 0xa9, 0xbc, 0x6b, 0x5a, 0x82, 0x7a, 0x9b, 0xf7, 0xc6, 0xfb, 0xde, 0xcc, 0xd3, 0x18,
+	0x3a, 0x9a, 0x32, 0x42, 0xe5, 0x3a, 0x61, 0x3a, 0x14, 0x43, 0x11, 0xea, 0x47, 0x41, 0xd5, 0x40,
+	0x48, 0xae, 0x39, 0xba, 0xfc, 0xd7, 0x1b, 0x88, 0xa1, 0xe8, 0xb4, 0x17, 0x7c, 0xc1, 0x4d, 0x2b,
+	0xcc, 0x2b, 0xfb, 0x55, 0x2f, 0x02, 0xb8, 0xa3, 0xfa, 0x96, 0x10, 0x49, 0x95, 0x42, 0x6f, 0xa1,
+	0x9c, 0x10, 0xcf, 0xe9, 0x3a, 0xfd, 0xfa, 0xe8, 0x3c, 0xdb, 0x05, 0xe5, 0xc9, 0x18, 0x97, 0x13,
+	0x62, 0x78, 0xe1, 0x95, 0x8f, 0xf8, 0x08, 0x97, 0x13, 0x81, 0x10, 0xb8, 0x82, 0x4b, 0xed, 0x55,
+	0xba, 0x4e, 0xbf, 0x89, 0x4d, 0xdd, 0xfb, 0x0a, 0xad, 0x28, 0x97, 0x8e, 0x79, 0xfa, 0x8d, 0x4a,
+	0x95, 0x70, 0x86, 0xae, 0xa1, 0x22, 0x86, 0xc2, 0xe8, 0xba, 0xa3, 0x6a, 0xb6, 0x0b, 0x2a, 0xd1,
+	0x30, 0xc2, 0x39, 0x87, 0xda, 0x70, 0x36, 0x4f, 0x79, 0xbc, 0x32, 0xe2, 0x2e, 0xb6, 0x00, 0x5d,
+	0x41, 0x65, 0x26, 0x84, 0x91, 0x75, 0x71, 0x5e, 0xf6, 0x9e, 0x2a, 0xd0, 0x1a, 0xd3, 0xfb, 0xd9,
+	0x26, 0xd5, 0x77, 0x9c, 0xd0, 0x09, 0xbb, 0xe7, 0x28, 0x82, 0x2b, 0x51, 0x38, 0x4d, 0x1f, 0xac,
+	0x95, 0xf1, 0x68, 0x0c, 0x83, 0xc1, 0xeb, 0xe5, 0x07, 0x27, 0x13, 0x8d, 0xdc, 0xe7, 0x5d, 0x50,
+	0xc2, 0x2d, 0x71, 0x32, 0xe8, 0x47, 0x68, 0x11, 0x6b, 0x32, 0x65, 0x9c, 0xd0, 0x69, 0x42, 0x8a,
+	0xa5, 0xdf, 0x64, 0xbb, 0xa0, 0x79, 0xec, 0x3f, 0xc6, 0x4d, 0x72, 0x04, 0x09, 0x0a, 0xa0, 0x91,
+	0x26, 0x4a, 0x53, 0x36, 0x9d, 0x11, 0x22, 0xcd, 0xe8, 0x75, 0x0c, 0x96, 0xca, 0xe3, 0x45, 0x1e,
+	0x54, 0x19, 0xd5, 0x3f, 0xb9, 0x5c, 0x79, 0xae, 0x69, 0x1e, 0x60, 0xde, 0x39, 0x8c, 0x7f, 0x66,
+	0x3b, 0x05, 0x44, 0x1d, 0xa8, 0xc5, 0xcb, 0x19, 0x63, 0x34, 0x55, 0xde, 0x79, 0xd7, 0xe9, 0x5f,
+	0xe0, 0xbf, 0x38, 0x7f, 0xb5, 0xe6, 0x2c, 0x59, 0x51, 0xe9, 0x55, 0xed, 0xab, 0x02, 0xa2, 0x4f,
+	0x70, 0xc6, 0xf5, 0x92, 0x4a, 0xaf, 0x66, 0xc2, 0x78, 0x7f, 0x1a, 0xc6, 0x49, 0x8e, 0x5f, 0xf2,
+	0x6f, 0x8b, 0x44, 0xec, 0x43, 0xf4, 0x0e, 0x9a, 0x7c, 0xae, 0xa8, 0x7c, 0xa0, 0xc4, 0xae, 0x53,
+	0x37, 0x0e, 0x17, 0x07, 0x32, 0x5f, 0xa8, 0x37, 0x87, 0xf6, 0xff, 0x94, 0xd0, 0x35, 0xd4, 0xf4,
+	0x76, 0x9a, 0x30, 0x42, 0xb7, 0xf6, 0x94, 0x70, 0x55, 0x6f, 0x27, 0x39, 0x44, 0x21, 0x34, 0xa4,
+	0x88, 0x8d, 0x24, 0x55, 0xaa, 0xc8, 0xf6, 0x32, 0xdb, 0x05, 0x80, 0xa3, 0xcf, 0xc5, 0x11, 0x62,
+	0x90, 0x22, 0x2e, 0xea, 0xd1, 0x8f, 0xe7, 0xcc, 0x77, 0x5e, 0x32, 0xdf, 0xf9, 0x9d, 0xf9, 0xce,
+	0xd3, 0xde, 0x2f, 0xbd, 0xec, 0xfd, 0xd2, 0xaf, 0xbd, 0x5f, 0xfa, 0x7e, 0xbb, 0x48, 0xf4, 0x72,
+	0x33, 0x1f, 0xc4, 0x7c, 0x1d, 0xce, 0x64, 0xcc, 0x53, 0xbe, 0x78, 0xbc, 0x29, 0x12, 0x0d, 0x63,
+	0xce, 0x14, 0x65, 0x6a, 0xa3, 0x6e, 0x28, 0x5b, 0x24, 0x8c, 0x86, 0xf6, 0xee, 0x5f, 0xff, 0x2d,
+	0xf3, 0x73, 0xc3, 0x7e, 0xf8, 0x33, 0x00, 0x2a, 0x3b, 0x42, 0x7b, 0x46, 0x03, 0x00, 0x00,
 }
 
 func (m *NetAddress) Marshal() (dAtA []byte, err error) {
@@ -440,6 +450,13 @@ func (m *DefaultNodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ObservedAddr) > 0 {
+		i -= len(m.ObservedAddr)
+		copy(dAtA[i:], m.ObservedAddr)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ObservedAddr)))
+		i--
+		dAtA[i] = 0x4a
+	}
 	{
 		size, err := m.Other.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -625,6 +642,10 @@ func (m *DefaultNodeInfo) Size() (n int) {
 	}
 	l = m.Other.Size()
 	n += 1 + l + sovTypes(uint64(l))
+	l = len(m.ObservedAddr)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -1180,6 +1201,38 @@ func (m *DefaultNodeInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObservedAddr", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ObservedAddr = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
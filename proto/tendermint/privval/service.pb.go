@@ -0,0 +1,275 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: tendermint/privval/service.proto
+
+package privval
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() { proto.RegisterFile("tendermint/privval/service.proto", fileDescriptor_7afe74f9f46d3dc9) }
+
+var fileDescriptor_7afe74f9f46d3dc9 = []byte{
+	// 313 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xcd, 0x4a, 0x03, 0x31,
+	0x14, 0x85, 0x5b, 0x04, 0xd1, 0xd0, 0x85, 0x64, 0xd9, 0x45, 0xf0, 0x5f, 0x10, 0x9a, 0x80, 0x3e,
+	0x81, 0x82, 0x14, 0x71, 0x33, 0xb4, 0x50, 0x41, 0x17, 0x92, 0xa6, 0x97, 0x31, 0x38, 0x4d, 0x62,
+	0x72, 0x67, 0xa4, 0x6f, 0xe1, 0x63, 0xb9, 0xec, 0xd2, 0xa5, 0xb4, 0xef, 0xe0, 0x5a, 0xda, 0x99,
+	0xd0, 0x45, 0x3b, 0xea, 0xf6, 0x9e, 0xef, 0x9c, 0x6f, 0x73, 0xc9, 0x3e, 0x82, 0x19, 0x81, 0x1f,
+	0x6b, 0x83, 0xc2, 0x79, 0x5d, 0x14, 0x32, 0x13, 0x01, 0x7c, 0xa1, 0x15, 0x70, 0xe7, 0x2d, 0x5a,
+	0x4a, 0x57, 0x04, 0xaf, 0x88, 0x36, 0xdb, 0xd0, 0xc2, 0x89, 0x83, 0x50, 0x76, 0x2e, 0xbe, 0xb7,
+	0xc8, 0x5e, 0xe2, 0x75, 0x31, 0x90, 0x99, 0x1e, 0x49, 0xb4, 0xfe, 0x2a, 0xb9, 0xa5, 0x3d, 0xb2,
+	0xdb, 0x05, 0x4c, 0xf2, 0xe1, 0x1d, 0x4c, 0xe8, 0x01, 0x5f, 0x9f, 0xe5, 0x65, 0xd6, 0x83, 0xd7,
+	0x1c, 0x02, 0xb6, 0x0f, 0x7f, 0x43, 0x82, 0xb3, 0x26, 0x00, 0xbd, 0x27, 0x3b, 0x7d, 0x9d, 0x9a,
+	0x81, 0x45, 0xa0, 0x47, 0x9b, 0xf8, 0x98, 0xc6, 0xd1, 0xd3, 0x3a, 0x08, 0x46, 0x25, 0x56, 0x0d,
+	0x2b, 0xd2, 0x5a, 0x5c, 0x13, 0x6f, 0x9d, 0x0d, 0x32, 0xa3, 0x67, 0x75, 0xbd, 0x48, 0x44, 0xc1,
+	0x79, 0xbd, 0x60, 0x85, 0x56, 0x92, 0x47, 0xd2, 0xea, 0x02, 0x2e, 0xc2, 0x3e, 0x4a, 0x04, 0x7a,
+	0x5c, 0xd7, 0x5d, 0xc6, 0xd1, 0x70, 0xf2, 0x07, 0x55, 0x8d, 0x07, 0x42, 0x7b, 0xe0, 0x32, 0xad,
+	0x24, 0xc2, 0x4a, 0xd1, 0xd9, 0x54, 0x5e, 0xe7, 0xa2, 0x8b, 0xff, 0x17, 0x2f, 0xa5, 0xd7, 0x4f,
+	0x1f, 0x33, 0xd6, 0x9c, 0xce, 0x58, 0xf3, 0x6b, 0xc6, 0x9a, 0xef, 0x73, 0xd6, 0x98, 0xce, 0x59,
+	0xe3, 0x73, 0xce, 0x1a, 0x0f, 0x37, 0xa9, 0xc6, 0xe7, 0x7c, 0xc8, 0x95, 0x1d, 0x0b, 0xe9, 0x95,
+	0xcd, 0x6c, 0x3a, 0xe9, 0x18, 0xc0, 0x37, 0xeb, 0x5f, 0x84, 0x5a, 0x94, 0x4d, 0xc8, 0x43, 0x07,
+	0x4c, 0xaa, 0x0d, 0x88, 0xe5, 0x1b, 0x89, 0xf5, 0x2f, 0x1b, 0x6e, 0x2f, 0x93, 0xcb, 0x9f, 0x01,
+	0x00, 0x3a, 0xa8, 0x91, 0xd4, 0xb8, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PrivValidatorAPIClient is the client API for PrivValidatorAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PrivValidatorAPIClient interface {
+	GetPubKey(ctx context.Context, in *PubKeyRequest, opts ...grpc.CallOption) (*PubKeyResponse, error)
+	SignVote(ctx context.Context, in *SignVoteRequest, opts ...grpc.CallOption) (*SignedVoteResponse, error)
+	SignProposal(ctx context.Context, in *SignProposalRequest, opts ...grpc.CallOption) (*SignedProposalResponse, error)
+	GetSignState(ctx context.Context, in *SignStateRequest, opts ...grpc.CallOption) (*SignStateResponse, error)
+	ReplicateSignState(ctx context.Context, in *ReplicateSignStateRequest, opts ...grpc.CallOption) (*ReplicateSignStateResponse, error)
+}
+
+type privValidatorAPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPrivValidatorAPIClient(cc *grpc.ClientConn) PrivValidatorAPIClient {
+	return &privValidatorAPIClient{cc}
+}
+
+func (c *privValidatorAPIClient) GetPubKey(ctx context.Context, in *PubKeyRequest, opts ...grpc.CallOption) (*PubKeyResponse, error) {
+	out := new(PubKeyResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.PrivValidatorAPI/GetPubKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorAPIClient) SignVote(ctx context.Context, in *SignVoteRequest, opts ...grpc.CallOption) (*SignedVoteResponse, error) {
+	out := new(SignedVoteResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.PrivValidatorAPI/SignVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorAPIClient) SignProposal(ctx context.Context, in *SignProposalRequest, opts ...grpc.CallOption) (*SignedProposalResponse, error) {
+	out := new(SignedProposalResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.PrivValidatorAPI/SignProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorAPIClient) GetSignState(ctx context.Context, in *SignStateRequest, opts ...grpc.CallOption) (*SignStateResponse, error) {
+	out := new(SignStateResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.PrivValidatorAPI/GetSignState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorAPIClient) ReplicateSignState(ctx context.Context, in *ReplicateSignStateRequest, opts ...grpc.CallOption) (*ReplicateSignStateResponse, error) {
+	out := new(ReplicateSignStateResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.PrivValidatorAPI/ReplicateSignState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PrivValidatorAPIServer is the server API for PrivValidatorAPI service.
+type PrivValidatorAPIServer interface {
+	GetPubKey(context.Context, *PubKeyRequest) (*PubKeyResponse, error)
+	SignVote(context.Context, *SignVoteRequest) (*SignedVoteResponse, error)
+	SignProposal(context.Context, *SignProposalRequest) (*SignedProposalResponse, error)
+	GetSignState(context.Context, *SignStateRequest) (*SignStateResponse, error)
+	ReplicateSignState(context.Context, *ReplicateSignStateRequest) (*ReplicateSignStateResponse, error)
+}
+
+// UnimplementedPrivValidatorAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedPrivValidatorAPIServer struct {
+}
+
+func (*UnimplementedPrivValidatorAPIServer) GetPubKey(ctx context.Context, req *PubKeyRequest) (*PubKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPubKey not implemented")
+}
+func (*UnimplementedPrivValidatorAPIServer) SignVote(ctx context.Context, req *SignVoteRequest) (*SignedVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignVote not implemented")
+}
+func (*UnimplementedPrivValidatorAPIServer) SignProposal(ctx context.Context, req *SignProposalRequest) (*SignedProposalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignProposal not implemented")
+}
+func (*UnimplementedPrivValidatorAPIServer) GetSignState(ctx context.Context, req *SignStateRequest) (*SignStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSignState not implemented")
+}
+func (*UnimplementedPrivValidatorAPIServer) ReplicateSignState(ctx context.Context, req *ReplicateSignStateRequest) (*ReplicateSignStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplicateSignState not implemented")
+}
+
+func RegisterPrivValidatorAPIServer(s *grpc.Server, srv PrivValidatorAPIServer) {
+	s.RegisterService(&_PrivValidatorAPI_serviceDesc, srv)
+}
+
+func _PrivValidatorAPI_GetPubKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PubKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).GetPubKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.PrivValidatorAPI/GetPubKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).GetPubKey(ctx, req.(*PubKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorAPI_SignVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).SignVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.PrivValidatorAPI/SignVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).SignVote(ctx, req.(*SignVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorAPI_SignProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignProposalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).SignProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.PrivValidatorAPI/SignProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).SignProposal(ctx, req.(*SignProposalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorAPI_GetSignState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).GetSignState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.PrivValidatorAPI/GetSignState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).GetSignState(ctx, req.(*SignStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorAPI_ReplicateSignState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplicateSignStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).ReplicateSignState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.PrivValidatorAPI/ReplicateSignState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).ReplicateSignState(ctx, req.(*ReplicateSignStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PrivValidatorAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "tendermint.privval.PrivValidatorAPI",
+	HandlerType: (*PrivValidatorAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPubKey",
+			Handler:    _PrivValidatorAPI_GetPubKey_Handler,
+		},
+		{
+			MethodName: "SignVote",
+			Handler:    _PrivValidatorAPI_SignVote_Handler,
+		},
+		{
+			MethodName: "SignProposal",
+			Handler:    _PrivValidatorAPI_SignProposal_Handler,
+		},
+		{
+			MethodName: "GetSignState",
+			Handler:    _PrivValidatorAPI_GetSignState_Handler,
+		},
+		{
+			MethodName: "ReplicateSignState",
+			Handler:    _PrivValidatorAPI_ReplicateSignState_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "tendermint/privval/service.proto",
+}
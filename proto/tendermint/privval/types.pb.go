@@ -498,6 +498,281 @@ func (m *PingResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PingResponse proto.InternalMessageInfo
 
+// SignState is the last height/round/step a signer signed, along with the
+// signature and the signed bytes.
+type SignState struct {
+	Height    int64  `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round     int32  `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Step      int32  `protobuf:"varint,3,opt,name=step,proto3" json:"step,omitempty"`
+	Signature []byte `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	SignBytes []byte `protobuf:"bytes,5,opt,name=sign_bytes,json=signBytes,proto3" json:"sign_bytes,omitempty"`
+}
+
+func (m *SignState) Reset()         { *m = SignState{} }
+func (m *SignState) String() string { return proto.CompactTextString(m) }
+func (*SignState) ProtoMessage()    {}
+func (*SignState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{9}
+}
+func (m *SignState) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignState.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignState.Merge(m, src)
+}
+func (m *SignState) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignState) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignState proto.InternalMessageInfo
+
+func (m *SignState) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *SignState) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *SignState) GetStep() int32 {
+	if m != nil {
+		return m.Step
+	}
+	return 0
+}
+
+func (m *SignState) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *SignState) GetSignBytes() []byte {
+	if m != nil {
+		return m.SignBytes
+	}
+	return nil
+}
+
+// SignStateRequest is a request for the last sign state of a signer.
+type SignStateRequest struct {
+	ChainId string `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+}
+
+func (m *SignStateRequest) Reset()         { *m = SignStateRequest{} }
+func (m *SignStateRequest) String() string { return proto.CompactTextString(m) }
+func (*SignStateRequest) ProtoMessage()    {}
+func (*SignStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{10}
+}
+func (m *SignStateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignStateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignStateRequest.Merge(m, src)
+}
+func (m *SignStateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignStateRequest proto.InternalMessageInfo
+
+func (m *SignStateRequest) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+// SignStateResponse is a response containing the last sign state or an error.
+type SignStateResponse struct {
+	State SignState          `protobuf:"bytes,1,opt,name=state,proto3" json:"state"`
+	Error *RemoteSignerError `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *SignStateResponse) Reset()         { *m = SignStateResponse{} }
+func (m *SignStateResponse) String() string { return proto.CompactTextString(m) }
+func (*SignStateResponse) ProtoMessage()    {}
+func (*SignStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{11}
+}
+func (m *SignStateResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignStateResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignStateResponse.Merge(m, src)
+}
+func (m *SignStateResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignStateResponse proto.InternalMessageInfo
+
+func (m *SignStateResponse) GetState() SignState {
+	if m != nil {
+		return m.State
+	}
+	return SignState{}
+}
+
+func (m *SignStateResponse) GetError() *RemoteSignerError {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+// ReplicateSignStateRequest asks a signer to fast-forward its last sign state.
+type ReplicateSignStateRequest struct {
+	State   SignState `protobuf:"bytes,1,opt,name=state,proto3" json:"state"`
+	ChainId string    `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+}
+
+func (m *ReplicateSignStateRequest) Reset()         { *m = ReplicateSignStateRequest{} }
+func (m *ReplicateSignStateRequest) String() string { return proto.CompactTextString(m) }
+func (*ReplicateSignStateRequest) ProtoMessage()    {}
+func (*ReplicateSignStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{12}
+}
+func (m *ReplicateSignStateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReplicateSignStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReplicateSignStateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReplicateSignStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplicateSignStateRequest.Merge(m, src)
+}
+func (m *ReplicateSignStateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReplicateSignStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplicateSignStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplicateSignStateRequest proto.InternalMessageInfo
+
+func (m *ReplicateSignStateRequest) GetState() SignState {
+	if m != nil {
+		return m.State
+	}
+	return SignState{}
+}
+
+func (m *ReplicateSignStateRequest) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+// ReplicateSignStateResponse is a response to ReplicateSignStateRequest,
+// carrying an error if the state was refused.
+type ReplicateSignStateResponse struct {
+	Error *RemoteSignerError `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *ReplicateSignStateResponse) Reset()         { *m = ReplicateSignStateResponse{} }
+func (m *ReplicateSignStateResponse) String() string { return proto.CompactTextString(m) }
+func (*ReplicateSignStateResponse) ProtoMessage()    {}
+func (*ReplicateSignStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{13}
+}
+func (m *ReplicateSignStateResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReplicateSignStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReplicateSignStateResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReplicateSignStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplicateSignStateResponse.Merge(m, src)
+}
+func (m *ReplicateSignStateResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReplicateSignStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplicateSignStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplicateSignStateResponse proto.InternalMessageInfo
+
+func (m *ReplicateSignStateResponse) GetError() *RemoteSignerError {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 type Message struct {
 	// Types that are valid to be assigned to Sum:
 	//	*Message_PubKeyRequest
@@ -508,6 +783,10 @@ type Message struct {
 	//	*Message_SignedProposalResponse
 	//	*Message_PingRequest
 	//	*Message_PingResponse
+	//	*Message_SignStateRequest
+	//	*Message_SignStateResponse
+	//	*Message_ReplicateSignStateRequest
+	//	*Message_ReplicateSignStateResponse
 	Sum isMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -515,7 +794,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb4e437a5328cf9c, []int{9}
+	return fileDescriptor_cb4e437a5328cf9c, []int{14}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -574,15 +853,31 @@ type Message_PingRequest struct {
 type Message_PingResponse struct {
 	PingResponse *PingResponse `protobuf:"bytes,8,opt,name=ping_response,json=pingResponse,proto3,oneof" json:"ping_response,omitempty"`
 }
+type Message_SignStateRequest struct {
+	SignStateRequest *SignStateRequest `protobuf:"bytes,9,opt,name=sign_state_request,json=signStateRequest,proto3,oneof" json:"sign_state_request,omitempty"`
+}
+type Message_SignStateResponse struct {
+	SignStateResponse *SignStateResponse `protobuf:"bytes,10,opt,name=sign_state_response,json=signStateResponse,proto3,oneof" json:"sign_state_response,omitempty"`
+}
+type Message_ReplicateSignStateRequest struct {
+	ReplicateSignStateRequest *ReplicateSignStateRequest `protobuf:"bytes,11,opt,name=replicate_sign_state_request,json=replicateSignStateRequest,proto3,oneof" json:"replicate_sign_state_request,omitempty"`
+}
+type Message_ReplicateSignStateResponse struct {
+	ReplicateSignStateResponse *ReplicateSignStateResponse `protobuf:"bytes,12,opt,name=replicate_sign_state_response,json=replicateSignStateResponse,proto3,oneof" json:"replicate_sign_state_response,omitempty"`
+}
 
-func (*Message_PubKeyRequest) isMessage_Sum()          {}
-func (*Message_PubKeyResponse) isMessage_Sum()         {}
-func (*Message_SignVoteRequest) isMessage_Sum()        {}
-func (*Message_SignedVoteResponse) isMessage_Sum()     {}
-func (*Message_SignProposalRequest) isMessage_Sum()    {}
-func (*Message_SignedProposalResponse) isMessage_Sum() {}
-func (*Message_PingRequest) isMessage_Sum()            {}
-func (*Message_PingResponse) isMessage_Sum()           {}
+func (*Message_PubKeyRequest) isMessage_Sum()              {}
+func (*Message_PubKeyResponse) isMessage_Sum()             {}
+func (*Message_SignVoteRequest) isMessage_Sum()            {}
+func (*Message_SignedVoteResponse) isMessage_Sum()         {}
+func (*Message_SignProposalRequest) isMessage_Sum()        {}
+func (*Message_SignedProposalResponse) isMessage_Sum()     {}
+func (*Message_PingRequest) isMessage_Sum()                {}
+func (*Message_PingResponse) isMessage_Sum()               {}
+func (*Message_SignStateRequest) isMessage_Sum()           {}
+func (*Message_SignStateResponse) isMessage_Sum()          {}
+func (*Message_ReplicateSignStateRequest) isMessage_Sum()  {}
+func (*Message_ReplicateSignStateResponse) isMessage_Sum() {}
 
 func (m *Message) GetSum() isMessage_Sum {
 	if m != nil {
@@ -647,6 +942,34 @@ func (m *Message) GetPingResponse() *PingResponse {
 	return nil
 }
 
+func (m *Message) GetSignStateRequest() *SignStateRequest {
+	if x, ok := m.GetSum().(*Message_SignStateRequest); ok {
+		return x.SignStateRequest
+	}
+	return nil
+}
+
+func (m *Message) GetSignStateResponse() *SignStateResponse {
+	if x, ok := m.GetSum().(*Message_SignStateResponse); ok {
+		return x.SignStateResponse
+	}
+	return nil
+}
+
+func (m *Message) GetReplicateSignStateRequest() *ReplicateSignStateRequest {
+	if x, ok := m.GetSum().(*Message_ReplicateSignStateRequest); ok {
+		return x.ReplicateSignStateRequest
+	}
+	return nil
+}
+
+func (m *Message) GetReplicateSignStateResponse() *ReplicateSignStateResponse {
+	if x, ok := m.GetSum().(*Message_ReplicateSignStateResponse); ok {
+		return x.ReplicateSignStateResponse
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -658,6 +981,10 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_SignedProposalResponse)(nil),
 		(*Message_PingRequest)(nil),
 		(*Message_PingResponse)(nil),
+		(*Message_SignStateRequest)(nil),
+		(*Message_SignStateResponse)(nil),
+		(*Message_ReplicateSignStateRequest)(nil),
+		(*Message_ReplicateSignStateResponse)(nil),
 	}
 }
 
@@ -672,62 +999,81 @@ func init() {
 	proto.RegisterType((*SignedProposalResponse)(nil), "tendermint.privval.SignedProposalResponse")
 	proto.RegisterType((*PingRequest)(nil), "tendermint.privval.PingRequest")
 	proto.RegisterType((*PingResponse)(nil), "tendermint.privval.PingResponse")
+	proto.RegisterType((*SignState)(nil), "tendermint.privval.SignState")
+	proto.RegisterType((*SignStateRequest)(nil), "tendermint.privval.SignStateRequest")
+	proto.RegisterType((*SignStateResponse)(nil), "tendermint.privval.SignStateResponse")
+	proto.RegisterType((*ReplicateSignStateRequest)(nil), "tendermint.privval.ReplicateSignStateRequest")
+	proto.RegisterType((*ReplicateSignStateResponse)(nil), "tendermint.privval.ReplicateSignStateResponse")
 	proto.RegisterType((*Message)(nil), "tendermint.privval.Message")
 }
 
 func init() { proto.RegisterFile("tendermint/privval/types.proto", fileDescriptor_cb4e437a5328cf9c) }
 
 var fileDescriptor_cb4e437a5328cf9c = []byte{
-	// 774 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x95, 0x4f, 0x4f, 0xe3, 0x46,
-	0x18, 0xc6, 0x6d, 0xc8, 0x1f, 0x78, 0x43, 0x42, 0x18, 0x28, 0x0d, 0x11, 0x35, 0x69, 0xaa, 0xb6,
-	0x28, 0x52, 0x93, 0x8a, 0x4a, 0xbd, 0xd0, 0x4b, 0x01, 0xab, 0x8e, 0x22, 0x9c, 0x74, 0x12, 0x0a,
-	0x42, 0xaa, 0xac, 0xc4, 0x99, 0x3a, 0x16, 0x89, 0xc7, 0xf5, 0x38, 0x48, 0x39, 0xf7, 0xd6, 0x53,
-	0xa5, 0x7e, 0x89, 0x3d, 0xef, 0xa7, 0xe0, 0xc8, 0x71, 0x4f, 0xab, 0x15, 0x7c, 0x91, 0x55, 0xc6,
-	0x13, 0xc7, 0xf9, 0x87, 0x76, 0xc5, 0xcd, 0xf3, 0xbe, 0xef, 0xfc, 0xde, 0xe7, 0xb1, 0x9f, 0x28,
-	0xa0, 0xf8, 0xc4, 0xe9, 0x12, 0x6f, 0x60, 0x3b, 0x7e, 0xc5, 0xf5, 0xec, 0xfb, 0xfb, 0x76, 0xbf,
-	0xe2, 0x8f, 0x5c, 0xc2, 0xca, 0xae, 0x47, 0x7d, 0x8a, 0xd0, 0xb4, 0x5f, 0x16, 0xfd, 0xfc, 0x61,
-	0xe4, 0x8e, 0xe9, 0x8d, 0x5c, 0x9f, 0x56, 0xee, 0xc8, 0x48, 0xdc, 0x98, 0xe9, 0x72, 0x52, 0x94,
-	0x97, 0xdf, 0xb3, 0xa8, 0x45, 0xf9, 0x63, 0x65, 0xfc, 0x14, 0x54, 0x8b, 0x55, 0xd8, 0xc1, 0x64,
-	0x40, 0x7d, 0xd2, 0xb4, 0x2d, 0x87, 0x78, 0xaa, 0xe7, 0x51, 0x0f, 0x21, 0x88, 0x99, 0xb4, 0x4b,
-	0x72, 0x72, 0x41, 0x3e, 0x8e, 0x63, 0xfe, 0x8c, 0x0a, 0x90, 0xea, 0x12, 0x66, 0x7a, 0xb6, 0xeb,
-	0xdb, 0xd4, 0xc9, 0xad, 0x15, 0xe4, 0xe3, 0x4d, 0x1c, 0x2d, 0x15, 0x4b, 0x90, 0x6e, 0x0c, 0x3b,
-	0x35, 0x32, 0xc2, 0xe4, 0xef, 0x21, 0x61, 0x3e, 0x3a, 0x80, 0x0d, 0xb3, 0xd7, 0xb6, 0x1d, 0xc3,
-	0xee, 0x72, 0xd4, 0x26, 0x4e, 0xf2, 0x73, 0xb5, 0x5b, 0xfc, 0x57, 0x86, 0xcc, 0x64, 0x98, 0xb9,
-	0xd4, 0x61, 0x04, 0x9d, 0x42, 0xd2, 0x1d, 0x76, 0x8c, 0x3b, 0x32, 0xe2, 0xc3, 0xa9, 0x93, 0xc3,
-	0x72, 0xe4, 0x0d, 0x04, 0x6e, 0xcb, 0x8d, 0x61, 0xa7, 0x6f, 0x9b, 0x35, 0x32, 0x3a, 0x8b, 0x3d,
-	0xbc, 0x3f, 0x92, 0x70, 0xc2, 0xe5, 0x10, 0x74, 0x0a, 0x71, 0x32, 0x96, 0xce, 0x75, 0xa5, 0x4e,
-	0xbe, 0x2d, 0x2f, 0xbe, 0xbc, 0xf2, 0x82, 0x4f, 0x1c, 0xdc, 0x29, 0xde, 0xc0, 0xf6, 0xb8, 0xfa,
-	0x07, 0xf5, 0xc9, 0x44, 0x7a, 0x09, 0x62, 0xf7, 0xd4, 0x27, 0x42, 0xc9, 0x7e, 0x14, 0x17, 0xbc,
-	0x53, 0x3e, 0xcc, 0x67, 0x66, 0x6c, 0xae, 0xcd, 0xda, 0xfc, 0x47, 0x06, 0xc4, 0x17, 0x76, 0x03,
-	0xb8, 0xb0, 0xfa, 0xe3, 0xa7, 0xd0, 0x85, 0xc3, 0x60, 0xc7, 0xab, 0xfc, 0xf5, 0x60, 0x77, 0x5c,
-	0x6d, 0x78, 0xd4, 0xa5, 0xac, 0xdd, 0x9f, 0x78, 0xfc, 0x19, 0x36, 0x5c, 0x51, 0x12, 0x4a, 0xf2,
-	0x8b, 0x4a, 0xc2, 0x4b, 0xe1, 0xec, 0x4b, 0x7e, 0xff, 0x97, 0x61, 0x3f, 0xf0, 0x3b, 0x5d, 0x26,
-	0x3c, 0xff, 0xf2, 0x39, 0xdb, 0x84, 0xf7, 0xe9, 0xce, 0x57, 0xf9, 0x4f, 0x43, 0xaa, 0x61, 0x3b,
-	0x96, 0xf0, 0x5d, 0xcc, 0xc0, 0x56, 0x70, 0x0c, 0x94, 0x15, 0xdf, 0xc6, 0x21, 0x79, 0x49, 0x18,
-	0x6b, 0x5b, 0x04, 0xd5, 0x60, 0x5b, 0x84, 0xd0, 0xf0, 0x82, 0x71, 0x21, 0xf6, 0xeb, 0x65, 0x1b,
-	0x67, 0xe2, 0xae, 0x49, 0x38, 0xed, 0xce, 0xe4, 0x5f, 0x87, 0xec, 0x14, 0x16, 0x2c, 0x13, 0xfa,
-	0x8b, 0x2f, 0xd1, 0x82, 0x49, 0x4d, 0xc2, 0x19, 0x77, 0xf6, 0x17, 0xf2, 0x3b, 0xec, 0x30, 0xdb,
-	0x72, 0x8c, 0x71, 0x22, 0x42, 0x79, 0xeb, 0x1c, 0xf8, 0xcd, 0x32, 0xe0, 0x5c, 0xa8, 0x35, 0x09,
-	0x6f, 0xb3, 0xb9, 0x9c, 0xdf, 0xc2, 0x1e, 0xe3, 0xdf, 0x6b, 0x02, 0x15, 0x32, 0x63, 0x9c, 0xfa,
-	0xdd, 0x2a, 0xea, 0x6c, 0x9e, 0x35, 0x09, 0x23, 0xb6, 0x98, 0xf2, 0x3f, 0xe1, 0x0b, 0x2e, 0x77,
-	0xf2, 0x11, 0x43, 0xc9, 0x71, 0x0e, 0xff, 0x7e, 0x15, 0x7c, 0x2e, 0xa7, 0x9a, 0x84, 0x77, 0xd9,
-	0x92, 0xf8, 0xfe, 0x05, 0x39, 0x21, 0x3d, 0xb2, 0x40, 0xc8, 0x4f, 0xf0, 0x0d, 0xa5, 0xd5, 0xf2,
-	0xe7, 0xe3, 0xa9, 0x49, 0x78, 0x9f, 0x2d, 0x0f, 0xee, 0x05, 0x6c, 0xb9, 0xb6, 0x63, 0x85, 0xea,
-	0x93, 0x9c, 0x7d, 0xb4, 0xf4, 0x0b, 0x4e, 0x53, 0xa6, 0x49, 0x38, 0xe5, 0x4e, 0x8f, 0xe8, 0x37,
-	0x48, 0x0b, 0x8a, 0x90, 0xb8, 0xc1, 0x31, 0x85, 0xd5, 0x98, 0x50, 0xd8, 0x96, 0x1b, 0x39, 0x9f,
-	0xc5, 0x61, 0x9d, 0x0d, 0x07, 0xa5, 0x37, 0x32, 0x24, 0x78, 0xc8, 0x19, 0x42, 0x90, 0x51, 0x31,
-	0xae, 0xe3, 0xa6, 0x71, 0xa5, 0xd7, 0xf4, 0xfa, 0xb5, 0x9e, 0x95, 0x90, 0x02, 0xf9, 0xb0, 0xa6,
-	0xde, 0x34, 0xd4, 0xf3, 0x96, 0x7a, 0x61, 0x60, 0xb5, 0xd9, 0xa8, 0xeb, 0x4d, 0x35, 0x2b, 0xa3,
-	0x1c, 0xec, 0x89, 0xbe, 0x5e, 0x37, 0xce, 0xeb, 0xba, 0xae, 0x9e, 0xb7, 0xaa, 0x75, 0x3d, 0xbb,
-	0x86, 0xbe, 0x82, 0x03, 0xd1, 0x99, 0x96, 0x8d, 0x56, 0xf5, 0x52, 0xad, 0x5f, 0xb5, 0xb2, 0xeb,
-	0xe8, 0x4b, 0xd8, 0x15, 0x6d, 0xac, 0xfe, 0x7a, 0x11, 0x36, 0x62, 0x11, 0xe2, 0x35, 0xae, 0xb6,
-	0xd4, 0xb0, 0x13, 0x3f, 0x33, 0x1e, 0x9e, 0x14, 0xf9, 0xf1, 0x49, 0x91, 0x3f, 0x3c, 0x29, 0xf2,
-	0x7f, 0xcf, 0x8a, 0xf4, 0xf8, 0xac, 0x48, 0xef, 0x9e, 0x15, 0xe9, 0x56, 0xb5, 0x6c, 0xbf, 0x37,
-	0xec, 0x94, 0x4d, 0x3a, 0xa8, 0x68, 0x8d, 0x6a, 0xb3, 0x45, 0xcc, 0x9e, 0x43, 0xfb, 0xd4, 0xb2,
-	0x09, 0xab, 0x98, 0x63, 0xbb, 0x0e, 0x1b, 0xb2, 0x1f, 0x88, 0x63, 0xd9, 0x0e, 0xa9, 0x04, 0xff,
-	0x5d, 0x8b, 0xff, 0x9a, 0x9d, 0x04, 0xef, 0xfc, 0xf4, 0x31, 0x00, 0x00, 0xff, 0xff, 0xf3, 0x06,
-	0x11, 0xb1, 0x52, 0x07, 0x00, 0x00,
+	// 995 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x5d, 0x4f, 0xe3, 0x46,
+	0x14, 0xb5, 0xc9, 0x07, 0xe4, 0x26, 0x40, 0x18, 0x28, 0x0d, 0x11, 0x64, 0xd3, 0xf4, 0x0b, 0x21,
+	0x91, 0x54, 0x5b, 0xa9, 0x52, 0xb5, 0x7d, 0x29, 0x60, 0x35, 0x11, 0xda, 0x24, 0x9d, 0x64, 0xcb,
+	0x76, 0xa5, 0xca, 0xca, 0xc7, 0xd4, 0xb1, 0x08, 0x1e, 0xef, 0xcc, 0x84, 0x2a, 0xcf, 0x7d, 0x6b,
+	0x5f, 0x2a, 0xf5, 0x4f, 0xf4, 0xa7, 0xf0, 0xb8, 0x8f, 0x7d, 0xaa, 0x2a, 0xf8, 0x23, 0x95, 0xc7,
+	0x13, 0xdb, 0x21, 0x31, 0xa2, 0xcb, 0xdb, 0xcc, 0xb9, 0x73, 0xcf, 0x3d, 0xe7, 0xc6, 0xf7, 0x2a,
+	0x50, 0x12, 0xc4, 0x19, 0x12, 0x76, 0x65, 0x3b, 0xa2, 0xe6, 0x32, 0xfb, 0xfa, 0xba, 0x37, 0xae,
+	0x89, 0xa9, 0x4b, 0x78, 0xd5, 0x65, 0x54, 0x50, 0x84, 0xc2, 0x78, 0x55, 0xc5, 0x8b, 0xfb, 0x91,
+	0x9c, 0x01, 0x9b, 0xba, 0x82, 0xd6, 0x2e, 0xc9, 0x54, 0x65, 0xcc, 0x45, 0x25, 0x53, 0x94, 0xaf,
+	0xb8, 0x63, 0x51, 0x8b, 0xca, 0x63, 0xcd, 0x3b, 0xf9, 0x68, 0xa5, 0x01, 0x5b, 0x98, 0x5c, 0x51,
+	0x41, 0x3a, 0xb6, 0xe5, 0x10, 0x66, 0x30, 0x46, 0x19, 0x42, 0x90, 0x1c, 0xd0, 0x21, 0x29, 0xe8,
+	0x65, 0xfd, 0x30, 0x85, 0xe5, 0x19, 0x95, 0x21, 0x3b, 0x24, 0x7c, 0xc0, 0x6c, 0x57, 0xd8, 0xd4,
+	0x29, 0xac, 0x94, 0xf5, 0xc3, 0x0c, 0x8e, 0x42, 0x95, 0x23, 0x58, 0x6f, 0x4f, 0xfa, 0xe7, 0x64,
+	0x8a, 0xc9, 0xdb, 0x09, 0xe1, 0x02, 0xed, 0xc1, 0xda, 0x60, 0xd4, 0xb3, 0x1d, 0xd3, 0x1e, 0x4a,
+	0xaa, 0x0c, 0x5e, 0x95, 0xf7, 0xc6, 0xb0, 0xf2, 0x9b, 0x0e, 0x1b, 0xb3, 0xc7, 0xdc, 0xa5, 0x0e,
+	0x27, 0xe8, 0x05, 0xac, 0xba, 0x93, 0xbe, 0x79, 0x49, 0xa6, 0xf2, 0x71, 0xf6, 0xf9, 0x7e, 0x35,
+	0xd2, 0x01, 0xdf, 0x6d, 0xb5, 0x3d, 0xe9, 0x8f, 0xed, 0xc1, 0x39, 0x99, 0x9e, 0x24, 0x6f, 0xfe,
+	0x79, 0xa6, 0xe1, 0xb4, 0x2b, 0x49, 0xd0, 0x0b, 0x48, 0x11, 0x4f, 0xba, 0xd4, 0x95, 0x7d, 0xfe,
+	0x69, 0x75, 0xb1, 0x79, 0xd5, 0x05, 0x9f, 0xd8, 0xcf, 0xa9, 0xbc, 0x86, 0x4d, 0x0f, 0xfd, 0x81,
+	0x0a, 0x32, 0x93, 0x7e, 0x04, 0xc9, 0x6b, 0x2a, 0x88, 0x52, 0xb2, 0x1b, 0xa5, 0xf3, 0x7b, 0x2a,
+	0x1f, 0xcb, 0x37, 0x73, 0x36, 0x57, 0xe6, 0x6d, 0xfe, 0xaa, 0x03, 0x92, 0x05, 0x87, 0x3e, 0xb9,
+	0xb2, 0xfa, 0xc5, 0x63, 0xd8, 0x95, 0x43, 0xbf, 0xc6, 0x93, 0xfc, 0x8d, 0x60, 0xdb, 0x43, 0xdb,
+	0x8c, 0xba, 0x94, 0xf7, 0xc6, 0x33, 0x8f, 0x5f, 0xc1, 0x9a, 0xab, 0x20, 0xa5, 0xa4, 0xb8, 0xa8,
+	0x24, 0x48, 0x0a, 0xde, 0x3e, 0xe4, 0xf7, 0x4f, 0x1d, 0x76, 0x7d, 0xbf, 0x61, 0x31, 0xe5, 0xf9,
+	0x9b, 0xff, 0x53, 0x4d, 0x79, 0x0f, 0x6b, 0x3e, 0xc9, 0xff, 0x3a, 0x64, 0xdb, 0xb6, 0x63, 0x29,
+	0xdf, 0x95, 0x0d, 0xc8, 0xf9, 0x57, 0x5f, 0x99, 0xf7, 0x2d, 0x66, 0xbc, 0xac, 0x8e, 0xe8, 0x09,
+	0x82, 0x76, 0x21, 0x3d, 0x22, 0xb6, 0x35, 0x12, 0x52, 0x65, 0x02, 0xab, 0x1b, 0xda, 0x81, 0x14,
+	0xa3, 0x13, 0xc7, 0xb7, 0x9c, 0xc2, 0xfe, 0xc5, 0x9b, 0x14, 0x2e, 0x88, 0x5b, 0x48, 0xf8, 0x93,
+	0xe2, 0x9d, 0xd1, 0x3e, 0x64, 0xb8, 0x6d, 0x39, 0x3d, 0x31, 0x61, 0xa4, 0x90, 0x2c, 0xeb, 0x87,
+	0x39, 0x1c, 0x02, 0xe8, 0x00, 0xc0, 0xbb, 0x98, 0xfd, 0xa9, 0x20, 0xbc, 0x90, 0x0a, 0xc3, 0x27,
+	0x1e, 0x50, 0x39, 0x86, 0x7c, 0xa0, 0xe5, 0x11, 0x73, 0xf4, 0xbb, 0x0e, 0x5b, 0x91, 0xf7, 0xaa,
+	0xd7, 0x5f, 0x43, 0x8a, 0x7b, 0x80, 0x6a, 0xf4, 0xc1, 0xb2, 0x6e, 0x05, 0x59, 0xaa, 0xd7, 0x7e,
+	0xc6, 0xd3, 0x1a, 0xfd, 0x16, 0xf6, 0x30, 0x71, 0xc7, 0xf6, 0xa0, 0x27, 0x48, 0xc0, 0x3f, 0x73,
+	0xf1, 0x04, 0x51, 0x0f, 0x7c, 0x71, 0x3f, 0x42, 0x71, 0x59, 0xc9, 0x60, 0xa7, 0x28, 0x37, 0xfa,
+	0x7b, 0xb8, 0xb9, 0x59, 0x83, 0xd5, 0x97, 0x84, 0xf3, 0x9e, 0x45, 0xd0, 0x39, 0x6c, 0xaa, 0xe5,
+	0x64, 0x32, 0xdf, 0x8f, 0xa2, 0xfc, 0x68, 0x19, 0xe5, 0xdc, 0x1a, 0xac, 0x6b, 0x78, 0xdd, 0x8d,
+	0x02, 0xa8, 0x09, 0xf9, 0x90, 0xcc, 0x57, 0xaa, 0xda, 0x5d, 0x79, 0x88, 0xcd, 0x7f, 0x59, 0xd7,
+	0xf0, 0x86, 0x3b, 0x87, 0xa0, 0xef, 0x61, 0x4b, 0x7e, 0x52, 0xde, 0xa6, 0x08, 0xe4, 0x25, 0x24,
+	0xe1, 0xc7, 0x71, 0x5d, 0x8e, 0x2c, 0xbb, 0xba, 0x86, 0x37, 0xf9, 0x3c, 0x84, 0xde, 0xc0, 0x0e,
+	0x97, 0x73, 0x3c, 0x23, 0x55, 0x32, 0x93, 0x92, 0xf5, 0xb3, 0x38, 0xd6, 0xf9, 0x3d, 0x57, 0xd7,
+	0x30, 0xe2, 0x0b, 0x28, 0xfa, 0x09, 0x3e, 0x90, 0x72, 0x67, 0xc3, 0x1d, 0x48, 0x4e, 0x49, 0xf2,
+	0xcf, 0xe3, 0xc8, 0xef, 0xed, 0xaf, 0xba, 0x86, 0xb7, 0xf9, 0x22, 0x8c, 0x7e, 0x86, 0x82, 0x92,
+	0x1e, 0x29, 0xa0, 0xe4, 0xa7, 0x65, 0x85, 0xa3, 0x78, 0xf9, 0xf7, 0xd7, 0x56, 0x5d, 0xc3, 0xbb,
+	0x7c, 0x69, 0x04, 0x9d, 0x41, 0xce, 0xb5, 0x1d, 0x2b, 0x50, 0xbf, 0x2a, 0xb9, 0x9f, 0x2d, 0xfd,
+	0x05, 0xc3, 0xed, 0x53, 0xd7, 0x70, 0xd6, 0x0d, 0xaf, 0xe8, 0x3b, 0x58, 0x57, 0x2c, 0x4a, 0xe2,
+	0x9a, 0xa4, 0x29, 0xc7, 0xd3, 0x04, 0xc2, 0x72, 0x6e, 0xe4, 0x8e, 0xba, 0x20, 0x7b, 0x6d, 0xca,
+	0x89, 0x09, 0x44, 0x65, 0x24, 0xdb, 0x27, 0x0f, 0xce, 0x5a, 0xa8, 0x2c, 0xcf, 0xef, 0x0f, 0xed,
+	0x05, 0x6c, 0xcf, 0xb1, 0x2a, 0x91, 0x10, 0x3f, 0x4e, 0x0b, 0x43, 0x58, 0xd7, 0xf0, 0x16, 0x5f,
+	0x98, 0x4c, 0x17, 0xf6, 0xd9, 0x6c, 0x6e, 0xcd, 0x25, 0xc2, 0xb3, 0xb2, 0xc2, 0xf1, 0xf2, 0x81,
+	0x8d, 0x59, 0x31, 0x75, 0x0d, 0xef, 0xb1, 0xb8, 0x20, 0xe2, 0x70, 0x10, 0x53, 0x51, 0x99, 0xca,
+	0xc9, 0x92, 0xd5, 0xc7, 0x96, 0x0c, 0xdc, 0x15, 0x59, 0x6c, 0xf4, 0x24, 0x05, 0x09, 0x3e, 0xb9,
+	0x3a, 0xfa, 0x4b, 0x87, 0xb4, 0xdc, 0x2d, 0x1c, 0x21, 0xd8, 0x30, 0x30, 0x6e, 0xe1, 0x8e, 0xf9,
+	0xaa, 0x79, 0xde, 0x6c, 0x5d, 0x34, 0xf3, 0x1a, 0x2a, 0x41, 0x31, 0xc0, 0x8c, 0xd7, 0x6d, 0xe3,
+	0xb4, 0x6b, 0x9c, 0x99, 0xd8, 0xe8, 0xb4, 0x5b, 0xcd, 0x8e, 0x91, 0xd7, 0x51, 0x01, 0x76, 0x54,
+	0xbc, 0xd9, 0x32, 0x4f, 0x5b, 0xcd, 0xa6, 0x71, 0xda, 0x6d, 0xb4, 0x9a, 0xf9, 0x15, 0x74, 0x00,
+	0x7b, 0x2a, 0x12, 0xc2, 0x66, 0xb7, 0xf1, 0xd2, 0x68, 0xbd, 0xea, 0xe6, 0x13, 0xe8, 0x43, 0xd8,
+	0x56, 0x61, 0x6c, 0x7c, 0x7b, 0x16, 0x04, 0x92, 0x11, 0xc6, 0x0b, 0xdc, 0xe8, 0x1a, 0x41, 0x24,
+	0x75, 0x62, 0xde, 0xdc, 0x96, 0xf4, 0x77, 0xb7, 0x25, 0xfd, 0xdf, 0xdb, 0x92, 0xfe, 0xc7, 0x5d,
+	0x49, 0x7b, 0x77, 0x57, 0xd2, 0xfe, 0xbe, 0x2b, 0x69, 0x6f, 0x0c, 0xcb, 0x16, 0xa3, 0x49, 0xbf,
+	0x3a, 0xa0, 0x57, 0xb5, 0x1e, 0x1b, 0xd0, 0x31, 0xb5, 0xa6, 0xc7, 0x0e, 0x11, 0xbf, 0x50, 0x76,
+	0x59, 0x1b, 0x78, 0x76, 0x1d, 0x3e, 0xe1, 0xc7, 0xc4, 0xb1, 0x6c, 0x87, 0xd4, 0xfc, 0x7f, 0x9a,
+	0x8b, 0xff, 0x71, 0xfb, 0x69, 0x19, 0xf9, 0xf2, 0xbf, 0x01, 0x00, 0xc7, 0x68, 0x26, 0x71, 0x00,
+	0x0b, 0x00, 0x00,
 }
 
 func (m *RemoteSignerError) Marshal() (dAtA []byte, err error) {
@@ -1060,7 +1406,7 @@ func (m *PingResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *Message) Marshal() (dAtA []byte, err error) {
+func (m *SignState) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1070,59 +1416,101 @@ func (m *Message) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Message) MarshalTo(dAtA []byte) (int, error) {
+func (m *SignState) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SignState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Sum != nil {
-		{
-			size := m.Sum.Size()
-			i -= size
-			if _, err := m.Sum.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-		}
+	if len(m.SignBytes) > 0 {
+		i -= len(m.SignBytes)
+		copy(dAtA[i:], m.SignBytes)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.SignBytes)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Step != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Step))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Message_PubKeyRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *SignStateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignStateRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Message_PubKeyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SignStateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	if m.PubKeyRequest != nil {
-		{
-			size, err := m.PubKeyRequest.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintTypes(dAtA, i, uint64(size))
-		}
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ChainId)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
-func (m *Message_PubKeyResponse) MarshalTo(dAtA []byte) (int, error) {
+
+func (m *SignStateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignStateResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Message_PubKeyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SignStateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	if m.PubKeyResponse != nil {
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
 		{
-			size, err := m.PubKeyResponse.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Error.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -1132,9 +1520,169 @@ func (m *Message_PubKeyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 		i--
 		dAtA[i] = 0x12
 	}
-	return len(dAtA) - i, nil
-}
-func (m *Message_SignVoteRequest) MarshalTo(dAtA []byte) (int, error) {
+	{
+		size, err := m.State.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *ReplicateSignStateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReplicateSignStateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReplicateSignStateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ChainId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.State.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *ReplicateSignStateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReplicateSignStateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReplicateSignStateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
+		{
+			size, err := m.Error.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Message) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Message) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Sum != nil {
+		{
+			size := m.Sum.Size()
+			i -= size
+			if _, err := m.Sum.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Message_PubKeyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_PubKeyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PubKeyRequest != nil {
+		{
+			size, err := m.PubKeyRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_PubKeyResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_PubKeyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PubKeyResponse != nil {
+		{
+			size, err := m.PubKeyResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_SignVoteRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
@@ -1260,6 +1808,90 @@ func (m *Message_PingResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Message_SignStateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_SignStateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SignStateRequest != nil {
+		{
+			size, err := m.SignStateRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_SignStateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_SignStateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SignStateResponse != nil {
+		{
+			size, err := m.SignStateResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_ReplicateSignStateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_ReplicateSignStateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ReplicateSignStateRequest != nil {
+		{
+			size, err := m.ReplicateSignStateRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_ReplicateSignStateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_ReplicateSignStateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ReplicateSignStateResponse != nil {
+		{
+			size, err := m.ReplicateSignStateResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	return len(dAtA) - i, nil
+}
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -1397,67 +2029,149 @@ func (m *PingResponse) Size() (n int) {
 	return n
 }
 
-func (m *Message) Size() (n int) {
+func (m *SignState) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Sum != nil {
-		n += m.Sum.Size()
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	if m.Step != 0 {
+		n += 1 + sovTypes(uint64(m.Step))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.SignBytes)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
 	}
 	return n
 }
 
-func (m *Message_PubKeyRequest) Size() (n int) {
+func (m *SignStateRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.PubKeyRequest != nil {
-		l = m.PubKeyRequest.Size()
+	l = len(m.ChainId)
+	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
 	return n
 }
-func (m *Message_PubKeyResponse) Size() (n int) {
+
+func (m *SignStateResponse) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.PubKeyResponse != nil {
-		l = m.PubKeyResponse.Size()
+	l = m.State.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.Error != nil {
+		l = m.Error.Size()
 		n += 1 + l + sovTypes(uint64(l))
 	}
 	return n
 }
-func (m *Message_SignVoteRequest) Size() (n int) {
+
+func (m *ReplicateSignStateRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.SignVoteRequest != nil {
-		l = m.SignVoteRequest.Size()
+	l = m.State.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = len(m.ChainId)
+	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
 	return n
 }
-func (m *Message_SignedVoteResponse) Size() (n int) {
+
+func (m *ReplicateSignStateResponse) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.SignedVoteResponse != nil {
-		l = m.SignedVoteResponse.Size()
+	if m.Error != nil {
+		l = m.Error.Size()
 		n += 1 + l + sovTypes(uint64(l))
 	}
 	return n
 }
-func (m *Message_SignProposalRequest) Size() (n int) {
+
+func (m *Message) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Sum != nil {
+		n += m.Sum.Size()
+	}
+	return n
+}
+
+func (m *Message_PubKeyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PubKeyRequest != nil {
+		l = m.PubKeyRequest.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_PubKeyResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PubKeyResponse != nil {
+		l = m.PubKeyResponse.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_SignVoteRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SignVoteRequest != nil {
+		l = m.SignVoteRequest.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_SignedVoteResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SignedVoteResponse != nil {
+		l = m.SignedVoteResponse.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_SignProposalRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -1505,6 +2219,54 @@ func (m *Message_PingResponse) Size() (n int) {
 	}
 	return n
 }
+func (m *Message_SignStateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SignStateRequest != nil {
+		l = m.SignStateRequest.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_SignStateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SignStateResponse != nil {
+		l = m.SignStateResponse.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_ReplicateSignStateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ReplicateSignStateRequest != nil {
+		l = m.ReplicateSignStateRequest.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_ReplicateSignStateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ReplicateSignStateResponse != nil {
+		l = m.ReplicateSignStateResponse.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
@@ -1623,26 +2385,500 @@ func (m *PubKeyRequest) Unmarshal(dAtA []byte) error {
 			if shift >= 64 {
 				return ErrIntOverflowTypes
 			}
-			if iNdEx >= l {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PubKeyRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PubKeyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PubKeyResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PubKeyResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PubKeyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &RemoteSignerError{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignVoteRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignVoteRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignVoteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Vote == nil {
+				m.Vote = &types.Vote{}
+			}
+			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignedVoteResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignedVoteResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignedVoteResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &RemoteSignerError{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignProposalRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignProposalRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignProposalRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if m.Proposal == nil {
+				m.Proposal = &types.Proposal{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: PubKeyRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PubKeyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			if err := m.Proposal.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
 			}
@@ -1695,7 +2931,7 @@ func (m *PubKeyRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PubKeyResponse) Unmarshal(dAtA []byte) error {
+func (m *SignedProposalResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1718,15 +2954,15 @@ func (m *PubKeyResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PubKeyResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SignedProposalResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PubKeyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SignedProposalResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Proposal", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1753,7 +2989,7 @@ func (m *PubKeyResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Proposal.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1814,7 +3050,7 @@ func (m *PubKeyResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SignVoteRequest) Unmarshal(dAtA []byte) error {
+func (m *PingRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1837,17 +3073,174 @@ func (m *SignVoteRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SignVoteRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PingRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SignVoteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PingRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PingResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PingResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PingResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignState: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
+			}
+			m.Step = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Step |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1857,33 +3250,31 @@ func (m *SignVoteRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Vote == nil {
-				m.Vote = &types.Vote{}
-			}
-			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
 			}
 			iNdEx = postIndex
-		case 2:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SignBytes", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1893,23 +3284,25 @@ func (m *SignVoteRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ChainId = string(dAtA[iNdEx:postIndex])
+			m.SignBytes = append(m.SignBytes[:0], dAtA[iNdEx:postIndex]...)
+			if m.SignBytes == nil {
+				m.SignBytes = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1932,7 +3325,7 @@ func (m *SignVoteRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SignedVoteResponse) Unmarshal(dAtA []byte) error {
+func (m *SignStateRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1955,50 +3348,17 @@ func (m *SignedVoteResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SignedVoteResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SignStateRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SignedVoteResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SignStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2008,27 +3368,23 @@ func (m *SignedVoteResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Error == nil {
-				m.Error = &RemoteSignerError{}
-			}
-			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2051,7 +3407,7 @@ func (m *SignedVoteResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SignProposalRequest) Unmarshal(dAtA []byte) error {
+func (m *SignStateResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2074,15 +3430,15 @@ func (m *SignProposalRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SignProposalRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SignStateResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SignProposalRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SignStateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Proposal", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2109,18 +3465,15 @@ func (m *SignProposalRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Proposal == nil {
-				m.Proposal = &types.Proposal{}
-			}
-			if err := m.Proposal.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.State.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2130,23 +3483,27 @@ func (m *SignProposalRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ChainId = string(dAtA[iNdEx:postIndex])
+			if m.Error == nil {
+				m.Error = &RemoteSignerError{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2169,7 +3526,7 @@ func (m *SignProposalRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SignedProposalResponse) Unmarshal(dAtA []byte) error {
+func (m *ReplicateSignStateRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2192,15 +3549,15 @@ func (m *SignedProposalResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SignedProposalResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReplicateSignStateRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SignedProposalResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReplicateSignStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Proposal", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2227,15 +3584,15 @@ func (m *SignedProposalResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Proposal.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.State.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2245,27 +3602,23 @@ func (m *SignedProposalResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Error == nil {
-				m.Error = &RemoteSignerError{}
-			}
-			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2288,7 +3641,7 @@ func (m *SignedProposalResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PingRequest) Unmarshal(dAtA []byte) error {
+func (m *ReplicateSignStateResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2311,62 +3664,48 @@ func (m *PingRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PingRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReplicateSignStateResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PingRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReplicateSignStateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *PingResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if m.Error == nil {
+				m.Error = &RemoteSignerError{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: PingResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PingResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2697,6 +4036,146 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Message_PingResponse{v}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignStateRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &SignStateRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_SignStateRequest{v}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignStateResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &SignStateResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_SignStateResponse{v}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicateSignStateRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ReplicateSignStateRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_ReplicateSignStateRequest{v}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicateSignStateResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ReplicateSignStateResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_ReplicateSignStateResponse{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
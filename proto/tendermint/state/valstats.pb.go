@@ -0,0 +1,600 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: tendermint/state/valstats.proto
+
+package state
+
+import (
+	fmt "fmt"
+	types "github.com/arcology-network/consensus-engine/proto/tendermint/types"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// ValidatorStatsRecord holds the votes of the last commit included in a block
+// and the block's proposer. It is persisted for each recent height by the
+// validator stats tracker.
+type ValidatorStatsRecord struct {
+	ProposerAddress []byte          `protobuf:"bytes,1,opt,name=proposer_address,json=proposerAddress,proto3" json:"proposer_address,omitempty"`
+	Votes           []ValidatorVote `protobuf:"bytes,2,rep,name=votes,proto3" json:"votes"`
+}
+
+func (m *ValidatorStatsRecord) Reset()         { *m = ValidatorStatsRecord{} }
+func (m *ValidatorStatsRecord) String() string { return proto.CompactTextString(m) }
+func (*ValidatorStatsRecord) ProtoMessage()    {}
+func (*ValidatorStatsRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_992a9bbca1df98b3, []int{0}
+}
+func (m *ValidatorStatsRecord) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidatorStatsRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidatorStatsRecord.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ValidatorStatsRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorStatsRecord.Merge(m, src)
+}
+func (m *ValidatorStatsRecord) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidatorStatsRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorStatsRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorStatsRecord proto.InternalMessageInfo
+
+func (m *ValidatorStatsRecord) GetProposerAddress() []byte {
+	if m != nil {
+		return m.ProposerAddress
+	}
+	return nil
+}
+
+func (m *ValidatorStatsRecord) GetVotes() []ValidatorVote {
+	if m != nil {
+		return m.Votes
+	}
+	return nil
+}
+
+// ValidatorVote is the vote of a validator in a commit.
+type ValidatorVote struct {
+	Address []byte            `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Flag    types.BlockIDFlag `protobuf:"varint,2,opt,name=flag,proto3,enum=tendermint.types.BlockIDFlag" json:"flag,omitempty"`
+}
+
+func (m *ValidatorVote) Reset()         { *m = ValidatorVote{} }
+func (m *ValidatorVote) String() string { return proto.CompactTextString(m) }
+func (*ValidatorVote) ProtoMessage()    {}
+func (*ValidatorVote) Descriptor() ([]byte, []int) {
+	return fileDescriptor_992a9bbca1df98b3, []int{1}
+}
+func (m *ValidatorVote) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidatorVote) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidatorVote.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ValidatorVote) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorVote.Merge(m, src)
+}
+func (m *ValidatorVote) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidatorVote) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorVote.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorVote proto.InternalMessageInfo
+
+func (m *ValidatorVote) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *ValidatorVote) GetFlag() types.BlockIDFlag {
+	if m != nil {
+		return m.Flag
+	}
+	return types.BlockIDFlagUnknown
+}
+
+func init() {
+	proto.RegisterType((*ValidatorStatsRecord)(nil), "tendermint.state.ValidatorStatsRecord")
+	proto.RegisterType((*ValidatorVote)(nil), "tendermint.state.ValidatorVote")
+}
+
+func init() { proto.RegisterFile("tendermint/state/valstats.proto", fileDescriptor_992a9bbca1df98b3) }
+
+var fileDescriptor_992a9bbca1df98b3 = []byte{
+	// 304 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x91, 0xcf, 0x4e, 0x02, 0x31,
+	0x10, 0xc6, 0x77, 0x11, 0x35, 0xa9, 0xff, 0xc8, 0x86, 0xc3, 0x86, 0x68, 0x21, 0x9c, 0xf0, 0xc0,
+	0x36, 0xe2, 0xd1, 0x93, 0x68, 0x4c, 0xbc, 0xae, 0x09, 0x07, 0xa3, 0x31, 0x65, 0x77, 0xac, 0x1b,
+	0x4a, 0x67, 0xd3, 0x16, 0x0c, 0x17, 0x9f, 0xc1, 0xc7, 0xe2, 0xc8, 0xd1, 0x93, 0x31, 0xf0, 0x22,
+	0x86, 0x6d, 0x50, 0xe0, 0xd2, 0x4c, 0xbf, 0xef, 0xd7, 0xf9, 0xda, 0x0e, 0xa9, 0x5b, 0x50, 0x29,
+	0xe8, 0x61, 0xa6, 0x2c, 0x33, 0x96, 0x5b, 0x60, 0x63, 0x2e, 0x97, 0x85, 0x89, 0x72, 0x8d, 0x16,
+	0x83, 0xca, 0x3f, 0x10, 0x15, 0x40, 0xad, 0x2a, 0x50, 0x60, 0x61, 0xb2, 0x65, 0xe5, 0xb8, 0xda,
+	0xe9, 0x5a, 0x23, 0x3b, 0xc9, 0xc1, 0xb8, 0xd5, 0xb9, 0xcd, 0x0f, 0x52, 0xed, 0x71, 0x99, 0xa5,
+	0xdc, 0xa2, 0x7e, 0x58, 0x76, 0x8f, 0x21, 0x41, 0x9d, 0x06, 0xe7, 0xa4, 0x92, 0x6b, 0xcc, 0xd1,
+	0x80, 0x7e, 0xe1, 0x69, 0xaa, 0xc1, 0x98, 0xd0, 0x6f, 0xf8, 0xad, 0xc3, 0xf8, 0x64, 0xa5, 0x5f,
+	0x3b, 0x39, 0xb8, 0x22, 0xbb, 0x63, 0xb4, 0x60, 0xc2, 0x52, 0x63, 0xa7, 0x75, 0xd0, 0xa9, 0x47,
+	0xdb, 0x17, 0x8b, 0xfe, 0x12, 0x7a, 0x68, 0xa1, 0x5b, 0x9e, 0x7e, 0xd7, 0xbd, 0xd8, 0x9d, 0x69,
+	0x3e, 0x91, 0xa3, 0x0d, 0x37, 0x08, 0xc9, 0xfe, 0x66, 0xde, 0x6a, 0x1b, 0x5c, 0x90, 0xf2, 0xab,
+	0xe4, 0x22, 0x2c, 0x35, 0xfc, 0xd6, 0x71, 0xe7, 0x6c, 0x3d, 0xc6, 0xbd, 0xa8, 0x2b, 0x31, 0x19,
+	0xdc, 0xdf, 0xde, 0x49, 0x2e, 0xe2, 0x02, 0xed, 0x3e, 0x4f, 0xe7, 0xd4, 0x9f, 0xcd, 0xa9, 0xff,
+	0x33, 0xa7, 0xfe, 0xe7, 0x82, 0x7a, 0xb3, 0x05, 0xf5, 0xbe, 0x16, 0xd4, 0x7b, 0xbc, 0x11, 0x99,
+	0x7d, 0x1b, 0xf5, 0xa3, 0x04, 0x87, 0x8c, 0xeb, 0x04, 0x25, 0x8a, 0x49, 0x5b, 0x81, 0x7d, 0x47,
+	0x3d, 0x60, 0x09, 0x2a, 0x03, 0xca, 0x8c, 0x4c, 0x1b, 0x94, 0xc8, 0x14, 0x30, 0xf7, 0xa9, 0xdb,
+	0x13, 0xe9, 0xef, 0x15, 0xfa, 0xe5, 0xef, 0x00, 0xcd, 0x4a, 0x23, 0x16, 0xac, 0x01, 0x00, 0x00,
+}
+
+func (m *ValidatorStatsRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidatorStatsRecord) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ValidatorStatsRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Votes) > 0 {
+		for iNdEx := len(m.Votes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Votes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintValstats(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.ProposerAddress) > 0 {
+		i -= len(m.ProposerAddress)
+		copy(dAtA[i:], m.ProposerAddress)
+		i = encodeVarintValstats(dAtA, i, uint64(len(m.ProposerAddress)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ValidatorVote) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidatorVote) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ValidatorVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Flag != 0 {
+		i = encodeVarintValstats(dAtA, i, uint64(m.Flag))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintValstats(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintValstats(dAtA []byte, offset int, v uint64) int {
+	offset -= sovValstats(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ValidatorStatsRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ProposerAddress)
+	if l > 0 {
+		n += 1 + l + sovValstats(uint64(l))
+	}
+	if len(m.Votes) > 0 {
+		for _, e := range m.Votes {
+			l = e.Size()
+			n += 1 + l + sovValstats(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ValidatorVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovValstats(uint64(l))
+	}
+	if m.Flag != 0 {
+		n += 1 + sovValstats(uint64(m.Flag))
+	}
+	return n
+}
+
+func sovValstats(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozValstats(x uint64) (n int) {
+	return sovValstats(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ValidatorStatsRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowValstats
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidatorStatsRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidatorStatsRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposerAddress", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowValstats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthValstats
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthValstats
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProposerAddress = append(m.ProposerAddress[:0], dAtA[iNdEx:postIndex]...)
+			if m.ProposerAddress == nil {
+				m.ProposerAddress = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowValstats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthValstats
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthValstats
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Votes = append(m.Votes, ValidatorVote{})
+			if err := m.Votes[len(m.Votes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipValstats(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthValstats
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValidatorVote) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowValstats
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidatorVote: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidatorVote: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowValstats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthValstats
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthValstats
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = append(m.Address[:0], dAtA[iNdEx:postIndex]...)
+			if m.Address == nil {
+				m.Address = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flag", wireType)
+			}
+			m.Flag = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowValstats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Flag |= types.BlockIDFlag(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipValstats(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthValstats
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipValstats(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowValstats
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowValstats
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowValstats
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthValstats
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupValstats
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthValstats
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthValstats        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowValstats          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupValstats = fmt.Errorf("proto: unexpected end of group")
+)
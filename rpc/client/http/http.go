@@ -419,6 +419,31 @@ func (c *baseRPCClient) BlockByHash(ctx context.Context, hash []byte) (*ctypes.R
 	return result, nil
 }
 
+func (c *baseRPCClient) Header(ctx context.Context, height *int64) (*ctypes.ResultHeader, error) {
+	result := new(ctypes.ResultHeader)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "header", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) HeaderByHash(ctx context.Context, hash []byte) (*ctypes.ResultHeader, error) {
+	result := new(ctypes.ResultHeader)
+	params := map[string]interface{}{
+		"hash": hash,
+	}
+	_, err := c.caller.Call(ctx, "header_by_hash", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BlockResults(
 	ctx context.Context,
 	height *int64,
@@ -489,6 +514,44 @@ func (c *baseRPCClient) TxSearch(
 	return result, nil
 }
 
+func (c *baseRPCClient) BlockSearch(
+	ctx context.Context,
+	query string,
+	page,
+	perPage *int,
+	orderBy string,
+) (*ctypes.ResultBlockSearch, error) {
+	result := new(ctypes.ResultBlockSearch)
+	params := map[string]interface{}{
+		"query":    query,
+		"order_by": orderBy,
+	}
+	if page != nil {
+		params["page"] = page
+	}
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+	_, err := c.caller.Call(ctx, "block_search", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) ValidatorStats(ctx context.Context, window *int64) (*ctypes.ResultValidatorStats, error) {
+	result := new(ctypes.ResultValidatorStats)
+	params := make(map[string]interface{})
+	if window != nil {
+		params["window"] = window
+	}
+	_, err := c.caller.Call(ctx, "validator_stats", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Validators(
 	ctx context.Context,
 	height *int64,
@@ -539,6 +602,7 @@ type WSEvents struct {
 
 	mtx           tmsync.RWMutex
 	subscriptions map[string]chan ctypes.ResultEvent // query -> chan
+	cursors       map[string]string                  // query -> cursor of the last event
 }
 
 func newWSEvents(remote, endpoint string) (*WSEvents, error) {
@@ -546,6 +610,7 @@ func newWSEvents(remote, endpoint string) (*WSEvents, error) {
 		endpoint:      endpoint,
 		remote:        remote,
 		subscriptions: make(map[string]chan ctypes.ResultEvent),
+		cursors:       make(map[string]string),
 	}
 	w.BaseService = *service.NewBaseService(nil, "WSEvents", w)
 
@@ -589,12 +654,23 @@ func (w *WSEvents) OnStop() {
 // It returns an error if WSEvents is not running.
 func (w *WSEvents) Subscribe(ctx context.Context, subscriber, query string,
 	outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
+	return w.SubscribeFrom(ctx, subscriber, query, 0, outCapacity...)
+}
+
+// SubscribeFrom is like Subscribe, but the events of the committed blocks from
+// fromHeight are replayed first. If fromHeight is zero, only the live events
+// are received.
+//
+// After a reconnection, the subscription resumes after the last event
+// received, so the events of the blocks committed in between are not missed.
+func (w *WSEvents) SubscribeFrom(ctx context.Context, subscriber, query string, fromHeight int64,
+	outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
 
 	if !w.IsRunning() {
 		return nil, errNotRunning
 	}
 
-	if err := w.ws.Subscribe(ctx, query); err != nil {
+	if err := w.ws.SubscribeFrom(ctx, query, fromHeight, ""); err != nil {
 		return nil, err
 	}
 
@@ -608,6 +684,7 @@ func (w *WSEvents) Subscribe(ctx context.Context, subscriber, query string,
 	// subscriber param is ignored because Tendermint will override it with
 	// remote IP anyway.
 	w.subscriptions[query] = outc
+	delete(w.cursors, query)
 	w.mtx.Unlock()
 
 	return outc, nil
@@ -630,6 +707,7 @@ func (w *WSEvents) Unsubscribe(ctx context.Context, subscriber, query string) er
 	_, ok := w.subscriptions[query]
 	if ok {
 		delete(w.subscriptions, query)
+		delete(w.cursors, query)
 	}
 	w.mtx.Unlock()
 
@@ -651,20 +729,22 @@ func (w *WSEvents) UnsubscribeAll(ctx context.Context, subscriber string) error
 
 	w.mtx.Lock()
 	w.subscriptions = make(map[string]chan ctypes.ResultEvent)
+	w.cursors = make(map[string]string)
 	w.mtx.Unlock()
 
 	return nil
 }
 
 // After being reconnected, it is necessary to redo subscription to server
-// otherwise no data will be automatically received.
+// otherwise no data will be automatically received. The subscriptions resume
+// after the last event received.
 func (w *WSEvents) redoSubscriptionsAfter(d time.Duration) {
 	time.Sleep(d)
 
 	w.mtx.RLock()
 	defer w.mtx.RUnlock()
 	for q := range w.subscriptions {
-		err := w.ws.Subscribe(context.Background(), q)
+		err := w.ws.SubscribeFrom(context.Background(), q, 0, w.cursors[q])
 		if err != nil {
 			w.Logger.Error("Failed to resubscribe", "err", err)
 		}
@@ -704,6 +784,14 @@ func (w *WSEvents) eventListener() {
 				continue
 			}
 
+			if result.Cursor != "" {
+				w.mtx.Lock()
+				if _, ok := w.subscriptions[result.Query]; ok {
+					w.cursors[result.Query] = result.Cursor
+				}
+				w.mtx.Unlock()
+			}
+
 			w.mtx.RLock()
 			if out, ok := w.subscriptions[result.Query]; ok {
 				if cap(out) == 0 {
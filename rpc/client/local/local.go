@@ -145,6 +145,42 @@ func (c *Local) DialPeers(
 	return core.UnsafeDialPeers(c.ctx, peers, persistent, unconditional, private)
 }
 
+func (c *Local) BanPeer(ctx context.Context, peer, duration, reason string) (*ctypes.ResultBanPeer, error) {
+	return core.UnsafeBanPeer(c.ctx, peer, duration, reason)
+}
+
+func (c *Local) UnbanPeer(ctx context.Context, peer string) (*ctypes.ResultUnbanPeer, error) {
+	return core.UnsafeUnbanPeer(c.ctx, peer)
+}
+
+func (c *Local) BannedPeers(ctx context.Context) (*ctypes.ResultBannedPeers, error) {
+	return core.BannedPeers(c.ctx)
+}
+
+func (c *Local) ValidatorStats(ctx context.Context, window *int64) (*ctypes.ResultValidatorStats, error) {
+	return core.ValidatorStats(c.ctx, window)
+}
+
+func (c *Local) AddressBook(
+	ctx context.Context,
+	id, bucket, chainID string,
+	page, perPage *int,
+) (*ctypes.ResultAddressBook, error) {
+	return core.AddressBook(c.ctx, id, bucket, chainID, page, perPage)
+}
+
+func (c *Local) BandwidthLimits(ctx context.Context) (*ctypes.ResultBandwidthLimits, error) {
+	return core.BandwidthLimits(c.ctx)
+}
+
+func (c *Local) SetBandwidthLimit(
+	ctx context.Context,
+	class, channel string,
+	sendRate, recvRate int64,
+) (*ctypes.ResultBandwidthLimits, error) {
+	return core.UnsafeSetBandwidthLimit(c.ctx, class, channel, sendRate, recvRate)
+}
+
 func (c *Local) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
 	return core.BlockchainInfo(c.ctx, minHeight, maxHeight)
 }
@@ -165,6 +201,14 @@ func (c *Local) BlockResults(ctx context.Context, height *int64) (*ctypes.Result
 	return core.BlockResults(c.ctx, height)
 }
 
+func (c *Local) Header(ctx context.Context, height *int64) (*ctypes.ResultHeader, error) {
+	return core.Header(c.ctx, height)
+}
+
+func (c *Local) HeaderByHash(ctx context.Context, hash []byte) (*ctypes.ResultHeader, error) {
+	return core.HeaderByHash(c.ctx, hash)
+}
+
 func (c *Local) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
 	return core.Commit(c.ctx, height)
 }
@@ -188,6 +232,16 @@ func (c *Local) TxSearch(
 	return core.TxSearch(c.ctx, query, prove, page, perPage, orderBy)
 }
 
+func (c *Local) BlockSearch(
+	ctx context.Context,
+	query string,
+	page,
+	perPage *int,
+	orderBy string,
+) (*ctypes.ResultBlockSearch, error) {
+	return core.BlockSearch(c.ctx, query, page, perPage, orderBy)
+}
+
 func (c *Local) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return core.BroadcastEvidence(c.ctx, ev)
 }
@@ -149,6 +149,42 @@ func (c Client) DialPeers(
 	return core.UnsafeDialPeers(&rpctypes.Context{}, peers, persistent, unconditional, private)
 }
 
+func (c Client) BanPeer(ctx context.Context, peer, duration, reason string) (*ctypes.ResultBanPeer, error) {
+	return core.UnsafeBanPeer(&rpctypes.Context{}, peer, duration, reason)
+}
+
+func (c Client) UnbanPeer(ctx context.Context, peer string) (*ctypes.ResultUnbanPeer, error) {
+	return core.UnsafeUnbanPeer(&rpctypes.Context{}, peer)
+}
+
+func (c Client) BannedPeers(ctx context.Context) (*ctypes.ResultBannedPeers, error) {
+	return core.BannedPeers(&rpctypes.Context{})
+}
+
+func (c Client) ValidatorStats(ctx context.Context, window *int64) (*ctypes.ResultValidatorStats, error) {
+	return core.ValidatorStats(&rpctypes.Context{}, window)
+}
+
+func (c Client) AddressBook(
+	ctx context.Context,
+	id, bucket, chainID string,
+	page, perPage *int,
+) (*ctypes.ResultAddressBook, error) {
+	return core.AddressBook(&rpctypes.Context{}, id, bucket, chainID, page, perPage)
+}
+
+func (c Client) BandwidthLimits(ctx context.Context) (*ctypes.ResultBandwidthLimits, error) {
+	return core.BandwidthLimits(&rpctypes.Context{})
+}
+
+func (c Client) SetBandwidthLimit(
+	ctx context.Context,
+	class, channel string,
+	sendRate, recvRate int64,
+) (*ctypes.ResultBandwidthLimits, error) {
+	return core.UnsafeSetBandwidthLimit(&rpctypes.Context{}, class, channel, sendRate, recvRate)
+}
+
 func (c Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
 	return core.BlockchainInfo(&rpctypes.Context{}, minHeight, maxHeight)
 }
@@ -165,6 +201,14 @@ func (c Client) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlo
 	return core.BlockByHash(&rpctypes.Context{}, hash)
 }
 
+func (c Client) Header(ctx context.Context, height *int64) (*ctypes.ResultHeader, error) {
+	return core.Header(&rpctypes.Context{}, height)
+}
+
+func (c Client) HeaderByHash(ctx context.Context, hash []byte) (*ctypes.ResultHeader, error) {
+	return core.HeaderByHash(&rpctypes.Context{}, hash)
+}
+
 func (c Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
 	return core.Commit(&rpctypes.Context{}, height)
 }
@@ -1,11 +1,15 @@
 package core
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 
 	tmmath "github.com/arcology-network/consensus-engine/libs/math"
+	tmquery "github.com/arcology-network/consensus-engine/libs/pubsub/query"
 	ctypes "github.com/arcology-network/consensus-engine/rpc/core/types"
 	rpctypes "github.com/arcology-network/consensus-engine/rpc/jsonrpc/types"
+	blockidxnull "github.com/arcology-network/consensus-engine/state/indexer/block/null"
 	"github.com/arcology-network/consensus-engine/types"
 )
 
@@ -80,12 +84,24 @@ func Block(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlock, error)
 		return nil, err
 	}
 
+	if res, ok := cachedResult("block", height, ""); ok {
+		if heightPtr != nil {
+			ctx.SetImmutable()
+		}
+		return res.(*ctypes.ResultBlock), nil
+	}
+
 	block := env.BlockStore.LoadBlock(height)
 	blockMeta := env.BlockStore.LoadBlockMeta(height)
 	if blockMeta == nil {
 		return &ctypes.ResultBlock{BlockID: types.BlockID{}, Block: block}, nil
 	}
-	return &ctypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}, nil
+	if heightPtr != nil {
+		ctx.SetImmutable()
+	}
+	res := &ctypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}
+	cacheResult("block", height, "", res)
+	return res, nil
 }
 
 // BlockByHash gets block by hash.
@@ -100,6 +116,33 @@ func BlockByHash(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultBlock, error
 	return &ctypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}, nil
 }
 
+// Header gets block header at a given height.
+// If no height is provided, it will fetch the latest header. Unlike block, it
+// only loads the block meta, not the whole block.
+// More: https://docs.tendermint.com/master/rpc/#/Info/header
+func Header(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultHeader, error) {
+	height, err := getHeight(env.BlockStore.Height(), heightPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	blockMeta := env.BlockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return &ctypes.ResultHeader{}, nil
+	}
+	return &ctypes.ResultHeader{Header: &blockMeta.Header}, nil
+}
+
+// HeaderByHash gets header by hash.
+// More: https://docs.tendermint.com/master/rpc/#/Info/header_by_hash
+func HeaderByHash(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultHeader, error) {
+	blockMeta := env.BlockStore.LoadBlockMetaByHash(hash)
+	if blockMeta == nil {
+		return &ctypes.ResultHeader{}, nil
+	}
+	return &ctypes.ResultHeader{Header: &blockMeta.Header}, nil
+}
+
 // Commit gets block commit at a given height.
 // If no height is provided, it will fetch the commit for the latest block.
 // More: https://docs.tendermint.com/master/rpc/#/Info/commit
@@ -109,6 +152,13 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 		return nil, err
 	}
 
+	if res, ok := cachedResult("commit", height, ""); ok {
+		if heightPtr != nil {
+			ctx.SetImmutable()
+		}
+		return res.(*ctypes.ResultCommit), nil
+	}
+
 	blockMeta := env.BlockStore.LoadBlockMeta(height)
 	if blockMeta == nil {
 		return nil, nil
@@ -123,8 +173,13 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 	}
 
 	// Return the canonical commit (comes from the block at height+1)
+	if heightPtr != nil {
+		ctx.SetImmutable()
+	}
 	commit := env.BlockStore.LoadBlockCommit(height)
-	return ctypes.NewResultCommit(&header, commit, true), nil
+	res := ctypes.NewResultCommit(&header, commit, true)
+	cacheResult("commit", height, "", res)
+	return res, nil
 }
 
 // BlockResults gets ABCIResults at a given height.
@@ -140,17 +195,83 @@ func BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlockR
 		return nil, err
 	}
 
+	if heightPtr != nil {
+		ctx.SetImmutable()
+	}
+	if res, ok := cachedResult("block_results", height, ""); ok {
+		return res.(*ctypes.ResultBlockResults), nil
+	}
+
 	results, err := env.StateStore.LoadABCIResponses(height)
 	if err != nil {
 		return nil, err
 	}
 
-	return &ctypes.ResultBlockResults{
+	res := &ctypes.ResultBlockResults{
 		Height:                height,
 		TxsResults:            results.DeliverTxs,
 		BeginBlockEvents:      results.BeginBlock.Events,
 		EndBlockEvents:        results.EndBlock.Events,
 		ValidatorUpdates:      results.EndBlock.ValidatorUpdates,
 		ConsensusParamUpdates: results.EndBlock.ConsensusParamUpdates,
-	}, nil
+	}
+	cacheResult("block_results", height, "", res)
+	return res, nil
+}
+
+// BlockSearch allows you to query for blocks by their BeginBlock and EndBlock
+// events. It returns a list of blocks (maximum ?per_page entries) and the
+// total count.
+// More: https://docs.tendermint.com/master/rpc/#/Info/block_search
+func BlockSearch(ctx *rpctypes.Context, query string, pagePtr, perPagePtr *int, orderBy string) (
+	*ctypes.ResultBlockSearch, error) {
+	// if index is disabled, return error
+	if _, ok := env.BlockIndexer.(*blockidxnull.BlockIndex); ok {
+		return nil, errors.New("block indexing is disabled")
+	}
+
+	q, err := tmquery.New(query)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := env.BlockIndexer.Search(ctx.Context(), q)
+	if err != nil {
+		return nil, err
+	}
+
+	// sort results (must be done before pagination)
+	switch orderBy {
+	case "desc":
+		sort.Slice(results, func(i, j int) bool { return results[i] > results[j] })
+	case "asc", "":
+		sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+	default:
+		return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
+	}
+
+	// paginate results
+	totalCount := len(results)
+	perPage := validatePerPage(perPagePtr)
+	page, err := validatePage(pagePtr, perPage, totalCount)
+	if err != nil {
+		return nil, err
+	}
+	skipCount := validateSkipCount(page, perPage)
+	pageSize := tmmath.MinInt(perPage, totalCount-skipCount)
+
+	apiResults := make([]*ctypes.ResultBlock, 0, pageSize)
+	for i := skipCount; i < skipCount+pageSize; i++ {
+		block := env.BlockStore.LoadBlock(results[i])
+		if block == nil {
+			continue
+		}
+		blockMeta := env.BlockStore.LoadBlockMeta(block.Height)
+		if blockMeta == nil {
+			continue
+		}
+		apiResults = append(apiResults, &ctypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block})
+	}
+
+	return &ctypes.ResultBlockSearch{Blocks: apiResults, TotalCount: totalCount}, nil
 }
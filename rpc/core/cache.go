@@ -0,0 +1,140 @@
+package core
+
+import (
+	"container/list"
+
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
+)
+
+// cacheKey identifies a response by its route, its height and the other
+// parameters that change the result (e.g. the page).
+type cacheKey struct {
+	route  string
+	height int64
+	params string
+}
+
+type cacheEntry struct {
+	key   cacheKey
+	value interface{}
+}
+
+// ResponseCache is a LRU cache of the responses of the routes that return
+// the same result for a given height once the block is committed (block,
+// commit, block_results and validators). The cached results are shared by
+// all the callers and must not be modified.
+//
+// The responses of the heights below the base of the block store are
+// dropped as soon as the node prunes the blocks.
+type ResponseCache struct {
+	metrics *Metrics
+
+	mtx      tmsync.Mutex
+	size     int
+	base     int64
+	cacheMap map[cacheKey]*list.Element
+	list     *list.List
+}
+
+// NewResponseCache returns a new ResponseCache holding up to size responses.
+func NewResponseCache(size int, metrics *Metrics) *ResponseCache {
+	if metrics == nil {
+		metrics = NopMetrics()
+	}
+	return &ResponseCache{
+		metrics:  metrics,
+		size:     size,
+		cacheMap: make(map[cacheKey]*list.Element, size),
+		list:     list.New(),
+	}
+}
+
+// Get returns the cached response for the given key, if any. base is the
+// lowest height available in the block store: if it has risen since the
+// last call, the responses below it are dropped first.
+func (cache *ResponseCache) Get(route string, height int64, params string, base int64) (interface{}, bool) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	if base > cache.base {
+		cache.prune(base)
+	}
+
+	e, ok := cache.cacheMap[cacheKey{route, height, params}]
+	if !ok {
+		cache.metrics.CacheMisses.With("route", route).Add(1)
+		return nil, false
+	}
+	cache.list.MoveToBack(e)
+	cache.metrics.CacheHits.With("route", route).Add(1)
+	return e.Value.(*cacheEntry).value, true
+}
+
+// Add caches the response for the given key, evicting the least recently
+// used response if the cache is full.
+func (cache *ResponseCache) Add(route string, height int64, params string, value interface{}) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	key := cacheKey{route, height, params}
+	if height < cache.base {
+		return
+	}
+	if e, ok := cache.cacheMap[key]; ok {
+		e.Value.(*cacheEntry).value = value
+		cache.list.MoveToBack(e)
+		return
+	}
+
+	if cache.list.Len() >= cache.size {
+		popped := cache.list.Front()
+		if popped != nil {
+			delete(cache.cacheMap, popped.Value.(*cacheEntry).key)
+			cache.list.Remove(popped)
+		}
+	}
+	cache.cacheMap[key] = cache.list.PushBack(&cacheEntry{key: key, value: value})
+	cache.metrics.CacheSize.Set(float64(cache.list.Len()))
+}
+
+// Len returns the number of cached responses.
+func (cache *ResponseCache) Len() int {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	return cache.list.Len()
+}
+
+// prune drops the responses below the given height. The caller must hold
+// the lock.
+func (cache *ResponseCache) prune(base int64) {
+	cache.base = base
+	for e := cache.list.Front(); e != nil; {
+		next := e.Next()
+		if entry := e.Value.(*cacheEntry); entry.key.height < base {
+			delete(cache.cacheMap, entry.key)
+			cache.list.Remove(e)
+		}
+		e = next
+	}
+	cache.metrics.CacheSize.Set(float64(cache.list.Len()))
+}
+
+//----------------------------------------------
+
+// cachedResult returns the cached response of the route at the given
+// height, if the cache is enabled.
+func cachedResult(route string, height int64, params string) (interface{}, bool) {
+	if env.ResponseCache == nil {
+		return nil, false
+	}
+	return env.ResponseCache.Get(route, height, params, env.BlockStore.Base())
+}
+
+// cacheResult caches the response of the route at the given height, if the
+// cache is enabled.
+func cacheResult(route string, height int64, params string, value interface{}) {
+	if env.ResponseCache == nil {
+		return
+	}
+	env.ResponseCache.Add(route, height, params, value)
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseCache(t *testing.T) {
+	cache := NewResponseCache(3, nil)
+
+	_, ok := cache.Get("block", 1, "", 1)
+	assert.False(t, ok)
+
+	cache.Add("block", 1, "", 1)
+	cache.Add("block", 2, "", 2)
+	cache.Add("validators", 2, "1/30", "2a")
+	cache.Add("validators", 2, "2/30", "2b")
+	assert.Equal(t, 3, cache.Len())
+
+	// the least recently used response was evicted
+	_, ok = cache.Get("block", 1, "", 1)
+	assert.False(t, ok)
+	res, ok := cache.Get("validators", 2, "1/30", 1)
+	assert.True(t, ok)
+	assert.Equal(t, "2a", res)
+	res, ok = cache.Get("validators", 2, "2/30", 1)
+	assert.True(t, ok)
+	assert.Equal(t, "2b", res)
+
+	// block 2 is the least recently used now
+	cache.Add("commit", 3, "", 3)
+	_, ok = cache.Get("block", 2, "", 1)
+	assert.False(t, ok)
+	_, ok = cache.Get("commit", 3, "", 1)
+	assert.True(t, ok)
+}
+
+func TestResponseCachePrune(t *testing.T) {
+	cache := NewResponseCache(10, nil)
+	for h := int64(1); h <= 5; h++ {
+		cache.Add("block", h, "", h)
+	}
+
+	// the blocks below 3 were pruned
+	_, ok := cache.Get("block", 5, "", 3)
+	assert.True(t, ok)
+	assert.Equal(t, 3, cache.Len())
+
+	// responses below the base aren't cached
+	cache.Add("block", 2, "", 2)
+	assert.Equal(t, 3, cache.Len())
+	_, ok = cache.Get("block", 3, "", 3)
+	assert.True(t, ok)
+}
@@ -1,6 +1,9 @@
 package core
 
 import (
+	"errors"
+	"fmt"
+
 	cm "github.com/arcology-network/consensus-engine/consensus"
 	tmmath "github.com/arcology-network/consensus-engine/libs/math"
 	ctypes "github.com/arcology-network/consensus-engine/rpc/core/types"
@@ -22,13 +25,21 @@ func Validators(ctx *rpctypes.Context, heightPtr *int64, pagePtr, perPagePtr *in
 		return nil, err
 	}
 
+	if heightPtr != nil {
+		ctx.SetImmutable()
+	}
+	perPage := validatePerPage(perPagePtr)
+	params := fmt.Sprintf("%d/%d", pageOrDefault(pagePtr), perPage)
+	if res, ok := cachedResult("validators", height, params); ok {
+		return res.(*ctypes.ResultValidators), nil
+	}
+
 	validators, err := env.StateStore.LoadValidators(height)
 	if err != nil {
 		return nil, err
 	}
 
 	totalCount := len(validators.Validators)
-	perPage := validatePerPage(perPagePtr)
 	page, err := validatePage(pagePtr, perPage, totalCount)
 	if err != nil {
 		return nil, err
@@ -38,11 +49,45 @@ func Validators(ctx *rpctypes.Context, heightPtr *int64, pagePtr, perPagePtr *in
 
 	v := validators.Validators[skipCount : skipCount+tmmath.MinInt(perPage, totalCount-skipCount)]
 
-	return &ctypes.ResultValidators{
+	res := &ctypes.ResultValidators{
 		BlockHeight: height,
 		Validators:  v,
 		Count:       len(v),
-		Total:       totalCount}, nil
+		Total:       totalCount}
+	cacheResult("validators", height, params, res)
+	return res, nil
+}
+
+// ValidatorStats returns how often each validator signed over the last window
+// blocks (default: 100), along with the number of blocks it proposed and the
+// number of blocks it missed in a row. The votes of a block are those of the
+// last commit it includes. The window is capped by the number of blocks the
+// node retains the votes for, see [validator_stats] retain_blocks.
+//
+// More: https://docs.tendermint.com/master/rpc/#/Info/validator_stats
+func ValidatorStats(ctx *rpctypes.Context, windowPtr *int64) (*ctypes.ResultValidatorStats, error) {
+	if env.ValidatorStats == nil {
+		return nil, errors.New("validator statistics are disabled")
+	}
+
+	window := int64(defaultValidatorStatsWindow)
+	if windowPtr != nil {
+		window = *windowPtr
+	}
+	if window < 1 {
+		return nil, fmt.Errorf("window must be greater than 0, got %d", window)
+	}
+	window = tmmath.MinInt64(window, env.ValidatorStats.RetainBlocks())
+
+	stats, err := env.ValidatorStats.Stats(window)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultValidatorStats{
+		FromHeight: stats.FromHeight,
+		ToHeight:   stats.ToHeight,
+		Validators: stats.Validators,
+	}, nil
 }
 
 // DumpConsensusState dumps consensus state.
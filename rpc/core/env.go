@@ -10,9 +10,12 @@ import (
 	"github.com/arcology-network/consensus-engine/libs/log"
 	mempl "github.com/arcology-network/consensus-engine/mempool"
 	"github.com/arcology-network/consensus-engine/p2p"
+	"github.com/arcology-network/consensus-engine/p2p/pex"
 	"github.com/arcology-network/consensus-engine/proxy"
 	sm "github.com/arcology-network/consensus-engine/state"
+	"github.com/arcology-network/consensus-engine/state/indexer"
 	"github.com/arcology-network/consensus-engine/state/txindex"
+	"github.com/arcology-network/consensus-engine/state/valstats"
 	"github.com/arcology-network/consensus-engine/types"
 )
 
@@ -21,6 +24,8 @@ const (
 	defaultPerPage = 30
 	maxPerPage     = 100
 
+	defaultValidatorStatsWindow = 100
+
 	// SubscribeTimeout is the maximum time we wait to subscribe for an event.
 	// must be less than the server's write timeout (see rpcserver.DefaultConfig)
 	SubscribeTimeout = 5 * time.Second
@@ -60,6 +65,16 @@ type peers interface {
 	AddPrivatePeerIDs([]string) error
 	DialPeersAsync([]string) error
 	Peers() p2p.IPeerSet
+	PeerScore(p2p.ID) int
+	StopPeerForError(p2p.Peer, interface{})
+	BandwidthLimits() p2p.BandwidthLimits
+	SetPeerRateLimit(p2p.PeerClass, p2p.RateLimit) error
+	SetChannelRateLimit(string, p2p.RateLimit) error
+}
+
+type addrBook interface {
+	Addresses() []pex.AddrInfo
+	RemoveAddress(*p2p.NetAddress)
 }
 
 // ----------------------------------------------
@@ -77,11 +92,16 @@ type Environment struct {
 	ConsensusState Consensus
 	P2PPeers       peers
 	P2PTransport   transport
+	P2PBanStore    *p2p.BanStore
+	P2PAddrBook    addrBook
 
 	// objects
 	PubKey           crypto.PubKey
 	GenDoc           *types.GenesisDoc // cache the genesis structure
 	TxIndexer        txindex.TxIndexer
+	BlockIndexer     indexer.BlockIndexer
+	ValidatorStats   *valstats.Tracker // nil if disabled
+	ResponseCache    *ResponseCache    // nil if disabled
 	ConsensusReactor *consensus.Reactor
 	EventBus         *types.EventBus // thread safe
 	Mempool          mempl.Mempool
@@ -114,6 +134,15 @@ func validatePage(pagePtr *int, perPage, totalCount int) (int, error) {
 	return page, nil
 }
 
+// pageOrDefault returns the requested page, or the first page if none was
+// requested. Unlike validatePage, it doesn't check the page is in range.
+func pageOrDefault(pagePtr *int) int {
+	if pagePtr == nil {
+		return 1
+	}
+	return *pagePtr
+}
+
 func validatePerPage(perPagePtr *int) int {
 	if perPagePtr == nil { // no per_page parameter
 		return defaultPerPage
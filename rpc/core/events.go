@@ -2,85 +2,321 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	abci "github.com/arcology-network/consensus-engine/abci/types"
 	tmpubsub "github.com/arcology-network/consensus-engine/libs/pubsub"
 	tmquery "github.com/arcology-network/consensus-engine/libs/pubsub/query"
 	ctypes "github.com/arcology-network/consensus-engine/rpc/core/types"
 	rpctypes "github.com/arcology-network/consensus-engine/rpc/jsonrpc/types"
-)
-
-const (
-	// Buffer on the Tendermint (server) side to allow some slowness in clients.
-	subBufferSize = 100
+	sm "github.com/arcology-network/consensus-engine/state"
+	"github.com/arcology-network/consensus-engine/types"
 )
 
 // Subscribe for events via WebSocket.
+//
+// A subscription with a fromHeight or a cursor first replays the events of the
+// committed blocks, from fromHeight or after the cursor of the last event
+// received, whichever comes later, and then goes on with the live events.
+// Every event of a block carries its cursor.
+//
+// overflow tells what happens when the client doesn't pull the events fast
+// enough, see [rpc] subscription_overflow_policy (the default).
 // More: https://docs.tendermint.com/master/rpc/#/Websocket/subscribe
-func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
+func Subscribe(
+	ctx *rpctypes.Context,
+	query string,
+	fromHeight int64,
+	cursor string,
+	overflow string,
+) (*ctypes.ResultSubscribe, error) {
 	addr := ctx.RemoteAddr()
 
+	// Capture the current ID, since it can change in the future.
+	subscriptionID := ctx.JSONReq.ID
+	deliver := func(resultEvent *ctypes.ResultEvent) {
+		resp := rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
+		writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := ctx.WSConn.WriteRPCResponse(writeCtx, resp); err != nil {
+			env.Logger.Info("Can't write response (slow client)",
+				"to", addr, "subscriptionID", subscriptionID, "err", err)
+		}
+	}
+	cancelled := func(err error) {
+		if err == nil {
+			return
+		}
+		if ok := ctx.WSConn.TryWriteRPCResponse(rpctypes.RPCServerError(subscriptionID, err)); !ok {
+			env.Logger.Info("Can't write response (slow client)",
+				"to", addr, "subscriptionID", subscriptionID, "err", err)
+		}
+	}
+
+	err := SubscribeEvents(ctx.Context(), addr, query, fromHeight, cursor, overflow, deliver, cancelled)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultSubscribe{}, nil
+}
+
+// SubscribeEvents subscribes addr to the events matching query, replaying the
+// events of the committed blocks as described in Subscribe. The events are
+// passed to deliver from a separate goroutine until ctx is done or the
+// subscription is cancelled, in which case cancelled is called with the
+// reason (nil if the client unsubscribed). An empty overflow policy means the
+// default of the config.
+func SubscribeEvents(
+	ctx context.Context,
+	addr string,
+	query string,
+	fromHeight int64,
+	cursor string,
+	overflow string,
+	deliver func(*ctypes.ResultEvent),
+	cancelled func(error),
+) error {
 	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
-		return nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
+		return fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
 	} else if env.EventBus.NumClientSubscriptions(addr) >= env.Config.MaxSubscriptionsPerClient {
-		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
+		return fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
 	}
 
-	env.Logger.Info("Subscribe to query", "remote", addr, "query", query)
+	env.Logger.Info("Subscribe to query", "remote", addr, "query", query,
+		"fromHeight", fromHeight, "cursor", cursor)
 
 	q, err := tmquery.New(query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse query: %w", err)
+		return fmt.Errorf("failed to parse query: %w", err)
+	}
+
+	policy := tmpubsub.OverflowPolicy(env.Config.SubscriptionOverflowPolicy)
+	if overflow != "" {
+		policy = tmpubsub.OverflowPolicy(overflow)
+	}
+	if err := policy.ValidateBasic(); err != nil {
+		return err
+	}
+
+	// next is the cursor of the first event to deliver.
+	var next types.EventCursor
+	if fromHeight < 0 {
+		return fmt.Errorf("from_height must be non negative, but got %d", fromHeight)
+	} else if fromHeight > 0 {
+		next = types.EventCursor{Height: fromHeight}
+	}
+	if cursor != "" {
+		after, err := types.ParseEventCursor(cursor)
+		if err != nil {
+			return err
+		}
+		if after = (types.EventCursor{Height: after.Height, Index: after.Index + 1}); next.Before(after) {
+			next = after
+		}
+	}
+	if base := env.BlockStore.Base(); next.Height > 0 && next.Height < base {
+		return fmt.Errorf("height %d is not available, lowest height is %d", next.Height, base)
+	}
+	if maxReplay := env.Config.SubscriptionMaxReplayBlocks; next.Height > 0 &&
+		env.BlockStore.Height()-next.Height >= maxReplay {
+		return fmt.Errorf("height %d is too old, at most the last %d blocks can be replayed (subscription_max_replay_blocks)",
+			next.Height, maxReplay)
 	}
 
-	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
+	subCtx, cancel := context.WithTimeout(ctx, SubscribeTimeout)
 	defer cancel()
 
-	sub, err := env.EventBus.Subscribe(subCtx, addr, q, subBufferSize)
+	sub, err := env.EventBus.SubscribeWithPolicy(subCtx, addr, q, env.Config.SubscriptionBufferSize, policy)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	unsubscribe := func() {
+		err := env.EventBus.Unsubscribe(context.Background(), addr, q)
+		if err != nil && err != tmpubsub.ErrSubscriptionNotFound {
+			env.Logger.Error("Failed to unsubscribe", "remote", addr, "query", query, "err", err)
+		}
+	}
+
+	// The events of the blocks committed from now on are delivered live, so
+	// the replay stops at the last block committed so far.
+	var lastHeight int64
+	if next.Height > 0 {
+		state, err := env.StateStore.Load()
+		if err != nil {
+			unsubscribe()
+			return err
+		}
+		lastHeight = state.LastBlockHeight
+	}
+
+	deliverEvent := func(data types.TMEventData, events map[string][]string) {
+		resultEvent := &ctypes.ResultEvent{Query: query, Data: data, Events: events}
+		if c, ok := types.EventCursorFromEvents(events); ok {
+			resultEvent.Cursor = c.String()
+		}
+		deliver(resultEvent)
+	}
+	// deliverLive skips the live events which have been replayed.
+	deliverLive := func(msg tmpubsub.Message) {
+		if c, ok := types.EventCursorFromEvents(msg.Events()); ok {
+			if c.Before(next) {
+				return
+			}
+			next = types.EventCursor{Height: c.Height, Index: c.Index + 1}
+		}
+		deliverEvent(msg.Data(), msg.Events())
 	}
 
-	// Capture the current ID, since it can change in the future.
-	subscriptionID := ctx.JSONReq.ID
 	go func() {
+		// The live events are set aside while replaying, so they don't
+		// overflow the subscription.
+		pending := newReplayBuffer(env.Config.SubscriptionBufferSize, policy)
+		liveOut := func() <-chan tmpubsub.Message {
+			if pending.full() && policy == tmpubsub.OverflowSpill {
+				return nil
+			}
+			return sub.Out()
+		}
+	replay:
+		for height := next.Height; next.Height > 0 && height <= lastHeight; height++ {
+			events, err := blockEvents(height)
+			if err != nil {
+				unsubscribe()
+				cancelled(fmt.Errorf("failed to replay the events at height %d: %w", height, err))
+				return
+			}
+
+			for _, event := range events {
+				c, _ := types.EventCursorFromEvents(event.Events)
+				if c.Before(next) {
+					continue
+				}
+				matches, err := q.Matches(event.Events)
+				if err != nil {
+					env.Logger.Error("Failed to match the query", "query", query, "err", err)
+					continue
+				}
+				if matches {
+					deliverEvent(event.Data, event.Events)
+				}
+			}
+			next = types.EventCursor{Height: height + 1}
+
+			for {
+				select {
+				case msg := <-liveOut():
+					if err := pending.add(msg); err != nil {
+						unsubscribe()
+						cancelled(fmt.Errorf("subscription was cancelled (reason: %s)", err))
+						return
+					}
+				case <-sub.Cancelled():
+					break replay
+				case <-ctx.Done():
+					unsubscribe()
+					return
+				default:
+					continue replay
+				}
+			}
+		}
+		for _, msg := range pending.msgs {
+			deliverLive(msg)
+		}
+
 		for {
 			select {
 			case msg := <-sub.Out():
-				var (
-					resultEvent = &ctypes.ResultEvent{Query: query, Data: msg.Data(), Events: msg.Events()}
-					resp        = rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
-				)
-				writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-				defer cancel()
-				if err := ctx.WSConn.WriteRPCResponse(writeCtx, resp); err != nil {
-					env.Logger.Info("Can't write response (slow client)",
-						"to", addr, "subscriptionID", subscriptionID, "err", err)
-				}
+				deliverLive(msg)
 			case <-sub.Cancelled():
-				if sub.Err() != tmpubsub.ErrUnsubscribed {
-					var reason string
-					if sub.Err() == nil {
-						reason = "Tendermint exited"
-					} else {
-						reason = sub.Err().Error()
-					}
-					var (
-						err  = fmt.Errorf("subscription was cancelled (reason: %s)", reason)
-						resp = rpctypes.RPCServerError(subscriptionID, err)
-					)
-					if ok := ctx.WSConn.TryWriteRPCResponse(resp); !ok {
-						env.Logger.Info("Can't write response (slow client)",
-							"to", addr, "subscriptionID", subscriptionID, "err", err)
-					}
+				if sub.Err() == tmpubsub.ErrUnsubscribed {
+					cancelled(nil)
+					return
 				}
+				reason := "Tendermint exited"
+				if sub.Err() != nil {
+					reason = sub.Err().Error()
+				}
+				cancelled(fmt.Errorf("subscription was cancelled (reason: %s)", reason))
+				return
+			case <-ctx.Done():
+				unsubscribe()
 				return
 			}
 		}
 	}()
 
-	return &ctypes.ResultSubscribe{}, nil
+	return nil
+}
+
+// replayBuffer holds the live events received while replaying. Once it's
+// full, the overflow policy of the subscription applies to the next ones. With
+// the spill policy, the caller leaves them to the subscription instead, which
+// writes them to disk.
+type replayBuffer struct {
+	msgs   []tmpubsub.Message
+	size   int
+	policy tmpubsub.OverflowPolicy
+}
+
+func newReplayBuffer(size int, policy tmpubsub.OverflowPolicy) *replayBuffer {
+	return &replayBuffer{size: size, policy: policy}
+}
+
+func (b *replayBuffer) full() bool {
+	return len(b.msgs) >= b.size
+}
+
+// add sets msg aside, or drops a message if the buffer is full. An error is
+// returned if the subscription must be cancelled.
+func (b *replayBuffer) add(msg tmpubsub.Message) error {
+	if !b.full() {
+		b.msgs = append(b.msgs, msg)
+		return nil
+	}
+	switch b.policy {
+	case tmpubsub.OverflowDropOldest:
+		b.msgs = append(b.msgs[1:], msg)
+		return nil
+	case tmpubsub.OverflowDropNewest:
+		return nil
+	default:
+		return tmpubsub.ErrOutOfCapacity
+	}
+}
+
+// blockEvents returns the events published while committing the block at
+// height. If its results have been pruned, the tx results are taken from the
+// tx indexer, and the events which need the block results are left out.
+func blockEvents(height int64) ([]types.BlockEvent, error) {
+	block := env.BlockStore.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("block at height %d not found", height)
+	}
+
+	var (
+		beginBlock *abci.ResponseBeginBlock
+		endBlock   *abci.ResponseEndBlock
+		deliverTxs = make([]*abci.ResponseDeliverTx, len(block.Txs))
+	)
+	results, err := env.StateStore.LoadABCIResponses(height)
+	switch {
+	case err == nil:
+		beginBlock, endBlock, deliverTxs = results.BeginBlock, results.EndBlock, results.DeliverTxs
+	case errors.As(err, &sm.ErrNoABCIResponsesForHeight{}):
+		for i, tx := range block.Txs {
+			r, err := env.TxIndexer.Get(tx.Hash())
+			if err == nil && r != nil && r.Height == height {
+				deliverTxs[i] = &r.Result
+			}
+		}
+	default:
+		return nil, err
+	}
+
+	return types.BlockEvents(block, beginBlock, endBlock, deliverTxs)
 }
 
 // Unsubscribe from events via WebSocket.
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmpubsub "github.com/arcology-network/consensus-engine/libs/pubsub"
+)
+
+func TestReplayBuffer(t *testing.T) {
+	testCases := []struct {
+		policy  tmpubsub.OverflowPolicy
+		wantErr error
+		want    []interface{}
+	}{
+		{tmpubsub.OverflowDropOldest, nil, []interface{}{2, 3}},
+		{tmpubsub.OverflowDropNewest, nil, []interface{}{1, 2}},
+		{tmpubsub.OverflowCancel, tmpubsub.ErrOutOfCapacity, []interface{}{1, 2}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(string(tc.policy), func(t *testing.T) {
+			b := newReplayBuffer(2, tc.policy)
+			require.NoError(t, b.add(tmpubsub.NewMessage(1, nil)))
+			require.NoError(t, b.add(tmpubsub.NewMessage(2, nil)))
+			assert.True(t, b.full())
+
+			assert.Equal(t, tc.wantErr, b.add(tmpubsub.NewMessage(3, nil)))
+			assert.Len(t, b.msgs, 2)
+			for i, msg := range b.msgs {
+				assert.Equal(t, tc.want[i], msg.Data())
+			}
+		})
+	}
+}
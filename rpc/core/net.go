@@ -4,8 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	tmmath "github.com/arcology-network/consensus-engine/libs/math"
 	"github.com/arcology-network/consensus-engine/p2p"
+	"github.com/arcology-network/consensus-engine/p2p/pex"
 	ctypes "github.com/arcology-network/consensus-engine/rpc/core/types"
 	rpctypes "github.com/arcology-network/consensus-engine/rpc/jsonrpc/types"
 )
@@ -25,6 +28,7 @@ func NetInfo(ctx *rpctypes.Context) (*ctypes.ResultNetInfo, error) {
 			IsOutbound:       peer.IsOutbound(),
 			ConnectionStatus: peer.Status(),
 			RemoteIP:         peer.RemoteIP().String(),
+			TrustScore:       env.P2PPeers.PeerScore(peer.ID()),
 		})
 	}
 	// TODO: Should we include PersistentPeers and Seeds in here?
@@ -91,6 +95,154 @@ func UnsafeDialPeers(ctx *rpctypes.Context, peers []string, persistent, uncondit
 	return &ctypes.ResultDialPeers{Log: "Dialing peers in progress. See /net_info for details"}, nil
 }
 
+// UnsafeBanPeer bans a node ID or an IP address, across restarts, disconnects
+// the matching peers and drops the matching addresses from the address book.
+// duration is a Go duration string, like "24h"; the ban never expires if it is
+// empty.
+func UnsafeBanPeer(ctx *rpctypes.Context, peer, duration, reason string) (*ctypes.ResultBanPeer, error) {
+	if env.P2PBanStore == nil {
+		return nil, errors.New("peer ban list is not enabled")
+	}
+
+	var d time.Duration
+	if duration != "" {
+		var err error
+		if d, err = time.ParseDuration(duration); err != nil {
+			return nil, fmt.Errorf("invalid duration: %w", err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("duration must be positive, got %v", d)
+		}
+	}
+
+	ban, err := env.P2PBanStore.Ban(peer, reason, d)
+	if err != nil {
+		return nil, err
+	}
+	env.Logger.Info("BanPeer", "peer", peer, "duration", d, "reason", reason)
+
+	for _, p := range env.P2PPeers.Peers().List() {
+		if p.ID() == ban.ID || (ban.IP != "" && p.RemoteIP().String() == ban.IP) {
+			env.P2PPeers.StopPeerForError(p, fmt.Errorf("banned: %s", reason))
+		}
+	}
+	if env.P2PAddrBook != nil {
+		for _, info := range env.P2PAddrBook.Addresses() {
+			if (ban.ID != "" && info.Addr.ID == ban.ID) || (ban.IP != "" && info.Addr.IP.String() == ban.IP) {
+				env.P2PAddrBook.RemoveAddress(info.Addr)
+			}
+		}
+	}
+
+	return &ctypes.ResultBanPeer{Ban: ban}, nil
+}
+
+// UnsafeUnbanPeer lifts the ban of a node ID or an IP address.
+func UnsafeUnbanPeer(ctx *rpctypes.Context, peer string) (*ctypes.ResultUnbanPeer, error) {
+	if env.P2PBanStore == nil {
+		return nil, errors.New("peer ban list is not enabled")
+	}
+
+	ok, err := env.P2PBanStore.Unban(peer)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%s is not banned", peer)
+	}
+	env.Logger.Info("UnbanPeer", "peer", peer)
+
+	return &ctypes.ResultUnbanPeer{Log: fmt.Sprintf("Unbanned %s", peer)}, nil
+}
+
+// BannedPeers returns the banned node IDs and IP addresses, including the
+// peers banned automatically for misbehaving.
+func BannedPeers(ctx *rpctypes.Context) (*ctypes.ResultBannedPeers, error) {
+	if env.P2PBanStore == nil {
+		return &ctypes.ResultBannedPeers{Bans: []p2p.BanEntry{}}, nil
+	}
+	return &ctypes.ResultBannedPeers{Bans: env.P2PBanStore.List()}, nil
+}
+
+// AddressBook returns the addresses in the address book with what is known
+// about them, sorted by node ID. The addresses can be filtered by node ID, by
+// bucket ("new" or "old") and by chain ID.
+func AddressBook(
+	ctx *rpctypes.Context,
+	id, bucket, chainID string,
+	pagePtr, perPagePtr *int,
+) (*ctypes.ResultAddressBook, error) {
+	if bucket != "" && bucket != "new" && bucket != "old" {
+		return nil, fmt.Errorf("unknown bucket %q, expected \"new\" or \"old\"", bucket)
+	}
+
+	addrs := []pex.AddrInfo{}
+	if env.P2PAddrBook != nil {
+		for _, info := range env.P2PAddrBook.Addresses() {
+			if (id != "" && string(info.Addr.ID) != id) ||
+				(bucket != "" && info.Bucket != bucket) ||
+				(chainID != "" && info.ChainID != chainID) {
+				continue
+			}
+			addrs = append(addrs, info)
+		}
+	}
+
+	totalCount := len(addrs)
+	perPage := validatePerPage(perPagePtr)
+	page, err := validatePage(pagePtr, perPage, totalCount)
+	if err != nil {
+		return nil, err
+	}
+
+	skipCount := validateSkipCount(page, perPage)
+	addrs = addrs[skipCount : skipCount+tmmath.MinInt(perPage, totalCount-skipCount)]
+
+	return &ctypes.ResultAddressBook{
+		Addrs: addrs,
+		Count: len(addrs),
+		Total: totalCount,
+	}, nil
+}
+
+// BandwidthLimits returns the rate limits of the peer connections, per peer
+// class and per channel.
+func BandwidthLimits(ctx *rpctypes.Context) (*ctypes.ResultBandwidthLimits, error) {
+	limits := env.P2PPeers.BandwidthLimits()
+	return &ctypes.ResultBandwidthLimits{Classes: limits.Classes, Channels: limits.Channels}, nil
+}
+
+// UnsafeSetBandwidthLimit changes the send and receive rates, in bytes per
+// second, of either a peer class or a channel, given by reactor name (e.g.
+// "mempool") or by ID in hex (e.g. "0x30"). A rate of 0 is unlimited. The new
+// limits apply to the connected peers at once, but aren't saved to the config.
+func UnsafeSetBandwidthLimit(
+	ctx *rpctypes.Context,
+	class, channel string,
+	sendRate, recvRate int64,
+) (*ctypes.ResultBandwidthLimits, error) {
+	limit := p2p.RateLimit{SendRate: sendRate, RecvRate: recvRate}
+
+	var err error
+	switch {
+	case class != "" && channel != "":
+		return nil, errors.New("only one of class and channel can be set")
+	case class != "":
+		err = env.P2PPeers.SetPeerRateLimit(p2p.PeerClass(class), limit)
+	case channel != "":
+		err = env.P2PPeers.SetChannelRateLimit(channel, limit)
+	default:
+		return nil, errors.New("either class or channel must be set")
+	}
+	if err != nil {
+		return nil, err
+	}
+	env.Logger.Info("SetBandwidthLimit", "class", class, "channel", channel,
+		"send_rate", sendRate, "recv_rate", recvRate)
+
+	return BandwidthLimits(ctx)
+}
+
 // Genesis returns genesis file.
 // More: https://docs.tendermint.com/master/rpc/#/Info/genesis
 func Genesis(ctx *rpctypes.Context) (*ctypes.ResultGenesis, error) {
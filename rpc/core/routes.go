@@ -8,8 +8,10 @@ import (
 
 // Routes is a map of available routes.
 var Routes = map[string]*rpc.RPCFunc{
-	// subscribe/unsubscribe are reserved for websocket events.
-	"subscribe":       rpc.NewWSRPCFunc(Subscribe, "query"),
+	// subscribe/unsubscribe are reserved for websocket events. Websocket only
+	// functions are ordered, so an unsubscribe never overtakes the subscribe
+	// sent before it.
+	"subscribe":       rpc.NewWSRPCFunc(Subscribe, "query,from_height,cursor,overflow"),
 	"unsubscribe":     rpc.NewWSRPCFunc(Unsubscribe, "query"),
 	"unsubscribe_all": rpc.NewWSRPCFunc(UnsubscribeAll, ""),
 
@@ -17,16 +19,23 @@ var Routes = map[string]*rpc.RPCFunc{
 	"health":               rpc.NewRPCFunc(Health, ""),
 	"status":               rpc.NewRPCFunc(Status, ""),
 	"net_info":             rpc.NewRPCFunc(NetInfo, ""),
+	"banned_peers":         rpc.NewRPCFunc(BannedPeers, ""),
+	"address_book":         rpc.NewRPCFunc(AddressBook, "id,bucket,chain_id,page,per_page"),
+	"bandwidth_limits":     rpc.NewRPCFunc(BandwidthLimits, ""),
 	"blockchain":           rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight"),
 	"genesis":              rpc.NewRPCFunc(Genesis, ""),
 	"block":                rpc.NewRPCFunc(Block, "height"),
 	"block_by_hash":        rpc.NewRPCFunc(BlockByHash, "hash"),
+	"header":               rpc.NewRPCFunc(Header, "height"),
+	"header_by_hash":       rpc.NewRPCFunc(HeaderByHash, "hash"),
 	"block_results":        rpc.NewRPCFunc(BlockResults, "height"),
+	"block_search":         rpc.NewRPCFunc(BlockSearch, "query,page,per_page,order_by"),
 	"commit":               rpc.NewRPCFunc(Commit, "height"),
 	"check_tx":             rpc.NewRPCFunc(CheckTx, "tx"),
 	"tx":                   rpc.NewRPCFunc(Tx, "hash,prove"),
 	"tx_search":            rpc.NewRPCFunc(TxSearch, "query,prove,page,per_page,order_by"),
 	"validators":           rpc.NewRPCFunc(Validators, "height,page,per_page"),
+	"validator_stats":      rpc.NewRPCFunc(ValidatorStats, "window"),
 	"dump_consensus_state": rpc.NewRPCFunc(DumpConsensusState, ""),
 	"consensus_state":      rpc.NewRPCFunc(ConsensusState, ""),
 	"consensus_params":     rpc.NewRPCFunc(ConsensusParams, "height"),
@@ -34,22 +43,27 @@ var Routes = map[string]*rpc.RPCFunc{
 	"num_unconfirmed_txs":  rpc.NewRPCFunc(NumUnconfirmedTxs, ""),
 
 	// tx broadcast API
-	"broadcast_tx_commit": rpc.NewRPCFunc(BroadcastTxCommit, "tx"),
-	"broadcast_tx_sync":   rpc.NewRPCFunc(BroadcastTxSync, "tx"),
-	"broadcast_tx_async":  rpc.NewRPCFunc(BroadcastTxAsync, "tx"),
+	"broadcast_tx_commit": rpc.NewRPCFunc(BroadcastTxCommit, "tx", rpc.Ordered()),
+	"broadcast_tx_sync":   rpc.NewRPCFunc(BroadcastTxSync, "tx", rpc.Ordered()),
+	"broadcast_tx_async":  rpc.NewRPCFunc(BroadcastTxAsync, "tx", rpc.Ordered()),
 
 	// abci API
 	"abci_query": rpc.NewRPCFunc(ABCIQuery, "path,data,height,prove"),
 	"abci_info":  rpc.NewRPCFunc(ABCIInfo, ""),
 
 	// evidence API
-	"broadcast_evidence": rpc.NewRPCFunc(BroadcastEvidence, "evidence"),
+	"broadcast_evidence": rpc.NewRPCFunc(BroadcastEvidence, "evidence", rpc.Ordered()),
 }
 
-// AddUnsafeRoutes adds unsafe routes.
+// AddUnsafeRoutes adds unsafe routes. They change the state of the node, so
+// they are Ordered.
 func AddUnsafeRoutes() {
 	// control API
-	Routes["dial_seeds"] = rpc.NewRPCFunc(UnsafeDialSeeds, "seeds")
-	Routes["dial_peers"] = rpc.NewRPCFunc(UnsafeDialPeers, "peers,persistent,unconditional,private")
-	Routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(UnsafeFlushMempool, "")
+	Routes["dial_seeds"] = rpc.NewRPCFunc(UnsafeDialSeeds, "seeds", rpc.Ordered())
+	Routes["dial_peers"] = rpc.NewRPCFunc(UnsafeDialPeers, "peers,persistent,unconditional,private", rpc.Ordered())
+	Routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(UnsafeFlushMempool, "", rpc.Ordered())
+	Routes["unsafe_ban_peer"] = rpc.NewRPCFunc(UnsafeBanPeer, "peer,duration,reason", rpc.Ordered())
+	Routes["unsafe_unban_peer"] = rpc.NewRPCFunc(UnsafeUnbanPeer, "peer", rpc.Ordered())
+	Routes["unsafe_set_bandwidth_limit"] = rpc.NewRPCFunc(UnsafeSetBandwidthLimit,
+		"class,channel,send_rate,recv_rate", rpc.Ordered())
 }
@@ -8,7 +8,9 @@ import (
 	"github.com/arcology-network/consensus-engine/crypto"
 	"github.com/arcology-network/consensus-engine/libs/bytes"
 	"github.com/arcology-network/consensus-engine/p2p"
+	"github.com/arcology-network/consensus-engine/p2p/pex"
 	tmproto "github.com/arcology-network/consensus-engine/proto/tendermint/types"
+	"github.com/arcology-network/consensus-engine/state/valstats"
 	"github.com/arcology-network/consensus-engine/types"
 )
 
@@ -29,6 +31,17 @@ type ResultBlock struct {
 	Block   *types.Block  `json:"block"`
 }
 
+// Single block header
+type ResultHeader struct {
+	Header *types.Header `json:"header"`
+}
+
+// Result of searching for blocks
+type ResultBlockSearch struct {
+	Blocks     []*ResultBlock `json:"blocks"`
+	TotalCount int            `json:"total_count"`
+}
+
 // Commit and Header
 type ResultCommit struct {
 	types.SignedHeader `json:"signed_header"`
@@ -114,12 +127,43 @@ type ResultDialPeers struct {
 	Log string `json:"log"`
 }
 
+// A ban created by unsafe_ban_peer
+type ResultBanPeer struct {
+	Ban p2p.BanEntry `json:"ban"`
+}
+
+// Result of unsafe_unban_peer
+type ResultUnbanPeer struct {
+	Log string `json:"log"`
+}
+
+// The active peer bans
+type ResultBannedPeers struct {
+	Bans []p2p.BanEntry `json:"bans"`
+}
+
+// The addresses in the address book
+type ResultAddressBook struct {
+	Addrs []pex.AddrInfo `json:"addrs"`
+	// Count of addresses in this result
+	Count int `json:"count"`
+	// Total number of addresses matching the query
+	Total int `json:"total"`
+}
+
+// The rate limits of the peer connections
+type ResultBandwidthLimits struct {
+	Classes  map[p2p.PeerClass]p2p.RateLimit `json:"classes"`
+	Channels map[string]p2p.RateLimit        `json:"channels"`
+}
+
 // A peer
 type Peer struct {
 	NodeInfo         p2p.DefaultNodeInfo  `json:"node_info"`
 	IsOutbound       bool                 `json:"is_outbound"`
 	ConnectionStatus p2p.ConnectionStatus `json:"connection_status"`
 	RemoteIP         string               `json:"remote_ip"`
+	TrustScore       int                  `json:"trust_score"`
 }
 
 // Validators for a height.
@@ -132,6 +176,14 @@ type ResultValidators struct {
 	Total int `json:"total"`
 }
 
+// Signing statistics of the validators over the blocks FromHeight to
+// ToHeight.
+type ResultValidatorStats struct {
+	FromHeight int64                     `json:"from_height"`
+	ToHeight   int64                     `json:"to_height"`
+	Validators []valstats.ValidatorStats `json:"validators"`
+}
+
 // ConsensusParams for given height
 type ResultConsensusParams struct {
 	BlockHeight     int64                   `json:"block_height"`
@@ -232,4 +284,6 @@ type ResultEvent struct {
 	Query  string              `json:"query"`
 	Data   types.TMEventData   `json:"data"`
 	Events map[string][]string `json:"events"`
+	// Cursor is set on the events of a block, see types.EventCursor.
+	Cursor string `json:"cursor,omitempty"`
 }
@@ -2,29 +2,53 @@ package coregrpc_test
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/arcology-network/consensus-engine/abci/example/kvstore"
+	nm "github.com/arcology-network/consensus-engine/node"
 	core_grpc "github.com/arcology-network/consensus-engine/rpc/grpc"
 	rpctest "github.com/arcology-network/consensus-engine/rpc/test"
 )
 
-func TestMain(m *testing.M) {
-	// start a tendermint node in the background to test against
-	app := kvstore.NewApplication()
-	node := rpctest.StartTendermint(app)
+var (
+	nodeOnce sync.Once
+	node     *nm.Node
+	nodeErr  error
+)
 
+func TestMain(m *testing.M) {
 	code := m.Run()
 
 	// and shut down proper at the end
-	rpctest.StopTendermint(node)
+	if node != nil {
+		rpctest.StopTendermint(node)
+	}
 	os.Exit(code)
 }
 
+// startTendermint starts a tendermint node in the background to test against.
+// If the node fails to start, only the tests which need it fail.
+func startTendermint(t *testing.T) {
+	nodeOnce.Do(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				nodeErr = fmt.Errorf("failed to start the node: %v", r)
+			}
+		}()
+		app := kvstore.NewApplication()
+		node = rpctest.StartTendermint(app)
+	})
+	require.NoError(t, nodeErr)
+}
+
 func TestBroadcastTx(t *testing.T) {
+	startTendermint(t)
+
 	res, err := rpctest.GetGRPCClient().BroadcastTx(
 		context.Background(),
 		&core_grpc.RequestBroadcastTx{Tx: []byte("this is a tx")},
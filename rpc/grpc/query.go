@@ -0,0 +1,390 @@
+package coregrpc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"google.golang.org/grpc/peer"
+
+	cryptoenc "github.com/arcology-network/consensus-engine/crypto/encoding"
+	tmproto "github.com/arcology-network/consensus-engine/proto/tendermint/types"
+	core "github.com/arcology-network/consensus-engine/rpc/core"
+	ctypes "github.com/arcology-network/consensus-engine/rpc/core/types"
+	rpctypes "github.com/arcology-network/consensus-engine/rpc/jsonrpc/types"
+	"github.com/arcology-network/consensus-engine/types"
+)
+
+// queryAPI serves the queries of rpc/core over gRPC.
+type queryAPI struct {
+}
+
+func (qapi *queryAPI) Status(ctx context.Context, req *RequestStatus) (*ResponseStatus, error) {
+	res, err := core.Status(rpctypes.NewContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	validatorInfo := ValidatorInfo{
+		Address:     res.ValidatorInfo.Address,
+		VotingPower: res.ValidatorInfo.VotingPower,
+	}
+	if res.ValidatorInfo.PubKey != nil {
+		pk, err := cryptoenc.PubKeyToProto(res.ValidatorInfo.PubKey)
+		if err != nil {
+			return nil, err
+		}
+		validatorInfo.PubKey = &pk
+	}
+
+	return &ResponseStatus{
+		NodeInfo: *res.NodeInfo.ToProto(),
+		SyncInfo: SyncInfo{
+			LatestBlockHash:     res.SyncInfo.LatestBlockHash,
+			LatestAppHash:       res.SyncInfo.LatestAppHash,
+			LatestBlockHeight:   res.SyncInfo.LatestBlockHeight,
+			LatestBlockTime:     res.SyncInfo.LatestBlockTime,
+			EarliestBlockHash:   res.SyncInfo.EarliestBlockHash,
+			EarliestAppHash:     res.SyncInfo.EarliestAppHash,
+			EarliestBlockHeight: res.SyncInfo.EarliestBlockHeight,
+			EarliestBlockTime:   res.SyncInfo.EarliestBlockTime,
+			CatchingUp:          res.SyncInfo.CatchingUp,
+		},
+		ValidatorInfo: validatorInfo,
+	}, nil
+}
+
+func (qapi *queryAPI) Block(ctx context.Context, req *RequestBlock) (*ResponseBlock, error) {
+	res, err := core.Block(rpctypes.NewContext(ctx), heightPtr(req.Height))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ResponseBlock{BlockID: res.BlockID.ToProto()}
+	if res.Block != nil {
+		if resp.Block, err = res.Block.ToProto(); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (qapi *queryAPI) BlockResults(ctx context.Context, req *RequestBlockResults) (*ResponseBlockResults, error) {
+	res, err := core.BlockResults(rpctypes.NewContext(ctx), heightPtr(req.Height))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResponseBlockResults{
+		Height:                res.Height,
+		TxsResults:            res.TxsResults,
+		BeginBlockEvents:      res.BeginBlockEvents,
+		EndBlockEvents:        res.EndBlockEvents,
+		ValidatorUpdates:      res.ValidatorUpdates,
+		ConsensusParamUpdates: res.ConsensusParamUpdates,
+	}, nil
+}
+
+func (qapi *queryAPI) Commit(ctx context.Context, req *RequestCommit) (*ResponseCommit, error) {
+	res, err := core.Commit(rpctypes.NewContext(ctx), heightPtr(req.Height))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResponseCommit{
+		SignedHeader: res.SignedHeader.ToProto(),
+		Canonical:    res.CanonicalCommit,
+	}, nil
+}
+
+func (qapi *queryAPI) Validators(ctx context.Context, req *RequestValidators) (*ResponseValidators, error) {
+	res, err := core.Validators(rpctypes.NewContext(ctx), heightPtr(req.Height), intPtr(req.Page), intPtr(req.PerPage))
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := validatorsToProto(res.Validators)
+	if err != nil {
+		return nil, err
+	}
+	return &ResponseValidators{
+		BlockHeight: res.BlockHeight,
+		Validators:  validators,
+		Count:       int32(res.Count),
+		Total:       int32(res.Total),
+	}, nil
+}
+
+func (qapi *queryAPI) Tx(ctx context.Context, req *RequestTx) (*ResponseTx, error) {
+	res, err := core.Tx(rpctypes.NewContext(ctx), req.Hash, req.Prove)
+	if err != nil {
+		return nil, err
+	}
+	return txToProto(res, req.Prove), nil
+}
+
+func (qapi *queryAPI) TxSearch(ctx context.Context, req *RequestTxSearch) (*ResponseTxSearch, error) {
+	res, err := core.TxSearch(rpctypes.NewContext(ctx), req.Query, req.Prove,
+		intPtr(req.Page), intPtr(req.PerPage), req.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*ResponseTx, len(res.Txs))
+	for i, tx := range res.Txs {
+		txs[i] = txToProto(tx, req.Prove)
+	}
+	return &ResponseTxSearch{Txs: txs, TotalCount: int32(res.TotalCount)}, nil
+}
+
+func (qapi *queryAPI) NetInfo(ctx context.Context, req *RequestNetInfo) (*ResponseNetInfo, error) {
+	res, err := core.NetInfo(rpctypes.NewContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make([]Peer, len(res.Peers))
+	for i, p := range res.Peers {
+		peers[i] = Peer{
+			NodeInfo:   *p.NodeInfo.ToProto(),
+			IsOutbound: p.IsOutbound,
+			RemoteIp:   p.RemoteIP,
+			TrustScore: int32(p.TrustScore),
+		}
+	}
+	return &ResponseNetInfo{
+		Listening: res.Listening,
+		Listeners: res.Listeners,
+		NPeers:    int32(res.NPeers),
+		Peers:     peers,
+	}, nil
+}
+
+func (qapi *queryAPI) ConsensusParams(
+	ctx context.Context,
+	req *RequestConsensusParams,
+) (*ResponseConsensusParams, error) {
+	res, err := core.ConsensusParams(rpctypes.NewContext(ctx), heightPtr(req.Height))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResponseConsensusParams{
+		BlockHeight:     res.BlockHeight,
+		ConsensusParams: res.ConsensusParams,
+	}, nil
+}
+
+// Subscribe streams the events until the client cancels the call or the
+// subscription is cancelled. The subscriber is identified by the client's
+// address, so the subscription limits of the RPC config apply per connection.
+func (qapi *queryAPI) Subscribe(req *RequestSubscribe, stream QueryAPI_SubscribeServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var addr string
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+
+	// The events are sent from the handler goroutine, so the stream is not
+	// used once the handler has returned. The channel is unbuffered: a slow
+	// client is left to the buffer and the overflow policy of the subscription.
+	events := make(chan *ctypes.ResultEvent)
+	done := make(chan error, 1)
+	deliver := func(resultEvent *ctypes.ResultEvent) {
+		select {
+		case events <- resultEvent:
+		case <-ctx.Done():
+		}
+	}
+	cancelled := func(err error) {
+		done <- err
+	}
+
+	err := core.SubscribeEvents(ctx, addr, req.Query, req.FromHeight, req.Cursor, "", deliver, cancelled)
+	if err != nil {
+		return err
+	}
+
+	send := func(resultEvent *ctypes.ResultEvent) error {
+		resp, err := eventToProto(resultEvent)
+		if err != nil {
+			return err
+		}
+		return stream.Send(resp)
+	}
+	for {
+		select {
+		case resultEvent := <-events:
+			if err := send(resultEvent); err != nil {
+				return err
+			}
+		case err := <-done:
+			// The events delivered before the cancellation are sent first.
+			for {
+				select {
+				case resultEvent := <-events:
+					if err := send(resultEvent); err != nil {
+						return err
+					}
+				default:
+					return err
+				}
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+// heightPtr and intPtr map the zero value to the default of the core routes.
+func heightPtr(height int64) *int64 {
+	if height == 0 {
+		return nil
+	}
+	return &height
+}
+
+func intPtr(i int32) *int {
+	if i == 0 {
+		return nil
+	}
+	v := int(i)
+	return &v
+}
+
+func validatorsToProto(vals []*types.Validator) ([]*tmproto.Validator, error) {
+	pbVals := make([]*tmproto.Validator, len(vals))
+	for i, val := range vals {
+		pbVal, err := val.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		pbVals[i] = pbVal
+	}
+	return pbVals, nil
+}
+
+func txToProto(res *ctypes.ResultTx, prove bool) *ResponseTx {
+	tx := &ResponseTx{
+		Hash:     res.Hash,
+		Height:   res.Height,
+		Index:    res.Index,
+		TxResult: res.TxResult,
+		Tx:       res.Tx,
+	}
+	if prove {
+		proof := res.Proof.ToProto()
+		tx.Proof = &proof
+	}
+	return tx
+}
+
+func eventToProto(res *ctypes.ResultEvent) (*ResponseEvent, error) {
+	data, err := eventDataToProto(res.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(res.Events))
+	for key := range res.Events {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	events := make([]EventAttribute, len(keys))
+	for i, key := range keys {
+		events[i] = EventAttribute{Key: key, Values: res.Events[key]}
+	}
+
+	return &ResponseEvent{
+		Query:  res.Query,
+		Data:   data,
+		Events: events,
+		Cursor: res.Cursor,
+	}, nil
+}
+
+func eventDataToProto(data types.TMEventData) (EventData, error) {
+	switch data := data.(type) {
+	case types.EventDataNewBlock:
+		pb := &EventDataNewBlock{
+			ResultBeginBlock: data.ResultBeginBlock,
+			ResultEndBlock:   data.ResultEndBlock,
+		}
+		if data.Block != nil {
+			block, err := data.Block.ToProto()
+			if err != nil {
+				return EventData{}, err
+			}
+			pb.Block = block
+		}
+		return EventData{Data: &EventData_NewBlock{NewBlock: pb}}, nil
+
+	case types.EventDataNewBlockHeader:
+		return EventData{Data: &EventData_NewBlockHeader{NewBlockHeader: &EventDataNewBlockHeader{
+			Header:           *data.Header.ToProto(),
+			NumTxs:           data.NumTxs,
+			ResultBeginBlock: data.ResultBeginBlock,
+			ResultEndBlock:   data.ResultEndBlock,
+		}}}, nil
+
+	case types.EventDataNewEvidence:
+		ev, err := types.EvidenceToProto(data.Evidence)
+		if err != nil {
+			return EventData{}, err
+		}
+		return EventData{Data: &EventData_NewEvidence{NewEvidence: &EventDataNewEvidence{
+			Evidence: ev,
+			Height:   data.Height,
+		}}}, nil
+
+	case types.EventDataTx:
+		txResult := data.TxResult
+		return EventData{Data: &EventData_Tx{Tx: &txResult}}, nil
+
+	case types.EventDataRoundState:
+		return EventData{Data: &EventData_RoundState{RoundState: &tmproto.EventDataRoundState{
+			Height: data.Height,
+			Round:  data.Round,
+			Step:   data.Step,
+		}}}, nil
+
+	case types.EventDataNewRound:
+		return EventData{Data: &EventData_NewRound{NewRound: &EventDataNewRound{
+			Height:          data.Height,
+			Round:           data.Round,
+			Step:            data.Step,
+			ProposerAddress: data.Proposer.Address,
+			ProposerIndex:   data.Proposer.Index,
+		}}}, nil
+
+	case types.EventDataCompleteProposal:
+		return EventData{Data: &EventData_CompleteProposal{CompleteProposal: &EventDataCompleteProposal{
+			Height:  data.Height,
+			Round:   data.Round,
+			Step:    data.Step,
+			BlockID: data.BlockID.ToProto(),
+		}}}, nil
+
+	case types.EventDataVote:
+		return EventData{Data: &EventData_Vote{Vote: data.Vote.ToProto()}}, nil
+
+	case types.EventDataValidatorSetUpdates:
+		vals, err := validatorsToProto(data.ValidatorUpdates)
+		if err != nil {
+			return EventData{}, err
+		}
+		return EventData{Data: &EventData_ValidatorSetUpdates{ValidatorSetUpdates: &EventDataValidatorSetUpdates{
+			ValidatorUpdates: vals,
+		}}}, nil
+
+	case types.EventDataString:
+		return EventData{Data: &EventData_ProposalString{ProposalString: string(data)}}, nil
+
+	default:
+		return EventData{}, fmt.Errorf("unknown event data type %T", data)
+	}
+}
@@ -0,0 +1,150 @@
+package coregrpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+	"google.golang.org/grpc"
+
+	abci "github.com/arcology-network/consensus-engine/abci/types"
+	cfg "github.com/arcology-network/consensus-engine/config"
+	"github.com/arcology-network/consensus-engine/libs/log"
+	core "github.com/arcology-network/consensus-engine/rpc/core"
+	sm "github.com/arcology-network/consensus-engine/state"
+	"github.com/arcology-network/consensus-engine/store"
+	"github.com/arcology-network/consensus-engine/types"
+)
+
+// queryEnvHeight is the height of the block store of the tests.
+const queryEnvHeight = 2000
+
+var (
+	queryEnvOnce sync.Once
+	eventBus     *types.EventBus
+)
+
+// heightBlockStore is an empty block store reporting a given height.
+type heightBlockStore struct {
+	sm.BlockStore
+	height int64
+}
+
+func (bs heightBlockStore) Height() int64 { return bs.height }
+
+// startQueryAPI serves the query API in process and returns a client
+// connected to it. The environment of rpc/core is shared by the tests, since
+// the subscriptions outlive the calls for a little while.
+func startQueryAPI(t *testing.T) QueryAPIClient {
+	queryEnvOnce.Do(func() {
+		eventBus = types.NewEventBus()
+		eventBus.SetLogger(log.TestingLogger())
+		if err := eventBus.Start(); err != nil {
+			panic(err)
+		}
+		core.SetEnvironment(&core.Environment{
+			BlockStore: heightBlockStore{store.NewBlockStore(dbm.NewMemDB()), queryEnvHeight},
+			EventBus:   eventBus,
+			Logger:     log.TestingLogger(),
+			Config:     *cfg.TestRPCConfig(),
+		})
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	RegisterQueryAPIServer(server, &queryAPI{})
+	go server.Serve(ln) //nolint:errcheck // ignore for tests
+
+	conn, err := grpc.Dial(ln.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+		require.Eventually(t, func() bool { return eventBus.NumClients() == 0 }, time.Second, 10*time.Millisecond)
+	})
+	return NewQueryAPIClient(conn)
+}
+
+func TestQueryAPISubscribeLive(t *testing.T) {
+	client := startQueryAPI(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.Subscribe(ctx, &RequestSubscribe{Query: "tm.event='Tx'"})
+	require.NoError(t, err)
+
+	// the subscription is made before the first event is received
+	require.Eventually(t, func() bool { return eventBus.NumClients() == 1 }, time.Second, 10*time.Millisecond)
+
+	for i := uint32(0); i < 3; i++ {
+		err := eventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{
+			Height: 1,
+			Index:  i,
+			Tx:     types.Tx("foo"),
+			Result: abci.ResponseDeliverTx{Code: 0},
+		}})
+		require.NoError(t, err)
+	}
+
+	for i := uint32(0); i < 3; i++ {
+		event, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, "tm.event='Tx'", event.Query)
+		tx := event.Data.GetTx()
+		require.NotNil(t, tx)
+		assert.Equal(t, i, tx.Index)
+		assert.Equal(t, []byte("foo"), tx.Tx)
+		assert.NotEmpty(t, event.Events)
+	}
+}
+
+func TestQueryAPISubscribeClientCancels(t *testing.T) {
+	client := startQueryAPI(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := client.Subscribe(ctx, &RequestSubscribe{Query: "tm.event='NewBlock'"})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return eventBus.NumClients() == 1 }, time.Second, 10*time.Millisecond)
+
+	// the subscription is removed once the client is gone, even while events
+	// keep being published
+	cancel()
+	require.Eventually(t, func() bool {
+		err := eventBus.PublishEventNewBlock(types.EventDataNewBlock{Block: types.MakeBlock(1, nil, nil, nil)})
+		return err == nil && eventBus.NumClients() == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestQueryAPISubscribeInvalidQuery(t *testing.T) {
+	client := startQueryAPI(t)
+
+	stream, err := client.Subscribe(context.Background(), &RequestSubscribe{Query: "tm.event=="})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Error(t, err)
+}
+
+func TestQueryAPISubscribeReplayWindow(t *testing.T) {
+	client := startQueryAPI(t)
+	maxReplay := cfg.TestRPCConfig().SubscriptionMaxReplayBlocks
+
+	testCases := []*RequestSubscribe{
+		{Query: "tm.event='Tx'", FromHeight: 1},
+		{Query: "tm.event='Tx'", FromHeight: queryEnvHeight - maxReplay},
+		{Query: "tm.event='Tx'", Cursor: fmt.Sprintf("%d/0", queryEnvHeight-maxReplay-1)},
+	}
+	for _, req := range testCases {
+		stream, err := client.Subscribe(context.Background(), req)
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "too old")
+	}
+}
@@ -529,6 +529,19 @@ func (c *WSClient) Subscribe(ctx context.Context, query string) error {
 	return c.Call(ctx, "subscribe", params)
 }
 
+// SubscribeFrom to a query, like Subscribe, but the server first replays the
+// events of the committed blocks from fromHeight or after cursor, if set.
+func (c *WSClient) SubscribeFrom(ctx context.Context, query string, fromHeight int64, cursor string) error {
+	params := map[string]interface{}{"query": query}
+	if fromHeight > 0 {
+		params["from_height"] = fromHeight
+	}
+	if cursor != "" {
+		params["cursor"] = cursor
+	}
+	return c.Call(ctx, "subscribe", params)
+}
+
 // Unsubscribe from a query. Note the server must have a "unsubscribe" route
 // defined.
 func (c *WSClient) Unsubscribe(ctx context.Context, query string) error {
@@ -0,0 +1,470 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arcology-network/consensus-engine/libs/log"
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
+	types "github.com/arcology-network/consensus-engine/rpc/jsonrpc/types"
+)
+
+// Authentication and rate limiting
+
+const (
+	// APIKeyTypeBearer keys are sent as is: "Authorization: Bearer <secret>".
+	APIKeyTypeBearer = "bearer"
+	// APIKeyTypeHMAC keys sign every request without sending the secret:
+	// "Authorization: HMAC <id>:<unix timestamp>:<hex signature>". See
+	// HMACSignature.
+	APIKeyTypeHMAC = "hmac"
+
+	// MaxHMACClockSkew is the maximum difference between the timestamp of a
+	// signed request and the local clock.
+	MaxHMACClockSkew = 5 * time.Minute
+
+	// idle per-IP buckets are garbage collected this often
+	ipBucketSweepInterval = time.Minute
+
+	anonymousKeyLabel = "anonymous"
+	unknownMethod     = "unknown"
+)
+
+// APIKey is a credential accepted by AccessControl.
+type APIKey struct {
+	// ID identifies the key in metrics and logs. HMAC signatures reference the
+	// key by its ID.
+	ID string `json:"id"`
+	// Type is either "bearer" or "hmac".
+	Type string `json:"type"`
+	// Secret is the bearer token or the HMAC secret.
+	Secret string `json:"secret"`
+	// Methods the key is allowed to call. Empty means all methods.
+	Methods []string `json:"methods,omitempty"`
+	// Rate is the number of requests per second the key may make. 0 means
+	// AccessConfig.KeyRate is used.
+	Rate float64 `json:"rate,omitempty"`
+	// Burst is the number of requests the key may make at once. 0 means
+	// AccessConfig.KeyBurst is used.
+	Burst int `json:"burst,omitempty"`
+}
+
+// ValidateBasic performs basic validation.
+func (k APIKey) ValidateBasic() error {
+	if k.ID == "" {
+		return errors.New("empty id")
+	}
+	switch k.Type {
+	case APIKeyTypeBearer, APIKeyTypeHMAC:
+	default:
+		return fmt.Errorf("unknown type %q (must be %q or %q)", k.Type, APIKeyTypeBearer, APIKeyTypeHMAC)
+	}
+	if k.Secret == "" {
+		return errors.New("empty secret")
+	}
+	if k.Rate < 0 {
+		return errors.New("negative rate")
+	}
+	if k.Burst < 0 {
+		return errors.New("negative burst")
+	}
+	return nil
+}
+
+// LoadAPIKeys reads keys from a JSON file of the form
+//
+//	{"keys": [{"id": "partner", "type": "bearer", "secret": "...", "methods": ["status"]}]}
+func LoadAPIKeys(file string) ([]APIKey, error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Keys []APIKey `json:"keys"`
+	}
+	if err := json.Unmarshal(bz, &doc); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", file, err)
+	}
+	return doc.Keys, nil
+}
+
+// HMACSignature returns the signature of a request made with an HMAC key:
+// HMAC-SHA256 of "<timestamp>\n<http method>\n<request uri>\n<hex sha256 of
+// body>" under the key's secret.
+func HMACSignature(secret string, timestamp int64, method, requestURI string, body []byte) []byte {
+	bodyHash := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d\n%s\n%s\n%s", timestamp, method, requestURI, hex.EncodeToString(bodyHash[:]))
+	return mac.Sum(nil)
+}
+
+// AccessConfig configures AccessControl.
+type AccessConfig struct {
+	// Keys accepted by the server.
+	Keys []APIKey
+	// AuthRequired rejects requests without credentials. Otherwise they are
+	// served anonymously, subject to the per-IP rate limit.
+	AuthRequired bool
+	// KeyRate and KeyBurst limit keys which don't set their own rate. 0 rate
+	// means unlimited.
+	KeyRate  float64
+	KeyBurst int
+	// IPRate and IPBurst limit anonymous requests per client IP. 0 rate means
+	// unlimited.
+	IPRate  float64
+	IPBurst int
+}
+
+// AccessControl authenticates RPC requests, enforces per-key method
+// allowlists and rate limits requests per key and per IP.
+//
+// Handler authenticates the HTTP request (including the WebSocket upgrade);
+// the handlers registered by RegisterRPCFuncs and WebsocketManager then
+// admit every individual call.
+type AccessControl struct {
+	config  AccessConfig
+	metrics *Metrics
+	logger  log.Logger
+
+	bearer map[[sha256.Size]byte]*apiKeyState
+	hmac   map[string]*apiKeyState
+
+	mtx       tmsync.Mutex
+	ipBuckets map[string]*tokenBucket
+	lastSweep time.Time
+
+	now func() time.Time
+}
+
+type apiKeyState struct {
+	APIKey
+	methods map[string]struct{}
+	bucket  *tokenBucket // nil if unlimited
+}
+
+func (k *apiKeyState) allows(method string) bool {
+	if len(k.methods) == 0 {
+		return true
+	}
+	_, ok := k.methods[method]
+	return ok
+}
+
+// NewAccessControl returns a new AccessControl. metrics may be nil.
+func NewAccessControl(config AccessConfig, metrics *Metrics) (*AccessControl, error) {
+	if config.KeyRate < 0 || config.KeyBurst < 0 || config.IPRate < 0 || config.IPBurst < 0 {
+		return nil, errors.New("rate limits can't be negative")
+	}
+	if metrics == nil {
+		metrics = NopMetrics()
+	}
+	ac := &AccessControl{
+		config:    config,
+		metrics:   metrics,
+		logger:    log.NewNopLogger(),
+		bearer:    make(map[[sha256.Size]byte]*apiKeyState),
+		hmac:      make(map[string]*apiKeyState),
+		ipBuckets: make(map[string]*tokenBucket),
+		now:       time.Now,
+	}
+	ids := make(map[string]struct{}, len(config.Keys))
+	for i, key := range config.Keys {
+		if err := key.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("invalid key #%d: %w", i, err)
+		}
+		if _, ok := ids[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key id %s", key.ID)
+		}
+		ids[key.ID] = struct{}{}
+
+		state := &apiKeyState{APIKey: key}
+		if len(key.Methods) > 0 {
+			state.methods = make(map[string]struct{}, len(key.Methods))
+			for _, m := range key.Methods {
+				state.methods[m] = struct{}{}
+			}
+		}
+		rate, burst := key.Rate, key.Burst
+		if rate == 0 {
+			rate = config.KeyRate
+		}
+		if burst == 0 {
+			burst = config.KeyBurst
+		}
+		state.bucket = newTokenBucket(rate, burst, ac.now())
+
+		switch key.Type {
+		case APIKeyTypeBearer:
+			hash := sha256.Sum256([]byte(key.Secret))
+			if _, ok := ac.bearer[hash]; ok {
+				return nil, fmt.Errorf("key %s reuses the secret of another bearer key", key.ID)
+			}
+			ac.bearer[hash] = state
+		case APIKeyTypeHMAC:
+			ac.hmac[key.ID] = state
+		}
+	}
+	return ac, nil
+}
+
+// SetLogger sets the logger.
+func (ac *AccessControl) SetLogger(l log.Logger) {
+	ac.logger = l
+}
+
+// Handler returns a middleware, which authenticates requests before passing
+// them to next. Requests with invalid credentials, or without credentials if
+// they are required, are rejected with 401.
+func (ac *AccessControl) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, reason, err := ac.authenticate(r)
+		if err == nil && key == nil && ac.config.AuthRequired {
+			reason, err = "missing", errors.New("authorization required")
+		}
+		if err != nil {
+			ac.metrics.AuthFailures.With("reason", reason).Add(1)
+			ac.logger.Debug("Rejected unauthenticated RPC request", "remote", r.RemoteAddr, "err", err)
+			w.Header().Set("WWW-Authenticate", "Bearer, HMAC")
+			WriteRPCResponseHTTPError(w, http.StatusUnauthorized,
+				types.RPCServerError(types.JSONRPCIntID(-1), err))
+			return
+		}
+
+		access := &clientAccess{ac: ac, key: key, ip: remoteIP(r)}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), accessContextKey{}, access)))
+	})
+}
+
+// authenticate returns the key the request is made with, nil if it carries
+// no credentials, or the reason the credentials were rejected.
+func (ac *AccessControl) authenticate(r *http.Request) (*apiKeyState, string, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return nil, "", nil
+	}
+	scheme, creds, _ := strings.Cut(header, " ")
+	creds = strings.TrimSpace(creds)
+
+	switch strings.ToLower(scheme) {
+	case APIKeyTypeBearer:
+		key, ok := ac.bearer[sha256.Sum256([]byte(creds))]
+		if !ok {
+			return nil, "unknown_key", errors.New("invalid bearer token")
+		}
+		return key, "", nil
+
+	case APIKeyTypeHMAC:
+		parts := strings.Split(creds, ":")
+		if len(parts) != 3 {
+			return nil, "malformed", errors.New("malformed HMAC credentials (expected <id>:<timestamp>:<signature>)")
+		}
+		key, ok := ac.hmac[parts[0]]
+		if !ok {
+			return nil, "unknown_key", fmt.Errorf("unknown key %s", parts[0])
+		}
+		timestamp, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return nil, "malformed", fmt.Errorf("malformed timestamp: %w", err)
+		}
+		sig, err := hex.DecodeString(parts[2])
+		if err != nil {
+			return nil, "malformed", fmt.Errorf("malformed signature: %w", err)
+		}
+		skew := ac.now().Sub(time.Unix(timestamp, 0))
+		if skew > MaxHMACClockSkew || skew < -MaxHMACClockSkew {
+			return nil, "expired", fmt.Errorf("timestamp is %v off the server clock", skew)
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, "malformed", fmt.Errorf("error reading request body: %w", err)
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		if !hmac.Equal(sig, HMACSignature(key.Secret, timestamp, r.Method, r.URL.RequestURI(), body)) {
+			return nil, "bad_signature", errors.New("invalid HMAC signature")
+		}
+		return key, "", nil
+
+	default:
+		// Other schemes (e.g. basic auth terminated by a proxy) are not ours to
+		// judge; treat the request as anonymous.
+		return nil, "", nil
+	}
+}
+
+// admit checks whether key (nil if anonymous) may call method from ip and
+// charges the call to the corresponding rate limit. method is empty if it
+// does not exist. It returns the HTTP status code to respond with if not.
+func (ac *AccessControl) admit(key *apiKeyState, ip, method string) (int, error) {
+	keyLabel, methodLabel := anonymousKeyLabel, method
+	if key != nil {
+		keyLabel = key.ID
+	}
+	if method == "" {
+		methodLabel = unknownMethod
+	}
+
+	if key != nil && method != "" && !key.allows(method) {
+		ac.metrics.ForbiddenRequests.With("key", keyLabel, "method", methodLabel).Add(1)
+		return http.StatusForbidden, fmt.Errorf("method %s is not allowed for key %s", method, key.ID)
+	}
+
+	now := ac.now()
+	var (
+		limit   string
+		allowed bool
+		wait    time.Duration
+	)
+	ac.mtx.Lock()
+	if key != nil {
+		limit = "key"
+		allowed, wait = key.bucket.take(now)
+	} else {
+		limit = "ip"
+		allowed, wait = ac.ipBucket(ip, now).take(now)
+	}
+	ac.mtx.Unlock()
+	if !allowed {
+		ac.metrics.RateLimited.With("limit", limit).Add(1)
+		return http.StatusTooManyRequests, rateLimitError{limit: limit, retryAfter: wait}
+	}
+
+	ac.metrics.Requests.With("key", keyLabel, "method", methodLabel).Add(1)
+	return http.StatusOK, nil
+}
+
+// ipBucket returns the bucket of ip (nil if unlimited) and garbage collects
+// the buckets, which have been idle long enough to be full again.
+// CONTRACT: ac.mtx is held.
+func (ac *AccessControl) ipBucket(ip string, now time.Time) *tokenBucket {
+	if ac.config.IPRate <= 0 {
+		return nil
+	}
+	if now.Sub(ac.lastSweep) >= ipBucketSweepInterval {
+		for addr, b := range ac.ipBuckets {
+			if b.refill(now); b.tokens >= b.burst {
+				delete(ac.ipBuckets, addr)
+			}
+		}
+		ac.lastSweep = now
+	}
+	b, ok := ac.ipBuckets[ip]
+	if !ok {
+		b = newTokenBucket(ac.config.IPRate, ac.config.IPBurst, now)
+		ac.ipBuckets[ip] = b
+	}
+	return b
+}
+
+// rateLimitError is returned when a call exceeds a rate limit.
+type rateLimitError struct {
+	limit      string
+	retryAfter time.Duration
+}
+
+func (e rateLimitError) Error() string {
+	return fmt.Sprintf("%s rate limit exceeded, retry after %v", e.limit, e.retryAfter)
+}
+
+// writeAccessError writes the response to a call rejected by
+// AccessControl.admit.
+func writeAccessError(w http.ResponseWriter, code int, err error, res types.RPCResponse) {
+	var rlErr rateLimitError
+	if errors.As(err, &rlErr) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(rlErr.retryAfter.Seconds()))))
+	}
+	WriteRPCResponseHTTPError(w, code, res)
+}
+
+//-----------------------------------------------------------------------------
+
+type accessContextKey struct{}
+
+// clientAccess is the authenticated identity of a request, attached to its
+// context by AccessControl.Handler.
+type clientAccess struct {
+	ac  *AccessControl
+	key *apiKeyState // nil if anonymous
+	ip  string
+}
+
+func accessFromContext(ctx context.Context) *clientAccess {
+	access, _ := ctx.Value(accessContextKey{}).(*clientAccess)
+	return access
+}
+
+// admit admits a call of method (empty if it does not exist). A nil
+// clientAccess, i.e. no access control configured, admits everything.
+func (ca *clientAccess) admit(method string) (int, error) {
+	if ca == nil {
+		return http.StatusOK, nil
+	}
+	return ca.ac.admit(ca.key, ca.ip, method)
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+//-----------------------------------------------------------------------------
+
+// tokenBucket holds up to burst tokens and is refilled with rate tokens per
+// second. Every call takes one token. Not goroutine-safe.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a full bucket, or nil (unlimited) if rate is 0. A
+// zero burst defaults to one second worth of tokens.
+func newTokenBucket(rate float64, burst int, now time.Time) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	b := float64(burst)
+	if b == 0 {
+		b = math.Max(1, math.Ceil(rate))
+	}
+	return &tokenBucket{rate: rate, burst: b, tokens: b, last: now}
+}
+
+func (tb *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(tb.last).Seconds(); elapsed > 0 {
+		tb.tokens = math.Min(tb.burst, tb.tokens+elapsed*tb.rate)
+		tb.last = now
+	}
+}
+
+// take takes a token. If the bucket is empty, it returns false and the time
+// until the next token is available.
+func (tb *tokenBucket) take(now time.Time) (bool, time.Duration) {
+	if tb == nil {
+		return true, 0
+	}
+	tb.refill(now)
+	if tb.tokens >= 1 {
+		tb.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - tb.tokens) / tb.rate * float64(time.Second))
+}
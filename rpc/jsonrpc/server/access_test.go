@@ -0,0 +1,261 @@
+package server
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arcology-network/consensus-engine/libs/log"
+	types "github.com/arcology-network/consensus-engine/rpc/jsonrpc/types"
+)
+
+var testAPIKeys = []APIKey{
+	{ID: "full", Type: APIKeyTypeBearer, Secret: "full-token"},
+	{ID: "status-only", Type: APIKeyTypeBearer, Secret: "status-token", Methods: []string{"status"}},
+	{ID: "signer", Type: APIKeyTypeHMAC, Secret: "hmac-secret"},
+	{ID: "slow", Type: APIKeyTypeBearer, Secret: "slow-token", Rate: 1, Burst: 2},
+}
+
+func newAccessTestServer(t *testing.T, config AccessConfig) (*httptest.Server, *AccessControl) {
+	t.Helper()
+	ac, err := NewAccessControl(config, nil)
+	require.NoError(t, err)
+
+	funcMap := map[string]*RPCFunc{
+		"status": NewRPCFunc(func(ctx *types.Context) (string, error) { return "ok", nil }, ""),
+		"block":  NewRPCFunc(func(ctx *types.Context, height int64) (string, error) { return "block", nil }, "height"),
+		"ws":     NewWSRPCFunc(func(ctx *types.Context) (string, error) { return "ws", nil }, ""),
+	}
+	wm := NewWebsocketManager(funcMap)
+	wm.SetLogger(log.TestingLogger())
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger())
+
+	s := httptest.NewServer(ac.Handler(mux))
+	t.Cleanup(s.Close)
+	return s, ac
+}
+
+func doRequest(t *testing.T, method, url, body string, header http.Header) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	bz, err := ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+	return res, string(bz)
+}
+
+func bearer(token string) http.Header {
+	return http.Header{"Authorization": []string{"Bearer " + token}}
+}
+
+func TestAccessControlBearer(t *testing.T) {
+	s, _ := newAccessTestServer(t, AccessConfig{Keys: testAPIKeys, AuthRequired: true})
+
+	res, _ := doRequest(t, http.MethodGet, s.URL+"/status", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+	res, _ = doRequest(t, http.MethodGet, s.URL+"/status", "", bearer("wrong"))
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+	res, body := doRequest(t, http.MethodGet, s.URL+"/status", "", bearer("full-token"))
+	assert.Equal(t, http.StatusOK, res.StatusCode, body)
+	res, body = doRequest(t, http.MethodGet, s.URL+"/block?height=1", "", bearer("full-token"))
+	assert.Equal(t, http.StatusOK, res.StatusCode, body)
+
+	// allowlist
+	res, body = doRequest(t, http.MethodGet, s.URL+"/status", "", bearer("status-token"))
+	assert.Equal(t, http.StatusOK, res.StatusCode, body)
+	res, body = doRequest(t, http.MethodGet, s.URL+"/block?height=1", "", bearer("status-token"))
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	assert.Contains(t, body, "not allowed")
+
+	// a batch is rejected as a whole
+	batch := `[{"jsonrpc":"2.0","id":1,"method":"status"},{"jsonrpc":"2.0","id":2,"method":"block","params":{"height":"1"}}]`
+	res, _ = doRequest(t, http.MethodPost, s.URL, batch, bearer("status-token"))
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	res, body = doRequest(t, http.MethodPost, s.URL, batch, bearer("full-token"))
+	assert.Equal(t, http.StatusOK, res.StatusCode, body)
+}
+
+func TestAccessControlHMAC(t *testing.T) {
+	s, ac := newAccessTestServer(t, AccessConfig{Keys: testAPIKeys, AuthRequired: true})
+	now := time.Now()
+	ac.now = func() time.Time { return now }
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"status"}`
+	sign := func(id, secret string, ts time.Time, body string) http.Header {
+		sig := HMACSignature(secret, ts.Unix(), http.MethodPost, "/", []byte(body))
+		return http.Header{"Authorization": []string{
+			fmt.Sprintf("HMAC %s:%d:%s", id, ts.Unix(), hex.EncodeToString(sig)),
+		}}
+	}
+
+	res, resBody := doRequest(t, http.MethodPost, s.URL, body, sign("signer", "hmac-secret", now, body))
+	assert.Equal(t, http.StatusOK, res.StatusCode, resBody)
+	assert.Contains(t, resBody, `"ok"`)
+
+	testCases := map[string]http.Header{
+		"wrong secret":   sign("signer", "other", now, body),
+		"unknown key":    sign("nobody", "hmac-secret", now, body),
+		"tampered body":  sign("signer", "hmac-secret", now, `{"jsonrpc":"2.0","id":1,"method":"block"}`),
+		"too old":        sign("signer", "hmac-secret", now.Add(-2*MaxHMACClockSkew), body),
+		"bearer as hmac": {"Authorization": []string{"HMAC full-token"}},
+	}
+	for name, header := range testCases {
+		res, _ := doRequest(t, http.MethodPost, s.URL, body, header)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode, name)
+	}
+}
+
+func TestAccessControlRateLimit(t *testing.T) {
+	s, ac := newAccessTestServer(t, AccessConfig{Keys: testAPIKeys, IPRate: 1, IPBurst: 3})
+	now := time.Now()
+	ac.now = func() time.Time { return now }
+
+	// per key
+	for i := 0; i < 2; i++ {
+		res, body := doRequest(t, http.MethodGet, s.URL+"/status", "", bearer("slow-token"))
+		require.Equal(t, http.StatusOK, res.StatusCode, body)
+	}
+	res, body := doRequest(t, http.MethodGet, s.URL+"/status", "", bearer("slow-token"))
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	assert.Equal(t, "1", res.Header.Get("Retry-After"))
+	assert.Contains(t, body, "rate limit exceeded")
+
+	// keys without a rate are unlimited
+	for i := 0; i < 10; i++ {
+		res, body := doRequest(t, http.MethodGet, s.URL+"/status", "", bearer("full-token"))
+		require.Equal(t, http.StatusOK, res.StatusCode, body)
+	}
+
+	// anonymous requests are limited per IP
+	for i := 0; i < 3; i++ {
+		res, body := doRequest(t, http.MethodGet, s.URL+"/status", "", nil)
+		require.Equal(t, http.StatusOK, res.StatusCode, body)
+	}
+	res, _ = doRequest(t, http.MethodGet, s.URL+"/status", "", nil)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+
+	// buckets refill over time
+	now = now.Add(time.Second)
+	res, body = doRequest(t, http.MethodGet, s.URL+"/status", "", bearer("slow-token"))
+	assert.Equal(t, http.StatusOK, res.StatusCode, body)
+	res, body = doRequest(t, http.MethodGet, s.URL+"/status", "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, body)
+
+	// idle buckets are garbage collected
+	now = now.Add(ipBucketSweepInterval)
+	ac.mtx.Lock()
+	ac.ipBucket("10.0.0.1", now)
+	assert.Len(t, ac.ipBuckets, 1)
+	ac.mtx.Unlock()
+}
+
+func TestAccessControlWebsocket(t *testing.T) {
+	s, _ := newAccessTestServer(t, AccessConfig{Keys: testAPIKeys, AuthRequired: true})
+	addr := "ws://" + s.Listener.Addr().String() + "/websocket"
+
+	_, dialResp, err := websocket.DefaultDialer.Dial(addr, nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, dialResp.StatusCode)
+	dialResp.Body.Close()
+
+	c, dialResp, err := websocket.DefaultDialer.Dial(addr, bearer("status-token"))
+	require.NoError(t, err)
+	defer c.Close()
+	dialResp.Body.Close()
+
+	call := func(method string) types.RPCResponse {
+		require.NoError(t, c.WriteJSON(types.RPCRequest{
+			JSONRPC: "2.0", ID: types.JSONRPCStringID(method), Method: method,
+		}))
+		var resp types.RPCResponse
+		require.NoError(t, c.ReadJSON(&resp))
+		return resp
+	}
+
+	assert.Nil(t, call("status").Error)
+	resp := call("ws")
+	require.NotNil(t, resp.Error)
+	assert.Contains(t, resp.Error.Data, "not allowed")
+}
+
+func TestNewAccessControl(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config AccessConfig
+	}{
+		{"no id", AccessConfig{Keys: []APIKey{{Type: APIKeyTypeBearer, Secret: "s"}}}},
+		{"bad type", AccessConfig{Keys: []APIKey{{ID: "a", Type: "basic", Secret: "s"}}}},
+		{"no secret", AccessConfig{Keys: []APIKey{{ID: "a", Type: APIKeyTypeHMAC}}}},
+		{"duplicate id", AccessConfig{Keys: []APIKey{
+			{ID: "a", Type: APIKeyTypeBearer, Secret: "s1"},
+			{ID: "a", Type: APIKeyTypeHMAC, Secret: "s2"},
+		}}},
+		{"duplicate token", AccessConfig{Keys: []APIKey{
+			{ID: "a", Type: APIKeyTypeBearer, Secret: "s"},
+			{ID: "b", Type: APIKeyTypeBearer, Secret: "s"},
+		}}},
+		{"negative key rate", AccessConfig{Keys: []APIKey{{ID: "a", Type: APIKeyTypeBearer, Secret: "s", Rate: -1}}}},
+		{"negative ip rate", AccessConfig{IPRate: -1}},
+	}
+	for _, tc := range testCases {
+		_, err := NewAccessControl(tc.config, nil)
+		assert.Error(t, err, tc.name)
+	}
+}
+
+func TestLoadAPIKeys(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "keys.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"keys": [
+		{"id": "a", "type": "bearer", "secret": "s", "methods": ["status"], "rate": 0.5, "burst": 5}
+	]}`), 0600))
+
+	keys, err := LoadAPIKeys(file)
+	require.NoError(t, err)
+	assert.Equal(t, []APIKey{
+		{ID: "a", Type: APIKeyTypeBearer, Secret: "s", Methods: []string{"status"}, Rate: 0.5, Burst: 5},
+	}, keys)
+
+	require.NoError(t, os.WriteFile(file, []byte(`{"keys": [`), 0600))
+	_, err = LoadAPIKeys(file)
+	assert.Error(t, err)
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	assert.Nil(t, newTokenBucket(0, 10, now))
+
+	tb := newTokenBucket(2, 0, now)
+	for i := 0; i < 2; i++ {
+		ok, _ := tb.take(now)
+		assert.True(t, ok, strconv.Itoa(i))
+	}
+	ok, wait := tb.take(now)
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	ok, _ = tb.take(now.Add(500 * time.Millisecond))
+	assert.True(t, ok)
+}
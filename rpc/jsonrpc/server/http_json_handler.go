@@ -7,7 +7,9 @@ import (
 	"io/ioutil"
 	"net/http"
 	"reflect"
+	"runtime/debug"
 	"sort"
+	"sync"
 
 	tmjson "github.com/arcology-network/consensus-engine/libs/json"
 	"github.com/arcology-network/consensus-engine/libs/log"
@@ -16,8 +18,34 @@ import (
 
 // HTTP + JSON handler
 
+// batchLimits bounds the work done on behalf of a batch request.
+type batchLimits struct {
+	// maximum number of requests in a batch, 0 means unlimited
+	maxSize int
+	// number of requests of a batch executed at the same time
+	concurrency int
+}
+
+// MaxBatchSize sets the maximum number of requests in a JSON-RPC batch. Larger
+// batches are rejected as a whole. 0 (default) means unlimited.
+func MaxBatchSize(maxSize int) func(*batchLimits) {
+	return func(l *batchLimits) {
+		l.maxSize = maxSize
+	}
+}
+
+// BatchConcurrency sets the number of requests of a JSON-RPC batch, which are
+// executed at the same time. The responses are returned in the order of the
+// requests regardless. 1 (default) executes the requests one after another.
+// See Ordered for the functions, which are never executed concurrently.
+func BatchConcurrency(concurrency int) func(*batchLimits) {
+	return func(l *batchLimits) {
+		l.concurrency = concurrency
+	}
+}
+
 // jsonrpc calls grab the given method's function info and runs reflect.Call
-func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.HandlerFunc {
+func makeJSONRPCHandler(funcMap map[string]*RPCFunc, limits batchLimits, logger log.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		b, err := ioutil.ReadAll(r.Body)
 		if err != nil {
@@ -40,10 +68,7 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 		}
 
 		// first try to unmarshal the incoming request as an array of RPC requests
-		var (
-			requests  []types.RPCRequest
-			responses []types.RPCResponse
-		)
+		var requests []types.RPCRequest
 		if err := json.Unmarshal(b, &requests); err != nil {
 			// next, try to unmarshal as a single request
 			var request types.RPCRequest
@@ -60,8 +85,47 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 			requests = []types.RPCRequest{request}
 		}
 
-		for _, request := range requests {
-			request := request
+		if limits.maxSize > 0 && len(requests) > limits.maxSize {
+			WriteRPCResponseHTTPError(
+				w,
+				http.StatusBadRequest,
+				types.RPCInvalidRequestError(
+					nil,
+					fmt.Errorf("batch of %d requests exceeds the limit of %d", len(requests), limits.maxSize),
+				),
+			)
+			return
+		}
+
+		// admit the whole batch before executing any of it
+		if access := accessFromContext(r.Context()); access != nil && len(r.URL.Path) <= 1 {
+			for _, request := range requests {
+				if request.ID == nil {
+					continue
+				}
+				method := request.Method
+				if rpcFunc, ok := funcMap[method]; !ok || rpcFunc.ws {
+					method = ""
+				}
+				if code, err := access.admit(method); err != nil {
+					writeAccessError(w, code, err, types.RPCServerError(request.ID, err))
+					return
+				}
+			}
+		}
+
+		var (
+			// nil for notifications
+			responses = make([]*types.RPCResponse, len(requests))
+			// the result of a single request may be cached by the client if
+			// it's immutable
+			cacheable bool
+
+			wg      sync.WaitGroup
+			limiter = newLimiter(limits.concurrency)
+		)
+		for i := range requests {
+			request := &requests[i]
 
 			// A Notification is a Request object without an "id" member.
 			// The Server MUST NOT reply to a Notification, including those that are within a batch request.
@@ -73,43 +137,85 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 				continue
 			}
 			if len(r.URL.Path) > 1 {
-				responses = append(
-					responses,
-					types.RPCInvalidRequestError(request.ID, fmt.Errorf("path %s is invalid", r.URL.Path)),
-				)
+				res := types.RPCInvalidRequestError(request.ID, fmt.Errorf("path %s is invalid", r.URL.Path))
+				responses[i] = &res
 				continue
 			}
 			rpcFunc, ok := funcMap[request.Method]
 			if !ok || rpcFunc.ws {
-				responses = append(responses, types.RPCMethodNotFoundError(request.ID))
+				res := types.RPCMethodNotFoundError(request.ID)
+				responses[i] = &res
 				continue
 			}
-			ctx := &types.Context{JSONReq: &request, HTTPReq: r}
-			args := []reflect.Value{reflect.ValueOf(ctx)}
-			if len(request.Params) > 0 {
-				fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
-				if err != nil {
-					responses = append(
-						responses,
-						types.RPCInvalidParamsError(request.ID, fmt.Errorf("error converting json params to arguments: %w", err)),
-					)
-					continue
-				}
-				args = append(args, fnArgs...)
-			}
-			returns := rpcFunc.f.Call(args)
-			logger.Info("HTTPJSONRPC", "method", request.Method, "args", args, "returns", returns)
-			result, err := unreflectResult(returns)
-			if err != nil {
-				responses = append(responses, types.RPCInternalError(request.ID, err))
+
+			limiter.acquire(rpcFunc)
+			if len(requests) == 1 || limiter.sequential(rpcFunc) {
+				res, immutable := executeJSONRPCRequest(r, rpcFunc, request, logger)
+				limiter.release(rpcFunc)
+				responses[i] = &res
+				cacheable = len(requests) == 1 && immutable
 				continue
 			}
-			responses = append(responses, types.NewRPCSuccessResponse(request.ID, result))
+
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				defer limiter.release(rpcFunc)
+				defer func() {
+					if e := recover(); e != nil {
+						logger.Error("Panic in HTTPJSONRPC batch", "err", e, "stack", string(debug.Stack()))
+						res := types.RPCInternalError(request.ID, fmt.Errorf("%v", e))
+						responses[i] = &res
+					}
+				}()
+				res, _ := executeJSONRPCRequest(r, rpcFunc, request, logger)
+				responses[i] = &res
+			}(i)
+		}
+		wg.Wait()
+
+		if cacheable {
+			WriteCacheableRPCResponseHTTP(w, r, *responses[0])
+			return
+		}
+		written := make([]types.RPCResponse, 0, len(responses))
+		for _, res := range responses {
+			if res != nil {
+				written = append(written, *res)
+			}
+		}
+		if len(written) > 0 {
+			WriteRPCResponseHTTP(w, written...)
 		}
-		if len(responses) > 0 {
-			WriteRPCResponseHTTP(w, responses...)
+	}
+}
+
+// executeJSONRPCRequest calls the function of a request and returns the
+// response, along with whether the result is immutable.
+func executeJSONRPCRequest(
+	r *http.Request,
+	rpcFunc *RPCFunc,
+	request *types.RPCRequest,
+	logger log.Logger,
+) (types.RPCResponse, bool) {
+	ctx := &types.Context{JSONReq: request, HTTPReq: r}
+	args := []reflect.Value{reflect.ValueOf(ctx)}
+	if len(request.Params) > 0 {
+		fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
+		if err != nil {
+			return types.RPCInvalidParamsError(
+				request.ID,
+				fmt.Errorf("error converting json params to arguments: %w", err),
+			), false
 		}
+		args = append(args, fnArgs...)
+	}
+	result, err := rpcFunc.call(ctx, args)
+	logger.Info("HTTPJSONRPC", "method", request.Method, "args", args, "result", result, "err", err)
+	if err != nil {
+		return types.RPCInternalError(request.ID, err), false
 	}
+	return types.NewRPCSuccessResponse(request.ID, result), ctx.IsImmutable()
 }
 
 func handleInvalidJSONRPCPaths(next http.HandlerFunc) http.HandlerFunc {
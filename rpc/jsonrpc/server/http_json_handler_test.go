@@ -3,11 +3,14 @@ package server
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,6 +22,13 @@ import (
 func testMux() *http.ServeMux {
 	funcMap := map[string]*RPCFunc{
 		"c": NewRPCFunc(func(ctx *types.Context, s string, i int) (string, error) { return "foo", nil }, "s,i"),
+		"immutable": NewRPCFunc(func(ctx *types.Context, i int) (int, error) {
+			if i < 0 {
+				return 0, errors.New("negative")
+			}
+			ctx.SetImmutable()
+			return i, nil
+		}, "i"),
 	}
 	mux := http.NewServeMux()
 	buf := new(bytes.Buffer)
@@ -227,3 +237,153 @@ func TestUnknownRPCPath(t *testing.T) {
 	require.Equal(t, http.StatusNotFound, res.StatusCode, "should always return 404")
 	res.Body.Close()
 }
+
+func TestImmutableResponse(t *testing.T) {
+	mux := testMux()
+	tests := []struct {
+		method    string
+		payload   string
+		cacheable bool
+	}{
+		{"GET", "/immutable?i=1", true},
+		{"GET", "/immutable?i=-1", false},
+		{"GET", "/c?s=%22a%22&i=1", false},
+		{"POST", `{"jsonrpc": "2.0", "method": "immutable", "id": 0, "params": {"i": "1"}}`, true},
+		{"POST", `{"jsonrpc": "2.0", "method": "immutable", "id": 0, "params": {"i": "-1"}}`, false},
+		{"POST", `[{"jsonrpc": "2.0", "method": "immutable", "id": 0, "params": {"i": "1"}}]`, true},
+		{"POST", `[
+			{"jsonrpc": "2.0", "method": "immutable", "id": 0, "params": {"i": "1"}},
+			{"jsonrpc": "2.0", "method": "immutable", "id": 1, "params": {"i": "2"}}
+		]`, false},
+	}
+
+	for i, tt := range tests {
+		var req *http.Request
+		if tt.method == "GET" {
+			req = httptest.NewRequest("GET", "http://localhost"+tt.payload, nil)
+		} else {
+			req = httptest.NewRequest("POST", "http://localhost/", strings.NewReader(tt.payload))
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		etag := rec.Header().Get("ETag")
+		if !tt.cacheable {
+			assert.Empty(t, etag, "#%d", i)
+			assert.Empty(t, rec.Header().Get("Cache-Control"), "#%d", i)
+			continue
+		}
+		require.NotEmpty(t, etag, "#%d", i)
+		assert.Equal(t, CacheControlImmutable, rec.Header().Get("Cache-Control"), "#%d", i)
+	}
+}
+
+func TestBatchLimits(t *testing.T) {
+	var (
+		mtx              sync.Mutex
+		running, maxSeen int
+		started          = make(chan struct{}, 10)
+	)
+	track := func(d time.Duration) int {
+		mtx.Lock()
+		running++
+		if running > maxSeen {
+			maxSeen = running
+		}
+		n := running
+		mtx.Unlock()
+		started <- struct{}{}
+		time.Sleep(d)
+		mtx.Lock()
+		running--
+		mtx.Unlock()
+		return n
+	}
+	funcMap := map[string]*RPCFunc{
+		"slow": NewRPCFunc(func(ctx *types.Context, i int) (int, error) {
+			track(50 * time.Millisecond)
+			return i, nil
+		}, "i"),
+		"ordered": NewRPCFunc(func(ctx *types.Context, i int) (int, error) {
+			// no other request is running
+			return track(0), nil
+		}, "i", Ordered()),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger(), MaxBatchSize(5), BatchConcurrency(2))
+
+	call := func(payload string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "http://localhost/", strings.NewReader(payload))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := call(`[
+		{"jsonrpc": "2.0", "method": "slow", "id": 0, "params": {"i": "0"}},
+		{"jsonrpc": "2.0", "method": "slow", "id": 1, "params": {"i": "1"}},
+		{"jsonrpc": "2.0", "method": "ordered", "id": 2, "params": {"i": "2"}},
+		{"jsonrpc": "2.0", "method": "slow", "id": 3, "params": {"i": "3"}},
+		{"jsonrpc": "2.0", "method": "slow", "id": 4, "params": {"i": "4"}}
+	]`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var responses []types.RPCResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responses))
+	require.Len(t, responses, 5)
+	for i, res := range responses {
+		require.Nil(t, res.Error, "#%d", i)
+		assert.Equal(t, types.JSONRPCIntID(i), res.ID, "responses are in the order of the requests")
+	}
+	assert.Equal(t, `"1"`, string(responses[2].Result), "ordered request ran concurrently")
+	assert.Equal(t, 2, maxSeen)
+
+	// too large
+	rec = call(`[
+		{"jsonrpc": "2.0", "method": "slow", "id": 0, "params": {"i": "0"}},
+		{"jsonrpc": "2.0", "method": "slow", "id": 1, "params": {"i": "1"}},
+		{"jsonrpc": "2.0", "method": "slow", "id": 2, "params": {"i": "2"}},
+		{"jsonrpc": "2.0", "method": "slow", "id": 3, "params": {"i": "3"}},
+		{"jsonrpc": "2.0", "method": "slow", "id": 4, "params": {"i": "4"}},
+		{"jsonrpc": "2.0", "method": "slow", "id": 5, "params": {"i": "5"}}
+	]`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "exceeds the limit of 5")
+	assert.Len(t, started, 5, "rejected batch was executed")
+}
+
+func TestMethodTimeout(t *testing.T) {
+	funcMap := WithTimeouts(map[string]*RPCFunc{
+		"block": NewRPCFunc(func(ctx *types.Context) (string, error) {
+			<-ctx.Context().Done()
+			return "", ctx.Context().Err()
+		}, ""),
+		"fast":  NewRPCFunc(func(ctx *types.Context) (string, error) { return "foo", nil }, ""),
+		"panic": NewRPCFunc(func(ctx *types.Context) (string, error) { panic("boom") }, ""),
+	}, 10*time.Millisecond, map[string]time.Duration{"fast": 0})
+	assert.Equal(t, time.Duration(0), funcMap["fast"].timeout)
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger())
+
+	req := httptest.NewRequest("POST", "http://localhost/",
+		strings.NewReader(`{"jsonrpc": "2.0", "method": "block", "id": 0}`))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	var res types.RPCResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	require.NotNil(t, res.Error)
+	assert.Contains(t, res.Error.Data, "method timed out after 10ms")
+
+	req = httptest.NewRequest("GET", "http://localhost/block", nil)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "method timed out after 10ms")
+
+	req = httptest.NewRequest("GET", "http://localhost/fast", nil)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// the panic is propagated to RecoverAndLogHandler
+	req = httptest.NewRequest("GET", "http://localhost/panic", nil)
+	assert.Panics(t, func() { mux.ServeHTTP(httptest.NewRecorder(), req) })
+}
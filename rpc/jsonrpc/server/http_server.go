@@ -3,6 +3,8 @@ package server
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,6 +21,10 @@ import (
 	types "github.com/arcology-network/consensus-engine/rpc/jsonrpc/types"
 )
 
+// CacheControlImmutable is the Cache-Control header of the responses written
+// by WriteCacheableRPCResponseHTTP.
+const CacheControlImmutable = "public, max-age=86400, immutable"
+
 // Config is a RPC server configuration.
 type Config struct {
 	// see netutil.LimitListener
@@ -131,6 +137,45 @@ func WriteRPCResponseHTTP(w http.ResponseWriter, res ...types.RPCResponse) {
 	}
 }
 
+// WriteCacheableRPCResponseHTTP marshals res as JSON and writes it to w with
+// an ETag and a Cache-Control header allowing clients to cache it. The ETag
+// only depends on the result, not on the request ID. If the
+// request's If-None-Match header matches the ETag, only the status 304 Not
+// Modified is written. It must only be used for immutable results.
+//
+// Panics if it can't Marshal res or write to w.
+func WriteCacheableRPCResponseHTTP(w http.ResponseWriter, r *http.Request, res types.RPCResponse) {
+	jsonBytes, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	hash := sha256.Sum256(res.Result)
+	etag := `"` + hex.EncodeToString(hash[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", CacheControlImmutable)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	if _, err := w.Write(jsonBytes); err != nil {
+		panic(err)
+	}
+}
+
+// etagMatches returns true if the If-None-Match header value contains etag.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == etag || candidate == "W/"+etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
 //-----------------------------------------------------------------------------
 
 // RecoverAndLogHandler wraps an HTTP handler, adding error logging.
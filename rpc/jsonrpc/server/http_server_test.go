@@ -157,6 +157,48 @@ func TestWriteRPCResponseHTTP(t *testing.T) {
 ]`, string(body))
 }
 
+func TestWriteCacheableRPCResponseHTTP(t *testing.T) {
+	id := types.JSONRPCIntID(-1)
+	res := types.NewRPCSuccessResponse(id, &sampleResult{"hello"})
+
+	w := httptest.NewRecorder()
+	WriteCacheableRPCResponseHTTP(w, httptest.NewRequest("GET", "/", nil), res)
+	resp := w.Result()
+	body, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, CacheControlImmutable, resp.Header.Get("Cache-Control"))
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Contains(t, string(body), `"value": "hello"`)
+
+	for _, ifNoneMatch := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("If-None-Match", ifNoneMatch)
+		w = httptest.NewRecorder()
+		WriteCacheableRPCResponseHTTP(w, r, res)
+		assert.Equal(t, http.StatusNotModified, w.Code, ifNoneMatch)
+		assert.Empty(t, w.Body.Bytes(), ifNoneMatch)
+		assert.Equal(t, etag, w.Header().Get("ETag"), ifNoneMatch)
+	}
+
+	// the same result requested with another ID has the same ETag
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	WriteCacheableRPCResponseHTTP(w, r, types.NewRPCSuccessResponse(types.JSONRPCStringID("other"), &sampleResult{"hello"}))
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	// another result has another ETag
+	r = httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	WriteCacheableRPCResponseHTTP(w, r, types.NewRPCSuccessResponse(id, &sampleResult{"world"}))
+	assert.Equal(t, 200, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+}
+
 func TestWriteRPCResponseHTTPError(t *testing.T) {
 	w := httptest.NewRecorder()
 	WriteRPCResponseHTTPError(w,
@@ -34,6 +34,12 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 	return func(w http.ResponseWriter, r *http.Request) {
 		logger.Debug("HTTP HANDLER", "req", r)
 
+		method := strings.TrimPrefix(r.URL.Path, "/")
+		if code, err := accessFromContext(r.Context()).admit(method); err != nil {
+			writeAccessError(w, code, err, types.RPCServerError(dummyID, err))
+			return
+		}
+
 		ctx := &types.Context{HTTPReq: r}
 		args := []reflect.Value{reflect.ValueOf(ctx)}
 
@@ -51,15 +57,17 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 		}
 		args = append(args, fnArgs...)
 
-		returns := rpcFunc.f.Call(args)
-
-		logger.Debug("HTTPRestRPC", "method", r.URL.Path, "args", args, "returns", returns)
-		result, err := unreflectResult(returns)
+		result, err := rpcFunc.call(ctx, args)
+		logger.Debug("HTTPRestRPC", "method", r.URL.Path, "args", args, "result", result, "err", err)
 		if err != nil {
 			WriteRPCResponseHTTPError(w, http.StatusInternalServerError,
 				types.RPCInternalError(dummyID, err))
 			return
 		}
+		if ctx.IsImmutable() {
+			WriteCacheableRPCResponseHTTP(w, r, types.NewRPCSuccessResponse(dummyID, result))
+			return
+		}
 		WriteRPCResponseHTTP(w, types.NewRPCSuccessResponse(dummyID, result))
 	}
 }
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "rpc"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of requests admitted by the access control, per key and method.
+	Requests metrics.Counter
+	// Number of requests rejected because of missing or invalid credentials.
+	AuthFailures metrics.Counter
+	// Number of requests rejected by a rate limit.
+	RateLimited metrics.Counter
+	// Number of requests rejected by a key's method allowlist.
+	ForbiddenRequests metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		Requests: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "requests_total",
+			Help:      "Number of requests admitted by the access control.",
+		}, append(labels, "key", "method")).With(labelsAndValues...),
+		AuthFailures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "auth_failures_total",
+			Help:      "Number of requests rejected because of missing or invalid credentials.",
+		}, append(labels, "reason")).With(labelsAndValues...),
+		RateLimited: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rate_limited_total",
+			Help:      "Number of requests rejected by a rate limit.",
+		}, append(labels, "limit")).With(labelsAndValues...),
+		ForbiddenRequests: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "forbidden_requests_total",
+			Help:      "Number of requests rejected by a key's method allowlist.",
+		}, append(labels, "key", "method")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Requests:          discard.NewCounter(),
+		AuthFailures:      discard.NewCounter(),
+		RateLimited:       discard.NewCounter(),
+		ForbiddenRequests: discard.NewCounter(),
+	}
+}
@@ -1,26 +1,44 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/arcology-network/consensus-engine/libs/log"
+	types "github.com/arcology-network/consensus-engine/rpc/jsonrpc/types"
 )
 
+// ErrMethodTimeout is returned when a function doesn't complete within its
+// timeout (see WithTimeouts).
+var ErrMethodTimeout = errors.New("method timed out")
+
 // RegisterRPCFuncs adds a route for each function in the funcMap, as well as
 // general jsonrpc and websocket handlers for all functions. "result" is the
 // interface on which the result objects are registered, and is popualted with
 // every RPCResponse
-func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, logger log.Logger) {
+func RegisterRPCFuncs(
+	mux *http.ServeMux,
+	funcMap map[string]*RPCFunc,
+	logger log.Logger,
+	options ...func(*batchLimits),
+) {
+	limits := batchLimits{concurrency: 1}
+	for _, option := range options {
+		option(&limits)
+	}
+
 	// HTTP endpoints
 	for funcName, rpcFunc := range funcMap {
 		mux.HandleFunc("/"+funcName, makeHTTPHandler(rpcFunc, logger))
 	}
 
 	// JSONRPC endpoints
-	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, logger)))
+	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, limits, logger)))
 }
 
 // Function introspection
@@ -32,33 +50,142 @@ type RPCFunc struct {
 	returns  []reflect.Type // type of each return arg
 	argNames []string       // name of each argument
 	ws       bool           // websocket only
+	ordered  bool           // not executed concurrently with other requests
+	timeout  time.Duration  // 0 if the function may run indefinitely
 }
 
 // NewRPCFunc wraps a function for introspection.
 // f is the function, args are comma separated argument names
-func NewRPCFunc(f interface{}, args string) *RPCFunc {
-	return newRPCFunc(f, args, false)
+func NewRPCFunc(f interface{}, args string, options ...func(*RPCFunc)) *RPCFunc {
+	return newRPCFunc(f, args, false, options...)
 }
 
 // NewWSRPCFunc wraps a function for introspection and use in the websockets.
-func NewWSRPCFunc(f interface{}, args string) *RPCFunc {
-	return newRPCFunc(f, args, true)
+// Websocket only functions are always Ordered.
+func NewWSRPCFunc(f interface{}, args string, options ...func(*RPCFunc)) *RPCFunc {
+	return newRPCFunc(f, args, true, options...)
 }
 
-func newRPCFunc(f interface{}, args string, ws bool) *RPCFunc {
+func newRPCFunc(f interface{}, args string, ws bool, options ...func(*RPCFunc)) *RPCFunc {
 	var argNames []string
 	if args != "" {
 		argNames = strings.Split(args, ",")
 	}
-	return &RPCFunc{
+	rpcFunc := &RPCFunc{
 		f:        reflect.ValueOf(f),
 		args:     funcArgTypes(f),
 		returns:  funcReturnTypes(f),
 		argNames: argNames,
 		ws:       ws,
+		ordered:  ws,
+	}
+	for _, option := range options {
+		option(rpcFunc)
+	}
+	return rpcFunc
+}
+
+// Ordered marks a function with side effects (e.g. broadcasting a
+// transaction). Its calls are never executed concurrently with the other
+// requests of a batch or a websocket connection: they wait for the requests
+// received before them to complete, and the requests received after them wait
+// for them.
+func Ordered() func(*RPCFunc) {
+	return func(f *RPCFunc) {
+		f.ordered = true
+	}
+}
+
+// WithTimeouts returns a copy of funcMap, in which the functions fail with
+// ErrMethodTimeout if they don't complete within the duration given for them
+// in timeouts, or within defaultTimeout if there's none. A duration of 0
+// means no timeout. Websocket only functions are left as is, since they
+// subscribe for the lifetime of the connection.
+//
+// NOTE: a function, which times out, keeps running in the background until it
+// returns, unless it gives up once its context (types.Context#Context) is
+// done.
+func WithTimeouts(
+	funcMap map[string]*RPCFunc,
+	defaultTimeout time.Duration,
+	timeouts map[string]time.Duration,
+) map[string]*RPCFunc {
+	withTimeouts := make(map[string]*RPCFunc, len(funcMap))
+	for name, rpcFunc := range funcMap {
+		if !rpcFunc.ws {
+			rpcFunc = rpcFunc.withTimeout(defaultTimeout)
+			if timeout, ok := timeouts[name]; ok {
+				rpcFunc.timeout = timeout
+			}
+		}
+		withTimeouts[name] = rpcFunc
+	}
+	return withTimeouts
+}
+
+func (f *RPCFunc) withTimeout(timeout time.Duration) *RPCFunc {
+	copied := *f
+	copied.timeout = timeout
+	return &copied
+}
+
+// call calls the function with the given arguments, the first of which is
+// ctx, and returns its result. If the function has a timeout, it runs in a
+// separate goroutine and its context is canceled once the timeout expires. A
+// panic is propagated to the caller, unless the function has already timed
+// out.
+func (f *RPCFunc) call(ctx *types.Context, args []reflect.Value) (interface{}, error) {
+	if f.timeout <= 0 {
+		return unreflectResult(f.f.Call(args))
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx.Context(), f.timeout)
+	defer cancel()
+	if ctx.HTTPReq != nil {
+		ctx.HTTPReq = ctx.HTTPReq.WithContext(timeoutCtx)
+	} else if ctx.WSConn != nil {
+		ctx.WSConn = wsConnWithContext{WSRPCConnection: ctx.WSConn, ctx: timeoutCtx}
+	}
+
+	type outcome struct {
+		returns  []reflect.Value
+		panicked interface{}
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- outcome{panicked: r}
+			}
+		}()
+		done <- outcome{returns: f.f.Call(args)}
+	}()
+
+	select {
+	case o := <-done:
+		if o.panicked != nil {
+			panic(o.panicked)
+		}
+		return unreflectResult(o.returns)
+	case <-timeoutCtx.Done():
+		if timeoutCtx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("%w after %v", ErrMethodTimeout, f.timeout)
+		}
+		return nil, timeoutCtx.Err()
 	}
 }
 
+// wsConnWithContext overrides the context of a websocket connection for a
+// single call.
+type wsConnWithContext struct {
+	types.WSRPCConnection
+	ctx context.Context
+}
+
+func (c wsConnWithContext) Context() context.Context {
+	return c.ctx
+}
+
 // return a function's argument types
 func funcArgTypes(f interface{}) []reflect.Type {
 	t := reflect.TypeOf(f)
@@ -96,3 +223,40 @@ func unreflectResult(returns []reflect.Value) (interface{}, error) {
 	rvp.Elem().Set(rv)
 	return rvp.Interface(), nil
 }
+
+// limiter bounds the number of requests of a batch or a websocket connection,
+// which are executed at the same time. An ordered request takes all the slots,
+// so that it waits for the requests before it and delays the ones after it.
+type limiter chan struct{}
+
+func newLimiter(concurrency int) limiter {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return make(limiter, concurrency)
+}
+
+// sequential returns true if a request must be executed by the caller rather
+// than in a separate goroutine.
+func (l limiter) sequential(rpcFunc *RPCFunc) bool {
+	return cap(l) == 1 || rpcFunc.ordered
+}
+
+func (l limiter) acquire(rpcFunc *RPCFunc) {
+	for i := 0; i < l.slots(rpcFunc); i++ {
+		l <- struct{}{}
+	}
+}
+
+func (l limiter) release(rpcFunc *RPCFunc) {
+	for i := 0; i < l.slots(rpcFunc); i++ {
+		<-l
+	}
+}
+
+func (l limiter) slots(rpcFunc *RPCFunc) int {
+	if rpcFunc.ordered {
+		return cap(l)
+	}
+	return 1
+}
@@ -86,6 +86,7 @@ func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Requ
 
 	// register connection
 	con := newWSConnection(wsConn, wm.funcMap, wm.wsConnOptions...)
+	con.access = accessFromContext(r.Context())
 	con.SetLogger(wm.logger.With("remote", wsConn.RemoteAddr()))
 	wm.logger.Info("New websocket connection", "remote", con.remoteAddr)
 	err = con.Start() // BLOCKING
@@ -118,6 +119,10 @@ type wsConnection struct {
 
 	funcMap map[string]*RPCFunc
 
+	// identity the connection was authenticated with, nil if there's no
+	// access control
+	access *clientAccess
+
 	// write channel capacity
 	writeChanCapacity int
 
@@ -136,6 +141,10 @@ type wsConnection struct {
 	// callback which is called upon disconnect
 	onDisconnect func(remoteAddr string)
 
+	// number of requests executed at the same time
+	concurrency int
+	limiter     limiter
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
@@ -159,11 +168,16 @@ func newWSConnection(
 		writeChanCapacity: defaultWSWriteChanCapacity,
 		readWait:          defaultWSReadWait,
 		pingPeriod:        defaultWSPingPeriod,
+		concurrency:       1,
 		readRoutineQuit:   make(chan struct{}),
 	}
 	for _, option := range options {
 		option(wsc)
 	}
+	wsc.limiter = newLimiter(wsc.concurrency)
+	// the context is shared by the requests, which may be executed
+	// concurrently
+	wsc.ctx, wsc.cancel = context.WithCancel(context.Background())
 	wsc.baseConn.SetReadLimit(wsc.readLimit)
 	wsc.BaseService = *service.NewBaseService(nil, "wsConnection", wsc)
 	return wsc
@@ -217,6 +231,18 @@ func ReadLimit(readLimit int64) func(*wsConnection) {
 	}
 }
 
+// RequestConcurrency sets the number of requests of the connection, which are
+// executed at the same time. The responses are sent as soon as they are ready,
+// so they may be out of order. 1 (default) executes the requests one after
+// another. See Ordered for the functions, which are never executed
+// concurrently.
+// It should only be used in the constructor - not Goroutine-safe.
+func RequestConcurrency(concurrency int) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		wsc.concurrency = concurrency
+	}
+}
+
 // OnStart implements service.Service by starting the read and write routines. It
 // blocks until there's some error.
 func (wsc *wsConnection) OnStart() error {
@@ -237,9 +263,7 @@ func (wsc *wsConnection) OnStop() {
 		wsc.onDisconnect(wsc.remoteAddr)
 	}
 
-	if wsc.ctx != nil {
-		wsc.cancel()
-	}
+	wsc.cancel()
 }
 
 // GetRemoteAddr returns the remote address of the underlying connection.
@@ -279,10 +303,6 @@ func (wsc *wsConnection) TryWriteRPCResponse(resp types.RPCResponse) bool {
 // Context returns the connection's context.
 // The context is canceled when the client's connection closes.
 func (wsc *wsConnection) Context() context.Context {
-	if wsc.ctx != nil {
-		return wsc.ctx
-	}
-	wsc.ctx, wsc.cancel = context.WithCancel(context.Background())
 	return wsc.ctx
 }
 
@@ -356,45 +376,82 @@ func (wsc *wsConnection) readRoutine() {
 
 			// Now, fetch the RPCFunc and execute it.
 			rpcFunc := wsc.funcMap[request.Method]
+			method := request.Method
 			if rpcFunc == nil {
-				if err := wsc.WriteRPCResponse(writeCtx, types.RPCMethodNotFoundError(request.ID)); err != nil {
+				method = ""
+			}
+			if _, err := wsc.access.admit(method); err != nil {
+				if err := wsc.WriteRPCResponse(writeCtx, types.RPCServerError(request.ID, err)); err != nil {
 					wsc.Logger.Error("Error writing RPC response", "err", err)
 				}
 				continue
 			}
-
-			ctx := &types.Context{JSONReq: &request, WSConn: wsc}
-			args := []reflect.Value{reflect.ValueOf(ctx)}
-			if len(request.Params) > 0 {
-				fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
-				if err != nil {
-					if err := wsc.WriteRPCResponse(writeCtx,
-						types.RPCInternalError(request.ID, fmt.Errorf("error converting json params to arguments: %w", err)),
-					); err != nil {
-						wsc.Logger.Error("Error writing RPC response", "err", err)
-					}
-					continue
+			if rpcFunc == nil {
+				if err := wsc.WriteRPCResponse(writeCtx, types.RPCMethodNotFoundError(request.ID)); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
 				}
-				args = append(args, fnArgs...)
+				continue
 			}
 
-			returns := rpcFunc.f.Call(args)
-
-			// TODO: Need to encode args/returns to string if we want to log them
-			wsc.Logger.Info("WSJSONRPC", "method", request.Method)
-
-			result, err := unreflectResult(returns)
-			if err != nil {
-				if err := wsc.WriteRPCResponse(writeCtx, types.RPCInternalError(request.ID, err)); err != nil {
-					wsc.Logger.Error("Error writing RPC response", "err", err)
-				}
+			wsc.limiter.acquire(rpcFunc)
+			if wsc.limiter.sequential(rpcFunc) {
+				func() {
+					defer wsc.limiter.release(rpcFunc)
+					wsc.executeRequest(writeCtx, rpcFunc, &request)
+				}()
 				continue
 			}
+			go func(request types.RPCRequest) {
+				defer wsc.limiter.release(rpcFunc)
+				defer func() {
+					if r := recover(); r != nil {
+						err, ok := r.(error)
+						if !ok {
+							err = fmt.Errorf("WSJSONRPC: %v", r)
+						}
+						wsc.Logger.Error("Panic in WSJSONRPC handler", "err", err, "stack", string(debug.Stack()))
+						if err := wsc.WriteRPCResponse(writeCtx, types.RPCInternalError(request.ID, err)); err != nil {
+							wsc.Logger.Error("Error writing RPC response", "err", err)
+						}
+					}
+				}()
+				wsc.executeRequest(writeCtx, rpcFunc, &request)
+			}(request)
+		}
+	}
+}
 
-			if err := wsc.WriteRPCResponse(writeCtx, types.NewRPCSuccessResponse(request.ID, result)); err != nil {
+// executeRequest calls the function of a request and writes the response.
+func (wsc *wsConnection) executeRequest(writeCtx context.Context, rpcFunc *RPCFunc, request *types.RPCRequest) {
+	ctx := &types.Context{JSONReq: request, WSConn: wsc}
+	args := []reflect.Value{reflect.ValueOf(ctx)}
+	if len(request.Params) > 0 {
+		fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
+		if err != nil {
+			if err := wsc.WriteRPCResponse(writeCtx,
+				types.RPCInternalError(request.ID, fmt.Errorf("error converting json params to arguments: %w", err)),
+			); err != nil {
 				wsc.Logger.Error("Error writing RPC response", "err", err)
 			}
+			return
 		}
+		args = append(args, fnArgs...)
+	}
+
+	result, err := rpcFunc.call(ctx, args)
+
+	// TODO: Need to encode args/returns to string if we want to log them
+	wsc.Logger.Info("WSJSONRPC", "method", request.Method)
+
+	if err != nil {
+		if err := wsc.WriteRPCResponse(writeCtx, types.RPCInternalError(request.ID, err)); err != nil {
+			wsc.Logger.Error("Error writing RPC response", "err", err)
+		}
+		return
+	}
+
+	if err := wsc.WriteRPCResponse(writeCtx, types.NewRPCSuccessResponse(request.ID, result)); err != nil {
+		wsc.Logger.Error("Error writing RPC response", "err", err)
 	}
 }
 
@@ -1,14 +1,18 @@
 package server
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/arcology-network/consensus-engine/libs/log"
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
 	types "github.com/arcology-network/consensus-engine/rpc/jsonrpc/types"
 )
 
@@ -54,3 +58,106 @@ func newWSServer() *httptest.Server {
 
 	return httptest.NewServer(mux)
 }
+
+func TestWebsocketRequestConcurrency(t *testing.T) {
+	release := make(chan struct{})
+	funcMap := map[string]*RPCFunc{
+		"wait": NewRPCFunc(func(ctx *types.Context) (string, error) {
+			<-release
+			return "waited", nil
+		}, ""),
+		"c":         NewRPCFunc(func(ctx *types.Context, s string, i int) (string, error) { return "foo", nil }, "s,i"),
+		"subscribe": NewWSRPCFunc(func(ctx *types.Context) (string, error) { return "subscribed", nil }, ""),
+	}
+	wm := NewWebsocketManager(funcMap, RequestConcurrency(2))
+	wm.SetLogger(log.TestingLogger())
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	c, dialResp, err := websocket.DefaultDialer.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.NoError(t, err)
+	defer dialResp.Body.Close()
+	defer c.Close()
+
+	write := func(id int, method string, params map[string]interface{}) {
+		req, err := types.MapToRequest(types.JSONRPCIntID(id), method, params)
+		require.NoError(t, err)
+		require.NoError(t, c.WriteJSON(req))
+	}
+	read := func() types.RPCResponse {
+		var resp types.RPCResponse
+		require.NoError(t, c.ReadJSON(&resp))
+		require.Nil(t, resp.Error)
+		return resp
+	}
+
+	// the second request doesn't wait for the first one
+	write(1, "wait", nil)
+	write(2, "c", map[string]interface{}{"s": "a", "i": 10})
+	assert.Equal(t, types.JSONRPCIntID(2), read().ID)
+
+	// but the websocket only function does
+	write(3, "subscribe", nil)
+	write(4, "c", map[string]interface{}{"s": "a", "i": 10})
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	for _, id := range []int{1, 3, 4} {
+		assert.Equal(t, types.JSONRPCIntID(id), read().ID)
+	}
+}
+
+func TestWebsocketSubscribeThenUnsubscribe(t *testing.T) {
+	var (
+		mtx        tmsync.Mutex
+		subscribed = make(map[string]bool)
+	)
+	funcMap := map[string]*RPCFunc{
+		"subscribe": NewWSRPCFunc(func(ctx *types.Context, query string) (string, error) {
+			// a slow subscription must still complete before the unsubscribe
+			time.Sleep(50 * time.Millisecond)
+			mtx.Lock()
+			defer mtx.Unlock()
+			subscribed[query] = true
+			return "subscribed", nil
+		}, "query", Ordered()),
+		"unsubscribe": NewWSRPCFunc(func(ctx *types.Context, query string) (string, error) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			if !subscribed[query] {
+				return "", errors.New("subscription not found")
+			}
+			delete(subscribed, query)
+			return "unsubscribed", nil
+		}, "query", Ordered()),
+	}
+	wm := NewWebsocketManager(funcMap, RequestConcurrency(4))
+	wm.SetLogger(log.TestingLogger())
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	c, dialResp, err := websocket.DefaultDialer.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.NoError(t, err)
+	defer dialResp.Body.Close()
+	defer c.Close()
+
+	params := map[string]interface{}{"query": "tm.event='NewBlock'"}
+	for id, method := range []string{"subscribe", "unsubscribe"} {
+		req, err := types.MapToRequest(types.JSONRPCIntID(id), method, params)
+		require.NoError(t, err)
+		require.NoError(t, c.WriteJSON(req))
+	}
+	for id := range []string{"subscribe", "unsubscribe"} {
+		var resp types.RPCResponse
+		require.NoError(t, c.ReadJSON(&resp))
+		require.Nil(t, resp.Error)
+		assert.Equal(t, types.JSONRPCIntID(id), resp.ID)
+	}
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	assert.Empty(t, subscribed)
+}
@@ -262,6 +262,9 @@ type WSRPCConnection interface {
 // - JSONReq is non-nil when JSONRPC is called over websocket or HTTP.
 // - WSConn is non-nil when we're connected via a websocket.
 // - HTTPReq is non-nil when URI or JSONRPC is called over HTTP.
+//
+// Calls made over other transports (e.g. gRPC) carry none of them and use
+// NewContext instead.
 type Context struct {
 	// json-rpc request
 	JSONReq *RPCRequest
@@ -269,6 +272,30 @@ type Context struct {
 	WSConn WSRPCConnection
 	// http request
 	HTTPReq *http.Request
+
+	// context of a call made over another transport
+	ctx context.Context
+
+	// set by the RPC function if its result never changes
+	immutable bool
+}
+
+// NewContext returns a Context for a call, which is not made over HTTP or
+// websockets. ctx is returned by Context, so the call is canceled along with
+// it.
+func NewContext(ctx context.Context) *Context {
+	return &Context{ctx: ctx}
+}
+
+// SetImmutable marks the result of the call as immutable, i.e. the same call
+// will always return the same result, so HTTP clients may cache it.
+func (ctx *Context) SetImmutable() {
+	ctx.immutable = true
+}
+
+// IsImmutable returns true if the RPC function marked its result as immutable.
+func (ctx *Context) IsImmutable() bool {
+	return ctx.immutable
 }
 
 // RemoteAddr returns the remote address (usually a string "IP:port").
@@ -299,11 +326,17 @@ func (ctx *Context) RemoteAddr() string {
 // WS:
 //
 //	The context is canceled when the client's connections closes.
+//
+// Other:
+//
+//	The context given to NewContext.
 func (ctx *Context) Context() context.Context {
 	if ctx.HTTPReq != nil {
 		return ctx.HTTPReq.Context()
 	} else if ctx.WSConn != nil {
 		return ctx.WSConn.Context()
+	} else if ctx.ctx != nil {
+		return ctx.ctx
 	}
 	return context.Background()
 }
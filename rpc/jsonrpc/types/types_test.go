@@ -1,6 +1,7 @@
 package types
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -81,3 +82,13 @@ func TestRPCError(t *testing.T) {
 			Message: "Badness",
 		}))
 }
+
+func TestContextWithoutTransport(t *testing.T) {
+	assert.Equal(t, context.Background(), (&Context{}).Context())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rpcCtx := NewContext(ctx)
+	assert.Empty(t, rpcCtx.RemoteAddr())
+	cancel()
+	assert.Equal(t, context.Canceled, rpcCtx.Context().Err())
+}
@@ -20,3 +20,4 @@ rm -rf tendermint
 mv ./proto/tendermint/abci/types.pb.go ./abci/types
 
 mv ./proto/tendermint/rpc/grpc/types.pb.go ./rpc/grpc
+mv ./proto/tendermint/rpc/grpc/query.pb.go ./rpc/grpc
@@ -0,0 +1,354 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/arcology-network/consensus-engine/abci/types"
+	"github.com/arcology-network/consensus-engine/libs/pubsub/query"
+	"github.com/arcology-network/consensus-engine/state/indexer"
+	"github.com/arcology-network/consensus-engine/types"
+)
+
+var _ indexer.BlockIndexer = (*BlockIndex)(nil)
+
+// BlockIndex is a block indexer backed by key-value storage (levelDB). The
+// BeginBlock and EndBlock events are indexed by the height of their block, so
+// a search returns the heights of the matching blocks.
+type BlockIndex struct {
+	store dbm.DB
+}
+
+// NewBlockIndex creates new KV block indexer.
+func NewBlockIndex(store dbm.DB) *BlockIndex {
+	return &BlockIndex{
+		store: store,
+	}
+}
+
+// Has returns true if the given height has been indexed. An error is returned
+// upon database query failure.
+func (idx *BlockIndex) Has(height int64) (bool, error) {
+	return idx.store.Has(heightKey(height))
+}
+
+// Index indexes BeginBlock and EndBlock events for a given block by its height.
+// The following is indexed:
+//
+// primary key: encode(block.height | height) => encode(height)
+// BeginBlock events: encode(eventType.eventAttr|eventValue|height|begin_block) => encode(height)
+// EndBlock events: encode(eventType.eventAttr|eventValue|height|end_block) => encode(height)
+func (idx *BlockIndex) Index(bh types.EventDataNewBlockHeader) error {
+	batch := idx.store.NewBatch()
+	defer batch.Close()
+
+	height := bh.Header.Height
+
+	// 1. index by height
+	if err := batch.Set(heightKey(height), int64ToBytes(height)); err != nil {
+		return err
+	}
+
+	// 2. index BeginBlock events
+	if err := idx.indexEvents(batch, bh.ResultBeginBlock.Events, sourceBeginBlock, height); err != nil {
+		return fmt.Errorf("failed to index BeginBlock events: %w", err)
+	}
+
+	// 3. index EndBlock events
+	if err := idx.indexEvents(batch, bh.ResultEndBlock.Events, sourceEndBlock, height); err != nil {
+		return fmt.Errorf("failed to index EndBlock events: %w", err)
+	}
+
+	return batch.WriteSync()
+}
+
+func (idx *BlockIndex) indexEvents(batch dbm.Batch, events []abci.Event, source string, height int64) error {
+	heightBz := int64ToBytes(height)
+
+	for _, event := range events {
+		// only index events with a non-empty type
+		if len(event.Type) == 0 {
+			continue
+		}
+
+		for _, attr := range event.Attributes {
+			if len(attr.Key) == 0 {
+				continue
+			}
+
+			// bail out if the event key is reserved
+			compositeKey := fmt.Sprintf("%s.%s", event.Type, string(attr.Key))
+			if compositeKey == types.BlockHeightKey {
+				return fmt.Errorf("event type and attribute key \"%s\" is reserved; please use a different key", compositeKey)
+			}
+
+			// index if `index: true` is set
+			if attr.GetIndex() {
+				if err := batch.Set(eventKey(compositeKey, string(attr.Value), height, source), heightBz); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Search performs a query for block heights that match a given BeginBlock
+// and EndBlock event search criteria. The given query can match against zero,
+// one or more block heights. The heights are returned in ascending order.
+//
+// It breaks the query into conditions (like "block.height > 5"), queries the
+// DB index for each of them and intersects the results, like the tx indexer.
+// The operands of OR and NOT are searched the same way, and their results are
+// merged or removed.
+// Search will exit early and return any result fetched so far, when a message
+// is received on the context chan.
+func (idx *BlockIndex) Search(ctx context.Context, q *query.Query) ([]int64, error) {
+	results := make([]int64, 0)
+	select {
+	case <-ctx.Done():
+		return results, nil
+	default:
+	}
+
+	expr, err := q.Expression()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query conditions: %w", err)
+	}
+
+	search := indexer.ExpressionSearch{
+		SearchConditions: idx.searchConditions,
+		All: func(ctx context.Context) map[string][]byte {
+			// every block is indexed by height
+			c := query.Condition{CompositeKey: types.BlockHeightKey, Op: query.OpExists}
+			return idx.match(ctx, c, nil, nil, true)
+		},
+	}
+	filteredHeights, err := search.Search(ctx, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, heightBz := range filteredHeights {
+		results = append(results, int64FromBytes(heightBz))
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+
+	return results, nil
+}
+
+// searchConditions returns the heights of the blocks matching all the
+// conditions.
+func (idx *BlockIndex) searchConditions(ctx context.Context, conditions []query.Condition) (map[string][]byte, error) {
+	var heightsInitialized bool
+	filteredHeights := make(map[string][]byte)
+
+	// conditions to skip because they're handled before "everything else"
+	skipIndexes := make([]int, 0)
+
+	// extract ranges
+	// if both upper and lower bounds exist, it's better to get them in order
+	// not to iterate over kvs that are not within range.
+	ranges, rangeIndexes := indexer.LookForRanges(conditions)
+	if len(ranges) > 0 {
+		skipIndexes = append(skipIndexes, rangeIndexes...)
+
+		for _, qr := range ranges {
+			if !heightsInitialized {
+				filteredHeights = idx.matchRange(ctx, qr, startKey(qr.Key), filteredHeights, true)
+				heightsInitialized = true
+
+				// Ignore any remaining conditions if the first condition resulted
+				// in no matches (assuming implicit AND operand).
+				if len(filteredHeights) == 0 {
+					break
+				}
+			} else {
+				filteredHeights = idx.matchRange(ctx, qr, startKey(qr.Key), filteredHeights, false)
+			}
+		}
+	}
+
+	// if there is a height condition ("block.height=3"), extract it
+	height := lookForHeight(conditions)
+
+	// for all other conditions
+	for i, c := range conditions {
+		if intInSlice(i, skipIndexes) {
+			continue
+		}
+
+		if !heightsInitialized {
+			filteredHeights = idx.match(ctx, c, startKeyForCondition(c, height), filteredHeights, true)
+			heightsInitialized = true
+
+			// Ignore any remaining conditions if the first condition resulted
+			// in no matches (assuming implicit AND operand).
+			if len(filteredHeights) == 0 {
+				break
+			}
+		} else {
+			filteredHeights = idx.match(ctx, c, startKeyForCondition(c, height), filteredHeights, false)
+		}
+	}
+
+	return filteredHeights, nil
+}
+
+// matchRange returns all matching block heights that match a given QueryRange
+// and start key. An already filtered result (filteredHeights) is provided such
+// that any non-intersecting matches are removed.
+//
+// NOTE: The provided filteredHeights may be empty if no previous condition has
+// matched.
+func (idx *BlockIndex) matchRange(
+	ctx context.Context,
+	qr indexer.QueryRange,
+	startKey []byte,
+	filteredHeights map[string][]byte,
+	firstRun bool,
+) map[string][]byte {
+	// A previous match was attempted but resulted in no matches, so we return
+	// no matches (assuming AND operand).
+	if !firstRun && len(filteredHeights) == 0 {
+		return filteredHeights
+	}
+
+	tmpHeights := make(map[string][]byte)
+	lowerBound := qr.LowerBoundValue()
+	upperBound := qr.UpperBoundValue()
+
+	it, err := dbm.IteratePrefix(idx.store, startKey)
+	if err != nil {
+		panic(err)
+	}
+	defer it.Close()
+
+	for ; it.Valid() && ctx.Err() == nil; it.Next() {
+		if !isTagKey(it.Key()) {
+			continue
+		}
+
+		if _, ok := qr.AnyBound().(int64); ok {
+			v, err := strconv.ParseInt(extractValueFromKey(it.Key()), 10, 64)
+			if err != nil {
+				continue
+			}
+
+			include := true
+			if lowerBound != nil && v < lowerBound.(int64) {
+				include = false
+			}
+
+			if upperBound != nil && v > upperBound.(int64) {
+				include = false
+			}
+
+			if include {
+				tmpHeights[string(it.Value())] = it.Value()
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		panic(err)
+	}
+
+	return intersect(filteredHeights, tmpHeights, firstRun)
+}
+
+// match returns all matching heights that meet a given query condition and
+// start key. An already filtered result (filteredHeights) is provided such
+// that any non-intersecting matches are removed.
+//
+// NOTE: The provided filteredHeights may be empty if no previous condition has
+// matched.
+func (idx *BlockIndex) match(
+	ctx context.Context,
+	c query.Condition,
+	startKeyBz []byte,
+	filteredHeights map[string][]byte,
+	firstRun bool,
+) map[string][]byte {
+	// A previous match was attempted but resulted in no matches, so we return
+	// no matches (assuming AND operand).
+	if !firstRun && len(filteredHeights) == 0 {
+		return filteredHeights
+	}
+
+	var (
+		prefix   []byte
+		contains bool
+	)
+	switch c.Op {
+	case query.OpEqual:
+		prefix = startKeyBz
+
+	case query.OpExists:
+		// XXX: can't use startKeyBz here because c.Operand is nil
+		// (e.g. "account.owner/<nil>/" won't match w/ a single row)
+		prefix = startKey(c.CompositeKey)
+
+	case query.OpContains:
+		// XXX: startKey does not apply here.
+		// For example, if startKey = "account.owner/an/" and search query = "account.owner CONTAINS an"
+		// we can't iterate with prefix "account.owner/an/" because we might miss keys like "account.owner/Ulan/"
+		prefix = startKey(c.CompositeKey)
+		contains = true
+
+	default:
+		panic("other operators should be handled already")
+	}
+
+	tmpHeights := make(map[string][]byte)
+
+	it, err := dbm.IteratePrefix(idx.store, prefix)
+	if err != nil {
+		panic(err)
+	}
+	defer it.Close()
+
+	for ; it.Valid() && ctx.Err() == nil; it.Next() {
+		if contains {
+			if !isTagKey(it.Key()) || !strings.Contains(extractValueFromKey(it.Key()), c.Operand.(string)) {
+				continue
+			}
+		}
+		tmpHeights[string(it.Value())] = it.Value()
+	}
+	if err := it.Error(); err != nil {
+		panic(err)
+	}
+
+	return intersect(filteredHeights, tmpHeights, firstRun)
+}
+
+// intersect returns the heights of filteredHeights which are also in
+// tmpHeights, or tmpHeights if there was no previous match.
+func intersect(filteredHeights, tmpHeights map[string][]byte, firstRun bool) map[string][]byte {
+	if len(tmpHeights) == 0 || firstRun {
+		// Either:
+		//
+		// 1. Regardless if a previous match was attempted, which may have had
+		// results, but no match was found for the current condition, then we
+		// return no matches (assuming AND operand).
+		//
+		// 2. A previous match was not attempted, so we return all results.
+		return tmpHeights
+	}
+
+	// Remove/reduce matches in filteredHeights that were not found in this
+	// match (tmpHeights).
+	for k := range filteredHeights {
+		if tmpHeights[k] == nil {
+			delete(filteredHeights, k)
+		}
+	}
+
+	return filteredHeights
+}
@@ -0,0 +1,85 @@
+package kv
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/arcology-network/consensus-engine/libs/pubsub/query"
+	"github.com/arcology-network/consensus-engine/types"
+)
+
+const (
+	tagKeySeparator = "/"
+
+	// the sources of the indexed events, the last part of the keys
+	sourceBlock      = "block"
+	sourceBeginBlock = "begin_block"
+	sourceEndBlock   = "end_block"
+)
+
+func intInSlice(a int, list []int) bool {
+	for _, b := range list {
+		if b == a {
+			return true
+		}
+	}
+
+	return false
+}
+
+func int64ToBytes(i int64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(i))
+	return buf
+}
+
+func int64FromBytes(bz []byte) int64 {
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+// Keys are made of the composite key, the value, the height and the source of
+// the event, separated by tagKeySeparator; the height itself is indexed as the
+// block.height event of every block.
+
+func isTagKey(key []byte) bool {
+	return strings.Count(string(key), tagKeySeparator) == 3
+}
+
+func extractValueFromKey(key []byte) string {
+	parts := strings.SplitN(string(key), tagKeySeparator, 3)
+	return parts[1]
+}
+
+func heightKey(height int64) []byte {
+	return eventKey(types.BlockHeightKey, fmt.Sprintf("%d", height), height, sourceBlock)
+}
+
+func eventKey(compositeKey, value string, height int64, source string) []byte {
+	return []byte(fmt.Sprintf("%s/%s/%d/%s", compositeKey, value, height, source))
+}
+
+// lookForHeight returns a height if there is a "block.height=X" condition.
+func lookForHeight(conditions []query.Condition) (height int64) {
+	for _, c := range conditions {
+		if c.CompositeKey == types.BlockHeightKey && c.Op == query.OpEqual {
+			return c.Operand.(int64)
+		}
+	}
+	return 0
+}
+
+func startKeyForCondition(c query.Condition, height int64) []byte {
+	if height > 0 {
+		return startKey(c.CompositeKey, c.Operand, height)
+	}
+	return startKey(c.CompositeKey, c.Operand)
+}
+
+func startKey(fields ...interface{}) []byte {
+	var b strings.Builder
+	for _, f := range fields {
+		b.WriteString(fmt.Sprintf("%v", f) + tagKeySeparator)
+	}
+	return []byte(b.String())
+}
@@ -0,0 +1,30 @@
+package null
+
+import (
+	"context"
+	"errors"
+
+	"github.com/arcology-network/consensus-engine/libs/pubsub/query"
+	"github.com/arcology-network/consensus-engine/state/indexer"
+	"github.com/arcology-network/consensus-engine/types"
+)
+
+var _ indexer.BlockIndexer = (*BlockIndex)(nil)
+
+// BlockIndex acts as a /dev/null.
+type BlockIndex struct{}
+
+// Has always fails, as the blocks are not indexed.
+func (idx *BlockIndex) Has(height int64) (bool, error) {
+	return false, errors.New(`indexing is disabled (set 'tx_index = "kv"' in config)`)
+}
+
+// Index is a noop and always returns nil.
+func (idx *BlockIndex) Index(types.EventDataNewBlockHeader) error {
+	return nil
+}
+
+// Search always returns no heights.
+func (idx *BlockIndex) Search(ctx context.Context, q *query.Query) ([]int64, error) {
+	return []int64{}, nil
+}
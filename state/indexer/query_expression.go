@@ -0,0 +1,115 @@
+package indexer
+
+import (
+	"context"
+
+	"github.com/arcology-network/consensus-engine/libs/pubsub/query"
+)
+
+// ExpressionSearch looks up the entries of an index (txs or blocks) matching
+// a query expression, by their keys. SearchConditions returns the entries
+// matching all the given conditions, and All returns every entry.
+type ExpressionSearch struct {
+	SearchConditions func(ctx context.Context, conditions []query.Condition) (map[string][]byte, error)
+	All              func(ctx context.Context) map[string][]byte
+}
+
+// Search returns the entries matching e.
+func (s ExpressionSearch) Search(ctx context.Context, e query.Expression) (map[string][]byte, error) {
+	switch e.Op {
+	case query.ExprCondition:
+		return s.SearchConditions(ctx, []query.Condition{e.Condition})
+
+	case query.ExprOr:
+		entries := make(map[string][]byte)
+		for _, operand := range e.Operands {
+			operandEntries, err := s.Search(ctx, operand)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range operandEntries {
+				entries[k] = v
+			}
+		}
+		return entries, nil
+
+	case query.ExprNot:
+		return s.searchAnd(ctx, []query.Expression{e})
+
+	default:
+		return s.searchAnd(ctx, e.Operands)
+	}
+}
+
+// searchAnd returns the entries matching all the operands. The conditions are
+// looked up together, so ranges and heights narrow the lookups. The entries
+// matching the other operands are intersected with them, and those matching
+// the negated operands are removed. If all the operands are negated, they are
+// removed from all the entries.
+func (s ExpressionSearch) searchAnd(ctx context.Context, operands []query.Expression) (map[string][]byte, error) {
+	var (
+		conditions []query.Condition
+		others     []query.Expression
+		negated    []query.Expression
+	)
+	for len(operands) > 0 {
+		operand := operands[0]
+		operands = operands[1:]
+		switch operand.Op {
+		case query.ExprCondition:
+			conditions = append(conditions, operand.Condition)
+		case query.ExprAnd:
+			operands = append(operands, operand.Operands...)
+		case query.ExprNot:
+			negated = append(negated, operand.Operands[0])
+		default:
+			others = append(others, operand)
+		}
+	}
+
+	var (
+		entries map[string][]byte
+		err     error
+	)
+	if len(conditions) > 0 {
+		entries, err = s.SearchConditions(ctx, conditions)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, operand := range others {
+		if entries != nil && len(entries) == 0 {
+			return entries, nil
+		}
+		operandEntries, err := s.Search(ctx, operand)
+		if err != nil {
+			return nil, err
+		}
+		if entries == nil {
+			entries = operandEntries
+			continue
+		}
+		for k := range entries {
+			if operandEntries[k] == nil {
+				delete(entries, k)
+			}
+		}
+	}
+	if entries == nil {
+		entries = s.All(ctx)
+	}
+	for _, operand := range negated {
+		if len(entries) == 0 {
+			break
+		}
+		operandEntries, err := s.Search(ctx, operand)
+		if err != nil {
+			return nil, err
+		}
+		for k := range operandEntries {
+			delete(entries, k)
+		}
+	}
+
+	return entries, nil
+}
@@ -11,6 +11,7 @@ import (
 
 	abci "github.com/arcology-network/consensus-engine/abci/types"
 	"github.com/arcology-network/consensus-engine/libs/log"
+	blockidxkv "github.com/arcology-network/consensus-engine/state/indexer/block/kv"
 	"github.com/arcology-network/consensus-engine/state/txindex"
 	"github.com/arcology-network/consensus-engine/state/txindex/kv"
 	"github.com/arcology-network/consensus-engine/types"
@@ -31,8 +32,9 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	// tx indexer
 	store := db.NewMemDB()
 	txIndexer := kv.NewTxIndex(store)
+	blockIndexer := blockidxkv.NewBlockIndex(db.NewPrefixDB(store, []byte("block_events")))
 
-	service := txindex.NewIndexerService(txIndexer, eventBus)
+	service := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus)
 	service.SetLogger(log.TestingLogger())
 	err = service.Start()
 	require.NoError(t, err)
@@ -74,4 +76,8 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	res, err = txIndexer.Get(types.Tx("bar").Hash())
 	assert.NoError(t, err)
 	assert.Equal(t, txResult2, res)
+
+	ok, err := blockIndexer.Has(1)
+	assert.NoError(t, err)
+	assert.True(t, ok)
 }
@@ -7,13 +7,13 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/gogo/protobuf/proto"
 	dbm "github.com/tendermint/tm-db"
 
 	abci "github.com/arcology-network/consensus-engine/abci/types"
 	"github.com/arcology-network/consensus-engine/libs/pubsub/query"
+	"github.com/arcology-network/consensus-engine/state/indexer"
 	"github.com/arcology-network/consensus-engine/state/txindex"
 	"github.com/arcology-network/consensus-engine/types"
 )
@@ -165,7 +165,8 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 // "tx.hash" is found, it returns tx result for it (2) for range queries it is
 // better for the client to provide both lower and upper bounds, so we are not
 // performing a full scan. Results from querying indexes are then intersected
-// and returned to the caller, in no particular order.
+// and returned to the caller, in no particular order. The operands of OR and
+// NOT are searched the same way, and their results are merged or removed.
 //
 // Search will exit early and return any result fetched so far,
 // when a message is received on the context chan.
@@ -178,15 +179,48 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 	default:
 	}
 
-	var hashesInitialized bool
-	filteredHashes := make(map[string][]byte)
-
-	// get a list of conditions (like "tx.height > 5")
-	conditions, err := q.Conditions()
+	expr, err := q.Expression()
 	if err != nil {
 		return nil, fmt.Errorf("error during parsing conditions from query: %w", err)
 	}
 
+	search := indexer.ExpressionSearch{
+		SearchConditions: txi.searchConditions,
+		All: func(ctx context.Context) map[string][]byte {
+			// every tx is indexed by height
+			c := query.Condition{CompositeKey: types.TxHeightKey, Op: query.OpExists}
+			return txi.match(ctx, c, nil, nil, true)
+		},
+	}
+	filteredHashes, err := search.Search(ctx, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*abci.TxResult, 0, len(filteredHashes))
+	for _, h := range filteredHashes {
+		res, err := txi.Get(h)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
+		}
+		results = append(results, res)
+
+		// Potentially exit early.
+		select {
+		case <-ctx.Done():
+			break
+		default:
+		}
+	}
+
+	return results, nil
+}
+
+// searchConditions returns the hashes of the txs matching all the conditions.
+func (txi *TxIndex) searchConditions(ctx context.Context, conditions []query.Condition) (map[string][]byte, error) {
+	var hashesInitialized bool
+	filteredHashes := make(map[string][]byte)
+
 	// if there is a hash condition, return the result immediately
 	hash, ok, err := lookForHash(conditions)
 	if err != nil {
@@ -195,11 +229,12 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		res, err := txi.Get(hash)
 		switch {
 		case err != nil:
-			return []*abci.TxResult{}, fmt.Errorf("error while retrieving the result: %w", err)
+			return nil, fmt.Errorf("error while retrieving the result: %w", err)
 		case res == nil:
-			return []*abci.TxResult{}, nil
+			return filteredHashes, nil
 		default:
-			return []*abci.TxResult{res}, nil
+			filteredHashes[string(hash)] = hash
+			return filteredHashes, nil
 		}
 	}
 
@@ -209,13 +244,13 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 	// extract ranges
 	// if both upper and lower bounds exist, it's better to get them in order not
 	// no iterate over kvs that are not within range.
-	ranges, rangeIndexes := lookForRanges(conditions)
+	ranges, rangeIndexes := indexer.LookForRanges(conditions)
 	if len(ranges) > 0 {
 		skipIndexes = append(skipIndexes, rangeIndexes...)
 
 		for _, r := range ranges {
 			if !hashesInitialized {
-				filteredHashes = txi.matchRange(ctx, r, startKey(r.key), filteredHashes, true)
+				filteredHashes = txi.matchRange(ctx, r, startKey(r.Key), filteredHashes, true)
 				hashesInitialized = true
 
 				// Ignore any remaining conditions if the first condition resulted
@@ -224,7 +259,7 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 					break
 				}
 			} else {
-				filteredHashes = txi.matchRange(ctx, r, startKey(r.key), filteredHashes, false)
+				filteredHashes = txi.matchRange(ctx, r, startKey(r.Key), filteredHashes, false)
 			}
 		}
 	}
@@ -252,23 +287,7 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		}
 	}
 
-	results := make([]*abci.TxResult, 0, len(filteredHashes))
-	for _, h := range filteredHashes {
-		res, err := txi.Get(h)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
-		}
-		results = append(results, res)
-
-		// Potentially exit early.
-		select {
-		case <-ctx.Done():
-			break
-		default:
-		}
-	}
-
-	return results, nil
+	return filteredHashes, nil
 }
 
 func lookForHash(conditions []query.Condition) (hash []byte, ok bool, err error) {
@@ -291,100 +310,6 @@ func lookForHeight(conditions []query.Condition) (height int64) {
 	return 0
 }
 
-// special map to hold range conditions
-// Example: account.number => queryRange{lowerBound: 1, upperBound: 5}
-type queryRanges map[string]queryRange
-
-type queryRange struct {
-	lowerBound        interface{} // int || time.Time
-	upperBound        interface{} // int || time.Time
-	key               string
-	includeLowerBound bool
-	includeUpperBound bool
-}
-
-func (r queryRange) lowerBoundValue() interface{} {
-	if r.lowerBound == nil {
-		return nil
-	}
-
-	if r.includeLowerBound {
-		return r.lowerBound
-	}
-
-	switch t := r.lowerBound.(type) {
-	case int64:
-		return t + 1
-	case time.Time:
-		return t.Unix() + 1
-	default:
-		panic("not implemented")
-	}
-}
-
-func (r queryRange) AnyBound() interface{} {
-	if r.lowerBound != nil {
-		return r.lowerBound
-	}
-
-	return r.upperBound
-}
-
-func (r queryRange) upperBoundValue() interface{} {
-	if r.upperBound == nil {
-		return nil
-	}
-
-	if r.includeUpperBound {
-		return r.upperBound
-	}
-
-	switch t := r.upperBound.(type) {
-	case int64:
-		return t - 1
-	case time.Time:
-		return t.Unix() - 1
-	default:
-		panic("not implemented")
-	}
-}
-
-func lookForRanges(conditions []query.Condition) (ranges queryRanges, indexes []int) {
-	ranges = make(queryRanges)
-	for i, c := range conditions {
-		if isRangeOperation(c.Op) {
-			r, ok := ranges[c.CompositeKey]
-			if !ok {
-				r = queryRange{key: c.CompositeKey}
-			}
-			switch c.Op {
-			case query.OpGreater:
-				r.lowerBound = c.Operand
-			case query.OpGreaterEqual:
-				r.includeLowerBound = true
-				r.lowerBound = c.Operand
-			case query.OpLess:
-				r.upperBound = c.Operand
-			case query.OpLessEqual:
-				r.includeUpperBound = true
-				r.upperBound = c.Operand
-			}
-			ranges[c.CompositeKey] = r
-			indexes = append(indexes, i)
-		}
-	}
-	return ranges, indexes
-}
-
-func isRangeOperation(op query.Operator) bool {
-	switch op {
-	case query.OpGreater, query.OpGreaterEqual, query.OpLess, query.OpLessEqual:
-		return true
-	default:
-		return false
-	}
-}
-
 // match returns all matching txs by hash that meet a given condition and start
 // key. An already filtered result (filteredHashes) is provided such that any
 // non-intersecting matches are removed.
@@ -512,14 +437,14 @@ func (txi *TxIndex) match(
 	return filteredHashes
 }
 
-// matchRange returns all matching txs by hash that meet a given queryRange and
+// matchRange returns all matching txs by hash that meet a given QueryRange and
 // start key. An already filtered result (filteredHashes) is provided such that
 // any non-intersecting matches are removed.
 //
 // NOTE: filteredHashes may be empty if no previous condition has matched.
 func (txi *TxIndex) matchRange(
 	ctx context.Context,
-	r queryRange,
+	qr indexer.QueryRange,
 	startKey []byte,
 	filteredHashes map[string][]byte,
 	firstRun bool,
@@ -531,8 +456,8 @@ func (txi *TxIndex) matchRange(
 	}
 
 	tmpHashes := make(map[string][]byte)
-	lowerBound := r.lowerBoundValue()
-	upperBound := r.upperBoundValue()
+	lowerBound := qr.LowerBoundValue()
+	upperBound := qr.UpperBoundValue()
 
 	it, err := dbm.IteratePrefix(txi.store, startKey)
 	if err != nil {
@@ -546,7 +471,7 @@ LOOP:
 			continue
 		}
 
-		if _, ok := r.AnyBound().(int64); ok {
+		if _, ok := qr.AnyBound().(int64); ok {
 			v, err := strconv.ParseInt(extractValueFromKey(it.Key()), 10, 64)
 			if err != nil {
 				continue LOOP
@@ -313,6 +313,58 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	require.Len(t, results, 3)
 }
 
+func TestTxSearchOrAndNot(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+
+	owners := []string{"Ivan", "Igor", "Vlad", "Pavel"}
+	for i, owner := range owners {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{
+				{Key: []byte("number"), Value: []byte(fmt.Sprintf("%d", i+1)), Index: true},
+				{Key: []byte("owner"), Value: []byte(owner), Index: true},
+			}},
+		})
+		txResult.Tx = types.Tx(owner)
+		txResult.Height = int64(i/2 + 1)
+		txResult.Index = uint32(i % 2)
+		require.NoError(t, indexer.Index(txResult))
+	}
+
+	testCases := []struct {
+		q      string
+		owners []string
+	}{
+		{"account.owner = 'Ivan' OR account.owner = 'Vlad'", []string{"Ivan", "Vlad"}},
+		{"account.owner = 'Ivan' OR account.number >= 3", []string{"Ivan", "Vlad", "Pavel"}},
+		{"account.owner = 'Ivan' OR account.owner = 'John'", []string{"Ivan"}},
+		{"NOT account.owner = 'Ivan'", []string{"Igor", "Vlad", "Pavel"}},
+		{"NOT account.owner EXISTS", []string{}},
+		{"tx.height = 1 AND NOT account.owner = 'Ivan'", []string{"Igor"}},
+		{"tx.height = 2 AND (account.owner = 'Ivan' OR account.owner = 'Pavel')", []string{"Pavel"}},
+		{"(account.number <= 2 OR account.owner CONTAINS 'el') AND NOT tx.height = 1", []string{"Pavel"}},
+		// AND binds tighter than OR
+		{"tx.height = 1 AND account.number = 1 OR account.owner = 'Vlad'", []string{"Ivan", "Vlad"}},
+		{"NOT (account.owner = 'Ivan' OR account.owner = 'Vlad')", []string{"Igor", "Pavel"}},
+		{fmt.Sprintf("tx.hash = '%X' OR account.owner = 'Vlad'", types.Tx("Pavel").Hash()), []string{"Vlad", "Pavel"}},
+	}
+
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.q, func(t *testing.T) {
+			results, err := indexer.Search(ctx, query.MustParse(tc.q))
+			require.NoError(t, err)
+
+			owners := make([]string, 0, len(results))
+			for _, txr := range results {
+				owners = append(owners, string(txr.Tx))
+			}
+			assert.ElementsMatch(t, tc.owners, owners)
+		})
+	}
+}
+
 func txResultWithEvents(events []abci.Event) *abci.TxResult {
 	tx := types.Tx("HELLO WORLD")
 	return &abci.TxResult{
@@ -0,0 +1,19 @@
+package valstats
+
+import (
+	"github.com/arcology-network/consensus-engine/types"
+)
+
+// BlockStore is the part of the block store the tracker backfills from.
+type BlockStore interface {
+	Base() int64
+	Height() int64
+	LoadBlockMeta(height int64) *types.BlockMeta
+	LoadBlockCommit(height int64) *types.Commit
+}
+
+// StateStore is the part of the state store the tracker resolves the
+// validators of a commit from.
+type StateStore interface {
+	LoadValidators(height int64) (*types.ValidatorSet, error)
+}
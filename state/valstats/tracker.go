@@ -0,0 +1,412 @@
+package valstats
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/arcology-network/consensus-engine/libs/service"
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
+	tmstate "github.com/arcology-network/consensus-engine/proto/tendermint/state"
+	tmproto "github.com/arcology-network/consensus-engine/proto/tendermint/types"
+	"github.com/arcology-network/consensus-engine/types"
+)
+
+const (
+	// pollInterval is how often the tracker looks for new blocks in the block
+	// store. The blocks are committed through paths which don't all publish
+	// NewBlock events, so the block store is the source of truth.
+	pollInterval = time.Second
+)
+
+// ValidatorStats are the signing statistics of a validator over a window of
+// blocks.
+type ValidatorStats struct {
+	Address types.Address `json:"address"`
+	// Number of blocks of the window in which the validator was part of the
+	// validator set of the last commit.
+	Window int64 `json:"window"`
+	// Votes for the block, votes for nil and missing votes.
+	Signed int64 `json:"signed"`
+	Nil    int64 `json:"nil"`
+	Absent int64 `json:"absent"`
+	// Number of blocks of the window proposed by the validator.
+	Proposed int64 `json:"proposed"`
+	// Number of consecutive missing votes up to the latest block.
+	MissedStreak int64 `json:"missed_streak"`
+}
+
+// Stats are the signing statistics of all validators over the blocks
+// FromHeight to ToHeight. The votes of a block are those of the last commit it
+// includes, i.e. the votes for the previous height.
+type Stats struct {
+	FromHeight int64            `json:"from_height"`
+	ToHeight   int64            `json:"to_height"`
+	Validators []ValidatorStats `json:"validators"`
+}
+
+// Tracker records the votes of the last commit and the proposer of every
+// block saved to the block store, keeping the records of the last
+// retainBlocks blocks, and computes the signing statistics of the validators
+// from them.
+type Tracker struct {
+	service.BaseService
+
+	db           dbm.DB
+	stateStore   StateStore
+	blockStore   BlockStore
+	retainBlocks int64
+
+	metrics *Metrics
+	// our own validator, if any
+	address types.Address
+
+	mtx          tmsync.Mutex
+	lastHeight   int64
+	missedStreak int64
+}
+
+// TrackerOption sets an optional parameter on the Tracker.
+type TrackerOption func(*Tracker)
+
+// NewTracker returns a new Tracker keeping the records of the last
+// retainBlocks blocks in db.
+func NewTracker(
+	db dbm.DB,
+	stateStore StateStore,
+	blockStore BlockStore,
+	retainBlocks int64,
+	options ...TrackerOption,
+) *Tracker {
+	t := &Tracker{
+		db:           db,
+		stateStore:   stateStore,
+		blockStore:   blockStore,
+		retainBlocks: retainBlocks,
+		metrics:      NopMetrics(),
+	}
+	t.BaseService = *service.NewBaseService(nil, "ValidatorStatsTracker", t)
+	for _, option := range options {
+		option(t)
+	}
+	return t
+}
+
+// WithMetrics sets the metrics.
+func WithMetrics(metrics *Metrics) TrackerOption {
+	return func(t *Tracker) { t.metrics = metrics }
+}
+
+// WithValidatorAddress sets the address of our own validator, whose missed
+// blocks streak is reported by the metrics.
+func WithValidatorAddress(address types.Address) TrackerOption {
+	return func(t *Tracker) { t.address = address }
+}
+
+// OnStart implements service.Service by loading the last records,
+// backfilling the blocks committed since from the block store and following
+// the block store from then on.
+func (t *Tracker) OnStart() error {
+	if err := t.load(); err != nil {
+		return err
+	}
+	t.catchUp(t.blockStore.Height())
+
+	go func() {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				t.catchUp(t.blockStore.Height())
+			case <-t.Quit():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// RetainBlocks returns the number of blocks the records are kept for, which
+// is the largest window Stats can be computed over.
+func (t *Tracker) RetainBlocks() int64 {
+	return t.retainBlocks
+}
+
+// MissedStreak returns the number of consecutive blocks missed by our own
+// validator.
+func (t *Tracker) MissedStreak() int64 {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.missedStreak
+}
+
+// Stats returns the signing statistics of the validators over the last window
+// recorded blocks, ordered by address.
+func (t *Tracker) Stats(window int64) (*Stats, error) {
+	it, err := t.db.ReverseIterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var (
+		stats   = &Stats{}
+		tallies = make(map[string]*tally)
+		get     = func(address []byte) *tally {
+			tl, ok := tallies[string(address)]
+			if !ok {
+				tl = &tally{ValidatorStats: ValidatorStats{Address: address}}
+				tallies[string(address)] = tl
+			}
+			return tl
+		}
+	)
+	for n := int64(0); it.Valid() && n < window; it.Next() {
+		height := decodeHeight(it.Key())
+		rec, err := unmarshalRecord(it.Value())
+		if err != nil {
+			return nil, fmt.Errorf("can't decode record at height %d: %w", height, err)
+		}
+		if stats.ToHeight == 0 {
+			stats.ToHeight = height
+		}
+		stats.FromHeight = height
+		n++
+
+		if len(rec.ProposerAddress) > 0 {
+			get(rec.ProposerAddress).Proposed++
+		}
+		for _, vote := range rec.Votes {
+			if len(vote.Address) == 0 {
+				continue
+			}
+			get(vote.Address).add(height, stats.ToHeight, vote.Flag)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	stats.Validators = make([]ValidatorStats, 0, len(tallies))
+	for _, tl := range tallies {
+		stats.Validators = append(stats.Validators, tl.ValidatorStats)
+	}
+	sort.Slice(stats.Validators, func(i, j int) bool {
+		return bytes.Compare(stats.Validators[i].Address, stats.Validators[j].Address) < 0
+	})
+	return stats, nil
+}
+
+// load restores the last recorded height and our missed blocks streak, and
+// prunes the records a larger retainBlocks may have left behind.
+func (t *Tracker) load() error {
+	it, err := t.db.ReverseIterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	var lastHeight, streak int64
+	for ; it.Valid(); it.Next() {
+		height := decodeHeight(it.Key())
+		if lastHeight == 0 {
+			lastHeight = height
+		}
+		if t.address == nil {
+			break
+		}
+		rec, err := unmarshalRecord(it.Value())
+		if err != nil {
+			return fmt.Errorf("can't decode record at height %d: %w", height, err)
+		}
+		if flag, ok := voteOf(rec, t.address); !ok || flag != tmproto.BlockIDFlagAbsent {
+			break
+		}
+		streak++
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	t.mtx.Lock()
+	t.lastHeight = lastHeight
+	t.missedStreak = streak
+	t.mtx.Unlock()
+	t.metrics.MissedBlocksStreak.Set(float64(streak))
+
+	if lastHeight > t.retainBlocks {
+		return t.prune(lastHeight - t.retainBlocks + 1)
+	}
+	return nil
+}
+
+// prune deletes the records below the given height.
+func (t *Tracker) prune(height int64) error {
+	it, err := t.db.Iterator(nil, encodeHeight(height))
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	batch := t.db.NewBatch()
+	defer batch.Close()
+	for ; it.Valid(); it.Next() {
+		if err := batch.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// catchUp records the blocks up to the given height from the block store
+// which haven't been recorded yet.
+func (t *Tracker) catchUp(height int64) {
+	t.mtx.Lock()
+	from := t.lastHeight + 1
+	t.mtx.Unlock()
+
+	if h := height - t.retainBlocks + 1; from < h {
+		from = h
+	}
+	if base := t.blockStore.Base(); from < base {
+		from = base
+	}
+	for h := from; h <= height; h++ {
+		meta := t.blockStore.LoadBlockMeta(h)
+		if meta == nil {
+			continue
+		}
+		var commit *types.Commit
+		if h > 1 {
+			commit = t.blockStore.LoadBlockCommit(h - 1)
+		}
+		if err := t.record(h, meta.Header.ProposerAddress, commit); err != nil {
+			t.Logger.Error("Failed to record validator votes", "height", h, "err", err)
+		}
+	}
+}
+
+// record saves the proposer and the votes of the last commit of the block at
+// the given height and prunes the records leaving the window.
+func (t *Tracker) record(height int64, proposer types.Address, lastCommit *types.Commit) error {
+	t.mtx.Lock()
+	lastHeight := t.lastHeight
+	t.mtx.Unlock()
+	if height <= lastHeight {
+		return nil
+	}
+
+	rec := &tmstate.ValidatorStatsRecord{ProposerAddress: proposer}
+	if lastCommit != nil && len(lastCommit.Signatures) > 0 {
+		// absent votes carry no address, so the validators are resolved by index
+		vals, err := t.stateStore.LoadValidators(lastCommit.Height)
+		if err != nil {
+			return fmt.Errorf("can't load validators at height %d: %w", lastCommit.Height, err)
+		}
+		rec.Votes = make([]tmstate.ValidatorVote, len(lastCommit.Signatures))
+		for i, sig := range lastCommit.Signatures {
+			address, _ := vals.GetByIndex(int32(i))
+			rec.Votes[i] = tmstate.ValidatorVote{
+				Address: address,
+				Flag:    tmproto.BlockIDFlag(sig.BlockIDFlag),
+			}
+		}
+	}
+
+	bz, err := rec.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := t.db.Set(encodeHeight(height), bz); err != nil {
+		return err
+	}
+	// the records below the window, including those left before a gap
+	if pruneHeight := height - t.retainBlocks + 1; pruneHeight > 1 {
+		if err := t.prune(pruneHeight); err != nil {
+			return err
+		}
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.lastHeight = height
+	if t.address != nil {
+		if flag, ok := voteOf(rec, t.address); ok && flag == tmproto.BlockIDFlagAbsent {
+			t.missedStreak++
+		} else {
+			t.missedStreak = 0
+		}
+		t.metrics.MissedBlocksStreak.Set(float64(t.missedStreak))
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+// tally accumulates the stats of a validator, newest block first.
+type tally struct {
+	ValidatorStats
+
+	lastSeen    int64
+	streakEnded bool
+}
+
+func (tl *tally) add(height, toHeight int64, flag tmproto.BlockIDFlag) {
+	// the streak ends at the first block the validator voted in or was not
+	// part of the validator set
+	if (tl.lastSeen == 0 && height != toHeight) || (tl.lastSeen != 0 && tl.lastSeen != height+1) {
+		tl.streakEnded = true
+	}
+	tl.lastSeen = height
+	tl.Window++
+
+	switch flag {
+	case tmproto.BlockIDFlagCommit:
+		tl.Signed++
+		tl.streakEnded = true
+	case tmproto.BlockIDFlagNil:
+		tl.Nil++
+		tl.streakEnded = true
+	case tmproto.BlockIDFlagAbsent:
+		tl.Absent++
+		if !tl.streakEnded {
+			tl.MissedStreak++
+		}
+	}
+}
+
+func voteOf(rec *tmstate.ValidatorStatsRecord, address types.Address) (tmproto.BlockIDFlag, bool) {
+	for _, vote := range rec.Votes {
+		if bytes.Equal(vote.Address, address) {
+			return vote.Flag, true
+		}
+	}
+	return tmproto.BlockIDFlagUnknown, false
+}
+
+func unmarshalRecord(bz []byte) (*tmstate.ValidatorStatsRecord, error) {
+	rec := new(tmstate.ValidatorStatsRecord)
+	if err := rec.Unmarshal(bz); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// The records are keyed by height in big endian, so they iterate in order.
+func encodeHeight(height int64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(height))
+	return key
+}
+
+func decodeHeight(key []byte) int64 {
+	return int64(binary.BigEndian.Uint64(key))
+}
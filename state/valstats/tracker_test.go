@@ -0,0 +1,206 @@
+package valstats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/arcology-network/consensus-engine/libs/log"
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
+	"github.com/arcology-network/consensus-engine/types"
+)
+
+type testStore struct {
+	vals *types.ValidatorSet
+
+	mtx     tmsync.Mutex
+	metas   map[int64]*types.BlockMeta
+	commits map[int64]*types.Commit
+}
+
+// save adds the block at the given height, with the commit for it.
+func (s *testStore) save(height int64, proposer types.Address, flags string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.metas[height] = &types.BlockMeta{Header: types.Header{Height: height, ProposerAddress: proposer}}
+	s.commits[height] = s.makeCommit(height, flags)
+}
+
+func (s *testStore) Base() int64 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	base := int64(0)
+	for h := range s.metas {
+		if base == 0 || h < base {
+			base = h
+		}
+	}
+	return base
+}
+
+func (s *testStore) Height() int64 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	height := int64(0)
+	for h := range s.metas {
+		if h > height {
+			height = h
+		}
+	}
+	return height
+}
+
+func (s *testStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.metas[height]
+}
+
+func (s *testStore) LoadBlockCommit(height int64) *types.Commit {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.commits[height]
+}
+
+func (s *testStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
+	return s.vals, nil
+}
+
+// makeCommit returns a commit for the given height with one flag per
+// validator: 'c' for a vote for the block, 'n' for nil and 'a' for absent.
+func (s *testStore) makeCommit(height int64, flags string) *types.Commit {
+	sigs := make([]types.CommitSig, len(flags))
+	for i, f := range flags {
+		address, _ := s.vals.GetByIndex(int32(i))
+		switch f {
+		case 'c':
+			sigs[i] = types.NewCommitSigForBlock([]byte("sig"), address, time.Now())
+		case 'n':
+			sigs[i] = types.CommitSig{BlockIDFlag: types.BlockIDFlagNil, ValidatorAddress: address}
+		default:
+			sigs[i] = types.NewCommitSigAbsent()
+		}
+	}
+	return types.NewCommit(height, 0, types.BlockID{}, sigs)
+}
+
+func newTestStore() *testStore {
+	vals, _ := types.RandValidatorSet(3, 10)
+	return &testStore{
+		vals:    vals,
+		metas:   make(map[int64]*types.BlockMeta),
+		commits: make(map[int64]*types.Commit),
+	}
+}
+
+func (s *testStore) address(i int) types.Address {
+	address, _ := s.vals.GetByIndex(int32(i))
+	return address
+}
+
+func statsOf(t *testing.T, stats *Stats, address types.Address) ValidatorStats {
+	t.Helper()
+	for _, vs := range stats.Validators {
+		if vs.Address.String() == address.String() {
+			return vs
+		}
+	}
+	t.Fatalf("no stats for %v", address)
+	return ValidatorStats{}
+}
+
+func TestTrackerStats(t *testing.T) {
+	store := newTestStore()
+	me := store.address(2)
+	tracker := NewTracker(dbm.NewMemDB(), store, store, 4,
+		WithValidatorAddress(me))
+
+	// the last commits of the blocks 2 to 6
+	flags := []string{"ccc", "cna", "cca", "ccc", "caa"}
+	for i, f := range flags {
+		height := int64(i + 2)
+		require.NoError(t, tracker.record(height, store.address(i%2), store.makeCommit(height-1, f)))
+	}
+	// an already recorded block is ignored
+	require.NoError(t, tracker.record(4, store.address(1), store.makeCommit(3, "aaa")))
+	assert.EqualValues(t, 1, tracker.MissedStreak())
+
+	stats, err := tracker.Stats(10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, stats.FromHeight, "older records should be pruned")
+	assert.EqualValues(t, 6, stats.ToHeight)
+	require.Len(t, stats.Validators, 3)
+
+	assert.Equal(t, ValidatorStats{
+		Address: store.address(0), Window: 4, Signed: 4, Proposed: 2,
+	}, statsOf(t, stats, store.address(0)))
+	assert.Equal(t, ValidatorStats{
+		Address: store.address(1), Window: 4, Signed: 2, Nil: 1, Absent: 1, Proposed: 2, MissedStreak: 1,
+	}, statsOf(t, stats, store.address(1)))
+	assert.Equal(t, ValidatorStats{
+		Address: me, Window: 4, Signed: 1, Absent: 3, MissedStreak: 1,
+	}, statsOf(t, stats, me))
+
+	stats, err = tracker.Stats(1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, stats.FromHeight)
+	assert.Equal(t, ValidatorStats{
+		Address: me, Window: 1, Absent: 1, MissedStreak: 1,
+	}, statsOf(t, stats, me))
+}
+
+func TestTrackerCatchUp(t *testing.T) {
+	store := newTestStore()
+	me := store.address(1)
+	for h := int64(1); h <= 5; h++ {
+		store.save(h, store.address(0), "caa")
+	}
+
+	db := dbm.NewMemDB()
+	tracker := NewTracker(db, store, store, 100, WithValidatorAddress(me))
+	tracker.SetLogger(log.TestingLogger())
+	require.NoError(t, tracker.Start())
+
+	stats, err := tracker.Stats(100)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, stats.FromHeight)
+	assert.EqualValues(t, 5, stats.ToHeight)
+	assert.EqualValues(t, 5, statsOf(t, stats, store.address(0)).Proposed)
+	assert.EqualValues(t, 4, tracker.MissedStreak())
+
+	// the blocks saved to the block store while running are recorded
+	store.save(6, store.address(1), "ccc")
+	store.save(7, store.address(2), "ccc")
+
+	assert.Eventually(t, func() bool {
+		stats, err := tracker.Stats(100)
+		return err == nil && stats.ToHeight == 7
+	}, 3*pollInterval, 10*time.Millisecond)
+	assert.EqualValues(t, 0, tracker.MissedStreak())
+	require.NoError(t, tracker.Stop())
+
+	// the streak is restored on restart
+	require.NoError(t, tracker.record(8, store.address(0), store.makeCommit(7, "cac")))
+	restarted := NewTracker(db, store, store, 100, WithValidatorAddress(me))
+	require.NoError(t, restarted.load())
+	assert.EqualValues(t, 1, restarted.MissedStreak())
+}
+
+func TestTrackerPrunesAcrossGaps(t *testing.T) {
+	store := newTestStore()
+	tracker := NewTracker(dbm.NewMemDB(), store, store, 4)
+
+	for _, height := range []int64{2, 3, 5} {
+		require.NoError(t, tracker.record(height, store.address(0), store.makeCommit(height-1, "ccc")))
+	}
+	// the records below the window are pruned, not only the one leaving it
+	require.NoError(t, tracker.record(12, store.address(0), store.makeCommit(11, "ccc")))
+
+	stats, err := tracker.Stats(100)
+	require.NoError(t, err)
+	assert.EqualValues(t, 12, stats.FromHeight)
+	assert.EqualValues(t, 12, stats.ToHeight)
+}
@@ -143,12 +143,31 @@ func (bs *BlockStore) LoadBlock(height int64) *types.Block {
 // If no block is found for that hash, it returns nil.
 // Panics if it fails to parse height associated with the given hash.
 func (bs *BlockStore) LoadBlockByHash(hash []byte) *types.Block {
+	height, ok := bs.loadHeightByHash(hash)
+	if !ok {
+		return nil
+	}
+	return bs.LoadBlock(height)
+}
+
+// LoadBlockMetaByHash returns the BlockMeta of the block with the given hash.
+// If no block is found for that hash, it returns nil.
+// Panics if it fails to parse height associated with the given hash.
+func (bs *BlockStore) LoadBlockMetaByHash(hash []byte) *types.BlockMeta {
+	height, ok := bs.loadHeightByHash(hash)
+	if !ok {
+		return nil
+	}
+	return bs.LoadBlockMeta(height)
+}
+
+func (bs *BlockStore) loadHeightByHash(hash []byte) (int64, bool) {
 	bz, err := bs.db.Get(calcBlockHashKey(hash))
 	if err != nil {
 		panic(err)
 	}
 	if len(bz) == 0 {
-		return nil
+		return 0, false
 	}
 
 	s := string(bz)
@@ -157,7 +176,7 @@ func (bs *BlockStore) LoadBlockByHash(hash []byte) *types.Block {
 	if err != nil {
 		panic(fmt.Sprintf("failed to extract height from %s: %v", s, err))
 	}
-	return bs.LoadBlock(height)
+	return height, true
 }
 
 // LoadBlockPart returns the Part at the given index
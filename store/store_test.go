@@ -470,6 +470,7 @@ func TestPruneBlocks(t *testing.T) {
 	require.NotNil(t, bs.LoadBlock(1200))
 	require.Nil(t, bs.LoadBlock(1199))
 	require.Nil(t, bs.LoadBlockByHash(prunedBlock.Hash()))
+	require.Nil(t, bs.LoadBlockMetaByHash(prunedBlock.Hash()))
 	require.Nil(t, bs.LoadBlockCommit(1199))
 	require.Nil(t, bs.LoadBlockMeta(1199))
 	require.Nil(t, bs.LoadBlockPart(1199, 1))
@@ -570,6 +571,12 @@ func TestBlockFetchAtHeight(t *testing.T) {
 	require.Equal(t, block.Hash(), blockAtHeight.Hash(),
 		"expecting a successful load of the last saved block")
 
+	metaByHash := bs.LoadBlockMetaByHash(block.Hash())
+	require.NotNil(t, metaByHash)
+	require.Equal(t, block.Hash(), metaByHash.Header.Hash(),
+		"expecting a successful load of the meta of the last saved block by hash")
+	require.Nil(t, bs.LoadBlockMetaByHash([]byte("unknown")))
+
 	blockAtHeightPlus1 := bs.LoadBlock(bs.Height() + 1)
 	require.Nil(t, blockAtHeightPlus1, "expecting an unsuccessful load of Height()+1")
 	blockAtHeightPlus2 := bs.LoadBlock(bs.Height() + 2)
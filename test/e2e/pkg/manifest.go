@@ -67,6 +67,11 @@ type ManifestNode struct {
 	// this defaults to all other nodes in the network.
 	PersistentPeers []string `toml:"persistent_peers"`
 
+	// Sentries is a list of node names to run in front of this validator. The
+	// validator then only connects to these sentries, which run in sentry mode
+	// for it, and no other node connects to it. Defaults to none.
+	Sentries []string `toml:"sentries"`
+
 	// Database specifies the database backend: "goleveldb", "cleveldb",
 	// "rocksdb", "boltdb", or "badgerdb". Defaults to goleveldb.
 	Database string `toml:"database"`
@@ -81,6 +81,8 @@ type Node struct {
 	RetainBlocks     uint64
 	Seeds            []*Node
 	PersistentPeers  []*Node
+	Sentries         []*Node
+	SentryFor        []*Node
 	Perturbations    []Perturbation
 	Misbehaviors     map[int64]string
 }
@@ -186,7 +188,18 @@ func LoadTestnet(file string) (*Testnet, error) {
 		testnet.Nodes = append(testnet.Nodes, node)
 	}
 
-	// We do a second pass to set up seeds and persistent peers, which allows graph cycles.
+	// We do a second pass to set up sentries, seeds and persistent peers, which
+	// allows graph cycles.
+	for _, node := range testnet.Nodes {
+		for _, sentryName := range manifest.Nodes[node.Name].Sentries {
+			sentry := testnet.LookupNode(sentryName)
+			if sentry == nil {
+				return nil, fmt.Errorf("unknown sentry %q for node %q", sentryName, node.Name)
+			}
+			node.Sentries = append(node.Sentries, sentry)
+			sentry.SentryFor = append(sentry.SentryFor, node)
+		}
+	}
 	for _, node := range testnet.Nodes {
 		nodeManifest, ok := manifest.Nodes[node.Name]
 		if !ok {
@@ -208,10 +221,11 @@ func LoadTestnet(file string) (*Testnet, error) {
 		}
 
 		// If there are no seeds or persistent peers specified, default to persistent
-		// connections to all other nodes.
-		if len(node.PersistentPeers) == 0 && len(node.Seeds) == 0 {
+		// connections to all other nodes, except for validators behind sentries,
+		// which are only connected to their sentries.
+		if len(node.PersistentPeers) == 0 && len(node.Seeds) == 0 && len(node.Sentries) == 0 {
 			for _, peer := range testnet.Nodes {
-				if peer.Name == node.Name {
+				if peer.Name == node.Name || len(peer.Sentries) > 0 {
 					continue
 				}
 				node.PersistentPeers = append(node.PersistentPeers, peer)
@@ -334,6 +348,33 @@ func (n Node) Validate(testnet Testnet) error {
 		return errors.New("snapshot_interval must be less than er equal to retain_blocks")
 	}
 
+	if len(n.Sentries) > 0 {
+		if n.Mode != ModeValidator {
+			return errors.New("only validators can run behind sentries")
+		}
+		if len(n.Seeds) > 0 {
+			return errors.New("validators behind sentries can't use seeds")
+		}
+		for _, peer := range n.PersistentPeers {
+			if !n.HasSentry(peer) {
+				return fmt.Errorf("persistent peer %q is not a sentry", peer.Name)
+			}
+		}
+	}
+	for _, sentry := range n.Sentries {
+		if sentry.Name == n.Name {
+			return errors.New("node can't be its own sentry")
+		}
+		if sentry.Mode == ModeSeed || len(sentry.Sentries) > 0 {
+			return fmt.Errorf("sentry %q must be a full node or validator without sentries", sentry.Name)
+		}
+	}
+	for _, peer := range append(append([]*Node{}, n.Seeds...), n.PersistentPeers...) {
+		if len(peer.Sentries) > 0 && !peer.HasSentry(&n) {
+			return fmt.Errorf("peer %q is behind sentries", peer.Name)
+		}
+	}
+
 	for _, perturbation := range n.Perturbations {
 		switch perturbation {
 		case PerturbationDisconnect, PerturbationKill, PerturbationPause, PerturbationRestart:
@@ -369,6 +410,16 @@ func (n Node) Validate(testnet Testnet) error {
 	return nil
 }
 
+// HasSentry returns true if the node runs behind the given sentry.
+func (n Node) HasSentry(sentry *Node) bool {
+	for _, s := range n.Sentries {
+		if s.Name == sentry.Name {
+			return true
+		}
+	}
+	return false
+}
+
 // LookupNode looks up a node by name. For now, simply do a linear search.
 func (t Testnet) LookupNode(name string) *Node {
 	for _, node := range t.Nodes {
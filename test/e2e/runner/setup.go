@@ -306,6 +306,25 @@ func MakeConfig(node *e2e.Node) (*config.Config, error) {
 		}
 		cfg.P2P.PersistentPeers += peer.AddressP2P(true)
 	}
+
+	if len(node.Sentries) > 0 {
+		cfg.P2P.SentryMode = config.SentryModeValidator
+		for _, sentry := range node.Sentries {
+			if len(cfg.P2P.SentryPeers) > 0 {
+				cfg.P2P.SentryPeers += ","
+			}
+			cfg.P2P.SentryPeers += sentry.AddressP2P(true)
+		}
+	}
+	if len(node.SentryFor) > 0 {
+		cfg.P2P.SentryMode = config.SentryModeSentry
+		for _, validator := range node.SentryFor {
+			if len(cfg.P2P.SentryValidators) > 0 {
+				cfg.P2P.SentryValidators += ","
+			}
+			cfg.P2P.SentryValidators += validator.AddressP2P(true)
+		}
+	}
 	return cfg, nil
 }
 
@@ -44,3 +44,38 @@ func TestNet_Peers(t *testing.T) {
 		}
 	})
 }
+
+// Tests that validators behind sentries are only connected to their sentries,
+// and that the sentries are connected to them.
+func TestNet_Sentries(t *testing.T) {
+	testNode(t, func(t *testing.T, node e2e.Node) {
+		client, err := node.Client()
+		require.NoError(t, err)
+		netInfo, err := client.NetInfo(ctx)
+		require.NoError(t, err)
+
+		peers := map[string]bool{}
+		for _, peerInfo := range netInfo.Peers {
+			peer := node.Testnet.LookupNode(peerInfo.NodeInfo.Moniker)
+			require.NotNil(t, peer, "unknown node %v", peerInfo.NodeInfo.Moniker)
+			peers[peer.Name] = true
+
+			if len(node.Sentries) > 0 {
+				require.True(t, node.HasSentry(peer), "validator %v peered with %v, which is not a sentry",
+					node.Name, peer.Name)
+			}
+			if len(peer.Sentries) > 0 {
+				require.True(t, peer.HasSentry(&node), "node %v peered with validator %v, which is behind sentries",
+					node.Name, peer.Name)
+			}
+		}
+
+		if len(node.Sentries) > 0 {
+			require.NotEmpty(t, peers, "validator %v is not connected to any sentry", node.Name)
+		}
+		for _, validator := range node.SentryFor {
+			require.True(t, peers[validator.Name], "sentry %v not peered with validator %v",
+				node.Name, validator.Name)
+		}
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/arcology-network/consensus-engine/libs/log"
 	tmpubsub "github.com/arcology-network/consensus-engine/libs/pubsub"
 	"github.com/arcology-network/consensus-engine/libs/service"
+	tmsync "github.com/arcology-network/consensus-engine/libs/sync"
 )
 
 const defaultCapacity = 0
@@ -33,6 +34,9 @@ type Subscription interface {
 type EventBus struct {
 	service.BaseService
 	pubsub *tmpubsub.Server
+
+	mtx    tmsync.Mutex
+	cursor EventCursor // of the last block event published
 }
 
 // NewEventBus returns a new event bus.
@@ -43,7 +47,13 @@ func NewEventBus() *EventBus {
 // NewEventBusWithBufferCapacity returns a new event bus with the given buffer capacity.
 func NewEventBusWithBufferCapacity(cap int) *EventBus {
 	// capacity could be exposed later if needed
-	pubsub := tmpubsub.NewServer(tmpubsub.BufferCapacity(cap))
+	return NewEventBusWithOptions(tmpubsub.BufferCapacity(cap))
+}
+
+// NewEventBusWithOptions returns a new event bus, whose pubsub server is
+// configured with the given options.
+func NewEventBusWithOptions(options ...tmpubsub.Option) *EventBus {
+	pubsub := tmpubsub.NewServer(options...)
 	b := &EventBus{pubsub: pubsub}
 	b.BaseService = *service.NewBaseService(nil, "EventBus", b)
 	return b
@@ -81,6 +91,19 @@ func (b *EventBus) Subscribe(
 	return b.pubsub.Subscribe(ctx, subscriber, query, outCapacity...)
 }
 
+// SubscribeWithPolicy subscribes with the given policy for when the
+// subscriber is not pulling events fast enough, see
+// tmpubsub.Server#SubscribeWithPolicy.
+func (b *EventBus) SubscribeWithPolicy(
+	ctx context.Context,
+	subscriber string,
+	query tmpubsub.Query,
+	outCapacity int,
+	policy tmpubsub.OverflowPolicy,
+) (Subscription, error) {
+	return b.pubsub.SubscribeWithPolicy(ctx, subscriber, query, outCapacity, policy)
+}
+
 // This method can be used for a local consensus explorer and synchronous
 // testing. Do not use for for public facing / untrusted subscriptions!
 func (b *EventBus) SubscribeUnbuffered(
@@ -102,14 +125,35 @@ func (b *EventBus) UnsubscribeAll(ctx context.Context, subscriber string) error
 func (b *EventBus) Publish(eventType string, eventData TMEventData) error {
 	// no explicit deadline for publishing events
 	ctx := context.Background()
-	return b.pubsub.PublishWithEvents(ctx, eventData, map[string][]string{EventTypeKey: {eventType}})
+	return b.pubsub.PublishWithEvents(ctx, eventData, eventTypeEvents(eventType))
+}
+
+// stampCursor adds the cursor of the next event published while committing
+// the block at height to events. The first one is the new block event. A
+// height of zero stands for the current block.
+func (b *EventBus) stampCursor(events map[string][]string, height int64, newBlock bool) {
+	b.mtx.Lock()
+	switch {
+	case newBlock || (height != 0 && height != b.cursor.Height):
+		b.cursor = EventCursor{Height: height}
+	default:
+		b.cursor.Index++
+	}
+	cursor := b.cursor
+	b.mtx.Unlock()
+
+	events[EventCursorKey] = []string{cursor.String()}
+}
+
+func eventTypeEvents(eventType string) map[string][]string {
+	return map[string][]string{EventTypeKey: {eventType}}
 }
 
 // validateAndStringifyEvents takes a slice of event objects and creates a
 // map of stringified events where each key is composed of the event
 // type and each of the event's attributes keys in the form of
 // "{event.Type}.{attribute.Key}" and the value is each attribute's value.
-func (b *EventBus) validateAndStringifyEvents(events []types.Event, logger log.Logger) map[string][]string {
+func validateAndStringifyEvents(events []types.Event, logger log.Logger) map[string][]string {
 	result := make(map[string][]string)
 	for _, event := range events {
 		if len(event.Type) == 0 {
@@ -131,35 +175,68 @@ func (b *EventBus) validateAndStringifyEvents(events []types.Event, logger log.L
 	return result
 }
 
-func (b *EventBus) PublishEventNewBlock(data EventDataNewBlock) error {
-	// no explicit deadline for publishing events
-	ctx := context.Background()
-
+func newBlockEvents(data EventDataNewBlock, logger log.Logger) map[string][]string {
 	resultEvents := append(data.ResultBeginBlock.Events, data.ResultEndBlock.Events...)
-	events := b.validateAndStringifyEvents(resultEvents, b.Logger.With("block", data.Block.StringShort()))
+	events := validateAndStringifyEvents(resultEvents, logger.With("block", data.Block.StringShort()))
 
 	// add predefined new block event
 	events[EventTypeKey] = append(events[EventTypeKey], EventNewBlock)
 
-	return b.pubsub.PublishWithEvents(ctx, data, events)
+	return events
 }
 
-func (b *EventBus) PublishEventNewBlockHeader(data EventDataNewBlockHeader) error {
-	// no explicit deadline for publishing events
-	ctx := context.Background()
-
+func newBlockHeaderEvents(data EventDataNewBlockHeader, logger log.Logger) map[string][]string {
 	resultTags := append(data.ResultBeginBlock.Events, data.ResultEndBlock.Events...)
 	// TODO: Create StringShort method for Header and use it in logger.
-	events := b.validateAndStringifyEvents(resultTags, b.Logger.With("header", data.Header))
+	events := validateAndStringifyEvents(resultTags, logger.With("header", data.Header))
 
 	// add predefined new block header event
 	events[EventTypeKey] = append(events[EventTypeKey], EventNewBlockHeader)
 
+	return events
+}
+
+func txEvents(data EventDataTx, logger log.Logger) map[string][]string {
+	events := validateAndStringifyEvents(data.Result.Events, logger.With("tx", data.Tx))
+
+	// add predefined compositeKeys
+	events[EventTypeKey] = append(events[EventTypeKey], EventTx)
+	events[TxHashKey] = append(events[TxHashKey], fmt.Sprintf("%X", Tx(data.Tx).Hash()))
+	events[TxHeightKey] = append(events[TxHeightKey], fmt.Sprintf("%d", data.Height))
+
+	return events
+}
+
+func (b *EventBus) PublishEventNewBlock(data EventDataNewBlock) error {
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+
+	events := newBlockEvents(data, b.Logger)
+	if data.Block != nil {
+		b.stampCursor(events, data.Block.Height, true)
+	}
+
+	return b.pubsub.PublishWithEvents(ctx, data, events)
+}
+
+func (b *EventBus) PublishEventNewBlockHeader(data EventDataNewBlockHeader) error {
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+
+	events := newBlockHeaderEvents(data, b.Logger)
+	b.stampCursor(events, data.Header.Height, false)
+
 	return b.pubsub.PublishWithEvents(ctx, data, events)
 }
 
 func (b *EventBus) PublishEventNewEvidence(evidence EventDataNewEvidence) error {
-	return b.Publish(EventNewEvidence, evidence)
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+
+	events := eventTypeEvents(EventNewEvidence)
+	b.stampCursor(events, evidence.Height, false)
+
+	return b.pubsub.PublishWithEvents(ctx, evidence, events)
 }
 
 func (b *EventBus) PublishEventVote(data EventDataVote) error {
@@ -171,18 +248,14 @@ func (b *EventBus) PublishEventValidBlock(data EventDataRoundState) error {
 }
 
 // PublishEventTx publishes tx event with events from Result. Note it will add
-// predefined keys (EventTypeKey, TxHashKey). Existing events with the same keys
-// will be overwritten.
+// predefined keys (EventTypeKey, TxHashKey, TxHeightKey, EventCursorKey).
+// Existing events with the same keys will be overwritten.
 func (b *EventBus) PublishEventTx(data EventDataTx) error {
 	// no explicit deadline for publishing events
 	ctx := context.Background()
 
-	events := b.validateAndStringifyEvents(data.Result.Events, b.Logger.With("tx", data.Tx))
-
-	// add predefined compositeKeys
-	events[EventTypeKey] = append(events[EventTypeKey], EventTx)
-	events[TxHashKey] = append(events[TxHashKey], fmt.Sprintf("%X", Tx(data.Tx).Hash()))
-	events[TxHeightKey] = append(events[TxHeightKey], fmt.Sprintf("%d", data.Height))
+	events := txEvents(data, b.Logger)
+	b.stampCursor(events, data.Height, false)
 
 	return b.pubsub.PublishWithEvents(ctx, data, events)
 }
@@ -224,7 +297,13 @@ func (b *EventBus) PublishEventLock(data EventDataRoundState) error {
 }
 
 func (b *EventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpdates) error {
-	return b.Publish(EventValidatorSetUpdates, data)
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+
+	events := eventTypeEvents(EventValidatorSetUpdates)
+	b.stampCursor(events, 0, false)
+
+	return b.pubsub.PublishWithEvents(ctx, data, events)
 }
 
 // -----------------------------------------------------------------------------
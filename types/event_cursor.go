@@ -0,0 +1,143 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	abci "github.com/arcology-network/consensus-engine/abci/types"
+	"github.com/arcology-network/consensus-engine/libs/log"
+)
+
+// EventCursor is the position of an event published while committing a block:
+// the height of the block, and the index of the event among the events of the
+// block, in the order they are published (see BlockEvents). Cursors only grow,
+// so a subscriber can resume after the last event it has received.
+type EventCursor struct {
+	Height int64
+	Index  int
+}
+
+// ParseEventCursor parses a cursor in the form returned by String.
+func ParseEventCursor(s string) (EventCursor, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return EventCursor{}, fmt.Errorf("invalid event cursor %q", s)
+	}
+	height, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || height <= 0 {
+		return EventCursor{}, fmt.Errorf("invalid event cursor height in %q", s)
+	}
+	index, err := strconv.Atoi(parts[1])
+	if err != nil || index < 0 {
+		return EventCursor{}, fmt.Errorf("invalid event cursor index in %q", s)
+	}
+	return EventCursor{Height: height, Index: index}, nil
+}
+
+// String returns the cursor as "height/index".
+func (c EventCursor) String() string {
+	return fmt.Sprintf("%d/%d", c.Height, c.Index)
+}
+
+// Before returns true if c comes before other.
+func (c EventCursor) Before(other EventCursor) bool {
+	if c.Height != other.Height {
+		return c.Height < other.Height
+	}
+	return c.Index < other.Index
+}
+
+// EventCursorFromEvents returns the cursor of an event from its events, and
+// false if it has none.
+func EventCursorFromEvents(events map[string][]string) (EventCursor, bool) {
+	values := events[EventCursorKey]
+	if len(values) != 1 {
+		return EventCursor{}, false
+	}
+	c, err := ParseEventCursor(values[0])
+	if err != nil {
+		return EventCursor{}, false
+	}
+	return c, true
+}
+
+// BlockEvent is an event published while committing a block, with the events
+// it is matched against.
+type BlockEvent struct {
+	Data   TMEventData
+	Events map[string][]string
+}
+
+// BlockEvents returns the events published while committing block, in the
+// order they are published, with the same events and cursors. beginBlock and
+// endBlock may be nil if the block results are no longer known, in which case
+// the events that carry them are left out. So are the txs with a nil result.
+func BlockEvents(
+	block *Block,
+	beginBlock *abci.ResponseBeginBlock,
+	endBlock *abci.ResponseEndBlock,
+	deliverTxs []*abci.ResponseDeliverTx,
+) ([]BlockEvent, error) {
+	if len(deliverTxs) != len(block.Txs) {
+		return nil, errors.New("the number of tx results doesn't match the number of txs")
+	}
+
+	var (
+		logger = log.NewNopLogger()
+		result []BlockEvent
+		cursor = EventCursor{Height: block.Height}
+	)
+	add := func(data TMEventData, events map[string][]string) {
+		events[EventCursorKey] = []string{cursor.String()}
+		result = append(result, BlockEvent{Data: data, Events: events})
+		cursor.Index++
+	}
+
+	if beginBlock != nil && endBlock != nil {
+		newBlock := EventDataNewBlock{
+			Block:            block,
+			ResultBeginBlock: *beginBlock,
+			ResultEndBlock:   *endBlock,
+		}
+		add(newBlock, newBlockEvents(newBlock, logger))
+		header := EventDataNewBlockHeader{
+			Header:           block.Header,
+			NumTxs:           int64(len(block.Txs)),
+			ResultBeginBlock: *beginBlock,
+			ResultEndBlock:   *endBlock,
+		}
+		add(header, newBlockHeaderEvents(header, logger))
+	} else {
+		cursor.Index += 2
+	}
+
+	for _, ev := range block.Evidence.Evidence {
+		add(EventDataNewEvidence{Evidence: ev, Height: block.Height}, eventTypeEvents(EventNewEvidence))
+	}
+
+	for i, tx := range block.Txs {
+		if deliverTxs[i] == nil {
+			cursor.Index++
+			continue
+		}
+		data := EventDataTx{TxResult: abci.TxResult{
+			Height: block.Height,
+			Index:  uint32(i),
+			Tx:     tx,
+			Result: *deliverTxs[i],
+		}}
+		add(data, txEvents(data, logger))
+	}
+
+	if endBlock != nil && len(endBlock.ValidatorUpdates) > 0 {
+		validators, err := PB2TM.ValidatorUpdates(endBlock.ValidatorUpdates)
+		if err != nil {
+			return nil, err
+		}
+		add(EventDataValidatorSetUpdates{ValidatorUpdates: validators}, eventTypeEvents(EventValidatorSetUpdates))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,106 @@
+package types
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/arcology-network/consensus-engine/abci/types"
+	"github.com/arcology-network/consensus-engine/crypto/ed25519"
+	tmquery "github.com/arcology-network/consensus-engine/libs/pubsub/query"
+)
+
+func TestEventCursor(t *testing.T) {
+	c, err := ParseEventCursor("12/3")
+	require.NoError(t, err)
+	assert.Equal(t, EventCursor{Height: 12, Index: 3}, c)
+	assert.Equal(t, "12/3", c.String())
+
+	assert.True(t, EventCursor{Height: 12, Index: 2}.Before(c))
+	assert.True(t, EventCursor{Height: 11, Index: 9}.Before(c))
+	assert.False(t, c.Before(c))
+	assert.False(t, EventCursor{Height: 13}.Before(c))
+
+	for _, s := range []string{"", "12", "12/3/4", "a/3", "12/b", "0/1", "12/-1"} {
+		_, err := ParseEventCursor(s)
+		assert.Error(t, err, s)
+	}
+
+	c, ok := EventCursorFromEvents(map[string][]string{EventCursorKey: {"5/0"}})
+	assert.True(t, ok)
+	assert.Equal(t, EventCursor{Height: 5}, c)
+	_, ok = EventCursorFromEvents(map[string][]string{EventTypeKey: {EventVote}})
+	assert.False(t, ok)
+}
+
+// BlockEvents returns the events the event bus publishes while committing a
+// block.
+func TestBlockEvents(t *testing.T) {
+	eventBus := NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	sub, err := eventBus.Subscribe(context.Background(), "test", tmquery.Empty{}, 10)
+	require.NoError(t, err)
+
+	ev := NewMockDuplicateVoteEvidence(2, time.Now(), "test-chain")
+	block := MakeBlock(3, []Tx{Tx("foo"), Tx("bar")}, nil, []Evidence{ev})
+	beginBlock := &abci.ResponseBeginBlock{Events: []abci.Event{
+		{Type: "begin", Attributes: []abci.EventAttribute{{Key: []byte("k"), Value: []byte("v")}}},
+	}}
+	val := NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+	endBlock := &abci.ResponseEndBlock{ValidatorUpdates: []abci.ValidatorUpdate{TM2PB.ValidatorUpdate(val)}}
+	deliverTxs := []*abci.ResponseDeliverTx{
+		{Code: 0, Events: []abci.Event{
+			{Type: "transfer", Attributes: []abci.EventAttribute{{Key: []byte("to"), Value: []byte("alice")}}},
+		}},
+		{Code: 1},
+	}
+
+	require.NoError(t, eventBus.PublishEventNewBlock(EventDataNewBlock{
+		Block: block, ResultBeginBlock: *beginBlock, ResultEndBlock: *endBlock,
+	}))
+	require.NoError(t, eventBus.PublishEventNewBlockHeader(EventDataNewBlockHeader{
+		Header: block.Header, NumTxs: 2, ResultBeginBlock: *beginBlock, ResultEndBlock: *endBlock,
+	}))
+	require.NoError(t, eventBus.PublishEventNewEvidence(EventDataNewEvidence{Evidence: ev, Height: 3}))
+	for i, tx := range block.Txs {
+		require.NoError(t, eventBus.PublishEventTx(EventDataTx{TxResult: abci.TxResult{
+			Height: 3, Index: uint32(i), Tx: tx, Result: *deliverTxs[i],
+		}}))
+	}
+	require.NoError(t, eventBus.PublishEventValidatorSetUpdates(EventDataValidatorSetUpdates{
+		ValidatorUpdates: []*Validator{val},
+	}))
+
+	events, err := BlockEvents(block, beginBlock, endBlock, deliverTxs)
+	require.NoError(t, err)
+	require.Len(t, events, 6)
+	for i, event := range events {
+		select {
+		case msg := <-sub.Out():
+			assert.Equal(t, msg.Events(), event.Events, i)
+			assert.Equal(t, []string{EventCursor{Height: 3, Index: i}.String()}, event.Events[EventCursorKey])
+			assert.IsType(t, msg.Data(), event.Data, i)
+		case <-time.After(time.Second):
+			t.Fatal("did not receive an event")
+		}
+	}
+
+	// without the block results, only the evidence and the known txs are left,
+	// with the same cursors
+	events, err = BlockEvents(block, nil, nil, []*abci.ResponseDeliverTx{nil, deliverTxs[1]})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, []string{"3/2"}, events[0].Events[EventCursorKey])
+	assert.Equal(t, []string{"3/4"}, events[1].Events[EventCursorKey])
+
+	_, err = BlockEvents(block, beginBlock, endBlock, deliverTxs[:1])
+	assert.Error(t, err)
+}
@@ -136,6 +136,15 @@ const (
 	// TxHeightKey is a reserved key, used to specify transaction block's height.
 	// see EventBus#PublishEventTx
 	TxHeightKey = "tx.height"
+
+	// BlockHeightKey is a reserved key used for indexing BeginBlock and
+	// EndBlock events.
+	BlockHeightKey = "block.height"
+
+	// EventCursorKey is a reserved key, used to specify the cursor of an event
+	// published while committing a block.
+	// see EventBus#stampCursor
+	EventCursorKey = "tm.cursor"
 )
 
 var (